@@ -0,0 +1,23 @@
+package mp4
+
+import (
+	amp4 "github.com/abema/go-mp4"
+)
+
+func boxTypeAc4() amp4.BoxType { return amp4.StrToBoxType("ac-4") }
+
+func boxTypeDac4() amp4.BoxType { return amp4.StrToBoxType("dac4") }
+
+// Dac4 is a AC-4 configuration box.
+// Its content is a ac4.DSI, that has a variable layout
+// and is therefore kept as raw bytes.
+// Specification: ETSI TS 103 190-2, Annex E.5
+type Dac4 struct {
+	amp4.Box
+	Data []byte `mp4:"0,size=8"`
+}
+
+// GetType implements amp4.IBox.
+func (*Dac4) GetType() amp4.BoxType {
+	return boxTypeDac4()
+}
@@ -1,21 +1,75 @@
 package mp4
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"sync"
 
 	amp4 "github.com/abema/go-mp4"
 
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac4"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/eac3"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1video"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mp4/codecs"
 )
 
+// maximum size of a dec3 payload, that is way larger than the biggest eac3.Config.
+const maxDec3Size = 256
+
+// size of a FLAC metadata block header.
+const flacMetadataBlockHeaderSize = 4
+
 // ErrReadEnded is returned when reading codec boxes has ended.
 var ErrReadEnded = fmt.Errorf("OK")
 
+func boxTypeAlaw() amp4.BoxType { return amp4.StrToBoxType("alaw") }
+
+func boxTypeUlaw() amp4.BoxType { return amp4.StrToBoxType("ulaw") }
+
+func boxTypeC608() amp4.BoxType { return amp4.StrToBoxType("c608") }
+
+var registerBoxTypesOnce sync.Once
+
+// registerBoxTypes registers box types that are not provided by go-mp4.
+func registerBoxTypes() {
+	registerBoxTypesOnce.Do(func() {
+		// G711 sample entries, defined by QuickTime
+		amp4.AddAnyTypeBoxDef(&amp4.AudioSampleEntry{}, boxTypeAlaw())
+		amp4.AddAnyTypeBoxDef(&amp4.AudioSampleEntry{}, boxTypeUlaw())
+
+		// CEA-608 sample entry, defined by QuickTime
+		amp4.AddAnyTypeBoxDef(&amp4.SampleEntry{}, boxTypeC608())
+
+		// Dolby Vision sample entries and configurations
+		amp4.AddAnyTypeBoxDef(&amp4.VisualSampleEntry{}, boxTypeDvh1())
+		amp4.AddAnyTypeBoxDef(&amp4.VisualSampleEntry{}, boxTypeDvhe())
+		amp4.AddAnyTypeBoxDef(&amp4.VisualSampleEntry{}, boxTypeDav1())
+		amp4.AddAnyTypeBoxDef(&DoviConfig{}, boxTypeDvcC())
+		amp4.AddAnyTypeBoxDef(&DoviConfig{}, boxTypeDvvC())
+		amp4.AddAnyTypeBoxDef(&DoviConfig{}, boxTypeDvwC())
+
+		// VVC sample entries and configuration
+		amp4.AddAnyTypeBoxDef(&amp4.VisualSampleEntry{}, boxTypeVvc1())
+		amp4.AddAnyTypeBoxDef(&amp4.VisualSampleEntry{}, boxTypeVvi1())
+		amp4.AddBoxDef(&VvcC{}, 0)
+
+		// AC-4 sample entry and configuration
+		amp4.AddAnyTypeBoxDef(&amp4.AudioSampleEntry{}, boxTypeAc4())
+		amp4.AddBoxDef(&Dac4{})
+
+		amp4.AddBoxDef(&Nmhd{}, 0)
+		amp4.AddBoxDef(&Mdcv{})
+		amp4.AddBoxDef(&Clli{})
+	})
+}
+
 func boolToUint8(v bool) uint8 {
 	if v {
 		return 1
@@ -23,6 +77,76 @@ func boolToUint8(v bool) uint8 {
 	return 0
 }
 
+func writeHDRBoxes(
+	w *Writer,
+	colorInfo *codecs.ColorInfo,
+	masteringDisplay *codecs.MasteringDisplay,
+	contentLightLevel *codecs.ContentLightLevel,
+) error {
+	if colorInfo != nil {
+		_, err := w.WriteBox(&amp4.Colr{ // <colr/>
+			ColourType:              [4]byte{'n', 'c', 'l', 'x'},
+			ColourPrimaries:         uint16(colorInfo.ColorPrimaries),
+			TransferCharacteristics: uint16(colorInfo.TransferCharacteristics),
+			MatrixCoefficients:      uint16(colorInfo.MatrixCoefficients),
+			FullRangeFlag:           colorInfo.FullRange,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if masteringDisplay != nil {
+		_, err := w.WriteBox(&Mdcv{ // <mdcv/>
+			DisplayPrimaries: [6]uint16{
+				masteringDisplay.DisplayPrimariesX[0], masteringDisplay.DisplayPrimariesY[0],
+				masteringDisplay.DisplayPrimariesX[1], masteringDisplay.DisplayPrimariesY[1],
+				masteringDisplay.DisplayPrimariesX[2], masteringDisplay.DisplayPrimariesY[2],
+			},
+			WhitePointX:                  masteringDisplay.WhitePointX,
+			WhitePointY:                  masteringDisplay.WhitePointY,
+			MaxDisplayMasteringLuminance: masteringDisplay.MaxLuminance,
+			MinDisplayMasteringLuminance: masteringDisplay.MinLuminance,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if contentLightLevel != nil {
+		_, err := w.WriteBox(&Clli{ // <clli/>
+			MaxContentLightLevel:    contentLightLevel.MaxContentLightLevel,
+			MaxPicAverageLightLevel: contentLightLevel.MaxPicAverageLightLevel,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeDoviConfig(w *Writer, cfg *codecs.DolbyVisionConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	_, err := w.WriteBox(&DoviConfig{ // <dvcC/>, <dvvC/> or <dvwC/>
+		AnyTypeBox: amp4.AnyTypeBox{
+			Type: doviConfigBoxType(cfg.Profile),
+		},
+		DVVersionMajor:            cfg.VersionMajor,
+		DVVersionMinor:            cfg.VersionMinor,
+		DVProfile:                 cfg.Profile,
+		DVLevel:                   cfg.Level,
+		RPUPresentFlag:            cfg.RPUPresent,
+		ELPresentFlag:             cfg.ELPresent,
+		BLPresentFlag:             cfg.BLPresent,
+		DVBLSignalCompatibilityID: cfg.BLSignalCompatibilityID,
+	})
+	return err
+}
+
 func av1FindSequenceHeader(buf []byte) ([]byte, error) {
 	var tu av1.Bitstream
 	err := tu.Unmarshal(buf)
@@ -122,6 +246,187 @@ func h265FindParams(params []amp4.HEVCNaluArray) ([]byte, []byte, []byte, error)
 	return vps, sps, pps, nil
 }
 
+func h266FindParams(params []VvcNaluArray) ([]byte, []byte, []byte, error) {
+	var vps []byte
+	var sps []byte
+	var pps []byte
+
+	for _, arr := range params {
+		switch arr.NaluType {
+		case h266.NALUType_VPS_NUT, h266.NALUType_SPS_NUT, h266.NALUType_PPS_NUT:
+			if len(arr.Nalus) != 1 {
+				return nil, nil, nil, fmt.Errorf("multiple H266 VPS/SPS/PPS are not supported")
+			}
+
+			switch arr.NaluType {
+			case h266.NALUType_VPS_NUT:
+				vps = arr.Nalus[0]
+
+			case h266.NALUType_SPS_NUT:
+				sps = arr.Nalus[0]
+
+				var spsp h266.SPS
+				err := spsp.Unmarshal(sps)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("unable to parse H266 SPS: %w", err)
+				}
+
+			case h266.NALUType_PPS_NUT:
+				pps = arr.Nalus[0]
+			}
+		}
+	}
+
+	if len(sps) == 0 {
+		return nil, nil, nil, fmt.Errorf("H266 SPS not provided")
+	}
+
+	if len(pps) == 0 {
+		return nil, nil, nil, fmt.Errorf("H266 PPS not provided")
+	}
+
+	return vps, sps, pps, nil
+}
+
+// flacUnmarshalDfLaBlocks decodes the metadata blocks of a dfLa box.
+// Only STREAMINFO is required. Trailing blocks that cannot be decoded
+// are kept as UnknownMetadataBlock, truncated ones are dropped.
+func flacUnmarshalDfLaBlocks(buf []byte) (*flac.StreamInfo, flac.MetadataBlocks, error) {
+	var h flac.MetadataBlockHeader
+	err := h.Unmarshal(buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid FLAC dfLa box: %w", err)
+	}
+
+	if h.Type != flac.MetadataBlockTypeStreamInfo {
+		return nil, nil, fmt.Errorf("first FLAC metadata block is not STREAMINFO")
+	}
+
+	end := flacMetadataBlockHeaderSize + int(h.Length)
+	if end > len(buf) {
+		return nil, nil, fmt.Errorf("FLAC dfLa box is too short")
+	}
+
+	var streamInfo flac.StreamInfo
+	err = streamInfo.Unmarshal(buf[flacMetadataBlockHeaderSize:end])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid FLAC dfLa box: %w", err)
+	}
+	buf = buf[end:]
+
+	var blocks flac.MetadataBlocks
+
+	for !h.Last && len(buf) != 0 {
+		err = h.Unmarshal(buf)
+		if err != nil {
+			break
+		}
+
+		end = flacMetadataBlockHeaderSize + int(h.Length)
+		if end > len(buf) {
+			break
+		}
+
+		var block flac.MetadataBlocks
+		err = block.Unmarshal(buf[:end])
+		if err != nil {
+			blocks = append(blocks, &flac.UnknownMetadataBlock{
+				Type: h.Type,
+				Data: buf[flacMetadataBlockHeaderSize:end],
+			})
+		} else {
+			blocks = append(blocks, block...)
+		}
+
+		buf = buf[end:]
+	}
+
+	return &streamInfo, blocks, nil
+}
+
+// mpeg1VideoObjectTypeIndication returns the object type indication
+// that corresponds to the profile of a MPEG-1/2 Video stream.
+func mpeg1VideoObjectTypeIndication(conf *mpeg1video.Config) uint8 {
+	if conf == nil {
+		return ObjectTypeIndicationVisualISO1318part2Main
+	}
+
+	if !conf.IsMPEG2() {
+		return ObjectTypeIndicationVisualISO11172part2
+	}
+
+	if conf.SequenceExtension.IsEscape() {
+		// 4:2:2 profile
+		if conf.SequenceExtension.ProfileAndLevelIndication == 0x82 ||
+			conf.SequenceExtension.ProfileAndLevelIndication == 0x85 {
+			return ObjectTypeIndicationVisualISO1318part2422
+		}
+		return ObjectTypeIndicationVisualISO1318part2Main
+	}
+
+	switch conf.SequenceExtension.Profile() {
+	case mpeg1video.ProfileSimple:
+		return ObjectTypeIndicationVisualISO1318part2Simple
+
+	case mpeg1video.ProfileSNRScalable:
+		return ObjectTypeIndicationVisualISO1318part2SNR
+
+	case mpeg1video.ProfileSpatiallyScalable:
+		return ObjectTypeIndicationVisualISO1318part2Spatial
+
+	case mpeg1video.ProfileHigh:
+		return ObjectTypeIndicationVisualISO1318part2High
+
+	default:
+		return ObjectTypeIndicationVisualISO1318part2Main
+	}
+}
+
+// h265ConstraintIndicator packs the general constraint flags of a profile_tier_level.
+func h265ConstraintIndicator(ptl *h265.SPS_ProfileTierLevel) [6]uint8 {
+	flags := []bool{
+		ptl.GeneralProgressiveSourceFlag,
+		ptl.GeneralInterlacedSourceFlag,
+		ptl.GeneralNonPackedConstraintFlag,
+		ptl.GeneralFrameOnlyConstraintFlag,
+		ptl.GeneralMax12bitConstraintFlag,
+		ptl.GeneralMax10bitConstraintFlag,
+		ptl.GeneralMax8bitConstraintFlag,
+		ptl.GeneralMax422ChromeConstraintFlag,
+		ptl.GeneralMax420ChromaConstraintFlag,
+		ptl.GeneralMaxMonochromeConstraintFlag,
+		ptl.GeneralIntraConstraintFlag,
+		ptl.GeneralOnePictureOnlyConstraintFlag,
+		ptl.GeneralLowerBitRateConstraintFlag,
+		ptl.GeneralMax14BitConstraintFlag,
+	}
+
+	var ret [6]uint8
+
+	for i, flag := range flags {
+		if flag {
+			ret[i/8] |= 1 << (7 - (i % 8))
+		}
+	}
+
+	return ret
+}
+
+// h265HasProfile checks whether a profile_tier_level carries a profile.
+func h265HasProfile(ptl *h265.SPS_ProfileTierLevel) bool {
+	if ptl.GeneralProfileIdc != 0 {
+		return true
+	}
+
+	for _, flag := range ptl.GeneralProfileCompatibilityFlag {
+		if flag {
+			return true
+		}
+	}
+
+	return false
+}
+
 func esdsFindDecoderConf(descriptors []amp4.Descriptor) *amp4.DecoderConfigDescriptor {
 	for _, desc := range descriptors {
 		if desc.Tag == amp4.DecoderConfigDescrTag {
@@ -146,13 +451,16 @@ const (
 	initial readState = iota
 	waitingAv1C
 	waitingVpcC
+	waitingVP8VpcC
 	waitingHvcC
+	waitingVvcC
 	waitingAvcC
 	waitingVideoEsds
 	waitingAudioEsds
 	waitingDOps
 	waitingDac3
 	waitingDec3
+	waitingDac4
 	waitingPcmC
 	waitingDfLa
 	waitingAdditional
@@ -162,30 +470,167 @@ const (
 type CodecBoxesReader struct {
 	Codec codecs.Codec
 
-	state        readState
-	width        int
-	height       int
-	sampleRate   int
-	channelCount int
+	state             readState
+	width             int
+	height            int
+	sampleRate        int
+	channelCount      int
+	colorInfo         *codecs.ColorInfo
+	masteringDisplay  *codecs.MasteringDisplay
+	contentLightLevel *codecs.ContentLightLevel
+	dolbyVision       *codecs.DolbyVisionConfig
+}
+
+func colorInfoFromColr(colr *amp4.Colr) *codecs.ColorInfo {
+	switch colr.ColourType {
+	case [4]byte{'n', 'c', 'l', 'x'}:
+		return &codecs.ColorInfo{
+			ColorPrimaries:          uint8(colr.ColourPrimaries),
+			TransferCharacteristics: uint8(colr.TransferCharacteristics),
+			MatrixCoefficients:      uint8(colr.MatrixCoefficients),
+			FullRange:               colr.FullRangeFlag,
+		}
+
+	// QuickTime variant, without the full range flag
+	case [4]byte{'n', 'c', 'l', 'c'}:
+		if len(colr.Unknown) < 6 {
+			return nil
+		}
+
+		return &codecs.ColorInfo{
+			ColorPrimaries:          uint8(binary.BigEndian.Uint16(colr.Unknown)),
+			TransferCharacteristics: uint8(binary.BigEndian.Uint16(colr.Unknown[2:])),
+			MatrixCoefficients:      uint8(binary.BigEndian.Uint16(colr.Unknown[4:])),
+		}
+
+	default:
+		return nil
+	}
+}
+
+func (r *CodecBoxesReader) fillDolbyVision() {
+	switch codec := r.Codec.(type) {
+	case *codecs.AV1:
+		codec.DolbyVision = r.dolbyVision
+
+	case *codecs.H265:
+		codec.DolbyVision = r.dolbyVision
+	}
+}
+
+// fillHDR fills HDR-related fields of the codec.
+// The colour description is filled only when it differs from the one
+// that is carried by the codec configuration.
+func (r *CodecBoxesReader) fillHDR() {
+	var colorInfo **codecs.ColorInfo
+	var masteringDisplay **codecs.MasteringDisplay
+	var contentLightLevel **codecs.ContentLightLevel
+
+	switch codec := r.Codec.(type) {
+	case *codecs.AV1:
+		colorInfo, masteringDisplay, contentLightLevel = &codec.ColorInfo, &codec.MasteringDisplay, &codec.ContentLightLevel
+
+	case *codecs.VP9:
+		colorInfo, masteringDisplay, contentLightLevel = &codec.ColorInfo, &codec.MasteringDisplay, &codec.ContentLightLevel
+
+	case *codecs.H265:
+		colorInfo, masteringDisplay, contentLightLevel = &codec.ColorInfo, &codec.MasteringDisplay, &codec.ContentLightLevel
+
+	case *codecs.H264:
+		colorInfo, masteringDisplay, contentLightLevel = &codec.ColorInfo, &codec.MasteringDisplay, &codec.ContentLightLevel
+
+	default:
+		return
+	}
+
+	*masteringDisplay = r.masteringDisplay
+	*contentLightLevel = r.contentLightLevel
+
+	if r.colorInfo != nil {
+		var info CodecInfo
+		err := info.Fill(r.Codec)
+		if err != nil || info.ColorInfo == nil || *info.ColorInfo != *r.colorInfo {
+			*colorInfo = r.colorInfo
+		}
+	}
 }
 
 // ReadCodecBoxes reads codec-related boxes.
 func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
+	registerBoxTypes()
+
 	if len(h.Path) < 7 {
 		if r.state != waitingAdditional {
 			return nil, fmt.Errorf("codec information not found")
 		}
 
+		r.fillHDR()
+		r.fillDolbyVision()
+
 		return nil, ErrReadEnded
 	}
 
 	switch h.BoxInfo.Type.String() {
-	// codecs not supported yet
+	case "dvcC", "dvvC", "dvwC":
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+		dovi := box.(*DoviConfig)
+
+		r.dolbyVision = &codecs.DolbyVisionConfig{
+			VersionMajor:            dovi.DVVersionMajor,
+			VersionMinor:            dovi.DVVersionMinor,
+			Profile:                 dovi.DVProfile,
+			Level:                   dovi.DVLevel,
+			RPUPresent:              dovi.RPUPresentFlag,
+			ELPresent:               dovi.ELPresentFlag,
+			BLPresent:               dovi.BLPresentFlag,
+			BLSignalCompatibilityID: dovi.DVBLSignalCompatibilityID,
+		}
+
+	case "colr":
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+
+		r.colorInfo = colorInfoFromColr(box.(*amp4.Colr))
+
+	case "mdcv":
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+		mdcv := box.(*Mdcv)
+
+		r.masteringDisplay = &codecs.MasteringDisplay{
+			DisplayPrimariesX: [3]uint16{mdcv.DisplayPrimaries[0], mdcv.DisplayPrimaries[2], mdcv.DisplayPrimaries[4]},
+			DisplayPrimariesY: [3]uint16{mdcv.DisplayPrimaries[1], mdcv.DisplayPrimaries[3], mdcv.DisplayPrimaries[5]},
+			WhitePointX:       mdcv.WhitePointX,
+			WhitePointY:       mdcv.WhitePointY,
+			MaxLuminance:      mdcv.MaxDisplayMasteringLuminance,
+			MinLuminance:      mdcv.MinDisplayMasteringLuminance,
+		}
+
+	case "clli":
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+		clli := box.(*Clli)
+
+		r.contentLightLevel = &codecs.ContentLightLevel{
+			MaxContentLightLevel:    clli.MaxContentLightLevel,
+			MaxPicAverageLightLevel: clli.MaxPicAverageLightLevel,
+		}
+
 	case "c608":
 		if r.state != initial {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 		}
 
+		r.Codec = &codecs.CEA608{}
 		r.state = waitingAdditional
 
 	case "avc1":
@@ -218,6 +663,26 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		}
 		r.state = waitingAdditional
 
+	case "vp08":
+		if r.state != initial {
+			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+		}
+
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+		vp08 := box.(*amp4.VisualSampleEntry)
+
+		if vp08.Width == 0 || vp08.Height == 0 {
+			return nil, fmt.Errorf("VP8 parameters not provided")
+		}
+
+		r.width = int(vp08.Width)
+		r.height = int(vp08.Height)
+		r.state = waitingVP8VpcC
+		return h.Expand()
+
 	case "vp09":
 		if r.state != initial {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
@@ -239,7 +704,7 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		return h.Expand()
 
 	case "vpcC":
-		if r.state != waitingVpcC {
+		if r.state != waitingVpcC && r.state != waitingVP8VpcC {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 		}
 
@@ -249,6 +714,15 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		}
 		vpcc := box.(*amp4.VpcC)
 
+		if r.state == waitingVP8VpcC {
+			r.Codec = &codecs.VP8{
+				Width:  r.width,
+				Height: r.height,
+			}
+			r.state = waitingAdditional
+			return nil, nil
+		}
+
 		r.Codec = &codecs.VP9{
 			Width:             r.width,
 			Height:            r.height,
@@ -259,7 +733,7 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		}
 		r.state = waitingAdditional
 
-	case "hvc1", "hev1":
+	case "hvc1", "hev1", "dvh1", "dvhe":
 		if r.state != initial {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 		}
@@ -290,6 +764,43 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		}
 		r.state = waitingAdditional
 
+	case "vvc1", "vvi1":
+		if r.state != initial {
+			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+		}
+
+		r.state = waitingVvcC
+		return h.Expand()
+
+	case "vvcC":
+		if r.state != waitingVvcC {
+			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+		}
+
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+		vvcc := box.(*VvcC)
+
+		var rec VvcDecoderConfigurationRecord
+		err = rec.Unmarshal(vvcc.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vvcC: %w", err)
+		}
+
+		vps, sps, pps, err := h266FindParams(rec.NaluArrays)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Codec = &codecs.H266{
+			VPS: vps,
+			SPS: sps,
+			PPS: pps,
+		}
+		r.state = waitingAdditional
+
 	case "mp4a":
 		if r.state != initial {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
@@ -306,7 +817,7 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		r.state = waitingAudioEsds
 		return h.Expand()
 
-	case "av01":
+	case "av01", "dav1":
 		if r.state != initial {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 		}
@@ -386,18 +897,14 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 		}
 		dfla := box.(*amp4.DfLa)
 
-		if len(dfla.Blocks) < 4 {
-			return nil, fmt.Errorf("FLAC dfLa box is too short")
-		}
-
-		var streamInfo flac.StreamInfo
-		err = streamInfo.Unmarshal(dfla.Blocks[4:])
+		streamInfo, blocks, err := flacUnmarshalDfLaBlocks(dfla.Blocks)
 		if err != nil {
 			return nil, err
 		}
 
 		r.Codec = &codecs.FLAC{
-			StreamInfo: &streamInfo,
+			StreamInfo:     streamInfo,
+			MetadataBlocks: blocks,
 		}
 		r.state = waitingAdditional
 
@@ -443,7 +950,13 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 				}
 				r.state = waitingAdditional
 
-			case ObjectTypeIndicationVisualISO1318part2Main:
+			case ObjectTypeIndicationVisualISO1318part2Simple,
+				ObjectTypeIndicationVisualISO1318part2Main,
+				ObjectTypeIndicationVisualISO1318part2SNR,
+				ObjectTypeIndicationVisualISO1318part2Spatial,
+				ObjectTypeIndicationVisualISO1318part2High,
+				ObjectTypeIndicationVisualISO1318part2422,
+				ObjectTypeIndicationVisualISO11172part2:
 				spec := esdsFindDecoderSpecificInfo(esds.Descriptors)
 				if len(spec) == 0 {
 					return nil, fmt.Errorf("unable to find decoder specific info")
@@ -563,26 +1076,107 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
 		}
 
+		// the definition provided by go-mp4 does not support
+		// the Joint Object Coding extension, therefore the box is read as raw bytes.
+		if (h.BoxInfo.Size - h.BoxInfo.HeaderSize) > maxDec3Size {
+			return nil, fmt.Errorf("dec3 is too big")
+		}
+
+		var buf bytes.Buffer
+		_, err := h.ReadData(&buf)
+		if err != nil {
+			return nil, err
+		}
+
+		var config eac3.Config
+		err = config.Unmarshal(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("invalid dec3: %w", err)
+		}
+
+		codec := &codecs.EAC3{
+			SampleRate:            r.sampleRate,
+			ChannelCount:          r.channelCount,
+			DataRate:              config.DataRate,
+			Asvc:                  config.IndependentSubstreams[0].Asvc,
+			Bsmod:                 config.IndependentSubstreams[0].Bsmod,
+			Acmod:                 config.IndependentSubstreams[0].Acmod,
+			LfeOn:                 config.IndependentSubstreams[0].LfeOn,
+			NumDepSub:             config.IndependentSubstreams[0].NumDepSub,
+			ChanLoc:               config.IndependentSubstreams[0].ChanLoc,
+			FlagEC3ExtensionTypeA: config.FlagEC3ExtensionTypeA,
+			ComplexityIndexTypeA:  config.ComplexityIndexTypeA,
+		}
+
+		for _, sub := range config.IndependentSubstreams[1:] {
+			codec.AdditionalSubstreams = append(codec.AdditionalSubstreams, codecs.EAC3Substream{
+				Asvc:      sub.Asvc,
+				Bsmod:     sub.Bsmod,
+				Acmod:     sub.Acmod,
+				LfeOn:     sub.LfeOn,
+				NumDepSub: sub.NumDepSub,
+				ChanLoc:   sub.ChanLoc,
+			})
+		}
+
+		r.Codec = codec
+		r.state = waitingAdditional
+
+	case "ac-4":
+		if r.state != initial {
+			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+		}
+
 		box, _, err := h.ReadPayload()
 		if err != nil {
 			return nil, err
 		}
-		dec3 := box.(*amp4.Dec3)
+		ac4Entry := box.(*amp4.AudioSampleEntry)
 
-		if dec3.NumIndSub != 0 {
-			return nil, fmt.Errorf("multi-substream E-AC-3 content is not supported")
+		r.sampleRate = int(ac4Entry.SampleRate / 65536)
+		r.channelCount = int(ac4Entry.ChannelCount)
+		r.state = waitingDac4
+		return h.Expand()
+
+	case "dac4":
+		if r.state != waitingDac4 {
+			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+		}
+
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
 		}
+		dac4 := box.(*Dac4)
 
-		r.Codec = &codecs.EAC3{
+		var dsi ac4.DSI
+		err = dsi.Unmarshal(dac4.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dac4: %w", err)
+		}
+
+		r.Codec = &codecs.AC4{
 			SampleRate:   r.sampleRate,
 			ChannelCount: r.channelCount,
-			DataRate:     dec3.DataRate,
-			Asvc:         dec3.IndSubs[0].Asvc != 0,
-			Bsmod:        dec3.IndSubs[0].Bsmod,
-			Acmod:        dec3.IndSubs[0].Acmod,
-			LfeOn:        dec3.IndSubs[0].LfeOn != 0,
-			NumDepSub:    dec3.IndSubs[0].NumDepSub,
-			ChanLoc:      dec3.IndSubs[0].ChanLoc,
+			DSI:          &dsi,
+		}
+		r.state = waitingAdditional
+
+	case "alaw", "ulaw":
+		if r.state != initial {
+			return nil, fmt.Errorf("unexpected box '%v'", h.BoxInfo.Type)
+		}
+
+		box, _, err := h.ReadPayload()
+		if err != nil {
+			return nil, err
+		}
+		entry := box.(*amp4.AudioSampleEntry)
+
+		r.Codec = &codecs.G711{
+			MULaw:        h.BoxInfo.Type.String() == "ulaw",
+			SampleRate:   int(entry.SampleRate / 65536),
+			ChannelCount: int(entry.ChannelCount),
 		}
 		r.state = waitingAdditional
 
@@ -627,15 +1221,37 @@ func (r *CodecBoxesReader) Read(h *amp4.ReadHandle) (any, error) {
 
 // WriteCodecBoxes writes codec-related boxes.
 func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo, avgBitrate, maxBitrate uint32) error {
+	registerBoxTypes()
+
 	/*
 		|av01| (AV1)
+		|dav1| (AV1 with Dolby Vision, not backward compatible)
 		|    |av1C|
+		|    |dvvC| (optional)
+		|    |colr| (optional)
+		|    |mdcv| (optional)
+		|    |clli| (optional)
+		|vp08| (VP8)
+		|    |vpcC|
 		|vp09| (VP9)
 		|    |vpcC|
+		|    |colr| (optional)
+		|    |mdcv| (optional)
+		|    |clli| (optional)
 		|hvc1| (H265)
+		|dvh1| (H265 with Dolby Vision, not backward compatible)
 		|    |hvcC|
+		|    |dvcC| or |dvvC| (optional)
+		|    |colr| (optional)
+		|    |mdcv| (optional)
+		|    |clli| (optional)
+		|vvc1| (H266)
+		|    |vvcC|
 		|avc1| (H264)
 		|    |avcC|
+		|    |colr| (optional)
+		|    |mdcv| (optional)
+		|    |clli| (optional)
 		|mp4v| (MPEG-4/2/1 video, MJPEG)
 		|    |esds|
 		|Opus| (Opus)
@@ -648,16 +1264,26 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 		|    |dac3|
 		|ec-3| (E-AC-3 / Dolby Digital Plus)
 		|    |dec3|
+		|ac-4| (AC-4)
+		|    |dac4|
+		|alaw| (G711 A-law)
+		|ulaw| (G711 mu-law)
 		|ipcm| (LPCM)
 		|    |pcmC|
+		|c608| (CEA-608)
 	*/
 
 	switch codec := codec.(type) {
 	case *codecs.AV1:
-		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <av01>
+		typ := amp4.BoxTypeAv01()
+		if codec.DolbyVision != nil && codec.DolbyVision.BLSignalCompatibilityID == 0 {
+			typ = boxTypeDav1()
+		}
+
+		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <av01> or <dav1>
 			SampleEntry: amp4.SampleEntry{
 				AnyTypeBox: amp4.AnyTypeBox{
-					Type: amp4.BoxTypeAv01(),
+					Type: typ,
 				},
 				DataReferenceIndex: 1,
 			},
@@ -697,6 +1323,49 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return err
 		}
 
+		err = writeDoviConfig(w, codec.DolbyVision)
+		if err != nil {
+			return err
+		}
+
+		err = writeHDRBoxes(w, info.ColorInfo, codec.MasteringDisplay, codec.ContentLightLevel)
+		if err != nil {
+			return err
+		}
+
+	case *codecs.VP8:
+		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <vp08>
+			SampleEntry: amp4.SampleEntry{
+				AnyTypeBox: amp4.AnyTypeBox{
+					Type: amp4.BoxTypeVp08(),
+				},
+				DataReferenceIndex: 1,
+			},
+			Width:           uint16(info.Width),
+			Height:          uint16(info.Height),
+			Horizresolution: 4718592,
+			Vertresolution:  4718592,
+			FrameCount:      1,
+			Depth:           24,
+			PreDefined3:     -1,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteBox(&amp4.VpcC{ // <vpcC/>
+			FullBox: amp4.FullBox{
+				Version: 1,
+			},
+			Profile:           0,
+			Level:             10, // level 1
+			BitDepth:          8,
+			ChromaSubsampling: 1, // 4:2:0 colocated with luma
+		})
+		if err != nil {
+			return err
+		}
+
 	case *codecs.VP9:
 		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <vp09>
 			SampleEntry: amp4.SampleEntry{
@@ -717,7 +1386,7 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return err
 		}
 
-		_, err = w.WriteBox(&amp4.VpcC{ // <vpcC/>
+		vpcc := &amp4.VpcC{
 			FullBox: amp4.FullBox{
 				Version: 1,
 			},
@@ -726,16 +1395,47 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			BitDepth:           codec.BitDepth,
 			ChromaSubsampling:  codec.ChromaSubsampling,
 			VideoFullRangeFlag: boolToUint8(codec.ColorRange),
-		})
+		}
+
+		if info.ColorInfo != nil {
+			vpcc.ColourPrimaries = info.ColorInfo.ColorPrimaries
+			vpcc.TransferCharacteristics = info.ColorInfo.TransferCharacteristics
+			vpcc.MatrixCoefficients = info.ColorInfo.MatrixCoefficients
+		}
+
+		_, err = w.WriteBox(vpcc) // <vpcC/>
+		if err != nil {
+			return err
+		}
+
+		err = writeHDRBoxes(w, info.ColorInfo, codec.MasteringDisplay, codec.ContentLightLevel)
 		if err != nil {
 			return err
 		}
 
 	case *codecs.H265:
-		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <hvc1>
+		ptl := &info.H265SPS.ProfileTierLevel
+		constraintIndicator := [6]uint8{
+			codec.SPS[7], codec.SPS[8], codec.SPS[9],
+			codec.SPS[10], codec.SPS[11], codec.SPS[12],
+		}
+
+		// some encoders leave the profile_tier_level of the SPS empty,
+		// in this case take profile, tier, level and constraints from the VPS
+		if !h265HasProfile(ptl) && info.H265VPS != nil {
+			ptl = &info.H265VPS.ProfileTierLevel
+			constraintIndicator = h265ConstraintIndicator(ptl)
+		}
+
+		typ := amp4.BoxTypeHvc1()
+		if codec.DolbyVision != nil && codec.DolbyVision.BLSignalCompatibilityID == 0 {
+			typ = boxTypeDvh1()
+		}
+
+		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <hvc1> or <dvh1>
 			SampleEntry: amp4.SampleEntry{
 				AnyTypeBox: amp4.AnyTypeBox{
-					Type: amp4.BoxTypeHvc1(),
+					Type: typ,
 				},
 				DataReferenceIndex: 1,
 			},
@@ -753,14 +1453,13 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 
 		_, err = w.WriteBox(&amp4.HvcC{ // <hvcC/>
 			ConfigurationVersion:        1,
-			GeneralProfileIdc:           info.H265SPS.ProfileTierLevel.GeneralProfileIdc,
-			GeneralProfileCompatibility: info.H265SPS.ProfileTierLevel.GeneralProfileCompatibilityFlag,
-			GeneralConstraintIndicator: [6]uint8{
-				codec.SPS[7], codec.SPS[8], codec.SPS[9],
-				codec.SPS[10], codec.SPS[11], codec.SPS[12],
-			},
-			GeneralLevelIdc: info.H265SPS.ProfileTierLevel.GeneralLevelIdc,
-			Reserved1:       0b1111,
+			GeneralProfileSpace:         ptl.GeneralProfileSpace,
+			GeneralTierFlag:             ptl.GeneralTierFlag == 1,
+			GeneralProfileIdc:           ptl.GeneralProfileIdc,
+			GeneralProfileCompatibility: ptl.GeneralProfileCompatibilityFlag,
+			GeneralConstraintIndicator:  constraintIndicator,
+			GeneralLevelIdc:             ptl.GeneralLevelIdc,
+			Reserved1:                   0b1111,
 			// MinSpatialSegmentationIdc
 			Reserved2: 0b111111,
 			// ParallelismType
@@ -807,6 +1506,83 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return err
 		}
 
+		err = writeDoviConfig(w, codec.DolbyVision)
+		if err != nil {
+			return err
+		}
+
+		err = writeHDRBoxes(w, info.ColorInfo, codec.MasteringDisplay, codec.ContentLightLevel)
+		if err != nil {
+			return err
+		}
+
+	case *codecs.H266:
+		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <vvc1>
+			SampleEntry: amp4.SampleEntry{
+				AnyTypeBox: amp4.AnyTypeBox{
+					Type: boxTypeVvc1(),
+				},
+				DataReferenceIndex: 1,
+			},
+			Width:           uint16(info.Width),
+			Height:          uint16(info.Height),
+			Horizresolution: 4718592,
+			Vertresolution:  4718592,
+			FrameCount:      1,
+			Depth:           24,
+			PreDefined3:     -1,
+		})
+		if err != nil {
+			return err
+		}
+
+		rec := VvcDecoderConfigurationRecord{
+			LengthSizeMinusOne: 3,
+		}
+
+		if info.H266SPS.ProfileTierLevel != nil {
+			rec.PTL = &VvcPTLInfo{
+				NumSublayers:     info.H266SPS.MaxSubLayersMinus1 + 1,
+				ChromaFormatIdc:  info.H266SPS.ChromaFormatIdc,
+				BitDepthMinus8:   uint8(info.H266SPS.BitDepthMinus8),
+				NativePTL:        *info.H266SPS.ProfileTierLevel,
+				MaxPictureWidth:  uint16(info.H266SPS.PicWidthMaxInLumaSamples),
+				MaxPictureHeight: uint16(info.H266SPS.PicHeightMaxInLumaSamples),
+			}
+		}
+
+		if codec.VPS != nil {
+			rec.NaluArrays = append(rec.NaluArrays, VvcNaluArray{
+				ArrayCompleteness: true,
+				NaluType:          h266.NALUType_VPS_NUT,
+				Nalus:             [][]byte{codec.VPS},
+			})
+		}
+
+		rec.NaluArrays = append(rec.NaluArrays,
+			VvcNaluArray{
+				ArrayCompleteness: true,
+				NaluType:          h266.NALUType_SPS_NUT,
+				Nalus:             [][]byte{codec.SPS},
+			},
+			VvcNaluArray{
+				ArrayCompleteness: true,
+				NaluType:          h266.NALUType_PPS_NUT,
+				Nalus:             [][]byte{codec.PPS},
+			})
+
+		data, err := rec.Marshal()
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteBox(&VvcC{ // <vvcC/>
+			Data: data,
+		})
+		if err != nil {
+			return err
+		}
+
 	case *codecs.H264:
 		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <avc1>
 			SampleEntry: amp4.SampleEntry{
@@ -857,6 +1633,11 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return err
 		}
 
+		err = writeHDRBoxes(w, info.ColorInfo, codec.MasteringDisplay, codec.ContentLightLevel)
+		if err != nil {
+			return err
+		}
+
 	case *codecs.MPEG4Video: //nolint:dupl
 		_, err := w.WriteBoxStart(&amp4.VisualSampleEntry{ // <mp4v>
 			SampleEntry: amp4.SampleEntry{
@@ -946,7 +1727,7 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 					Tag:  amp4.DecoderConfigDescrTag,
 					Size: 18 + uint32(len(codec.Config)),
 					DecoderConfigDescriptor: &amp4.DecoderConfigDescriptor{
-						ObjectTypeIndication: ObjectTypeIndicationVisualISO1318part2Main,
+						ObjectTypeIndication: mpeg1VideoObjectTypeIndication(info.MPEG1VideoConfig),
 						StreamType:           StreamTypeVisualStream,
 						Reserved:             true,
 						MaxBitrate:           maxBitrate,
@@ -1061,22 +1842,17 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return err
 		}
 
-		enc, err := codec.StreamInfo.Marshal()
+		// STREAMINFO is followed by optional metadata blocks,
+		// and the last one is marked as last.
+		blocks := append(flac.MetadataBlocks{codec.StreamInfo}, codec.MetadataBlocks...)
+
+		enc, err := blocks.Marshal()
 		if err != nil {
 			return err
 		}
 
-		// build METADATA_BLOCK_HEADER: is_last=1, block_type=0 (STREAMINFO), length=len(StreamInfo)
-		blockLen := len(enc)
-		header := []byte{
-			0x80, // is_last=1, block_type=0
-			byte(blockLen >> 16),
-			byte(blockLen >> 8),
-			byte(blockLen),
-		}
-
 		_, err = w.WriteBox(&amp4.DfLa{ // <dfLa/>
-			Blocks: append(header, enc...),
+			Blocks: enc,
 		})
 		if err != nil {
 			return err
@@ -1095,6 +1871,9 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			case codec.Config.ChannelConfig == 7:
 				channelCount = 8
 
+			case codec.Config.USACConfig != nil && len(codec.Config.USACConfig.OutputChannelPositions) != 0:
+				channelCount = uint16(len(codec.Config.USACConfig.OutputChannelPositions))
+
 			default:
 				return fmt.Errorf("MPEG-4 audio channelConfig = 0 is not supported (yet)")
 			}
@@ -1115,7 +1894,10 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return err
 		}
 
-		enc, _ := codec.Config.Marshal()
+		enc, err := codec.Config.Marshal()
+		if err != nil {
+			return err
+		}
 
 		_, err = w.WriteBox(&amp4.Esds{ // <esds/>
 			Descriptors: []amp4.Descriptor{
@@ -1273,19 +2055,100 @@ func WriteCodecBoxes(w *Writer, codec codecs.Codec, trackID int, info *CodecInfo
 			return fmt.Errorf("unsupported sample rate: %v", codec.SampleRate)
 		}
 
-		_, err = w.WriteBox(&amp4.Dec3{
-			DataRate:  codec.DataRate,
-			NumIndSub: 0,
-			IndSubs: []amp4.Dec3IndSub{{
+		config := eac3.Config{
+			DataRate: codec.DataRate,
+			IndependentSubstreams: []eac3.IndependentSubstream{{
 				Fscod:     fscod,
 				Bsid:      16,
-				Asvc:      boolToUint8(codec.Asvc),
+				Asvc:      codec.Asvc,
 				Bsmod:     codec.Bsmod,
 				Acmod:     codec.Acmod,
-				LfeOn:     boolToUint8(codec.LfeOn),
+				LfeOn:     codec.LfeOn,
 				NumDepSub: codec.NumDepSub,
 				ChanLoc:   codec.ChanLoc,
 			}},
+			FlagEC3ExtensionTypeA: codec.FlagEC3ExtensionTypeA,
+			ComplexityIndexTypeA:  codec.ComplexityIndexTypeA,
+		}
+
+		for _, sub := range codec.AdditionalSubstreams {
+			config.IndependentSubstreams = append(config.IndependentSubstreams, eac3.IndependentSubstream{
+				Fscod:     fscod,
+				Bsid:      16,
+				Asvc:      sub.Asvc,
+				Bsmod:     sub.Bsmod,
+				Acmod:     sub.Acmod,
+				LfeOn:     sub.LfeOn,
+				NumDepSub: sub.NumDepSub,
+				ChanLoc:   sub.ChanLoc,
+			})
+		}
+
+		enc, err := config.Marshal()
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteRawBox(amp4.BoxTypeDec3(), enc) // <dec3/>
+		if err != nil {
+			return err
+		}
+
+	case *codecs.AC4:
+		_, err := w.WriteBoxStart(&amp4.AudioSampleEntry{ // <ac-4>
+			SampleEntry: amp4.SampleEntry{
+				AnyTypeBox: amp4.AnyTypeBox{
+					Type: boxTypeAc4(),
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.ChannelCount),
+			SampleSize:   16,
+			SampleRate:   uint32(codec.SampleRate * 65536),
+		})
+		if err != nil {
+			return err
+		}
+
+		enc, err := codec.DSI.Marshal()
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteBox(&Dac4{ // <dac4/>
+			Data: enc,
+		})
+		if err != nil {
+			return err
+		}
+
+	case *codecs.G711:
+		typ := boxTypeAlaw()
+		if codec.MULaw {
+			typ = boxTypeUlaw()
+		}
+
+		_, err := w.WriteBoxStart(&amp4.AudioSampleEntry{ // <alaw> or <ulaw>
+			SampleEntry: amp4.SampleEntry{
+				AnyTypeBox: amp4.AnyTypeBox{
+					Type: typ,
+				},
+				DataReferenceIndex: 1,
+			},
+			ChannelCount: uint16(codec.ChannelCount),
+			SampleSize:   16,
+			SampleRate:   uint32(codec.SampleRate * 65536),
+		})
+		if err != nil {
+			return err
+		}
+
+	case *codecs.CEA608:
+		_, err := w.WriteBoxStart(&amp4.SampleEntry{ // <c608>
+			AnyTypeBox: amp4.AnyTypeBox{
+				Type: boxTypeC608(),
+			},
+			DataReferenceIndex: 1,
 		})
 		if err != nil {
 			return err
@@ -6,16 +6,29 @@ import (
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1video"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4video"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mp4/codecs"
 )
 
+// IsClosedCaptions checks whether a codec is a closed caption codec.
+func IsClosedCaptions(codec codecs.Codec) bool {
+	_, ok := codec.(*codecs.CEA608)
+	return ok
+}
+
 // CodecInfo contains codec-dependent infos.
 type CodecInfo struct {
 	Width             int
 	Height            int
 	AV1SequenceHeader *av1.SequenceHeader
+	H265VPS           *h265.VPS
 	H265SPS           *h265.SPS
+	H266SPS           *h266.SPS
 	H264SPS           *h264.SPS
+	MPEG1VideoConfig  *mpeg1video.Config
+	ColorInfo         *codecs.ColorInfo
 }
 
 // Fill fills CodecInfo from a codecs.Codec.
@@ -31,6 +44,27 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 		ci.Width = av1SequenceHeader.Width()
 		ci.Height = av1SequenceHeader.Height()
 		ci.AV1SequenceHeader = av1SequenceHeader
+		ci.ColorInfo = codec.ColorInfo
+
+		if ci.ColorInfo == nil && av1SequenceHeader.ColorConfig.ColorDescriptionPresentFlag {
+			cc := &av1SequenceHeader.ColorConfig
+			ci.ColorInfo = &codecs.ColorInfo{
+				ColorPrimaries:          uint8(cc.ColorPrimaries),
+				TransferCharacteristics: uint8(cc.TransferCharacteristics),
+				MatrixCoefficients:      uint8(cc.MatrixCoefficients),
+				FullRange:               cc.ColorRange,
+			}
+		}
+
+		return nil
+
+	case *codecs.VP8:
+		if codec.Width == 0 {
+			return fmt.Errorf("VP8 parameters not provided")
+		}
+
+		ci.Width = codec.Width
+		ci.Height = codec.Height
 		return nil
 
 	case *codecs.VP9:
@@ -40,6 +74,23 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 
 		ci.Width = codec.Width
 		ci.Height = codec.Height
+		ci.ColorInfo = codec.ColorInfo
+		return nil
+
+	case *codecs.H266:
+		if len(codec.SPS) == 0 || len(codec.PPS) == 0 {
+			return fmt.Errorf("H266 parameters not provided")
+		}
+
+		h266SPS := &h266.SPS{}
+		err := h266SPS.Unmarshal(codec.SPS)
+		if err != nil {
+			return fmt.Errorf("unable to parse H266 SPS: %w", err)
+		}
+
+		ci.Width = h266SPS.Width()
+		ci.Height = h266SPS.Height()
+		ci.H266SPS = h266SPS
 		return nil
 
 	case *codecs.H265:
@@ -53,9 +104,29 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 			return fmt.Errorf("unable to parse H265 SPS: %w", err)
 		}
 
+		// VPS is used only when the SPS lacks a profile, therefore parsing errors are not fatal
+		h265VPS := &h265.VPS{}
+		err = h265VPS.Unmarshal(codec.VPS)
+		if err != nil {
+			h265VPS = nil
+		}
+
 		ci.Width = h265SPS.Width()
 		ci.Height = h265SPS.Height()
+		ci.H265VPS = h265VPS
 		ci.H265SPS = h265SPS
+		ci.ColorInfo = codec.ColorInfo
+
+		if ci.ColorInfo == nil && h265SPS.VUI != nil &&
+			h265SPS.VUI.VideoSignalTypePresentFlag && h265SPS.VUI.ColourDescriptionPresentFlag {
+			ci.ColorInfo = &codecs.ColorInfo{
+				ColorPrimaries:          h265SPS.VUI.ColourPrimaries,
+				TransferCharacteristics: h265SPS.VUI.TransferCharacteristics,
+				MatrixCoefficients:      h265SPS.VUI.MatrixCoefficients,
+				FullRange:               h265SPS.VUI.VideoFullRangeFlag,
+			}
+		}
+
 		return nil
 
 	case *codecs.H264:
@@ -72,6 +143,18 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 		ci.Width = h264SPS.Width()
 		ci.Height = h264SPS.Height()
 		ci.H264SPS = h264SPS
+		ci.ColorInfo = codec.ColorInfo
+
+		if ci.ColorInfo == nil && h264SPS.VUI != nil &&
+			h264SPS.VUI.VideoSignalTypePresentFlag && h264SPS.VUI.ColourDescriptionPresentFlag {
+			ci.ColorInfo = &codecs.ColorInfo{
+				ColorPrimaries:          h264SPS.VUI.ColourPrimaries,
+				TransferCharacteristics: h264SPS.VUI.TransferCharacteristics,
+				MatrixCoefficients:      h264SPS.VUI.MatrixCoefficients,
+				FullRange:               h264SPS.VUI.VideoFullRangeFlag,
+			}
+		}
+
 		return nil
 
 	case *codecs.MPEG4Video:
@@ -79,9 +162,17 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 			return fmt.Errorf("MPEG-4 Video config not provided")
 		}
 
-		// TODO: parse config and use real values
-		ci.Width = 800
-		ci.Height = 600
+		var mpeg4VideoConfig mpeg4video.Config
+		err := mpeg4VideoConfig.Unmarshal(codec.Config)
+		if err != nil {
+			// config is written as is, use placeholder dimensions.
+			ci.Width = 800
+			ci.Height = 600
+			return nil //nolint:nilerr
+		}
+
+		ci.Width = mpeg4VideoConfig.Width()
+		ci.Height = mpeg4VideoConfig.Height()
 		return nil
 
 	case *codecs.MPEG1Video:
@@ -89,9 +180,18 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 			return fmt.Errorf("MPEG-1/2 Video config not provided")
 		}
 
-		// TODO: parse config and use real values
-		ci.Width = 800
-		ci.Height = 600
+		mpeg1VideoConfig := &mpeg1video.Config{}
+		err := mpeg1VideoConfig.Unmarshal(codec.Config)
+		if err != nil {
+			// config is written as is, use placeholder dimensions.
+			ci.Width = 800
+			ci.Height = 600
+			return nil //nolint:nilerr
+		}
+
+		ci.Width = mpeg1VideoConfig.Width()
+		ci.Height = mpeg1VideoConfig.Height()
+		ci.MPEG1VideoConfig = mpeg1VideoConfig
 		return nil
 
 	case *codecs.MJPEG:
@@ -103,7 +203,14 @@ func (ci *CodecInfo) Fill(codec codecs.Codec) error {
 		ci.Height = codec.Height
 		return nil
 
-	case *codecs.Opus, *codecs.MPEG4Audio, *codecs.MPEG1Audio, *codecs.AC3, *codecs.EAC3, *codecs.LPCM, *codecs.FLAC:
+	case *codecs.AC4:
+		if codec.DSI == nil {
+			return fmt.Errorf("AC-4 DSI not provided")
+		}
+		return nil
+
+	case *codecs.Opus, *codecs.MPEG4Audio, *codecs.MPEG1Audio, *codecs.AC3, *codecs.EAC3, *codecs.LPCM, *codecs.FLAC,
+		*codecs.G711, *codecs.CEA608:
 		return nil
 
 	default:
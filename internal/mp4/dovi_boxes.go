@@ -0,0 +1,48 @@
+package mp4
+
+import (
+	amp4 "github.com/abema/go-mp4"
+)
+
+func boxTypeDvh1() amp4.BoxType { return amp4.StrToBoxType("dvh1") }
+
+func boxTypeDvhe() amp4.BoxType { return amp4.StrToBoxType("dvhe") }
+
+func boxTypeDav1() amp4.BoxType { return amp4.StrToBoxType("dav1") }
+
+func boxTypeDvcC() amp4.BoxType { return amp4.StrToBoxType("dvcC") }
+
+func boxTypeDvvC() amp4.BoxType { return amp4.StrToBoxType("dvvC") }
+
+func boxTypeDvwC() amp4.BoxType { return amp4.StrToBoxType("dvwC") }
+
+// DoviConfig is a Dolby Vision configuration box (dvcC, dvvC or dvwC).
+// Specification: Dolby Vision Streams Within the ISO Base Media File Format, section 3.2
+type DoviConfig struct {
+	amp4.AnyTypeBox
+	DVVersionMajor            uint8     `mp4:"0,size=8"`
+	DVVersionMinor            uint8     `mp4:"1,size=8"`
+	DVProfile                 uint8     `mp4:"2,size=7"`
+	DVLevel                   uint8     `mp4:"3,size=6"`
+	RPUPresentFlag            bool      `mp4:"4,size=1"`
+	ELPresentFlag             bool      `mp4:"5,size=1"`
+	BLPresentFlag             bool      `mp4:"6,size=1"`
+	DVBLSignalCompatibilityID uint8     `mp4:"7,size=4"`
+	Reserved                  uint32    `mp4:"8,size=28,const=0"`
+	Reserved2                 [4]uint32 `mp4:"9,size=32,const=0"`
+}
+
+// doviConfigBoxType returns the type of the configuration box
+// that must be used with the given Dolby Vision profile.
+func doviConfigBoxType(profile uint8) amp4.BoxType {
+	switch {
+	case profile <= 7:
+		return boxTypeDvcC()
+
+	case profile <= 10:
+		return boxTypeDvvC()
+
+	default:
+		return boxTypeDvwC()
+	}
+}
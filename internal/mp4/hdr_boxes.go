@@ -0,0 +1,40 @@
+package mp4
+
+import (
+	amp4 "github.com/abema/go-mp4"
+)
+
+// BoxTypeMdcv returns the type of the mastering display colour volume box.
+func BoxTypeMdcv() amp4.BoxType { return amp4.StrToBoxType("mdcv") }
+
+// BoxTypeClli returns the type of the content light level box.
+func BoxTypeClli() amp4.BoxType { return amp4.StrToBoxType("clli") }
+
+// Mdcv is a mastering display colour volume box.
+// Specification: ISO 14496-12
+type Mdcv struct {
+	amp4.Box
+	DisplayPrimaries             [6]uint16 `mp4:"0,size=16"` // x0, y0, x1, y1, x2, y2
+	WhitePointX                  uint16    `mp4:"1,size=16"`
+	WhitePointY                  uint16    `mp4:"2,size=16"`
+	MaxDisplayMasteringLuminance uint32    `mp4:"3,size=32"`
+	MinDisplayMasteringLuminance uint32    `mp4:"4,size=32"`
+}
+
+// GetType implements amp4.IBox.
+func (*Mdcv) GetType() amp4.BoxType {
+	return BoxTypeMdcv()
+}
+
+// Clli is a content light level box.
+// Specification: ISO 14496-12
+type Clli struct {
+	amp4.Box
+	MaxContentLightLevel    uint16 `mp4:"0,size=16"`
+	MaxPicAverageLightLevel uint16 `mp4:"1,size=16"`
+}
+
+// GetType implements amp4.IBox.
+func (*Clli) GetType() amp4.BoxType {
+	return BoxTypeClli()
+}
@@ -0,0 +1,19 @@
+package mp4
+
+import (
+	amp4 "github.com/abema/go-mp4"
+)
+
+// BoxTypeNmhd returns the type of the null media header box.
+func BoxTypeNmhd() amp4.BoxType { return amp4.StrToBoxType("nmhd") }
+
+// Nmhd is a null media header box, used by tracks that are neither video nor audio.
+// Specification: ISO 14496-12, section 8.4.5.2
+type Nmhd struct {
+	amp4.FullBox `mp4:"0,extend"`
+}
+
+// GetType implements amp4.IBox.
+func (*Nmhd) GetType() amp4.BoxType {
+	return BoxTypeNmhd()
+}
@@ -2,9 +2,15 @@ package mp4
 
 // Specification: ISO 14496-1, Table 5
 const (
-	ObjectTypeIndicationVisualISO14496part2    = 0x20
-	ObjectTypeIndicationAudioISO14496part3     = 0x40
-	ObjectTypeIndicationVisualISO1318part2Main = 0x61
-	ObjectTypeIndicationAudioISO11172part3     = 0x6B
-	ObjectTypeIndicationVisualISO10918part1    = 0x6C
+	ObjectTypeIndicationVisualISO14496part2       = 0x20
+	ObjectTypeIndicationAudioISO14496part3        = 0x40
+	ObjectTypeIndicationVisualISO1318part2Simple  = 0x60
+	ObjectTypeIndicationVisualISO1318part2Main    = 0x61
+	ObjectTypeIndicationVisualISO1318part2SNR     = 0x62
+	ObjectTypeIndicationVisualISO1318part2Spatial = 0x63
+	ObjectTypeIndicationVisualISO1318part2High    = 0x64
+	ObjectTypeIndicationVisualISO1318part2422     = 0x65
+	ObjectTypeIndicationVisualISO11172part2       = 0x6A
+	ObjectTypeIndicationAudioISO11172part3        = 0x6B
+	ObjectTypeIndicationVisualISO10918part1       = 0x6C
 )
@@ -0,0 +1,351 @@
+package mp4
+
+import (
+	"fmt"
+
+	amp4 "github.com/abema/go-mp4"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
+)
+
+func boxTypeVvc1() amp4.BoxType { return amp4.StrToBoxType("vvc1") }
+
+func boxTypeVvi1() amp4.BoxType { return amp4.StrToBoxType("vvi1") }
+
+// BoxTypeVvcC returns the type of the VVC configuration box.
+func BoxTypeVvcC() amp4.BoxType { return amp4.StrToBoxType("vvcC") }
+
+// VvcC is a VVC configuration box.
+// Its content is a VvcDecoderConfigurationRecord, that has a variable layout
+// and is therefore kept as raw bytes.
+// Specification: ISO 14496-15, section 11.2.4.2
+type VvcC struct {
+	amp4.FullBox `mp4:"0,extend"`
+	Data         []byte `mp4:"1,size=8"`
+}
+
+// GetType implements amp4.IBox.
+func (*VvcC) GetType() amp4.BoxType {
+	return BoxTypeVvcC()
+}
+
+// VvcNaluArray is an array of NAL units of a VvcDecoderConfigurationRecord.
+type VvcNaluArray struct {
+	ArrayCompleteness bool
+	NaluType          h266.NALUType
+	Nalus             [][]byte
+}
+
+// VvcPTLInfo contains the optional profile, tier and level part of a VvcDecoderConfigurationRecord.
+type VvcPTLInfo struct {
+	OlsIdx            uint16
+	NumSublayers      uint8
+	ConstantFrameRate uint8
+	ChromaFormatIdc   uint8
+	BitDepthMinus8    uint8
+	NativePTL         h266.SPS_ProfileTierLevel
+	MaxPictureWidth   uint16
+	MaxPictureHeight  uint16
+	AvgFrameRate      uint16
+}
+
+// VvcDecoderConfigurationRecord is the content of a vvcC box.
+// Specification: ISO 14496-15, section 11.2.4.2
+type VvcDecoderConfigurationRecord struct {
+	LengthSizeMinusOne uint8
+	PTL                *VvcPTLInfo
+	NaluArrays         []VvcNaluArray
+}
+
+func vvcNaluArrayHasCount(typ h266.NALUType) bool {
+	return typ != h266.NALUType_DCI_NUT && typ != h266.NALUType_OPI_NUT
+}
+
+// Unmarshal decodes a VvcDecoderConfigurationRecord.
+func (r *VvcDecoderConfigurationRecord) Unmarshal(buf []byte) error {
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	pos += 5 // reserved
+	r.LengthSizeMinusOne = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	ptlPresentFlag := bits.ReadFlagUnsafe(buf, &pos)
+
+	if ptlPresentFlag {
+		r.PTL = &VvcPTLInfo{}
+		err = r.PTL.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		r.PTL = nil
+	}
+
+	numOfArrays, err := bits.ReadBits(buf, &pos, 8)
+	if err != nil {
+		return err
+	}
+
+	r.NaluArrays = make([]VvcNaluArray, numOfArrays)
+
+	for i := range r.NaluArrays {
+		arr := &r.NaluArrays[i]
+
+		err = bits.HasSpace(buf, pos, 8)
+		if err != nil {
+			return err
+		}
+
+		arr.ArrayCompleteness = bits.ReadFlagUnsafe(buf, &pos)
+		pos += 2 // reserved
+		arr.NaluType = h266.NALUType(bits.ReadBitsUnsafe(buf, &pos, 5))
+
+		numNalus := uint64(1)
+
+		if vvcNaluArrayHasCount(arr.NaluType) {
+			numNalus, err = bits.ReadBits(buf, &pos, 16)
+			if err != nil {
+				return err
+			}
+		}
+
+		arr.Nalus = make([][]byte, numNalus)
+
+		for j := range arr.Nalus {
+			var l uint64
+			l, err = bits.ReadBits(buf, &pos, 16)
+			if err != nil {
+				return err
+			}
+
+			err = bits.HasSpace(buf, pos, 8*int(l))
+			if err != nil {
+				return err
+			}
+
+			arr.Nalus[j] = append([]byte(nil), buf[pos/8:pos/8+int(l)]...)
+			pos += 8 * int(l)
+		}
+	}
+
+	return nil
+}
+
+func (p *VvcPTLInfo) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 9+3+2+2+3+5+2+6+7+1+8)
+	if err != nil {
+		return err
+	}
+
+	p.OlsIdx = uint16(bits.ReadBitsUnsafe(buf, pos, 9))
+	p.NumSublayers = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	p.ConstantFrameRate = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	p.ChromaFormatIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	p.BitDepthMinus8 = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	*pos += 5 // reserved
+
+	*pos += 2 // reserved
+	numBytesConstraintInfo := int(bits.ReadBitsUnsafe(buf, pos, 6))
+	p.NativePTL.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 7))
+	p.NativePTL.GeneralTierFlag = bits.ReadFlagUnsafe(buf, pos)
+	p.NativePTL.GeneralLevelIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+
+	err = bits.HasSpace(buf, *pos, 8*numBytesConstraintInfo)
+	if err != nil {
+		return err
+	}
+
+	p.NativePTL.ConstraintInfo = append([]byte(nil), buf[*pos/8:*pos/8+numBytesConstraintInfo]...)
+	*pos += 8 * numBytesConstraintInfo
+
+	if p.NumSublayers > 1 {
+		err = bits.HasSpace(buf, *pos, 8)
+		if err != nil {
+			return err
+		}
+
+		p.NativePTL.SubLayerLevelPresentFlag = make([]bool, p.NumSublayers-1)
+		p.NativePTL.SubLayerLevelIdc = make([]uint8, p.NumSublayers-1)
+
+		for j := int(p.NumSublayers) - 2; j >= 0; j-- {
+			p.NativePTL.SubLayerLevelPresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+		}
+		*pos += 9 - int(p.NumSublayers) // reserved
+
+		for j := int(p.NumSublayers) - 2; j >= 0; j-- {
+			if p.NativePTL.SubLayerLevelPresentFlag[j] {
+				var tmp uint64
+				tmp, err = bits.ReadBits(buf, pos, 8)
+				if err != nil {
+					return err
+				}
+				p.NativePTL.SubLayerLevelIdc[j] = uint8(tmp)
+			}
+		}
+	} else {
+		p.NativePTL.SubLayerLevelPresentFlag = nil
+		p.NativePTL.SubLayerLevelIdc = nil
+	}
+
+	numSubProfiles, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	if numSubProfiles != 0 {
+		err = bits.HasSpace(buf, *pos, 32*int(numSubProfiles))
+		if err != nil {
+			return err
+		}
+
+		p.NativePTL.GeneralSubProfileIdc = make([]uint32, numSubProfiles)
+		for j := range p.NativePTL.GeneralSubProfileIdc {
+			p.NativePTL.GeneralSubProfileIdc[j] = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+		}
+	} else {
+		p.NativePTL.GeneralSubProfileIdc = nil
+	}
+
+	err = bits.HasSpace(buf, *pos, 16+16+16)
+	if err != nil {
+		return err
+	}
+
+	p.MaxPictureWidth = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	p.MaxPictureHeight = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+	p.AvgFrameRate = uint16(bits.ReadBitsUnsafe(buf, pos, 16))
+
+	return nil
+}
+
+func (p *VvcPTLInfo) marshalSize() int {
+	n := 4 + 4 + len(p.NativePTL.ConstraintInfo)
+	if p.NumSublayers > 1 {
+		n++
+		for _, f := range p.NativePTL.SubLayerLevelPresentFlag {
+			if f {
+				n++
+			}
+		}
+	}
+	n += 1 + 4*len(p.NativePTL.GeneralSubProfileIdc)
+	n += 6
+	return n
+}
+
+func (p *VvcPTLInfo) marshalTo(buf []byte, pos *int) {
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.OlsIdx), 9)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.NumSublayers), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.ConstantFrameRate), 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.ChromaFormatIdc), 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.BitDepthMinus8), 3)
+	bits.WriteBitsUnsafe(buf, pos, 0b11111, 5)
+
+	bits.WriteBitsUnsafe(buf, pos, 0, 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.NativePTL.ConstraintInfo)), 6)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.NativePTL.GeneralProfileIdc), 7)
+	bits.WriteFlagUnsafe(buf, pos, p.NativePTL.GeneralTierFlag)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.NativePTL.GeneralLevelIdc), 8)
+	*pos += 8 * copy(buf[*pos/8:], p.NativePTL.ConstraintInfo)
+
+	if p.NumSublayers > 1 {
+		for j := int(p.NumSublayers) - 2; j >= 0; j-- {
+			bits.WriteFlagUnsafe(buf, pos, p.NativePTL.SubLayerLevelPresentFlag[j])
+		}
+		*pos += 9 - int(p.NumSublayers) // reserved
+
+		for j := int(p.NumSublayers) - 2; j >= 0; j-- {
+			if p.NativePTL.SubLayerLevelPresentFlag[j] {
+				bits.WriteBitsUnsafe(buf, pos, uint64(p.NativePTL.SubLayerLevelIdc[j]), 8)
+			}
+		}
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(len(p.NativePTL.GeneralSubProfileIdc)), 8)
+	for _, v := range p.NativePTL.GeneralSubProfileIdc {
+		bits.WriteBitsUnsafe(buf, pos, uint64(v), 32)
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.MaxPictureWidth), 16)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.MaxPictureHeight), 16)
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.AvgFrameRate), 16)
+}
+
+// Marshal encodes a VvcDecoderConfigurationRecord.
+func (r VvcDecoderConfigurationRecord) Marshal() ([]byte, error) {
+	n := 1
+
+	if r.PTL != nil {
+		if len(r.PTL.NativePTL.ConstraintInfo) > 63 {
+			return nil, fmt.Errorf("constraint info is too big")
+		}
+
+		if r.PTL.NumSublayers > 1 && (len(r.PTL.NativePTL.SubLayerLevelPresentFlag) != int(r.PTL.NumSublayers-1) ||
+			len(r.PTL.NativePTL.SubLayerLevelIdc) != int(r.PTL.NumSublayers-1)) {
+			return nil, fmt.Errorf("invalid sublayer levels")
+		}
+
+		if len(r.PTL.NativePTL.GeneralSubProfileIdc) > 255 {
+			return nil, fmt.Errorf("too many sub profiles")
+		}
+
+		n += r.PTL.marshalSize()
+	}
+
+	if len(r.NaluArrays) > 255 {
+		return nil, fmt.Errorf("too many NALU arrays")
+	}
+
+	n++
+
+	for _, arr := range r.NaluArrays {
+		n++
+
+		if vvcNaluArrayHasCount(arr.NaluType) {
+			n += 2
+		} else if len(arr.Nalus) != 1 {
+			return nil, fmt.Errorf("%v arrays must contain exactly one NALU", arr.NaluType)
+		}
+
+		for _, nalu := range arr.Nalus {
+			if len(nalu) > 0xFFFF {
+				return nil, fmt.Errorf("NALU is too big")
+			}
+			n += 2 + len(nalu)
+		}
+	}
+
+	buf := make([]byte, n)
+	pos := 0
+
+	bits.WriteBitsUnsafe(buf, &pos, 0b11111, 5)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(r.LengthSizeMinusOne), 2)
+	bits.WriteFlagUnsafe(buf, &pos, r.PTL != nil)
+
+	if r.PTL != nil {
+		r.PTL.marshalTo(buf, &pos)
+	}
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(len(r.NaluArrays)), 8)
+
+	for _, arr := range r.NaluArrays {
+		bits.WriteFlagUnsafe(buf, &pos, arr.ArrayCompleteness)
+		bits.WriteBitsUnsafe(buf, &pos, 0, 2)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(arr.NaluType), 5)
+
+		if vvcNaluArrayHasCount(arr.NaluType) {
+			bits.WriteBitsUnsafe(buf, &pos, uint64(len(arr.Nalus)), 16)
+		}
+
+		for _, nalu := range arr.Nalus {
+			bits.WriteBitsUnsafe(buf, &pos, uint64(len(nalu)), 16)
+			pos += 8 * copy(buf[pos/8:], nalu)
+		}
+	}
+
+	return buf, nil
+}
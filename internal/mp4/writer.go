@@ -16,6 +16,7 @@ type Writer struct {
 
 // Initialize initializes a Writer.
 func (w *Writer) Initialize() {
+	registerBoxTypes()
 	w.mw = amp4.NewWriter(w.W)
 }
 
@@ -59,6 +60,28 @@ func (w *Writer) WriteBox(box amp4.IImmutableBox) (int, error) {
 	return off, nil
 }
 
+// WriteRawBox writes a box with the given type and payload.
+func (w *Writer) WriteRawBox(typ amp4.BoxType, payload []byte) (int, error) {
+	bi, err := w.mw.StartBox(&amp4.BoxInfo{
+		Type: typ,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = w.mw.Write(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	err = w.WriteBoxEnd()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(bi.Offset), nil
+}
+
 // RewriteBox rewrites a box.
 func (w *Writer) RewriteBox(off int, box amp4.IImmutableBox) error {
 	prevOff, err := w.mw.Seek(0, io.SeekCurrent)
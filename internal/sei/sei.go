@@ -0,0 +1,110 @@
+// Package sei contains the SEI message syntax shared by H264 and H265.
+package sei
+
+import (
+	"fmt"
+)
+
+// Message is a SEI message.
+type Message struct {
+	PayloadType uint32
+	Payload     []byte
+}
+
+func readValue(buf []byte, pos *int) (uint32, error) {
+	v := uint32(0)
+
+	for {
+		if *pos >= len(buf) {
+			return 0, fmt.Errorf("not enough bits")
+		}
+
+		b := buf[*pos]
+		*pos++
+		v += uint32(b)
+
+		if b != 0xFF {
+			return v, nil
+		}
+	}
+}
+
+func valueSize(v uint32) int {
+	return int(v/255) + 1
+}
+
+func writeValue(buf []byte, pos *int, v uint32) {
+	for v >= 255 {
+		buf[*pos] = 0xFF
+		*pos++
+		v -= 255
+	}
+
+	buf[*pos] = byte(v)
+	*pos++
+}
+
+// Unmarshal decodes SEI messages from a RBSP.
+func Unmarshal(buf []byte) ([]Message, error) {
+	var messages []Message
+	pos := 0
+
+	for {
+		// rbsp_trailing_bits, optional in order to support non-conformant encoders
+		if pos == len(buf) || (pos == (len(buf)-1) && buf[pos] == 0x80) {
+			break
+		}
+
+		payloadType, err := readValue(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+
+		payloadSize, err := readValue(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if int(payloadSize) > (len(buf) - pos) {
+			return nil, fmt.Errorf("payload size (%d) exceeds available data (%d)", payloadSize, len(buf)-pos)
+		}
+
+		messages = append(messages, Message{
+			PayloadType: payloadType,
+			Payload:     buf[pos : pos+int(payloadSize)],
+		})
+		pos += int(payloadSize)
+	}
+
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("SEI does not contain any message")
+	}
+
+	return messages, nil
+}
+
+// Marshal encodes SEI messages into a RBSP.
+func Marshal(messages []Message) ([]byte, error) {
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("SEI does not contain any message")
+	}
+
+	n := 1
+
+	for _, msg := range messages {
+		n += valueSize(msg.PayloadType) + valueSize(uint32(len(msg.Payload))) + len(msg.Payload)
+	}
+
+	buf := make([]byte, n)
+	pos := 0
+
+	for _, msg := range messages {
+		writeValue(buf, &pos, msg.PayloadType)
+		writeValue(buf, &pos, uint32(len(msg.Payload)))
+		pos += copy(buf[pos:], msg.Payload)
+	}
+
+	buf[pos] = 0x80 // rbsp_trailing_bits
+
+	return buf, nil
+}
@@ -33,3 +33,38 @@ func WriteFlagUnsafe(buf []byte, pos *int, v bool) {
 		WriteBitsUnsafe(buf, pos, 0, 1)
 	}
 }
+
+// GolombUnsignedSize returns the size in bits of an unsigned golomb-encoded value.
+func GolombUnsignedSize(v uint32) int {
+	n := 0
+	for v64 := uint64(v) + 1; v64 != 0; v64 >>= 1 {
+		n++
+	}
+	return 2*n - 1
+}
+
+// GolombSignedSize returns the size in bits of a signed golomb-encoded value.
+func GolombSignedSize(v int32) int {
+	return GolombUnsignedSize(golombSignedToUnsigned(v))
+}
+
+// WriteGolombUnsignedUnsafe writes an unsigned golomb-encoded value.
+// The buffer must be zeroed.
+func WriteGolombUnsignedUnsafe(buf []byte, pos *int, v uint32) {
+	n := (GolombUnsignedSize(v) + 1) / 2
+	*pos += n - 1 // leading zeros
+	WriteBitsUnsafe(buf, pos, uint64(v)+1, n)
+}
+
+// WriteGolombSignedUnsafe writes a signed golomb-encoded value.
+// The buffer must be zeroed.
+func WriteGolombSignedUnsafe(buf []byte, pos *int, v int32) {
+	WriteGolombUnsignedUnsafe(buf, pos, golombSignedToUnsigned(v))
+}
+
+func golombSignedToUnsigned(v int32) uint32 {
+	if v > 0 {
+		return uint32(v)*2 - 1
+	}
+	return uint32(-int64(v)) * 2
+}
@@ -31,3 +31,36 @@ func TestWriteFlagUnsafe(t *testing.T) {
 	require.Equal(t, 2, pos)
 	require.Equal(t, []byte{0x80}, buf)
 }
+
+func TestWriteGolombUnsignedUnsafe(t *testing.T) {
+	for _, v := range []uint32{0, 1, 2, 6, 255, 65535, 0xFFFFFFFE, 0xFFFFFFFF} {
+		buf := make([]byte, 9)
+		pos := 0
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, v)
+		require.Equal(t, bits.GolombUnsignedSize(v), pos)
+
+		pos = 0
+		dec, err := bits.ReadGolombUnsigned(buf, &pos)
+		require.NoError(t, err)
+		require.Equal(t, v, dec)
+	}
+
+	buf := make([]byte, 1)
+	pos := 0
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, 6)
+	require.Equal(t, []byte{0x38}, buf)
+}
+
+func TestWriteGolombSignedUnsafe(t *testing.T) {
+	for _, v := range []int32{0, 1, -1, 2, -2, 127, -128, 65535, -65535} {
+		buf := make([]byte, 9)
+		pos := 0
+		bits.WriteGolombSignedUnsafe(buf, &pos, v)
+		require.Equal(t, bits.GolombSignedSize(v), pos)
+
+		pos = 0
+		dec, err := bits.ReadGolombSigned(buf, &pos)
+		require.NoError(t, err)
+		require.Equal(t, v, dec)
+	}
+}
@@ -0,0 +1,180 @@
+// Package ac4 contains utilities to work with the AC-4 codec.
+package ac4
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// sample rates, indexed by fs_index.
+var sampleRates = [...]int{44100, 48000}
+
+// frame rates at 48 kHz, indexed by frame_rate_index, expressed as fractions.
+var frameRates = [...][2]int{
+	{24000, 1001},
+	{24, 1},
+	{25, 1},
+	{30000, 1001},
+	{30, 1},
+	{48000, 1001},
+	{48, 1},
+	{50, 1},
+	{60000, 1001},
+	{60, 1},
+	{100, 1},
+	{120000, 1001},
+	{120, 1},
+	{48000, 2048},
+}
+
+// ChannelMode is a channel mode.
+type ChannelMode uint8
+
+// channel modes.
+const (
+	ChannelModeMono     ChannelMode = 0
+	ChannelModeStereo   ChannelMode = 1
+	ChannelMode30       ChannelMode = 2
+	ChannelMode50       ChannelMode = 3
+	ChannelMode51       ChannelMode = 4
+	ChannelMode70Back   ChannelMode = 5  // 3/4/0
+	ChannelMode71Back   ChannelMode = 6  // 3/4/0.1
+	ChannelMode70Screen ChannelMode = 7  // 5/2/0
+	ChannelMode71Screen ChannelMode = 8  // 5/2/0.1
+	ChannelMode70Height ChannelMode = 9  // 3/2/2
+	ChannelMode71Height ChannelMode = 10 // 3/2/2.1
+	ChannelMode704      ChannelMode = 11
+	ChannelMode714      ChannelMode = 12
+	ChannelMode904      ChannelMode = 13
+	ChannelMode914      ChannelMode = 14
+	ChannelMode222      ChannelMode = 15
+)
+
+// bits of presentation_channel_mask_v1, counted from the least significant one.
+const (
+	channelMaskL    = 1 << 0  // L, R
+	channelMaskC    = 1 << 1  // C
+	channelMaskLs   = 1 << 2  // Ls, Rs
+	channelMaskLb   = 1 << 3  // Lb, Rb
+	channelMaskTfl  = 1 << 4  // Tfl, Tfr
+	channelMaskTbl  = 1 << 5  // Tbl, Tbr
+	channelMaskLFE  = 1 << 6  // LFE
+	channelMaskTl   = 1 << 7  // Tl, Tr
+	channelMaskTsl  = 1 << 8  // Tsl, Tsr
+	channelMaskTfc  = 1 << 9  // Tfc
+	channelMaskTbc  = 1 << 10 // Tbc
+	channelMaskTc   = 1 << 11 // Tc
+	channelMaskLFE2 = 1 << 12 // LFE2
+	channelMaskBfl  = 1 << 13 // Bfl, Bfr
+	channelMaskBfc  = 1 << 14 // Bfc
+	channelMaskCb   = 1 << 15 // Cb
+	channelMaskLscr = 1 << 16 // Lscr, Rscr
+	channelMaskLw   = 1 << 17 // Lw, Rw
+	channelMaskVhl  = 1 << 18 // Vhl, Vhr
+)
+
+// bits of presentation_channel_mask_v1 that describe two channels.
+const channelMaskPairs = channelMaskL | channelMaskLs | channelMaskLb | channelMaskTfl |
+	channelMaskTbl | channelMaskTl | channelMaskTsl | channelMaskBfl | channelMaskLscr |
+	channelMaskLw | channelMaskVhl
+
+var channelModeMasks = [...]uint32{
+	channelMaskC,
+	channelMaskL,
+	channelMaskL | channelMaskC,
+	channelMaskL | channelMaskC | channelMaskLs,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLFE,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb | channelMaskLFE,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLscr,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLscr | channelMaskLFE,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskVhl,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskVhl | channelMaskLFE,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb | channelMaskTfl | channelMaskTbl,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb | channelMaskTfl | channelMaskTbl |
+		channelMaskLFE,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb | channelMaskTfl | channelMaskTbl |
+		channelMaskLw,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb | channelMaskTfl | channelMaskTbl |
+		channelMaskLw | channelMaskLFE,
+	channelMaskL | channelMaskC | channelMaskLs | channelMaskLb | channelMaskTfl | channelMaskTbl |
+		channelMaskLFE | channelMaskTsl | channelMaskTfc | channelMaskTbc | channelMaskTc | channelMaskLFE2 |
+		channelMaskBfl | channelMaskBfc | channelMaskCb | channelMaskLscr,
+}
+
+// ChannelMask returns the presentation_channel_mask_v1 of the channel mode.
+func (m ChannelMode) ChannelMask() uint32 {
+	if int(m) < len(channelModeMasks) {
+		return channelModeMasks[m]
+	}
+	return 0
+}
+
+// ChannelCount returns the channel count of the channel mode.
+func (m ChannelMode) ChannelCount() int {
+	return channelMaskCount(m.ChannelMask())
+}
+
+func channelMaskCount(mask uint32) int {
+	n := 0
+	for i := 0; i < 24; i++ {
+		if (mask & (1 << i)) != 0 {
+			if (channelMaskPairs & (1 << i)) != 0 {
+				n += 2
+			} else {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func readVariableBits(buf []byte, pos *int, n int) (uint32, error) {
+	v := uint32(0)
+
+	for {
+		tmp, err := bits.ReadBits(buf, pos, n)
+		if err != nil {
+			return 0, err
+		}
+		v += uint32(tmp)
+
+		more, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return 0, err
+		}
+
+		if !more {
+			return v, nil
+		}
+
+		if v > (1 << 24) {
+			return 0, fmt.Errorf("variable-length value is too big")
+		}
+
+		v <<= n
+		v += 1 << n
+	}
+}
+
+// readEscapedBits reads a n-bit value that is extended with variable_bits(m)
+// when all its bits are set.
+func readEscapedBits(buf []byte, pos *int, n int, m int) (uint32, error) {
+	tmp, err := bits.ReadBits(buf, pos, n)
+	if err != nil {
+		return 0, err
+	}
+	v := uint32(tmp)
+
+	if v == (1<<n)-1 {
+		var add uint32
+		add, err = readVariableBits(buf, pos, m)
+		if err != nil {
+			return 0, err
+		}
+		v += add
+	}
+
+	return v, nil
+}
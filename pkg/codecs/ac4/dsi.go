@@ -0,0 +1,1327 @@
+package ac4
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+const (
+	dsiVersion                 = 1
+	presentationConfigSingle   = 0x1F
+	presentationConfigOnlyEMDF = 6
+	maxPresBytes               = 255 + 0xFFFF
+)
+
+// writeBits writes bits into buf, or only advances pos when buf is nil.
+// This allows to compute sizes with the same code used for encoding.
+func writeBits(buf []byte, pos *int, v uint64, n int) {
+	if buf != nil {
+		bits.WriteBitsUnsafe(buf, pos, v, n)
+	} else {
+		*pos += n
+	}
+}
+
+func writeFlag(buf []byte, pos *int, v bool) {
+	if v {
+		writeBits(buf, pos, 1, 1)
+	} else {
+		writeBits(buf, pos, 0, 1)
+	}
+}
+
+func alignPos(pos *int) {
+	*pos = (*pos + 7) &^ 7
+}
+
+// DSIBitRate contains bit rate informations.
+type DSIBitRate struct {
+	Mode      uint8
+	BitRate   uint32
+	Precision uint32
+}
+
+func (b *DSIBitRate) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 66)
+	if err != nil {
+		return err
+	}
+
+	b.Mode = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	b.BitRate = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	b.Precision = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	return nil
+}
+
+func (b DSIBitRate) marshalBits(buf []byte, pos *int) error {
+	if b.Mode > 3 {
+		return fmt.Errorf("invalid bit rate mode: %d", b.Mode)
+	}
+
+	writeBits(buf, pos, uint64(b.Mode), 2)
+	writeBits(buf, pos, uint64(b.BitRate), 32)
+	writeBits(buf, pos, uint64(b.Precision), 32)
+	return nil
+}
+
+// DSISubstream contains the properties of a substream.
+type DSISubstream struct {
+	// sampling frequency multiplier (0 = none, 1 = 2x, 2 = 4x).
+	SFMultiplier uint8
+
+	// bitrate indicator.
+	BitrateIndicator *uint8
+
+	// channel mask of a channel-coded substream.
+	ChannelMask uint32
+
+	// whether an object-coded substream uses Advanced Joint Object Coding.
+	AJOC bool
+
+	// properties of object-coded substreams.
+	StaticDmx      bool
+	DmxObjects     uint8 // AJOC only
+	UmxObjects     uint8 // AJOC only
+	BedObjects     bool
+	DynamicObjects bool
+	ISFObjects     bool
+}
+
+func (s *DSISubstream) unmarshal(buf []byte, pos *int, channelCoded bool) error {
+	tmp, err := bits.ReadBits(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+	s.SFMultiplier = uint8(tmp >> 1)
+
+	if (tmp & 1) != 0 {
+		tmp, err = bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+		v := uint8(tmp)
+		s.BitrateIndicator = &v
+	}
+
+	if channelCoded {
+		tmp, err = bits.ReadBits(buf, pos, 24)
+		if err != nil {
+			return err
+		}
+		s.ChannelMask = uint32(tmp)
+		return nil
+	}
+
+	s.AJOC, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if s.AJOC {
+		s.StaticDmx, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if s.StaticDmx {
+			s.DmxObjects = 5
+		} else {
+			tmp, err = bits.ReadBits(buf, pos, 4)
+			if err != nil {
+				return err
+			}
+			s.DmxObjects = uint8(tmp) + 1
+		}
+
+		tmp, err = bits.ReadBits(buf, pos, 6)
+		if err != nil {
+			return err
+		}
+		s.UmxObjects = uint8(tmp) + 1
+	}
+
+	tmp, err = bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	s.BedObjects = (tmp & 0b1000) != 0
+	s.DynamicObjects = (tmp & 0b100) != 0
+	s.ISFObjects = (tmp & 0b10) != 0
+
+	return nil
+}
+
+func (s DSISubstream) marshalBits(buf []byte, pos *int, channelCoded bool) error {
+	if s.SFMultiplier > 3 {
+		return fmt.Errorf("invalid sampling frequency multiplier: %d", s.SFMultiplier)
+	}
+
+	writeBits(buf, pos, uint64(s.SFMultiplier), 2)
+	writeFlag(buf, pos, s.BitrateIndicator != nil)
+
+	if s.BitrateIndicator != nil {
+		if *s.BitrateIndicator > 31 {
+			return fmt.Errorf("invalid bitrate indicator: %d", *s.BitrateIndicator)
+		}
+		writeBits(buf, pos, uint64(*s.BitrateIndicator), 5)
+	}
+
+	if channelCoded {
+		if s.ChannelMask > 0xFFFFFF {
+			return fmt.Errorf("invalid channel mask: 0x%x", s.ChannelMask)
+		}
+		writeBits(buf, pos, uint64(s.ChannelMask), 24)
+		return nil
+	}
+
+	writeFlag(buf, pos, s.AJOC)
+
+	if s.AJOC {
+		writeFlag(buf, pos, s.StaticDmx)
+
+		if !s.StaticDmx {
+			if s.DmxObjects < 1 || s.DmxObjects > 16 {
+				return fmt.Errorf("invalid downmix object count: %d", s.DmxObjects)
+			}
+			writeBits(buf, pos, uint64(s.DmxObjects-1), 4)
+		}
+
+		if s.UmxObjects < 1 || s.UmxObjects > 64 {
+			return fmt.Errorf("invalid upmix object count: %d", s.UmxObjects)
+		}
+		writeBits(buf, pos, uint64(s.UmxObjects-1), 6)
+	}
+
+	writeFlag(buf, pos, s.BedObjects)
+	writeFlag(buf, pos, s.DynamicObjects)
+	writeFlag(buf, pos, s.ISFObjects)
+	writeBits(buf, pos, 0, 1) // reserved
+
+	return nil
+}
+
+// DSISubstreamGroup is a substream group.
+type DSISubstreamGroup struct {
+	SubstreamsPresent bool
+	HSFExt            bool
+	ChannelCoded      bool
+	Substreams        []DSISubstream
+	ContentType       *ContentType
+}
+
+func (g *DSISubstreamGroup) unmarshal(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 11)
+	if err != nil {
+		return err
+	}
+
+	g.SubstreamsPresent = (tmp & 0b100_0000_0000) != 0
+	g.HSFExt = (tmp & 0b10_0000_0000) != 0
+	g.ChannelCoded = (tmp & 0b1_0000_0000) != 0
+	g.Substreams = make([]DSISubstream, tmp&0xFF)
+
+	for i := range g.Substreams {
+		err = g.Substreams[i].unmarshal(buf, pos, g.ChannelCoded)
+		if err != nil {
+			return err
+		}
+	}
+
+	contentType, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if contentType {
+		g.ContentType = &ContentType{}
+
+		tmp, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+		g.ContentType.Classifier = uint8(tmp >> 1)
+
+		if (tmp & 1) != 0 {
+			tmp, err = bits.ReadBits(buf, pos, 6)
+			if err != nil {
+				return err
+			}
+
+			err = g.ContentType.readLanguageTag(buf, pos, int(tmp))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g DSISubstreamGroup) marshalBits(buf []byte, pos *int) error {
+	if len(g.Substreams) > maxSubstreams {
+		return fmt.Errorf("too many substreams")
+	}
+
+	writeFlag(buf, pos, g.SubstreamsPresent)
+	writeFlag(buf, pos, g.HSFExt)
+	writeFlag(buf, pos, g.ChannelCoded)
+	writeBits(buf, pos, uint64(len(g.Substreams)), 8)
+
+	for _, s := range g.Substreams {
+		err := s.marshalBits(buf, pos, g.ChannelCoded)
+		if err != nil {
+			return err
+		}
+	}
+
+	writeFlag(buf, pos, g.ContentType != nil)
+
+	if g.ContentType != nil {
+		if g.ContentType.Classifier > 7 {
+			return fmt.Errorf("invalid content classifier: %d", g.ContentType.Classifier)
+		}
+		writeBits(buf, pos, uint64(g.ContentType.Classifier), 3)
+
+		writeFlag(buf, pos, g.ContentType.LanguageTag != "")
+
+		if g.ContentType.LanguageTag != "" {
+			if len(g.ContentType.LanguageTag) > 63 {
+				return fmt.Errorf("language tag is too long")
+			}
+
+			writeBits(buf, pos, uint64(len(g.ContentType.LanguageTag)), 6)
+			for i := 0; i < len(g.ContentType.LanguageTag); i++ {
+				writeBits(buf, pos, uint64(g.ContentType.LanguageTag[i]), 8)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DSITarget is a target device of an alternative presentation.
+type DSITarget struct {
+	MDCompat       uint8
+	DeviceCategory uint8
+}
+
+// DSIAlternative contains informations about an alternative presentation.
+type DSIAlternative struct {
+	Name    string
+	Targets []DSITarget
+}
+
+func (a *DSIAlternative) unmarshal(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 16)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, *pos, int(tmp)*8+5)
+	if err != nil {
+		return err
+	}
+
+	name := make([]byte, tmp)
+	for i := range name {
+		name[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+	a.Name = string(name)
+
+	n := bits.ReadBitsUnsafe(buf, pos, 5)
+
+	err = bits.HasSpace(buf, *pos, int(n)*11)
+	if err != nil {
+		return err
+	}
+
+	a.Targets = make([]DSITarget, n)
+	for i := range a.Targets {
+		a.Targets[i].MDCompat = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+		a.Targets[i].DeviceCategory = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+
+	return nil
+}
+
+func (a DSIAlternative) marshalBits(buf []byte, pos *int) error {
+	if len(a.Name) > 0xFFFF {
+		return fmt.Errorf("presentation name is too long")
+	}
+
+	if len(a.Targets) > 31 {
+		return fmt.Errorf("too many targets")
+	}
+
+	writeBits(buf, pos, uint64(len(a.Name)), 16)
+	for i := 0; i < len(a.Name); i++ {
+		writeBits(buf, pos, uint64(a.Name[i]), 8)
+	}
+
+	writeBits(buf, pos, uint64(len(a.Targets)), 5)
+	for _, t := range a.Targets {
+		if t.MDCompat > 7 {
+			return fmt.Errorf("invalid target MD compatibility: %d", t.MDCompat)
+		}
+		writeBits(buf, pos, uint64(t.MDCompat), 3)
+		writeBits(buf, pos, uint64(t.DeviceCategory), 8)
+	}
+
+	return nil
+}
+
+// DSIPresentation is a presentation.
+type DSIPresentation struct {
+	Version uint8
+
+	// fields available when Version is 1 or 2.
+	Config                 uint8 // presentation_config_v1. 0x1F means single substream group.
+	MDCompat               uint8
+	ID                     *uint8
+	FrameRateMultiplyInfo  uint8
+	FrameRateFractionInfo  uint8
+	EMDF                   EMDFInfo
+	ChannelCoded           bool
+	ChannelMode            ChannelMode
+	Back4ChannelsPresent   bool
+	TopChannelPairs        uint8
+	ChannelMask            uint32
+	CoreDiffers            bool
+	CoreChannelCoded       bool
+	CoreChannelMode        uint8
+	Filter                 bool
+	Enabled                bool
+	FilterData             []byte
+	MultiPID               bool
+	SubstreamGroups        []DSISubstreamGroup
+	PreVirtualized         bool
+	AddEMDFSubstreams      []EMDFInfo
+	BitRate                *DSIBitRate
+	Alternative            *DSIAlternative
+	DEIndicator            bool
+	DolbyAtmosIndicator    bool
+	ExtendedPresentationID *uint16
+
+	// content of presentations whose Version is not 1 or 2.
+	Payload []byte
+}
+
+func (p DSIPresentation) isV1() bool {
+	return p.Version == 1 || p.Version == 2
+}
+
+// IsIMS returns whether the presentation is an immersive stereo (IMS) presentation.
+func (p DSIPresentation) IsIMS() bool {
+	return p.Version == 2
+}
+
+// ChannelCount returns the channel count of the presentation.
+// For object-coded presentations, it is the channel count of the
+// core downmix of AJOC substreams.
+func (p DSIPresentation) ChannelCount() int {
+	if p.ChannelCoded {
+		return channelMaskCount(p.ChannelMask)
+	}
+
+	n := 0
+	for _, g := range p.SubstreamGroups {
+		for _, s := range g.Substreams {
+			if s.AJOC {
+				n = max(n, int(s.DmxObjects))
+			}
+		}
+	}
+	return n
+}
+
+func (p *DSIPresentation) unmarshal(buf []byte) error {
+	if !p.isV1() {
+		p.Payload = buf
+		return nil
+	}
+
+	pos := 0
+
+	tmp, err := bits.ReadBits(buf, &pos, 5)
+	if err != nil {
+		return err
+	}
+	p.Config = uint8(tmp)
+
+	addEMDFSubstreams := true
+
+	if p.Config != presentationConfigOnlyEMDF {
+		err = p.unmarshalContent(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		addEMDFSubstreams, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if addEMDFSubstreams {
+		tmp, err = bits.ReadBits(buf, &pos, 7)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(buf, pos, int(tmp)*15)
+		if err != nil {
+			return err
+		}
+
+		p.AddEMDFSubstreams = make([]EMDFInfo, tmp)
+		for i := range p.AddEMDFSubstreams {
+			p.AddEMDFSubstreams[i].Version = uint32(bits.ReadBitsUnsafe(buf, &pos, 5))
+			p.AddEMDFSubstreams[i].KeyID = uint32(bits.ReadBitsUnsafe(buf, &pos, 10))
+		}
+	}
+
+	bitRateInfo, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if bitRateInfo {
+		p.BitRate = &DSIBitRate{}
+		err = p.BitRate.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	alternative, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if alternative {
+		alignPos(&pos)
+		p.Alternative = &DSIAlternative{}
+		err = p.Alternative.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	alignPos(&pos)
+
+	if pos/8 < len(buf) {
+		tmp = bits.ReadBitsUnsafe(buf, &pos, 8)
+
+		p.DEIndicator = (tmp & 0b1000_0000) != 0
+		p.DolbyAtmosIndicator = (tmp & 0b0100_0000) != 0
+
+		if (tmp & 0b10) != 0 {
+			tmp2, err := bits.ReadBits(buf, &pos, 8)
+			if err != nil {
+				return err
+			}
+
+			id := uint16(tmp&1)<<8 | uint16(tmp2)
+			p.ExtendedPresentationID = &id
+		}
+	}
+
+	return nil
+}
+
+func (p *DSIPresentation) unmarshalContent(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+	p.MDCompat = uint8(tmp >> 1)
+
+	if (tmp & 1) != 0 {
+		tmp, err = bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+		id := uint8(tmp)
+		p.ID = &id
+	}
+
+	tmp, err = bits.ReadBits(buf, pos, 20)
+	if err != nil {
+		return err
+	}
+
+	p.FrameRateMultiplyInfo = uint8(tmp >> 18)
+	p.FrameRateFractionInfo = uint8((tmp >> 16) & 0b11)
+	p.EMDF.Version = uint32((tmp >> 11) & 0x1F)
+	p.EMDF.KeyID = uint32((tmp >> 1) & 0x3FF)
+	p.ChannelCoded = (tmp & 1) != 0
+
+	if p.ChannelCoded {
+		tmp, err = bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+		p.ChannelMode = ChannelMode(tmp)
+
+		if hasImmersiveChannels(p.ChannelMode) {
+			tmp, err = bits.ReadBits(buf, pos, 3)
+			if err != nil {
+				return err
+			}
+			p.Back4ChannelsPresent = (tmp & 0b100) != 0
+			p.TopChannelPairs = uint8(tmp & 0b11)
+		}
+
+		tmp, err = bits.ReadBits(buf, pos, 24)
+		if err != nil {
+			return err
+		}
+		p.ChannelMask = uint32(tmp)
+	}
+
+	err = p.unmarshalCoreAndFilter(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	err = p.unmarshalSubstreamGroups(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	p.PreVirtualized, err = bits.ReadFlag(buf, pos)
+	return err
+}
+
+func (p *DSIPresentation) unmarshalCoreAndFilter(buf []byte, pos *int) error {
+	var err error
+	p.CoreDiffers, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if p.CoreDiffers {
+		p.CoreChannelCoded, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if p.CoreChannelCoded {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+			p.CoreChannelMode = uint8(tmp)
+		}
+	}
+
+	p.Enabled = true
+
+	p.Filter, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if p.Filter {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 9)
+		if err != nil {
+			return err
+		}
+		p.Enabled = (tmp & 0x100) != 0
+
+		err = bits.HasSpace(buf, *pos, int(tmp&0xFF)*8)
+		if err != nil {
+			return err
+		}
+
+		p.FilterData = make([]byte, tmp&0xFF)
+		for i := range p.FilterData {
+			p.FilterData[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+		}
+	}
+
+	return nil
+}
+
+func (p *DSIPresentation) unmarshalSubstreamGroups(buf []byte, pos *int) error {
+	n := 1
+
+	if p.Config != presentationConfigSingle {
+		var err error
+		p.MultiPID, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		switch p.Config {
+		case 0, 1, 2:
+			n = 2
+
+		case 3, 4:
+			n = 3
+
+		case 5:
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 3)
+			if err != nil {
+				return err
+			}
+			n = int(tmp) + 2
+
+		default:
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 7)
+			if err != nil {
+				return err
+			}
+
+			err = bits.HasSpace(buf, *pos, int(tmp)*8)
+			if err != nil {
+				return err
+			}
+			*pos += int(tmp) * 8
+
+			n = 0
+		}
+	}
+
+	p.SubstreamGroups = make([]DSISubstreamGroup, n)
+
+	for i := range p.SubstreamGroups {
+		err := p.SubstreamGroups[i].unmarshal(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p DSIPresentation) substreamGroupCount() int {
+	switch p.Config {
+	case presentationConfigSingle:
+		return 1
+
+	case 0, 1, 2:
+		return 2
+
+	case 3, 4:
+		return 3
+
+	case 5:
+		return -1
+	}
+
+	return 0
+}
+
+func (p DSIPresentation) marshalBits(buf []byte, pos *int) error {
+	if !p.isV1() {
+		for _, b := range p.Payload {
+			writeBits(buf, pos, uint64(b), 8)
+		}
+		return nil
+	}
+
+	if p.Config > presentationConfigSingle {
+		return fmt.Errorf("invalid presentation config: %d", p.Config)
+	}
+
+	writeBits(buf, pos, uint64(p.Config), 5)
+
+	if p.Config != presentationConfigOnlyEMDF {
+		err := p.marshalContent(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		writeFlag(buf, pos, len(p.AddEMDFSubstreams) != 0)
+	}
+
+	if p.Config == presentationConfigOnlyEMDF || len(p.AddEMDFSubstreams) != 0 {
+		if len(p.AddEMDFSubstreams) > maxAddEMDFSubstream {
+			return fmt.Errorf("too many additional EMDF substreams")
+		}
+
+		writeBits(buf, pos, uint64(len(p.AddEMDFSubstreams)), 7)
+
+		for _, e := range p.AddEMDFSubstreams {
+			err := e.marshalDSIBits(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writeFlag(buf, pos, p.BitRate != nil)
+
+	if p.BitRate != nil {
+		err := p.BitRate.marshalBits(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	writeFlag(buf, pos, p.Alternative != nil)
+
+	if p.Alternative != nil {
+		alignPos(pos)
+		err := p.Alternative.marshalBits(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	alignPos(pos)
+
+	writeFlag(buf, pos, p.DEIndicator)
+	writeFlag(buf, pos, p.DolbyAtmosIndicator)
+	writeBits(buf, pos, 0, 4) // reserved
+	writeFlag(buf, pos, p.ExtendedPresentationID != nil)
+
+	if p.ExtendedPresentationID != nil {
+		if *p.ExtendedPresentationID > 0x1FF {
+			return fmt.Errorf("invalid extended presentation ID: %d", *p.ExtendedPresentationID)
+		}
+		writeBits(buf, pos, uint64(*p.ExtendedPresentationID), 9)
+	} else {
+		writeBits(buf, pos, 0, 1) // reserved
+	}
+
+	return nil
+}
+
+func (e EMDFInfo) marshalDSIBits(buf []byte, pos *int) error {
+	if e.Version > 0x1F {
+		return fmt.Errorf("invalid EMDF version: %d", e.Version)
+	}
+
+	if e.KeyID > 0x3FF {
+		return fmt.Errorf("invalid EMDF key ID: %d", e.KeyID)
+	}
+
+	writeBits(buf, pos, uint64(e.Version), 5)
+	writeBits(buf, pos, uint64(e.KeyID), 10)
+	return nil
+}
+
+func (p DSIPresentation) marshalContent(buf []byte, pos *int) error {
+	if p.MDCompat > 7 {
+		return fmt.Errorf("invalid MD compatibility: %d", p.MDCompat)
+	}
+
+	writeBits(buf, pos, uint64(p.MDCompat), 3)
+	writeFlag(buf, pos, p.ID != nil)
+
+	if p.ID != nil {
+		if *p.ID > 0x1F {
+			return fmt.Errorf("invalid presentation ID: %d", *p.ID)
+		}
+		writeBits(buf, pos, uint64(*p.ID), 5)
+	}
+
+	if p.FrameRateMultiplyInfo > 3 || p.FrameRateFractionInfo > 3 {
+		return fmt.Errorf("invalid frame rate informations")
+	}
+
+	writeBits(buf, pos, uint64(p.FrameRateMultiplyInfo), 2)
+	writeBits(buf, pos, uint64(p.FrameRateFractionInfo), 2)
+
+	err := p.EMDF.marshalDSIBits(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	writeFlag(buf, pos, p.ChannelCoded)
+
+	if p.ChannelCoded {
+		if p.ChannelMode > 0x1F {
+			return fmt.Errorf("invalid channel mode: %d", p.ChannelMode)
+		}
+
+		writeBits(buf, pos, uint64(p.ChannelMode), 5)
+
+		if hasImmersiveChannels(p.ChannelMode) {
+			if p.TopChannelPairs > 3 {
+				return fmt.Errorf("invalid top channel pairs: %d", p.TopChannelPairs)
+			}
+			writeFlag(buf, pos, p.Back4ChannelsPresent)
+			writeBits(buf, pos, uint64(p.TopChannelPairs), 2)
+		}
+
+		if p.ChannelMask > 0xFFFFFF {
+			return fmt.Errorf("invalid channel mask: 0x%x", p.ChannelMask)
+		}
+		writeBits(buf, pos, uint64(p.ChannelMask), 24)
+	}
+
+	writeFlag(buf, pos, p.CoreDiffers)
+
+	if p.CoreDiffers {
+		writeFlag(buf, pos, p.CoreChannelCoded)
+
+		if p.CoreChannelCoded {
+			if p.CoreChannelMode > 3 {
+				return fmt.Errorf("invalid core channel mode: %d", p.CoreChannelMode)
+			}
+			writeBits(buf, pos, uint64(p.CoreChannelMode), 2)
+		}
+	}
+
+	writeFlag(buf, pos, p.Filter)
+
+	if p.Filter {
+		if len(p.FilterData) > 0xFF {
+			return fmt.Errorf("filter data is too big")
+		}
+
+		writeFlag(buf, pos, p.Enabled)
+		writeBits(buf, pos, uint64(len(p.FilterData)), 8)
+		for _, b := range p.FilterData {
+			writeBits(buf, pos, uint64(b), 8)
+		}
+	}
+
+	err = p.marshalSubstreamGroups(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	writeFlag(buf, pos, p.PreVirtualized)
+	return nil
+}
+
+func (p DSIPresentation) marshalSubstreamGroups(buf []byte, pos *int) error {
+	n := p.substreamGroupCount()
+
+	if p.Config != presentationConfigSingle {
+		writeFlag(buf, pos, p.MultiPID)
+
+		switch {
+		case p.Config == 5:
+			if len(p.SubstreamGroups) < 2 || len(p.SubstreamGroups) > 9 {
+				return fmt.Errorf("invalid substream group count: %d", len(p.SubstreamGroups))
+			}
+			writeBits(buf, pos, uint64(len(p.SubstreamGroups)-2), 3)
+			n = len(p.SubstreamGroups)
+
+		case p.Config > 5:
+			writeBits(buf, pos, 0, 7) // n_skip_bytes
+		}
+	}
+
+	if len(p.SubstreamGroups) != n {
+		return fmt.Errorf("presentation config %d requires %d substream groups, got %d",
+			p.Config, n, len(p.SubstreamGroups))
+	}
+
+	for _, g := range p.SubstreamGroups {
+		err := g.marshalBits(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DSI is the AC-4 decoder specific information (ac4_dsi_v1),
+// contained in the dac4 box of MP4 files.
+// Specification: ETSI TS 103 190-2, Annex E.6
+type DSI struct {
+	BitstreamVersion uint8
+	FsIndex          uint8
+	FrameRateIndex   uint8
+	ShortProgramID   *uint16
+	ProgramUUID      *[16]byte
+	BitRate          DSIBitRate
+	Presentations    []DSIPresentation
+}
+
+// Unmarshal decodes a DSI.
+func (d *DSI) Unmarshal(buf []byte) error {
+	*d = DSI{}
+	pos := 0
+
+	tmp, err := bits.ReadBits(buf, &pos, 24)
+	if err != nil {
+		return err
+	}
+
+	if v := tmp >> 21; v != dsiVersion {
+		return fmt.Errorf("unsupported DSI version: %d", v)
+	}
+
+	d.BitstreamVersion = uint8((tmp >> 14) & 0x7F)
+	d.FsIndex = uint8((tmp >> 13) & 1)
+	d.FrameRateIndex = uint8((tmp >> 9) & 0xF)
+	n := int(tmp & 0x1FF)
+
+	if d.BitstreamVersion > 1 {
+		err = d.unmarshalProgramID(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = d.BitRate.unmarshal(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	alignPos(&pos)
+
+	d.Presentations = make([]DSIPresentation, n)
+
+	for i := range d.Presentations {
+		tmp, err = bits.ReadBits(buf, &pos, 16)
+		if err != nil {
+			return err
+		}
+
+		d.Presentations[i].Version = uint8(tmp >> 8)
+		presBytes := int(tmp & 0xFF)
+
+		if presBytes == 255 {
+			tmp, err = bits.ReadBits(buf, &pos, 16)
+			if err != nil {
+				return err
+			}
+			presBytes += int(tmp)
+		}
+
+		if (pos/8 + presBytes) > len(buf) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		err = d.Presentations[i].unmarshal(buf[pos/8 : pos/8+presBytes])
+		if err != nil {
+			return err
+		}
+
+		pos += presBytes * 8
+	}
+
+	return nil
+}
+
+func (d *DSI) unmarshalProgramID(buf []byte, pos *int) error {
+	programID, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !programID {
+		return nil
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 17)
+	if err != nil {
+		return err
+	}
+
+	id := uint16(tmp >> 1)
+	d.ShortProgramID = &id
+
+	if (tmp & 1) != 0 {
+		err = bits.HasSpace(buf, *pos, 128)
+		if err != nil {
+			return err
+		}
+
+		var uuid [16]byte
+		for i := range uuid {
+			uuid[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+		}
+		d.ProgramUUID = &uuid
+	}
+
+	return nil
+}
+
+func (d DSI) marshalBits(buf []byte, pos *int) error {
+	if d.BitstreamVersion > 0x7F {
+		return fmt.Errorf("invalid bitstream version: %d", d.BitstreamVersion)
+	}
+
+	if d.FsIndex > 1 {
+		return fmt.Errorf("invalid fs index: %d", d.FsIndex)
+	}
+
+	if d.FrameRateIndex > 0xF {
+		return fmt.Errorf("invalid frame rate index: %d", d.FrameRateIndex)
+	}
+
+	if len(d.Presentations) > maxPresentations {
+		return fmt.Errorf("too many presentations")
+	}
+
+	writeBits(buf, pos, dsiVersion, 3)
+	writeBits(buf, pos, uint64(d.BitstreamVersion), 7)
+	writeBits(buf, pos, uint64(d.FsIndex), 1)
+	writeBits(buf, pos, uint64(d.FrameRateIndex), 4)
+	writeBits(buf, pos, uint64(len(d.Presentations)), 9)
+
+	if d.BitstreamVersion > 1 {
+		writeFlag(buf, pos, d.ShortProgramID != nil)
+
+		if d.ShortProgramID != nil {
+			writeBits(buf, pos, uint64(*d.ShortProgramID), 16)
+			writeFlag(buf, pos, d.ProgramUUID != nil)
+
+			if d.ProgramUUID != nil {
+				for _, b := range d.ProgramUUID {
+					writeBits(buf, pos, uint64(b), 8)
+				}
+			}
+		}
+	}
+
+	err := d.BitRate.marshalBits(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	alignPos(pos)
+
+	for _, p := range d.Presentations {
+		presPos := 0
+		err = p.marshalBits(nil, &presPos)
+		if err != nil {
+			return err
+		}
+		presBytes := (presPos + 7) / 8
+
+		if presBytes > maxPresBytes {
+			return fmt.Errorf("presentation is too big")
+		}
+
+		writeBits(buf, pos, uint64(p.Version), 8)
+
+		if presBytes >= 255 {
+			writeBits(buf, pos, 255, 8)
+			writeBits(buf, pos, uint64(presBytes-255), 16)
+		} else {
+			writeBits(buf, pos, uint64(presBytes), 8)
+		}
+
+		if buf != nil {
+			presPos = 0
+			err = p.marshalBits(buf[*pos/8:], &presPos)
+			if err != nil {
+				return err
+			}
+		}
+
+		*pos += presBytes * 8
+	}
+
+	return nil
+}
+
+// Marshal encodes a DSI.
+func (d DSI) Marshal() ([]byte, error) {
+	pos := 0
+	err := d.marshalBits(nil, &pos)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, (pos+7)/8)
+	pos = 0
+
+	err = d.marshalBits(buf, &pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// SampleRate returns the sample rate.
+func (d DSI) SampleRate() int {
+	if int(d.FsIndex) < len(sampleRates) {
+		return sampleRates[d.FsIndex]
+	}
+	return 0
+}
+
+// ChannelCount returns the channel count of the first presentation.
+func (d DSI) ChannelCount() int {
+	if len(d.Presentations) == 0 {
+		return 0
+	}
+	return d.Presentations[0].ChannelCount()
+}
+
+// UnmarshalFrame fills a DSI with the table of contents of a raw frame.
+func (d *DSI) UnmarshalFrame(frame []byte) error {
+	var toc TOC
+	err := toc.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	return d.fillFromTOC(&toc)
+}
+
+func (d *DSI) fillFromTOC(t *TOC) error {
+	if t.BitstreamVersion > 0x7F {
+		return fmt.Errorf("unsupported bitstream version: %d", t.BitstreamVersion)
+	}
+
+	*d = DSI{
+		BitstreamVersion: uint8(t.BitstreamVersion),
+		FsIndex:          t.FsIndex,
+		FrameRateIndex:   t.FrameRateIndex,
+		ShortProgramID:   t.ShortProgramID,
+		ProgramUUID:      t.ProgramUUID,
+		BitRate: DSIBitRate{
+			Precision: 0xFFFFFFFF, // unknown
+		},
+		Presentations: make([]DSIPresentation, len(t.Presentations)),
+	}
+
+	for i, p := range t.Presentations {
+		err := d.Presentations[i].fillFromTOC(t, &p)
+		if err != nil {
+			return err
+		}
+	}
+
+	// check that the TOC can be represented by a DSI.
+	pos := 0
+	return d.marshalBits(nil, &pos)
+}
+
+func frameRateInfoToDSI(v int) uint8 {
+	switch v {
+	case 2:
+		return 1
+	case 4:
+		return 2
+	}
+	return 0
+}
+
+func (p *DSIPresentation) fillFromTOC(t *TOC, tp *Presentation) error {
+	if tp.Version != 1 && tp.Version != 2 {
+		return fmt.Errorf("unsupported presentation version: %d", tp.Version)
+	}
+
+	p.Version = uint8(tp.Version)
+
+	if tp.SingleSubstreamGroup {
+		p.Config = presentationConfigSingle
+	} else {
+		if tp.Config >= presentationConfigSingle {
+			return fmt.Errorf("unsupported presentation config: %d", tp.Config)
+		}
+		p.Config = uint8(tp.Config)
+	}
+
+	p.AddEMDFSubstreams = tp.AddEMDFSubstreams
+
+	if p.Config == presentationConfigOnlyEMDF {
+		return nil
+	}
+
+	p.MDCompat = tp.MDCompat
+
+	if tp.ID != nil {
+		if *tp.ID > 0x1F {
+			return fmt.Errorf("unsupported presentation ID: %d", *tp.ID)
+		}
+		id := uint8(*tp.ID)
+		p.ID = &id
+	}
+
+	p.FrameRateMultiplyInfo = frameRateInfoToDSI(tp.FrameRateFactor)
+	p.FrameRateFractionInfo = frameRateInfoToDSI(tp.FrameRateFraction)
+	p.EMDF = tp.EMDF
+	p.Filter = tp.Filter
+	p.Enabled = tp.Enabled
+	if p.Filter {
+		p.FilterData = []byte{}
+	}
+	p.MultiPID = tp.MultiPID
+	p.PreVirtualized = tp.PreVirtualized
+	p.ChannelCoded = len(tp.SubstreamGroupIndexes) != 0
+
+	for _, idx := range tp.SubstreamGroupIndexes {
+		g := &t.SubstreamGroups[idx]
+
+		if !g.ChannelCoded {
+			p.ChannelCoded = false
+		}
+
+		p.SubstreamGroups = append(p.SubstreamGroups, g.toDSI())
+	}
+
+	if p.ChannelCoded {
+		for _, idx := range tp.SubstreamGroupIndexes {
+			for _, s := range t.SubstreamGroups[idx].Substreams {
+				p.ChannelMode = max(p.ChannelMode, s.ChannelMode)
+				p.ChannelMask |= s.ChannelMask()
+
+				if hasImmersiveChannels(s.ChannelMode) {
+					p.Back4ChannelsPresent = p.Back4ChannelsPresent || s.Back4ChannelsPresent
+					p.TopChannelPairs = max(p.TopChannelPairs, topChannelPairs(s.TopChannelsPresent))
+				}
+			}
+		}
+	}
+
+	p.DolbyAtmosIndicator = (p.ChannelMask&channelMaskTop) != 0 ||
+		(!p.ChannelCoded && len(p.SubstreamGroups) != 0)
+
+	return nil
+}
+
+// bits of presentation_channel_mask_v1 that describe top channels.
+const channelMaskTop = channelMaskTfl | channelMaskTbl | channelMaskTl | channelMaskTsl |
+	channelMaskTfc | channelMaskTbc | channelMaskTc | channelMaskVhl
+
+func topChannelPairs(topChannelsPresent uint8) uint8 {
+	switch topChannelsPresent {
+	case 0:
+		return 0
+	case 3:
+		return 2
+	}
+	return 1
+}
+
+func (g SubstreamGroup) toDSI() DSISubstreamGroup {
+	dg := DSISubstreamGroup{
+		SubstreamsPresent: g.SubstreamsPresent,
+		HSFExt:            g.HSFExt,
+		ChannelCoded:      g.ChannelCoded,
+		Substreams:        make([]DSISubstream, len(g.Substreams)),
+		ContentType:       g.ContentType,
+	}
+
+	for i, s := range g.Substreams {
+		ds := &dg.Substreams[i]
+		ds.SFMultiplier = s.SFMultiplier
+		ds.BitrateIndicator = s.BitrateIndicator
+
+		if g.ChannelCoded {
+			ds.ChannelMask = s.ChannelMask()
+		} else {
+			ds.AJOC = s.AJOC
+			ds.StaticDmx = s.StaticDmx
+			ds.DmxObjects = uint8(s.DmxObjects)
+			ds.UmxObjects = uint8(s.UmxObjects)
+			ds.BedObjects = s.BedObjects
+			ds.DynamicObjects = s.DynamicObjects
+			ds.ISFObjects = s.ISFObjects
+		}
+	}
+
+	return dg
+}
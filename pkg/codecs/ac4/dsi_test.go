@@ -0,0 +1,300 @@
+package ac4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+var casesDSI = []struct {
+	name string
+	enc  []byte
+	dec  DSI
+}{
+	{
+		"stereo",
+		[]byte{
+			0x20, 0xa4, 0x01, 0x00, 0x00, 0x00, 0x00, 0x1f, 0xff, 0xff, 0xff, 0xe0,
+			0x01, 0x13, 0xf8, 0x00, 0x00, 0x08, 0x40, 0x00, 0x00, 0x4a, 0x02, 0x00,
+			0x00, 0x00, 0x66, 0x1b, 0x2b, 0x73, 0x38, 0x00, 0x00,
+		},
+		DSI{
+			BitstreamVersion: 2,
+			FsIndex:          1,
+			FrameRateIndex:   2,
+			BitRate: DSIBitRate{
+				Precision: 0xFFFFFFFF,
+			},
+			Presentations: []DSIPresentation{{
+				Version:      1,
+				Config:       0x1F,
+				Enabled:      true,
+				ChannelCoded: true,
+				ChannelMode:  ChannelModeStereo,
+				ChannelMask:  channelMaskL,
+				SubstreamGroups: []DSISubstreamGroup{{
+					SubstreamsPresent: true,
+					ChannelCoded:      true,
+					Substreams: []DSISubstream{{
+						ChannelMask: channelMaskL,
+					}},
+					ContentType: &ContentType{
+						Classifier:  1,
+						LanguageTag: "eng",
+					},
+				}},
+			}},
+		},
+	},
+	{
+		"immersive and ims",
+		[]byte{
+			0x20, 0xa6, 0x02, 0x00, 0x00, 0x00, 0x00, 0x1f, 0xff, 0xff, 0xff, 0xe0,
+			0x01, 0x11, 0xf9, 0x8c, 0x00, 0x00, 0x59, 0x80, 0x00, 0x1f, 0xd8, 0x05,
+			0x01, 0x2d, 0x00, 0x00, 0x7f, 0x00, 0x40, 0x02, 0x0e, 0xf8, 0x00, 0x00,
+			0x08, 0x40, 0x00, 0x00, 0x4a, 0x02, 0x00, 0x00, 0x00, 0x50, 0x00,
+		},
+		DSI{
+			BitstreamVersion: 2,
+			FsIndex:          1,
+			FrameRateIndex:   3,
+			BitRate: DSIBitRate{
+				Precision: 0xFFFFFFFF,
+			},
+			Presentations: []DSIPresentation{
+				{
+					Version:              1,
+					Config:               0x1F,
+					MDCompat:             1,
+					ID:                   uint8Ptr(3),
+					ChannelCoded:         true,
+					ChannelMode:          ChannelMode714,
+					Back4ChannelsPresent: true,
+					TopChannelPairs:      2,
+					ChannelMask:          0x7F,
+					Filter:               true,
+					Enabled:              true,
+					FilterData:           []byte{},
+					SubstreamGroups: []DSISubstreamGroup{{
+						SubstreamsPresent: true,
+						ChannelCoded:      true,
+						Substreams: []DSISubstream{{
+							BitrateIndicator: uint8Ptr(13),
+							ChannelMask:      0x7F,
+						}},
+					}},
+					DolbyAtmosIndicator: true,
+				},
+				{
+					Version:      2,
+					Config:       0x1F,
+					Enabled:      true,
+					ChannelCoded: true,
+					ChannelMode:  ChannelModeStereo,
+					ChannelMask:  channelMaskL,
+					SubstreamGroups: []DSISubstreamGroup{{
+						SubstreamsPresent: true,
+						ChannelCoded:      true,
+						Substreams: []DSISubstream{{
+							ChannelMask: channelMaskL,
+						}},
+					}},
+					PreVirtualized: true,
+				},
+			},
+		},
+	},
+	{
+		"ajoc",
+		[]byte{
+			0x20, 0xa4, 0x01, 0x00, 0x00, 0x00, 0x00, 0x1f, 0xff, 0xff, 0xff, 0xe0,
+			0x01, 0x09, 0xf8, 0x20, 0x00, 0x01, 0x00, 0x46, 0x7e, 0x00, 0x40,
+		},
+		DSI{
+			BitstreamVersion: 2,
+			FsIndex:          1,
+			FrameRateIndex:   2,
+			BitRate: DSIBitRate{
+				Precision: 0xFFFFFFFF,
+			},
+			Presentations: []DSIPresentation{{
+				Version:               1,
+				Config:                0x1F,
+				FrameRateMultiplyInfo: 1,
+				Enabled:               true,
+				SubstreamGroups: []DSISubstreamGroup{{
+					SubstreamsPresent: true,
+					Substreams: []DSISubstream{{
+						AJOC:           true,
+						StaticDmx:      true,
+						DmxObjects:     5,
+						UmxObjects:     16,
+						BedObjects:     true,
+						DynamicObjects: true,
+					}},
+				}},
+				DolbyAtmosIndicator: true,
+			}},
+		},
+	},
+	{
+		"complex",
+		[]byte{
+			0x20, 0x9a, 0x03, 0x89, 0x1a, 0x40, 0x00, 0x40, 0x80, 0xc1, 0x01, 0x41,
+			0x81, 0xc2, 0x02, 0x42, 0x82, 0xc3, 0x03, 0x43, 0x83, 0xe0, 0x00, 0x3e,
+			0x80, 0x0f, 0xff, 0xff, 0xff, 0xf0, 0x01, 0x34, 0x2a, 0x00, 0x40, 0x57,
+			0x25, 0x80, 0xa0, 0x00, 0x04, 0x78, 0x84, 0xca, 0xdd, 0x00, 0x84, 0xc9,
+			0xa0, 0x52, 0x80, 0x80, 0x00, 0x00, 0x22, 0x08, 0x20, 0x10, 0xc0, 0x4a,
+			0x00, 0x03, 0xe8, 0x00, 0x00, 0x00, 0x00, 0xc9, 0x00, 0x0a, 0x43, 0x6f,
+			0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x72, 0x79, 0x09, 0x02, 0x83, 0x2c,
+			0x01, 0x05, 0x30, 0x10, 0x00, 0x20, 0x00, 0x00, 0x03, 0x01, 0x02, 0x03,
+		},
+		DSI{
+			BitstreamVersion: 2,
+			FsIndex:          0,
+			FrameRateIndex:   13,
+			ShortProgramID:   uint16Ptr(0x1234),
+			ProgramUUID:      &[16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			BitRate: DSIBitRate{
+				Mode:      2,
+				BitRate:   256000,
+				Precision: 0xFFFFFFFF,
+			},
+			Presentations: []DSIPresentation{
+				{
+					Version:  1,
+					Config:   5,
+					MDCompat: 2,
+					EMDF: EMDFInfo{
+						Version: 1,
+						KeyID:   5,
+					},
+					CoreDiffers:      true,
+					CoreChannelCoded: true,
+					CoreChannelMode:  2,
+					Enabled:          true,
+					MultiPID:         true,
+					SubstreamGroups: []DSISubstreamGroup{
+						{
+							HSFExt:       true,
+							ChannelCoded: true,
+							Substreams: []DSISubstream{{
+								SFMultiplier: 1,
+								ChannelMask:  0x47,
+							}},
+							ContentType: &ContentType{
+								Classifier:  0,
+								LanguageTag: "en",
+							},
+						},
+						{
+							SubstreamsPresent: true,
+							Substreams: []DSISubstream{
+								{
+									AJOC:           true,
+									DmxObjects:     7,
+									UmxObjects:     20,
+									DynamicObjects: true,
+								},
+								{
+									BedObjects: true,
+									ISFObjects: true,
+								},
+							},
+						},
+						{
+							SubstreamsPresent: true,
+							ChannelCoded:      true,
+							Substreams: []DSISubstream{{
+								ChannelMask: 0x2,
+							}},
+						},
+					},
+					AddEMDFSubstreams: []EMDFInfo{
+						{Version: 1, KeyID: 2},
+						{Version: 3, KeyID: 4},
+					},
+					BitRate: &DSIBitRate{
+						Mode:      1,
+						BitRate:   128000,
+						Precision: 100,
+					},
+					Alternative: &DSIAlternative{
+						Name: "Commentary",
+						Targets: []DSITarget{{
+							MDCompat:       1,
+							DeviceCategory: 2,
+						}},
+					},
+					DEIndicator:            true,
+					ExtendedPresentationID: uint16Ptr(300),
+				},
+				{
+					Version: 1,
+					Config:  6,
+					AddEMDFSubstreams: []EMDFInfo{
+						{Version: 0, KeyID: 1},
+					},
+				},
+				{
+					Version: 0,
+					Payload: []byte{1, 2, 3},
+				},
+			},
+		},
+	},
+}
+
+func TestDSIUnmarshal(t *testing.T) {
+	for _, ca := range casesDSI {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec DSI
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestDSIMarshal(t *testing.T) {
+	for _, ca := range casesDSI {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestDSIUnmarshalFrame(t *testing.T) {
+	for i, ca := range casesTOC {
+		t.Run(ca.name, func(t *testing.T) {
+			var dsi DSI
+			err := dsi.UnmarshalFrame(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, casesDSI[i].dec, dsi)
+			require.Equal(t, 48000, dsi.SampleRate())
+		})
+	}
+}
+
+func FuzzDSIUnmarshal(f *testing.F) {
+	for _, ca := range casesDSI {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var dsi DSI
+		err := dsi.Unmarshal(b)
+		if err == nil {
+			dsi.ChannelCount()
+
+			_, err = dsi.Marshal()
+			require.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package ac4
+
+import (
+	"fmt"
+)
+
+const (
+	syncWord    = 0xAC40
+	syncWordCRC = 0xAC41
+	crcSize     = 2
+)
+
+// SyncInfo is the header of an AC-4 sync frame.
+// Specification: ETSI TS 103 190-1, Annex G
+type SyncInfo struct {
+	// CRC tells whether a CRC word follows the raw frame.
+	CRC bool
+
+	// FrameSize is the size of the raw frame.
+	FrameSize int
+}
+
+// Unmarshal decodes a SyncInfo.
+func (s *SyncInfo) Unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	switch uint16(buf[0])<<8 | uint16(buf[1]) {
+	case syncWord:
+		s.CRC = false
+
+	case syncWordCRC:
+		s.CRC = true
+
+	default:
+		return fmt.Errorf("invalid sync word")
+	}
+
+	s.FrameSize = int(buf[2])<<8 | int(buf[3])
+
+	if s.FrameSize == 0xFFFF {
+		if len(buf) < 7 {
+			return fmt.Errorf("not enough bytes")
+		}
+		s.FrameSize = int(buf[4])<<16 | int(buf[5])<<8 | int(buf[6])
+	}
+
+	return nil
+}
+
+// HeaderSize returns the size of the sync frame header.
+func (s SyncInfo) HeaderSize() int {
+	if s.FrameSize >= 0xFFFF {
+		return 7
+	}
+	return 4
+}
+
+// SyncFrameSize returns the size of the whole sync frame.
+func (s SyncInfo) SyncFrameSize() int {
+	n := s.HeaderSize() + s.FrameSize
+	if s.CRC {
+		n += crcSize
+	}
+	return n
+}
+
+func (s SyncInfo) marshalTo(buf []byte) (int, error) {
+	if s.FrameSize > 0xFFFFFF {
+		return 0, fmt.Errorf("frame is too big")
+	}
+
+	if s.CRC {
+		buf[0], buf[1] = syncWordCRC>>8, syncWordCRC&0xFF
+	} else {
+		buf[0], buf[1] = syncWord>>8, syncWord&0xFF
+	}
+
+	if s.FrameSize >= 0xFFFF {
+		buf[2], buf[3] = 0xFF, 0xFF
+		buf[4] = byte(s.FrameSize >> 16)
+		buf[5] = byte(s.FrameSize >> 8)
+		buf[6] = byte(s.FrameSize)
+		return 7, nil
+	}
+
+	buf[2] = byte(s.FrameSize >> 8)
+	buf[3] = byte(s.FrameSize)
+	return 4, nil
+}
+
+// Marshal encodes a SyncInfo.
+func (s SyncInfo) Marshal() ([]byte, error) {
+	buf := make([]byte, s.HeaderSize())
+
+	_, err := s.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// UnmarshalSyncFrame decodes a sync frame and returns the raw frame it contains.
+// The CRC word, if present, is not verified.
+func UnmarshalSyncFrame(buf []byte) ([]byte, error) {
+	var s SyncInfo
+	err := s.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buf) != s.SyncFrameSize() {
+		return nil, fmt.Errorf("unexpected sync frame size: got %d, expected %d", len(buf), s.SyncFrameSize())
+	}
+
+	return buf[s.HeaderSize() : s.HeaderSize()+s.FrameSize], nil
+}
+
+// MarshalSyncFrame wraps a raw frame into a sync frame without CRC.
+func MarshalSyncFrame(frame []byte) ([]byte, error) {
+	s := SyncInfo{FrameSize: len(frame)}
+	buf := make([]byte, s.SyncFrameSize())
+
+	n, err := s.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	copy(buf[n:], frame)
+
+	return buf, nil
+}
@@ -0,0 +1,92 @@
+package ac4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSyncInfo = []struct {
+	name string
+	enc  []byte
+	dec  SyncInfo
+}{
+	{
+		"standard",
+		[]byte{0xac, 0x40, 0x01, 0x2c},
+		SyncInfo{
+			FrameSize: 300,
+		},
+	},
+	{
+		"crc",
+		[]byte{0xac, 0x41, 0x00, 0x10},
+		SyncInfo{
+			CRC:       true,
+			FrameSize: 16,
+		},
+	},
+	{
+		"extended size",
+		[]byte{0xac, 0x40, 0xff, 0xff, 0x01, 0x00, 0x00},
+		SyncInfo{
+			FrameSize: 65536,
+		},
+	},
+}
+
+func TestSyncInfoUnmarshal(t *testing.T) {
+	for _, ca := range casesSyncInfo {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec SyncInfo
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, len(ca.enc), dec.HeaderSize())
+		})
+	}
+}
+
+func TestSyncInfoMarshal(t *testing.T) {
+	for _, ca := range casesSyncInfo {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestSyncFrame(t *testing.T) {
+	frame := casesTOC[0].enc
+
+	enc, err := MarshalSyncFrame(frame)
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{0xac, 0x40, 0x00, byte(len(frame))}, frame...), enc)
+
+	dec, err := UnmarshalSyncFrame(enc)
+	require.NoError(t, err)
+	require.Equal(t, frame, dec)
+
+	dec, err = UnmarshalSyncFrame(append([]byte{0xac, 0x41, 0x00, byte(len(frame))}, append(frame, 0x12, 0x34)...))
+	require.NoError(t, err)
+	require.Equal(t, frame, dec)
+
+	_, err = UnmarshalSyncFrame(enc[:len(enc)-1])
+	require.EqualError(t, err, "unexpected sync frame size: got 19, expected 20")
+}
+
+func FuzzSyncInfoUnmarshal(f *testing.F) {
+	for _, ca := range casesSyncInfo {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var s SyncInfo
+		err := s.Unmarshal(b)
+		if err == nil {
+			_, err = s.Marshal()
+			require.NoError(t, err)
+		}
+	})
+}
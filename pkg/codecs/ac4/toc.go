@@ -0,0 +1,1427 @@
+package ac4
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+const (
+	maxPresentations    = 511
+	maxSubstreamGroups  = 256
+	maxSubstreams       = 255
+	maxAddEMDFSubstream = 127
+)
+
+// EMDFInfo contains the parameters of an EMDF (Extensible Metadata Delivery Format) payload.
+type EMDFInfo struct {
+	Version uint32
+	KeyID   uint32
+}
+
+func (e *EMDFInfo) unmarshal(buf []byte, pos *int) error {
+	var err error
+	e.Version, err = readEscapedBits(buf, pos, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	e.KeyID, err = readEscapedBits(buf, pos, 3, 3)
+	if err != nil {
+		return err
+	}
+
+	payloadsSubstreamInfo, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if payloadsSubstreamInfo {
+		_, err = readEscapedBits(buf, pos, 2, 2) // substream_index
+		if err != nil {
+			return err
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	n := protectionLength(uint8(tmp>>2)) + protectionLength(uint8(tmp&0b11))
+
+	err = bits.HasSpace(buf, *pos, n)
+	if err != nil {
+		return err
+	}
+	*pos += n
+
+	return nil
+}
+
+func protectionLength(v uint8) int {
+	switch v {
+	case 1:
+		return 8
+	case 2:
+		return 32
+	case 3:
+		return 128
+	}
+	return 0
+}
+
+// ContentType is the content type of a substream group.
+type ContentType struct {
+	// content classifier.
+	Classifier uint8
+
+	// language tag, as defined in BCP 47.
+	// It is empty when the language is not indicated or when the tag is serialized over multiple frames.
+	LanguageTag string
+}
+
+func (c *ContentType) unmarshalTOC(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+	c.Classifier = uint8(tmp)
+
+	languageIndicator, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !languageIndicator {
+		return nil
+	}
+
+	serializedLanguageTag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if serializedLanguageTag {
+		// b_start_tag, language_tag_chunk
+		err = bits.HasSpace(buf, *pos, 17)
+		if err != nil {
+			return err
+		}
+		*pos += 17
+		return nil
+	}
+
+	tmp, err = bits.ReadBits(buf, pos, 6)
+	if err != nil {
+		return err
+	}
+
+	return c.readLanguageTag(buf, pos, int(tmp))
+}
+
+func (c *ContentType) readLanguageTag(buf []byte, pos *int, n int) error {
+	err := bits.HasSpace(buf, *pos, n*8)
+	if err != nil {
+		return err
+	}
+
+	tag := make([]byte, n)
+	for i := range tag {
+		tag[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+	c.LanguageTag = string(tag)
+
+	return nil
+}
+
+// Substream contains the properties of a substream.
+type Substream struct {
+	// channel mode of a channel-coded substream.
+	ChannelMode ChannelMode
+
+	// properties of channel-coded substreams with a
+	// 7.0.4, 7.1.4, 9.0.4 or 9.1.4 channel mode.
+	Back4ChannelsPresent bool
+	CentrePresent        bool
+	TopChannelsPresent   uint8
+
+	// whether an object-coded substream uses Advanced Joint Object Coding.
+	AJOC bool
+
+	// properties of object-coded substreams.
+	LFE            bool
+	StaticDmx      bool
+	DmxObjects     int // AJOC only
+	UmxObjects     int // AJOC only
+	BedObjects     bool
+	DynamicObjects bool
+	ISFObjects     bool
+
+	// sampling frequency multiplier (0 = none, 1 = 2x, 2 = 4x).
+	SFMultiplier uint8
+
+	// bitrate indicator.
+	BitrateIndicator *uint8
+
+	// substream index.
+	Index uint32
+}
+
+// ChannelMask returns the channel mask of a channel-coded substream.
+func (s Substream) ChannelMask() uint32 {
+	mask := s.ChannelMode.ChannelMask()
+
+	if hasImmersiveChannels(s.ChannelMode) {
+		if !s.Back4ChannelsPresent {
+			mask &^= channelMaskLb
+		}
+
+		if !s.CentrePresent {
+			mask &^= channelMaskC
+		}
+
+		switch s.TopChannelsPresent {
+		case 0:
+			mask &^= channelMaskTfl | channelMaskTbl
+
+		case 1, 2:
+			mask &^= channelMaskTfl | channelMaskTbl
+			mask |= channelMaskTl
+		}
+	}
+
+	return mask
+}
+
+func hasImmersiveChannels(m ChannelMode) bool {
+	return m >= ChannelMode704 && m <= ChannelMode914
+}
+
+func readChannelMode(buf []byte, pos *int) (ChannelMode, error) {
+	// channel_mode is a prefix code
+	for i := 0; i < 2; i++ {
+		v, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return 0, err
+		}
+		if !v {
+			return ChannelMode(i), nil
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return 0, err
+	}
+	if tmp < 3 {
+		return ChannelMode30 + ChannelMode(tmp), nil
+	}
+
+	tmp, err = bits.ReadBits(buf, pos, 3)
+	if err != nil {
+		return 0, err
+	}
+	if tmp < 7 {
+		return ChannelMode70Back + ChannelMode(tmp), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		var v bool
+		v, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return 0, err
+		}
+		if !v {
+			return ChannelMode714 + ChannelMode(i), nil
+		}
+	}
+
+	add, err := readVariableBits(buf, pos, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	if add > uint32(ChannelMode222-ChannelMode914) {
+		return 0, fmt.Errorf("unsupported channel mode")
+	}
+
+	return ChannelMode914 + ChannelMode(add), nil
+}
+
+func (s *Substream) readSFMultiplierAndBitrate(buf []byte, pos *int, fsIndex uint8) error {
+	if fsIndex == 1 {
+		sfMultiplier, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if sfMultiplier {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 1)
+			if err != nil {
+				return err
+			}
+			s.SFMultiplier = 1 + uint8(tmp)
+		}
+	}
+
+	bitrateInfo, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if bitrateInfo {
+		tmp, err := bits.ReadBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+
+		if (tmp & 1) != 0 {
+			var tmp2 uint64
+			tmp2, err = bits.ReadBits(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+			tmp = tmp<<2 | tmp2
+		}
+
+		v := uint8(tmp)
+		s.BitrateIndicator = &v
+	}
+
+	return nil
+}
+
+func (s *Substream) readNdotAndIndex(buf []byte, pos *int, frameRateFactor int, substreamsPresent bool) error {
+	// b_audio_ndot
+	err := bits.HasSpace(buf, *pos, frameRateFactor)
+	if err != nil {
+		return err
+	}
+	*pos += frameRateFactor
+
+	if substreamsPresent {
+		s.Index, err = readEscapedBits(buf, pos, 2, 2)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Substream) unmarshalChan(
+	buf []byte,
+	pos *int,
+	fsIndex uint8,
+	frameRateFactor int,
+	substreamsPresent bool,
+) error {
+	var err error
+	s.ChannelMode, err = readChannelMode(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if hasImmersiveChannels(s.ChannelMode) {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+
+		s.Back4ChannelsPresent = (tmp & 0b1000) != 0
+		s.CentrePresent = (tmp & 0b100) != 0
+		s.TopChannelsPresent = uint8(tmp & 0b11)
+	}
+
+	err = s.readSFMultiplierAndBitrate(buf, pos, fsIndex)
+	if err != nil {
+		return err
+	}
+
+	if s.ChannelMode >= ChannelMode70Back && s.ChannelMode <= ChannelMode71Screen {
+		_, err = bits.ReadFlag(buf, pos) // add_ch_base
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.readNdotAndIndex(buf, pos, frameRateFactor, substreamsPresent)
+}
+
+// readBedDynObjAssignment decodes bed_dyn_obj_assignment()
+// and returns whether bed objects and ISF objects are present.
+func readBedDynObjAssignment(buf []byte, pos *int, n int) (bool, bool, error) {
+	dynObjectsOnly, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return false, false, err
+	}
+
+	if dynObjectsOnly {
+		return false, false, nil
+	}
+
+	isf, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return false, false, err
+	}
+
+	if isf {
+		_, err = bits.ReadBits(buf, pos, 3) // isf_config
+		return false, true, err
+	}
+
+	chAssignCode, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return false, false, err
+	}
+
+	if chAssignCode {
+		_, err = bits.ReadBits(buf, pos, 3) // bed_chan_assign_code
+		return true, false, err
+	}
+
+	chanAssignMask, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return false, false, err
+	}
+
+	if chanAssignMask {
+		return true, false, skipBedChannelAssignmentMask(buf, pos)
+	}
+
+	if n > 1 {
+		bedChBits := 0
+		for (1 << bedChBits) < n {
+			bedChBits++
+		}
+
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, bedChBits)
+		if err != nil {
+			return false, false, err
+		}
+
+		// nonstd_bed_channel_assignment
+		err = bits.HasSpace(buf, *pos, int(tmp+1)*4)
+		if err != nil {
+			return false, false, err
+		}
+		*pos += int(tmp+1) * 4
+	}
+
+	return true, false, nil
+}
+
+func skipBedChannelAssignmentMask(buf []byte, pos *int) error {
+	nonstd, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	n := 10
+	if nonstd {
+		n = 17
+	}
+
+	err = bits.HasSpace(buf, *pos, n)
+	if err != nil {
+		return err
+	}
+	*pos += n
+
+	return nil
+}
+
+func skipOAMDCommonData(buf []byte, pos *int) error {
+	defaultScreenSizeRatio, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !defaultScreenSizeRatio {
+		_, err = bits.ReadBits(buf, pos, 5) // master_screen_size_ratio_code
+		if err != nil {
+			return err
+		}
+	}
+
+	// b_bed_object_chan_distribute
+	tmp, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	additionalData := (tmp & 1) != 0
+	if !additionalData {
+		return nil
+	}
+
+	tmp, err = bits.ReadBits(buf, pos, 1)
+	if err != nil {
+		return err
+	}
+	addDataBytes := uint32(tmp) + 1
+
+	if addDataBytes == 2 {
+		var add uint32
+		add, err = readVariableBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		addDataBytes += add
+	}
+
+	err = bits.HasSpace(buf, *pos, int(addDataBytes)*8)
+	if err != nil {
+		return err
+	}
+	*pos += int(addDataBytes) * 8
+
+	return nil
+}
+
+func (s *Substream) unmarshalAJOC(
+	buf []byte,
+	pos *int,
+	fsIndex uint8,
+	frameRateFactor int,
+	substreamsPresent bool,
+) error {
+	s.AJOC = true
+	s.DynamicObjects = true
+
+	tmp, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+	s.LFE = (tmp & 0b10) != 0
+	s.StaticDmx = (tmp & 0b01) != 0
+
+	if s.StaticDmx {
+		s.DmxObjects = 5
+	} else {
+		tmp, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+		s.DmxObjects = int(tmp) + 1
+
+		_, _, err = readBedDynObjAssignment(buf, pos, s.DmxObjects)
+		if err != nil {
+			return err
+		}
+	}
+
+	oamdCommonDataPresent, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if oamdCommonDataPresent {
+		err = skipOAMDCommonData(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	tmp, err = bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+	umxObjects := uint32(tmp) + 1
+
+	if umxObjects == 16 {
+		var add uint32
+		add, err = readVariableBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+		umxObjects += add
+	}
+
+	if umxObjects > 64 {
+		return fmt.Errorf("too many upmix objects")
+	}
+	s.UmxObjects = int(umxObjects)
+
+	// the assignment of upmix signals describes the content
+	s.BedObjects, s.ISFObjects, err = readBedDynObjAssignment(buf, pos, s.UmxObjects)
+	if err != nil {
+		return err
+	}
+
+	err = s.readSFMultiplierAndBitrate(buf, pos, fsIndex)
+	if err != nil {
+		return err
+	}
+
+	return s.readNdotAndIndex(buf, pos, frameRateFactor, substreamsPresent)
+}
+
+func (s *Substream) unmarshalObj(
+	buf []byte,
+	pos *int,
+	fsIndex uint8,
+	frameRateFactor int,
+	substreamsPresent bool,
+) error {
+	// n_objects_code
+	_, err := bits.ReadBits(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	s.DynamicObjects, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if s.DynamicObjects {
+		s.LFE, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.BedObjects, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if s.BedObjects {
+			err = skipObjBedInfo(buf, pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			s.ISFObjects, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if s.ISFObjects {
+				err = skipObjISFInfo(buf, pos)
+			} else {
+				err = skipObjReservedData(buf, pos)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = s.readSFMultiplierAndBitrate(buf, pos, fsIndex)
+	if err != nil {
+		return err
+	}
+
+	return s.readNdotAndIndex(buf, pos, frameRateFactor, substreamsPresent)
+}
+
+func skipObjBedInfo(buf []byte, pos *int) error {
+	bedStart, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !bedStart {
+		return nil
+	}
+
+	chAssignCode, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if chAssignCode {
+		_, err = bits.ReadBits(buf, pos, 3) // bed_chan_assign_code
+		return err
+	}
+
+	return skipBedChannelAssignmentMask(buf, pos)
+}
+
+func skipObjISFInfo(buf []byte, pos *int) error {
+	isfStart, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if isfStart {
+		_, err = bits.ReadBits(buf, pos, 3) // isf_config
+		return err
+	}
+
+	return nil
+}
+
+func skipObjReservedData(buf []byte, pos *int) error {
+	resBytes, err := bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, *pos, int(resBytes)*8)
+	if err != nil {
+		return err
+	}
+	*pos += int(resBytes) * 8
+
+	return nil
+}
+
+func skipSubstreamIndex(buf []byte, pos *int, substreamsPresent bool) error {
+	if substreamsPresent {
+		_, err := readEscapedBits(buf, pos, 2, 2)
+		return err
+	}
+	return nil
+}
+
+// SubstreamGroup is a substream group.
+type SubstreamGroup struct {
+	SubstreamsPresent bool
+	HSFExt            bool
+	ChannelCoded      bool
+	Substreams        []Substream
+	ContentType       *ContentType
+}
+
+func (g *SubstreamGroup) unmarshal(buf []byte, pos *int, fsIndex uint8, frameRateFactor int) error {
+	tmp, err := bits.ReadBits(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	g.SubstreamsPresent = (tmp & 0b100) != 0
+	g.HSFExt = (tmp & 0b10) != 0
+	singleSubstream := (tmp & 0b1) != 0
+
+	n := uint32(1)
+
+	if !singleSubstream {
+		tmp, err = bits.ReadBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		n = uint32(tmp) + 2
+
+		if n == 5 {
+			var add uint32
+			add, err = readVariableBits(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+			n += add
+		}
+
+		if n > maxSubstreams {
+			return fmt.Errorf("too many substreams")
+		}
+	}
+
+	g.ChannelCoded, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	g.Substreams = make([]Substream, n)
+
+	if g.ChannelCoded {
+		for i := range g.Substreams {
+			err = g.Substreams[i].unmarshalChan(buf, pos, fsIndex, frameRateFactor, g.SubstreamsPresent)
+			if err != nil {
+				return err
+			}
+
+			if g.HSFExt {
+				err = skipSubstreamIndex(buf, pos, g.SubstreamsPresent)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		var oamdSubstream bool
+		oamdSubstream, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if oamdSubstream {
+			_, err = bits.ReadFlag(buf, pos) // b_oamd_ndot
+			if err != nil {
+				return err
+			}
+
+			err = skipSubstreamIndex(buf, pos, g.SubstreamsPresent)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := range g.Substreams {
+			var ajoc bool
+			ajoc, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if ajoc {
+				err = g.Substreams[i].unmarshalAJOC(buf, pos, fsIndex, frameRateFactor, g.SubstreamsPresent)
+			} else {
+				err = g.Substreams[i].unmarshalObj(buf, pos, fsIndex, frameRateFactor, g.SubstreamsPresent)
+			}
+			if err != nil {
+				return err
+			}
+
+			if g.HSFExt {
+				err = skipSubstreamIndex(buf, pos, g.SubstreamsPresent)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	contentType, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if contentType {
+		g.ContentType = &ContentType{}
+		err = g.ContentType.unmarshalTOC(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChannelMask returns the channel mask of a channel-coded substream group.
+func (g SubstreamGroup) ChannelMask() uint32 {
+	mask := uint32(0)
+	for _, s := range g.Substreams {
+		mask |= s.ChannelMask()
+	}
+	return mask
+}
+
+// ChannelCount returns the channel count of the substream group.
+// For object-coded substream groups, it is the channel count of the
+// core downmix of AJOC substreams.
+func (g SubstreamGroup) ChannelCount() int {
+	if g.ChannelCoded {
+		return channelMaskCount(g.ChannelMask())
+	}
+
+	n := 0
+	for _, s := range g.Substreams {
+		if s.AJOC {
+			c := s.DmxObjects
+			if s.LFE {
+				c++
+			}
+			n = max(n, c)
+		}
+	}
+	return n
+}
+
+// Presentation is a presentation.
+type Presentation struct {
+	Version              uint32
+	SingleSubstreamGroup bool
+	Config               uint32 // present when SingleSubstreamGroup is false
+	MDCompat             uint8
+	ID                   *uint32
+	FrameRateFactor      int
+	FrameRateFraction    int
+	EMDF                 EMDFInfo
+	Filter               bool
+	Enabled              bool
+	MultiPID             bool
+	PreVirtualized       bool
+
+	// indexes of substream groups inside TOC.SubstreamGroups.
+	SubstreamGroupIndexes []uint32
+
+	// additional EMDF substreams.
+	AddEMDFSubstreams []EMDFInfo
+}
+
+// IsIMS returns whether the presentation is an immersive stereo (IMS) presentation.
+func (p Presentation) IsIMS() bool {
+	return p.Version == 2
+}
+
+func (p *Presentation) readFrameRateInfo(buf []byte, pos *int, frameRateIndex uint8) error {
+	p.FrameRateFactor = 1
+	p.FrameRateFraction = 1
+
+	// frame_rate_multiply_info()
+	switch frameRateIndex {
+	case 2, 3, 4:
+		multiplier, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if multiplier {
+			var multiplierBit bool
+			multiplierBit, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if multiplierBit {
+				p.FrameRateFactor = 4
+			} else {
+				p.FrameRateFactor = 2
+			}
+		}
+
+	case 0, 1, 7, 8, 9:
+		multiplier, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if multiplier {
+			p.FrameRateFactor = 2
+		}
+	}
+
+	// frame_rate_fractions_info()
+	switch {
+	case frameRateIndex >= 5 && frameRateIndex <= 9:
+		if p.FrameRateFactor == 1 {
+			fraction, err := bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if fraction {
+				p.FrameRateFraction = 2
+			}
+		}
+
+	case frameRateIndex >= 10 && frameRateIndex <= 12:
+		fraction, err := bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if fraction {
+			var fractionIs4 bool
+			fractionIs4, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if fractionIs4 {
+				p.FrameRateFraction = 4
+			} else {
+				p.FrameRateFraction = 2
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Presentation) readSubstreamGroupIndex(buf []byte, pos *int) error {
+	v, err := readEscapedBits(buf, pos, 3, 2)
+	if err != nil {
+		return err
+	}
+
+	if v >= maxSubstreamGroups {
+		return fmt.Errorf("invalid substream group index: %d", v)
+	}
+
+	p.SubstreamGroupIndexes = append(p.SubstreamGroupIndexes, v)
+	return nil
+}
+
+func skipPresentationConfigExtInfo(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 6)
+	if err != nil {
+		return err
+	}
+
+	n := uint32(tmp >> 1)
+
+	if (tmp & 1) != 0 {
+		var add uint32
+		add, err = readVariableBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		n += add << 5
+	}
+
+	err = bits.HasSpace(buf, *pos, int(n)*8)
+	if err != nil {
+		return err
+	}
+	*pos += int(n) * 8
+
+	return nil
+}
+
+func (p *Presentation) unmarshal(buf []byte, pos *int, frameRateIndex uint8) error {
+	var err error
+	p.SingleSubstreamGroup, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !p.SingleSubstreamGroup {
+		p.Config, err = readEscapedBits(buf, pos, 3, 2)
+		if err != nil {
+			return err
+		}
+	}
+
+	// presentation_version()
+	p.Version = 0
+	for {
+		var b bool
+		b, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+		if !b {
+			break
+		}
+		p.Version++
+	}
+
+	p.FrameRateFactor = 1
+	p.FrameRateFraction = 1
+	p.Enabled = true
+
+	addEMDFSubstreams := false
+
+	if !p.SingleSubstreamGroup && p.Config == 6 {
+		addEMDFSubstreams = true
+	} else {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+		p.MDCompat = uint8(tmp)
+
+		var hasID bool
+		hasID, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if hasID {
+			var id uint32
+			id, err = readVariableBits(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+			p.ID = &id
+		}
+
+		err = p.readFrameRateInfo(buf, pos, frameRateIndex)
+		if err != nil {
+			return err
+		}
+
+		err = p.EMDF.unmarshal(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		p.Filter, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if p.Filter {
+			p.Enabled, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if p.SingleSubstreamGroup {
+			err = p.readSubstreamGroupIndex(buf, pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			p.MultiPID, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			err = p.readSubstreamGroupIndexes(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		p.PreVirtualized, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		addEMDFSubstreams, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		// ac4_presentation_substream_info()
+		_, err = bits.ReadBits(buf, pos, 2) // b_alternative, b_pres_ndot
+		if err != nil {
+			return err
+		}
+
+		err = skipSubstreamIndex(buf, pos, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if addEMDFSubstreams {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		n := uint32(tmp)
+
+		if n == 0 {
+			n, err = readVariableBits(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+			n += 4
+		}
+
+		if n > maxAddEMDFSubstream {
+			return fmt.Errorf("too many additional EMDF substreams")
+		}
+
+		p.AddEMDFSubstreams = make([]EMDFInfo, n)
+
+		for i := range p.AddEMDFSubstreams {
+			err = p.AddEMDFSubstreams[i].unmarshal(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Presentation) readSubstreamGroupIndexes(buf []byte, pos *int) error {
+	var n uint32
+
+	switch p.Config {
+	case 0, 1, 2:
+		n = 2
+
+	case 3, 4:
+		n = 3
+
+	case 5:
+		tmp, err := bits.ReadBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		n = uint32(tmp) + 2
+
+		if n == 5 {
+			var add uint32
+			add, err = readVariableBits(buf, pos, 2)
+			if err != nil {
+				return err
+			}
+			n += add
+		}
+
+		if n > maxSubstreamGroups {
+			return fmt.Errorf("too many substream groups")
+		}
+
+	default:
+		return skipPresentationConfigExtInfo(buf, pos)
+	}
+
+	for i := uint32(0); i < n; i++ {
+		err := p.readSubstreamGroupIndex(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TOC is the table of contents of a raw AC-4 frame.
+// Only bitstream versions greater than or equal to 2 are supported.
+// Specification: ETSI TS 103 190-2, section 6.2.1
+type TOC struct {
+	BitstreamVersion uint32
+	SequenceCounter  uint16
+	FsIndex          uint8
+	FrameRateIndex   uint8
+	IFrameGlobal     bool
+	ShortProgramID   *uint16
+	ProgramUUID      *[16]byte
+	Presentations    []Presentation
+	SubstreamGroups  []SubstreamGroup
+}
+
+// Unmarshal decodes a TOC from a raw frame.
+func (t *TOC) Unmarshal(buf []byte) error {
+	*t = TOC{}
+	pos := 0
+
+	var err error
+	t.BitstreamVersion, err = readEscapedBits(buf, &pos, 2, 2)
+	if err != nil {
+		return err
+	}
+
+	if t.BitstreamVersion < 2 {
+		return fmt.Errorf("unsupported bitstream version: %d", t.BitstreamVersion)
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 10)
+	if err != nil {
+		return err
+	}
+	t.SequenceCounter = uint16(tmp)
+
+	err = skipWaitFrames(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, 7)
+	if err != nil {
+		return err
+	}
+
+	t.FsIndex = uint8(tmp >> 6)
+	t.FrameRateIndex = uint8((tmp >> 2) & 0b1111)
+	t.IFrameGlobal = (tmp & 0b10) != 0
+	singlePresentation := (tmp & 0b1) != 0
+
+	if (t.FsIndex == 0 && t.FrameRateIndex != 13) || int(t.FrameRateIndex) >= len(frameRates) {
+		return fmt.Errorf("invalid frame rate index: %d", t.FrameRateIndex)
+	}
+
+	n, err := readPresentationCount(buf, &pos, singlePresentation)
+	if err != nil {
+		return err
+	}
+
+	err = skipPayloadBase(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = t.readProgramID(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	t.Presentations = make([]Presentation, n)
+	groupCount := 0
+	frameRateFactor := 1
+
+	for i := range t.Presentations {
+		p := &t.Presentations[i]
+
+		err = p.unmarshal(buf, &pos, t.FrameRateIndex)
+		if err != nil {
+			return err
+		}
+
+		for _, idx := range p.SubstreamGroupIndexes {
+			groupCount = max(groupCount, int(idx)+1)
+		}
+
+		// the frame rate factor of the last presentation
+		// is used to decode all substream groups
+		frameRateFactor = p.FrameRateFactor
+	}
+
+	t.SubstreamGroups = make([]SubstreamGroup, groupCount)
+
+	for i := range t.SubstreamGroups {
+		err = t.SubstreamGroups[i].unmarshal(buf, &pos, t.FsIndex, frameRateFactor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func skipWaitFrames(buf []byte, pos *int) error {
+	waitFrames, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if waitFrames {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+
+		if tmp > 0 {
+			_, err = bits.ReadBits(buf, pos, 2) // br_code
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func readPresentationCount(buf []byte, pos *int, singlePresentation bool) (uint32, error) {
+	if singlePresentation {
+		return 1, nil
+	}
+
+	morePresentations, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	if !morePresentations {
+		return 0, nil
+	}
+
+	n, err := readVariableBits(buf, pos, 2)
+	if err != nil {
+		return 0, err
+	}
+	n += 2
+
+	if n > maxPresentations {
+		return 0, fmt.Errorf("too many presentations")
+	}
+
+	return n, nil
+}
+
+func skipPayloadBase(buf []byte, pos *int) error {
+	payloadBase, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if payloadBase {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+
+		if tmp == 0x1F {
+			_, err = readVariableBits(buf, pos, 3)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *TOC) readProgramID(buf []byte, pos *int) error {
+	programID, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !programID {
+		return nil
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 16)
+	if err != nil {
+		return err
+	}
+	id := uint16(tmp)
+	t.ShortProgramID = &id
+
+	uuidPresent, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if uuidPresent {
+		err = bits.HasSpace(buf, *pos, 128)
+		if err != nil {
+			return err
+		}
+
+		var uuid [16]byte
+		for i := range uuid {
+			uuid[i] = byte(bits.ReadBitsUnsafe(buf, pos, 8))
+		}
+		t.ProgramUUID = &uuid
+	}
+
+	return nil
+}
+
+// SampleRate returns the sample rate.
+func (t TOC) SampleRate() int {
+	if int(t.FsIndex) < len(sampleRates) {
+		return sampleRates[t.FsIndex]
+	}
+	return 0
+}
+
+// FrameRate returns the frame rate, as a fraction.
+func (t TOC) FrameRate() (int, int) {
+	if t.FsIndex == 0 {
+		return 44100, 2048
+	}
+
+	if int(t.FrameRateIndex) < len(frameRates) {
+		return frameRates[t.FrameRateIndex][0], frameRates[t.FrameRateIndex][1]
+	}
+	return 0, 1
+}
+
+// PresentationChannelCount returns the channel count of a presentation,
+// that is the maximum channel count of its substream groups.
+func (t TOC) PresentationChannelCount(p *Presentation) int {
+	n := 0
+	for _, idx := range p.SubstreamGroupIndexes {
+		n = max(n, t.SubstreamGroups[idx].ChannelCount())
+	}
+	return n
+}
+
+// ChannelCount returns the channel count of the first presentation.
+func (t TOC) ChannelCount() int {
+	if len(t.Presentations) == 0 {
+		return 0
+	}
+	return t.PresentationChannelCount(&t.Presentations[0])
+}
@@ -0,0 +1,237 @@
+package ac4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func uint8Ptr(v uint8) *uint8 {
+	return &v
+}
+
+var casesTOC = []struct {
+	name         string
+	enc          []byte
+	dec          TOC
+	frameRate    [2]int
+	channelCount int
+}{
+	{
+		"stereo",
+		[]byte{
+			0x80, 0x54, 0xb3, 0x00, 0x04, 0xa5, 0x00, 0x6e, 0x04, 0xc1, 0xb2, 0xb7,
+			0x33, 0x80, 0x00, 0x00,
+		},
+		TOC{
+			BitstreamVersion: 2,
+			SequenceCounter:  5,
+			FsIndex:          1,
+			FrameRateIndex:   2,
+			IFrameGlobal:     true,
+			Presentations: []Presentation{{
+				Version:               1,
+				SingleSubstreamGroup:  true,
+				FrameRateFactor:       1,
+				FrameRateFraction:     1,
+				Enabled:               true,
+				SubstreamGroupIndexes: []uint32{0},
+			}},
+			SubstreamGroups: []SubstreamGroup{{
+				SubstreamsPresent: true,
+				ChannelCoded:      true,
+				Substreams: []Substream{{
+					ChannelMode: ChannelModeStereo,
+				}},
+				ContentType: &ContentType{
+					Classifier:  1,
+					LanguageTag: "eng",
+				},
+			}},
+		},
+		[2]int{25, 1},
+		2,
+	},
+	{
+		"immersive and ims",
+		[]byte{
+			0x80, 0x54, 0xe8, 0x31, 0xe0, 0x09, 0x4b, 0x80, 0x38, 0x00, 0x25, 0x28,
+			0xc3, 0x7f, 0xde, 0xb4, 0x2e, 0x10, 0x00, 0x00,
+		},
+		TOC{
+			BitstreamVersion: 2,
+			SequenceCounter:  5,
+			FsIndex:          1,
+			FrameRateIndex:   3,
+			IFrameGlobal:     true,
+			Presentations: []Presentation{
+				{
+					Version:               1,
+					SingleSubstreamGroup:  true,
+					MDCompat:              1,
+					ID:                    uint32Ptr(3),
+					FrameRateFactor:       1,
+					FrameRateFraction:     1,
+					Filter:                true,
+					Enabled:               true,
+					SubstreamGroupIndexes: []uint32{0},
+				},
+				{
+					Version:               2,
+					SingleSubstreamGroup:  true,
+					FrameRateFactor:       1,
+					FrameRateFraction:     1,
+					Enabled:               true,
+					PreVirtualized:        true,
+					SubstreamGroupIndexes: []uint32{1},
+				},
+			},
+			SubstreamGroups: []SubstreamGroup{
+				{
+					SubstreamsPresent: true,
+					ChannelCoded:      true,
+					Substreams: []Substream{{
+						ChannelMode:          ChannelMode714,
+						Back4ChannelsPresent: true,
+						CentrePresent:        true,
+						TopChannelsPresent:   3,
+						BitrateIndicator:     uint8Ptr(13),
+					}},
+				},
+				{
+					SubstreamsPresent: true,
+					ChannelCoded:      true,
+					Substreams: []Substream{{
+						ChannelMode: ChannelModeStereo,
+						Index:       2,
+					}},
+				},
+			},
+		},
+		[2]int{30000, 1001},
+		12,
+	},
+	{
+		"ajoc",
+		[]byte{
+			0x80, 0x54, 0xb3, 0x04, 0x02, 0x52, 0x80, 0x15, 0x3d, 0xe0, 0x50, 0x40,
+			0x00, 0x00,
+		},
+		TOC{
+			BitstreamVersion: 2,
+			SequenceCounter:  5,
+			FsIndex:          1,
+			FrameRateIndex:   2,
+			IFrameGlobal:     true,
+			Presentations: []Presentation{{
+				Version:               1,
+				SingleSubstreamGroup:  true,
+				FrameRateFactor:       2,
+				FrameRateFraction:     1,
+				Enabled:               true,
+				SubstreamGroupIndexes: []uint32{0},
+			}},
+			SubstreamGroups: []SubstreamGroup{{
+				SubstreamsPresent: true,
+				Substreams: []Substream{{
+					AJOC:           true,
+					LFE:            true,
+					StaticDmx:      true,
+					DmxObjects:     5,
+					UmxObjects:     16,
+					BedObjects:     true,
+					DynamicObjects: true,
+					Index:          2,
+				}},
+			}},
+		},
+		[2]int{25, 1},
+		6,
+	},
+}
+
+func TestTOCUnmarshal(t *testing.T) {
+	for _, ca := range casesTOC {
+		t.Run(ca.name, func(t *testing.T) {
+			var toc TOC
+			err := toc.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, toc)
+			require.Equal(t, 48000, toc.SampleRate())
+
+			num, den := toc.FrameRate()
+			require.Equal(t, ca.frameRate, [2]int{num, den})
+
+			require.Equal(t, ca.channelCount, toc.ChannelCount())
+		})
+	}
+}
+
+func TestTOCIMS(t *testing.T) {
+	var toc TOC
+	err := toc.Unmarshal(casesTOC[1].enc)
+	require.NoError(t, err)
+	require.False(t, toc.Presentations[0].IsIMS())
+	require.True(t, toc.Presentations[1].IsIMS())
+	require.Equal(t, 2, toc.PresentationChannelCount(&toc.Presentations[1]))
+}
+
+func TestTOCUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bits",
+		},
+		{
+			"unsupported bitstream version",
+			[]byte{0x40, 0x00, 0x00},
+			"unsupported bitstream version: 1",
+		},
+		{
+			"invalid frame rate index",
+			[]byte{0x80, 0x57, 0xb0},
+			"invalid frame rate index: 14",
+		},
+		{
+			"truncated",
+			casesTOC[1].enc[:10],
+			"not enough bits",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var toc TOC
+			err := toc.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzTOCUnmarshal(f *testing.F) {
+	for _, ca := range casesTOC {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var toc TOC
+		err := toc.Unmarshal(b)
+		if err == nil {
+			toc.ChannelCount()
+
+			var dsi DSI
+			err = dsi.fillFromTOC(&toc)
+			if err == nil {
+				_, err = dsi.Marshal()
+				require.NoError(t, err)
+			}
+		}
+	})
+}
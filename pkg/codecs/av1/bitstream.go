@@ -21,20 +21,23 @@ func (bs *Bitstream) Unmarshal(buf []byte) error {
 			return fmt.Errorf("OBU size not present")
 		}
 
+		hl := h.MarshalSize()
+
 		var size LEB128
-		n, err := size.Unmarshal(buf[1:])
+		n, err := size.Unmarshal(buf[hl:])
 		if err != nil {
 			return err
 		}
 
-		obuAndSizeLen := 1 + int(size) + n
+		obuAndSizeLen := hl + int(size) + n
 		if len(buf) < obuAndSizeLen {
 			return fmt.Errorf("not enough bytes")
 		}
 
-		obu := make([]byte, 1+int(size))
-		obu[0] = buf[0] & 0b11111101
-		copy(obu[1:], buf[1+n:])
+		obu := make([]byte, hl+int(size))
+		copy(obu, buf[:hl])
+		obu[0] &= 0b11111101
+		copy(obu[hl:], buf[hl+n:])
 
 		buf = buf[obuAndSizeLen:]
 
@@ -61,7 +64,7 @@ func (bs Bitstream) marshalSize() (int, error) {
 		}
 
 		if !h.HasSize {
-			size := len(obu) - 1
+			size := len(obu) - h.MarshalSize()
 			n += LEB128(uint32(size)).MarshalSize()
 		}
 	}
@@ -84,11 +87,13 @@ func (bs Bitstream) Marshal() ([]byte, error) {
 		h.Unmarshal(obu) //nolint:errcheck
 
 		if !h.HasSize {
-			buf[n] = obu[0] | 0b00000010
-			n++
-			size := len(obu) - 1
+			hl := h.MarshalSize()
+			copy(buf[n:], obu[:hl])
+			buf[n] |= 0b00000010
+			n += hl
+			size := len(obu) - hl
 			n += LEB128(uint32(size)).MarshalTo(buf[n:])
-			n += copy(buf[n:], obu[1:])
+			n += copy(buf[n:], obu[hl:])
 		} else {
 			n += copy(buf[n:], obu)
 		}
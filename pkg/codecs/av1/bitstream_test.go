@@ -30,6 +30,16 @@ var casesBitstream = []struct {
 			},
 		},
 	},
+	{
+		"extension",
+		[]byte{
+			0x12, 0x00, 0x36, 0x48, 0x03, 0x10, 0x01, 0x02,
+		},
+		[][]byte{
+			{0x10},
+			{0x34, 0x48, 0x10, 0x01, 0x02},
+		},
+	},
 }
 
 func TestBitstreamUnmarshal(t *testing.T) {
@@ -0,0 +1,456 @@
+package av1
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+const (
+	numRefFrames     = 8
+	refsPerFrame     = 7
+	primaryRefNone   = 7
+	superresNum      = 8
+	superresDenomMin = 9
+)
+
+// FrameType is a frame type.
+type FrameType uint8
+
+// frame types.
+// Specification: AV1 Bitstream & Decoding Process, section 6.8.2
+const (
+	FrameTypeKeyFrame       FrameType = 0
+	FrameTypeInterFrame     FrameType = 1
+	FrameTypeIntraOnlyFrame FrameType = 2
+	FrameTypeSwitchFrame    FrameType = 3
+)
+
+var frameTypeLabels = map[FrameType]string{
+	FrameTypeKeyFrame:       "KEY_FRAME",
+	FrameTypeInterFrame:     "INTER_FRAME",
+	FrameTypeIntraOnlyFrame: "INTRA_ONLY_FRAME",
+	FrameTypeSwitchFrame:    "SWITCH_FRAME",
+}
+
+// String implements fmt.Stringer.
+func (t FrameType) String() string {
+	if l, ok := frameTypeLabels[t]; ok {
+		return l
+	}
+	return fmt.Sprintf("unknown (%d)", t)
+}
+
+// FrameHeader_FrameSize is the frame size of a frame header.
+type FrameHeader_FrameSize struct { //nolint:revive
+	FrameWidth  uint32
+	FrameHeight uint32
+	UseSuperres bool
+
+	// UseSuperres == true
+	CodedDenom uint8
+
+	UpscaledWidth               uint32
+	RenderAndFrameSizeDifferent bool
+	RenderWidth                 uint32
+	RenderHeight                uint32
+}
+
+func (s *FrameHeader_FrameSize) unmarshal(
+	buf []byte,
+	pos *int,
+	sh *SequenceHeader,
+	frameSizeOverrideFlag bool,
+) error {
+	if frameSizeOverrideFlag {
+		n1 := int(sh.FrameWidthBitsMinus1) + 1
+		n2 := int(sh.FrameHeightBitsMinus1) + 1
+
+		err := bits.HasSpace(buf, *pos, n1+n2)
+		if err != nil {
+			return err
+		}
+
+		s.FrameWidth = uint32(bits.ReadBitsUnsafe(buf, pos, n1)) + 1
+		s.FrameHeight = uint32(bits.ReadBitsUnsafe(buf, pos, n2)) + 1
+	} else {
+		s.FrameWidth = sh.MaxFrameWidthMinus1 + 1
+		s.FrameHeight = sh.MaxFrameHeightMinus1 + 1
+	}
+
+	// superres_params()
+	var err error
+	if sh.EnableSuperRes {
+		s.UseSuperres, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.UseSuperres = false
+	}
+
+	s.UpscaledWidth = s.FrameWidth
+
+	if s.UseSuperres {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+		s.CodedDenom = uint8(tmp)
+
+		denom := uint32(s.CodedDenom) + superresDenomMin
+		s.FrameWidth = (s.UpscaledWidth*superresNum + (denom / 2)) / denom
+	} else {
+		s.CodedDenom = 0
+	}
+
+	// render_size()
+	s.RenderAndFrameSizeDifferent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if s.RenderAndFrameSizeDifferent {
+		err = bits.HasSpace(buf, *pos, 32)
+		if err != nil {
+			return err
+		}
+
+		s.RenderWidth = uint32(bits.ReadBitsUnsafe(buf, pos, 16)) + 1
+		s.RenderHeight = uint32(bits.ReadBitsUnsafe(buf, pos, 16)) + 1
+	} else {
+		s.RenderWidth = s.UpscaledWidth
+		s.RenderHeight = s.FrameHeight
+	}
+
+	return nil
+}
+
+// FrameHeader is a AV1 frame header.
+// It can be decoded from a OBU_FRAME_HEADER, OBU_FRAME or OBU_REDUNDANT_FRAME_HEADER.
+// Fields that depend on the state of reference frames are not filled.
+// Specification: AV1 Bitstream & Decoding Process, section 5.9
+type FrameHeader struct {
+	// from the OBU extension header
+	TemporalID uint8
+	SpatialID  uint8
+
+	ShowExistingFrame bool
+
+	// ShowExistingFrame == true
+	FrameToShowMapIdx uint8
+	DisplayFrameID    uint32
+
+	// ShowExistingFrame == false
+	FrameType               FrameType
+	ShowFrame               bool
+	ShowableFrame           bool
+	ErrorResilientMode      bool
+	DisableCdfUpdate        bool
+	AllowScreenContentTools bool
+	ForceIntegerMv          bool
+	CurrentFrameID          uint32
+	FrameSizeOverrideFlag   bool
+	OrderHint               uint32
+	PrimaryRefFrame         uint8
+	RefreshFrameFlags       uint8
+
+	// ErrorResilientMode == true && SequenceHeader.EnableOrderHint == true
+	RefOrderHint []uint32
+
+	// FrameType == FrameTypeInterFrame || FrameType == FrameTypeSwitchFrame
+	FrameRefsShortSignaling bool
+	LastFrameIdx            uint8 // FrameRefsShortSignaling == true
+	GoldFrameIdx            uint8 // FrameRefsShortSignaling == true
+	RefFrameIdx             [refsPerFrame]uint8
+	DeltaFrameIDMinus1      [refsPerFrame]uint32
+
+	// nil when the frame size is copied from a reference frame.
+	FrameSize *FrameHeader_FrameSize
+
+	// FrameType == FrameTypeKeyFrame || FrameType == FrameTypeIntraOnlyFrame
+	AllowIntrabc bool
+
+	// the remaining fields are not decoded.
+}
+
+// Unmarshal decodes a FrameHeader.
+// The active sequence header is needed.
+func (h *FrameHeader) Unmarshal(sh *SequenceHeader, buf []byte) error {
+	var oh OBUHeader
+	err := oh.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	if oh.Type != OBUTypeFrameHeader && oh.Type != OBUTypeFrame && oh.Type != OBUTypeRedundantFrameHeader {
+		return fmt.Errorf("not a frame header")
+	}
+
+	h.TemporalID = oh.TemporalID
+	h.SpatialID = oh.SpatialID
+
+	buf = buf[oh.MarshalSize():]
+
+	if oh.HasSize {
+		var size LEB128
+		var n int
+		n, err = size.Unmarshal(buf)
+		if err != nil {
+			return err
+		}
+
+		buf = buf[n:]
+		if len(buf) != int(size) {
+			return fmt.Errorf("wrong buffer size: expected %d, got %d", size, len(buf))
+		}
+	}
+
+	pos := 0
+
+	var idLen int
+	if sh.FrameIDNumbersPresentFlag {
+		idLen = int(sh.AdditionalFrameIDLengthMinus1) + int(sh.DeltaFrameIDLengthMinus2) + 3
+	}
+
+	var orderHintBits int
+	if sh.EnableOrderHint {
+		orderHintBits = int(sh.OrderHintBitsMinus1) + 1
+	}
+
+	*h = FrameHeader{
+		TemporalID: h.TemporalID,
+		SpatialID:  h.SpatialID,
+	}
+
+	if sh.ReducedStillPictureHeader {
+		h.FrameType = FrameTypeKeyFrame
+		h.ShowFrame = true
+		h.ErrorResilientMode = true
+	} else {
+		h.ShowExistingFrame, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.ShowExistingFrame {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, 3)
+			if err != nil {
+				return err
+			}
+			h.FrameToShowMapIdx = uint8(tmp)
+
+			if sh.FrameIDNumbersPresentFlag {
+				tmp, err = bits.ReadBits(buf, &pos, idLen)
+				if err != nil {
+					return err
+				}
+				h.DisplayFrameID = uint32(tmp)
+			}
+
+			return nil
+		}
+
+		err = bits.HasSpace(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+
+		h.FrameType = FrameType(bits.ReadBitsUnsafe(buf, &pos, 2))
+		h.ShowFrame = bits.ReadFlagUnsafe(buf, &pos)
+
+		if h.ShowFrame {
+			h.ShowableFrame = h.FrameType != FrameTypeKeyFrame
+		} else {
+			h.ShowableFrame, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if h.FrameType == FrameTypeSwitchFrame || (h.FrameType == FrameTypeKeyFrame && h.ShowFrame) {
+			h.ErrorResilientMode = true
+		} else {
+			h.ErrorResilientMode, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	frameIsIntra := h.FrameType == FrameTypeIntraOnlyFrame || h.FrameType == FrameTypeKeyFrame
+
+	h.DisableCdfUpdate, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if sh.SeqForceScreenContentTools == SequenceHeader_SeqForceScreenContentTools_SELECT_SCREEN_CONTENT_TOOLS {
+		h.AllowScreenContentTools, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.AllowScreenContentTools = sh.SeqForceScreenContentTools != 0
+	}
+
+	if h.AllowScreenContentTools {
+		if sh.SeqForceIntegerMv == SequenceHeader_SeqForceIntegerMv_SELECT_INTEGER_MV {
+			h.ForceIntegerMv, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			h.ForceIntegerMv = sh.SeqForceIntegerMv != 0
+		}
+	}
+
+	if frameIsIntra {
+		h.ForceIntegerMv = true
+	}
+
+	if sh.FrameIDNumbersPresentFlag {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, idLen)
+		if err != nil {
+			return err
+		}
+		h.CurrentFrameID = uint32(tmp)
+	}
+
+	switch {
+	case h.FrameType == FrameTypeSwitchFrame:
+		h.FrameSizeOverrideFlag = true
+
+	case sh.ReducedStillPictureHeader:
+		h.FrameSizeOverrideFlag = false
+
+	default:
+		h.FrameSizeOverrideFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	var tmp uint64
+
+	if orderHintBits > 0 {
+		tmp, err = bits.ReadBits(buf, &pos, orderHintBits)
+		if err != nil {
+			return err
+		}
+		h.OrderHint = uint32(tmp)
+	}
+
+	if frameIsIntra || h.ErrorResilientMode {
+		h.PrimaryRefFrame = primaryRefNone
+	} else {
+		tmp, err = bits.ReadBits(buf, &pos, 3)
+		if err != nil {
+			return err
+		}
+		h.PrimaryRefFrame = uint8(tmp)
+	}
+
+	if h.FrameType == FrameTypeSwitchFrame || (h.FrameType == FrameTypeKeyFrame && h.ShowFrame) {
+		h.RefreshFrameFlags = 1<<numRefFrames - 1
+	} else {
+		tmp, err = bits.ReadBits(buf, &pos, 8)
+		if err != nil {
+			return err
+		}
+		h.RefreshFrameFlags = uint8(tmp)
+	}
+
+	if (!frameIsIntra || h.RefreshFrameFlags != 1<<numRefFrames-1) &&
+		h.ErrorResilientMode && sh.EnableOrderHint {
+		err = bits.HasSpace(buf, pos, numRefFrames*orderHintBits)
+		if err != nil {
+			return err
+		}
+
+		h.RefOrderHint = make([]uint32, numRefFrames)
+
+		for i := range h.RefOrderHint {
+			h.RefOrderHint[i] = uint32(bits.ReadBitsUnsafe(buf, &pos, orderHintBits))
+		}
+	}
+
+	if frameIsIntra {
+		h.FrameSize = &FrameHeader_FrameSize{}
+		err = h.FrameSize.unmarshal(buf, &pos, sh, h.FrameSizeOverrideFlag)
+		if err != nil {
+			return err
+		}
+
+		if h.AllowScreenContentTools && h.FrameSize.UpscaledWidth == h.FrameSize.FrameWidth {
+			h.AllowIntrabc, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if sh.EnableOrderHint {
+		h.FrameRefsShortSignaling, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.FrameRefsShortSignaling {
+			err = bits.HasSpace(buf, pos, 6)
+			if err != nil {
+				return err
+			}
+
+			h.LastFrameIdx = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+			h.GoldFrameIdx = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+		}
+	}
+
+	for i := range refsPerFrame {
+		if !h.FrameRefsShortSignaling {
+			tmp, err = bits.ReadBits(buf, &pos, 3)
+			if err != nil {
+				return err
+			}
+			h.RefFrameIdx[i] = uint8(tmp)
+		}
+
+		if sh.FrameIDNumbersPresentFlag {
+			tmp, err = bits.ReadBits(buf, &pos, int(sh.DeltaFrameIDLengthMinus2)+2)
+			if err != nil {
+				return err
+			}
+			h.DeltaFrameIDMinus1[i] = uint32(tmp)
+		}
+	}
+
+	if h.FrameSizeOverrideFlag && !h.ErrorResilientMode {
+		// frame_size_with_refs()
+		for range refsPerFrame {
+			var foundRef bool
+			foundRef, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if foundRef {
+				return nil
+			}
+		}
+	}
+
+	h.FrameSize = &FrameHeader_FrameSize{}
+	return h.FrameSize.unmarshal(buf, &pos, sh, h.FrameSizeOverrideFlag)
+}
+
+// IsSync checks whether the frame can be used as a random access point.
+// This happens with shown key frames.
+func (h FrameHeader) IsSync() bool {
+	return !h.ShowExistingFrame && h.FrameType == FrameTypeKeyFrame && h.ShowFrame
+}
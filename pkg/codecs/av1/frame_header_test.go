@@ -0,0 +1,160 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesFrameHeader = []struct {
+	name string
+	sh   *SequenceHeader
+	byts []byte
+	fh   FrameHeader
+}{
+	{
+		"key frame",
+		&casesSequenceHeader[0].sh,
+		[]byte{0x32, 0x02, 0x10, 0x00},
+		FrameHeader{
+			FrameType:         FrameTypeKeyFrame,
+			ShowFrame:         true,
+			ForceIntegerMv:    true,
+			PrimaryRefFrame:   7,
+			RefreshFrameFlags: 0xff,
+			FrameSize: &FrameHeader_FrameSize{
+				FrameWidth:    1920,
+				FrameHeight:   804,
+				UpscaledWidth: 1920,
+				RenderWidth:   1920,
+				RenderHeight:  804,
+			},
+			ErrorResilientMode: true,
+		},
+	},
+	{
+		"inter frame",
+		&casesSequenceHeader[0].sh,
+		[]byte{0x1a, 0x06, 0x32, 0x00, 0x10, 0x53, 0x97, 0x00},
+		FrameHeader{
+			FrameType:               FrameTypeInterFrame,
+			ShowFrame:               true,
+			ShowableFrame:           true,
+			AllowScreenContentTools: true,
+			RefreshFrameFlags:       0x01,
+			RefFrameIdx:             [7]uint8{0, 1, 2, 3, 4, 5, 6},
+			FrameSize: &FrameHeader_FrameSize{
+				FrameWidth:    1920,
+				FrameHeight:   804,
+				UpscaledWidth: 1920,
+				RenderWidth:   1920,
+				RenderHeight:  804,
+			},
+		},
+	},
+	{
+		"inter frame with size from reference",
+		&casesSequenceHeader[1].sh,
+		[]byte{0x1a, 0x07, 0x31, 0x0a, 0x40, 0x80, 0xa7, 0x2e, 0x20},
+		FrameHeader{
+			FrameType:             FrameTypeInterFrame,
+			ShowFrame:             true,
+			ShowableFrame:         true,
+			FrameSizeOverrideFlag: true,
+			OrderHint:             5,
+			PrimaryRefFrame:       1,
+			RefreshFrameFlags:     0x02,
+			RefFrameIdx:           [7]uint8{0, 1, 2, 3, 4, 5, 6},
+		},
+	},
+	{
+		"switch frame",
+		&casesSequenceHeader[1].sh,
+		[]byte{
+			0x32, 0x13, 0x70, 0x48, 0x00, 0x20, 0x81, 0x84,
+			0x0a, 0x18, 0x3b, 0x58, 0xd1, 0x13, 0xfe, 0xcf,
+			0x82, 0x7f, 0x81, 0x67, 0x80,
+		},
+		FrameHeader{
+			FrameType:             FrameTypeSwitchFrame,
+			ShowFrame:             true,
+			ShowableFrame:         true,
+			ErrorResilientMode:    true,
+			FrameSizeOverrideFlag: true,
+			OrderHint:             9,
+			PrimaryRefFrame:       7,
+			RefreshFrameFlags:     0xff,
+			RefOrderHint:          []uint32{0, 1, 2, 3, 4, 5, 6, 7},
+			RefFrameIdx:           [7]uint8{6, 5, 4, 3, 2, 1, 0},
+			FrameSize: &FrameHeader_FrameSize{
+				FrameWidth:                  1280,
+				FrameHeight:                 720,
+				UpscaledWidth:               1280,
+				RenderAndFrameSizeDifferent: true,
+				RenderWidth:                 1280,
+				RenderHeight:                720,
+			},
+		},
+	},
+	{
+		"show existing frame",
+		&casesSequenceHeader[0].sh,
+		[]byte{0x1a, 0x01, 0xa0},
+		FrameHeader{
+			ShowExistingFrame: true,
+			FrameToShowMapIdx: 2,
+		},
+	},
+	{
+		"intra only frame with extension",
+		&casesSequenceHeader[0].sh,
+		[]byte{0x1e, 0x20, 0x03, 0x4b, 0x00, 0x50},
+		FrameHeader{
+			TemporalID:              1,
+			FrameType:               FrameTypeIntraOnlyFrame,
+			ShowableFrame:           true,
+			DisableCdfUpdate:        true,
+			AllowScreenContentTools: true,
+			ForceIntegerMv:          true,
+			PrimaryRefFrame:         7,
+			RefreshFrameFlags:       0x01,
+			FrameSize: &FrameHeader_FrameSize{
+				FrameWidth:    1920,
+				FrameHeight:   804,
+				UpscaledWidth: 1920,
+				RenderWidth:   1920,
+				RenderHeight:  804,
+			},
+			AllowIntrabc: true,
+		},
+	},
+}
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesFrameHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var fh FrameHeader
+			err := fh.Unmarshal(ca.sh, ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.fh, fh)
+		})
+	}
+}
+
+func TestFrameTypeString(t *testing.T) {
+	require.Equal(t, "SWITCH_FRAME", FrameTypeSwitchFrame.String())
+	require.Equal(t, "unknown (4)", FrameType(4).String())
+}
+
+func FuzzFrameHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesFrameHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		for _, ca := range casesSequenceHeader {
+			var fh FrameHeader
+			fh.Unmarshal(&ca.sh, b) //nolint:errcheck
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package av1
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MetadataType is a metadata type.
+// Specification: AV1 Bitstream & Decoding Process, section 6.7.1
+type MetadataType uint32
+
+// metadata types.
+const (
+	MetadataTypeHDRCLL      MetadataType = 1
+	MetadataTypeHDRMDCV     MetadataType = 2
+	MetadataTypeScalability MetadataType = 3
+	MetadataTypeITUTT35     MetadataType = 4
+	MetadataTypeTimecode    MetadataType = 5
+)
+
+// Metadata is a metadata OBU.
+// Specification: AV1 Bitstream & Decoding Process, section 5.8.1
+type Metadata struct {
+	Type MetadataType
+
+	// metadata content.
+	// When content is byte-aligned, trailing bits are not included.
+	Payload []byte
+}
+
+// Unmarshal decodes a Metadata.
+func (m *Metadata) Unmarshal(buf []byte) error {
+	var oh OBUHeader
+	err := oh.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	if oh.Type != OBUTypeMetadata {
+		return fmt.Errorf("OBU is not a metadata OBU")
+	}
+
+	buf = buf[oh.MarshalSize():]
+
+	if oh.HasSize {
+		var size LEB128
+		var n int
+		n, err = size.Unmarshal(buf)
+		if err != nil {
+			return err
+		}
+
+		buf = buf[n:]
+		if len(buf) != int(size) {
+			return fmt.Errorf("wrong buffer size: expected %d, got %d", size, len(buf))
+		}
+	}
+
+	var typ LEB128
+	n, err := typ.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	m.Type = MetadataType(typ)
+	m.Payload = buf[n:]
+
+	if len(m.Payload) != 0 && m.Payload[len(m.Payload)-1] == 0x80 {
+		m.Payload = m.Payload[:len(m.Payload)-1]
+	}
+
+	return nil
+}
+
+// Marshal encodes a Metadata into an OBU without the size field.
+func (m Metadata) Marshal() ([]byte, error) {
+	oh := OBUHeader{
+		Type: OBUTypeMetadata,
+	}
+	typ := LEB128(m.Type)
+
+	buf := make([]byte, oh.MarshalSize()+typ.MarshalSize()+len(m.Payload)+1)
+	n := oh.MarshalTo(buf)
+	n += typ.MarshalTo(buf[n:])
+	n += copy(buf[n:], m.Payload)
+	buf[n] = 0x80 // trailing bits
+
+	return buf, nil
+}
+
+// MetadataHDRCLL is the content of a metadata OBU of type MetadataTypeHDRCLL.
+// Specification: AV1 Bitstream & Decoding Process, section 5.8.3
+type MetadataHDRCLL struct {
+	// in units of candelas per square metre
+	MaxCLL  uint16
+	MaxFALL uint16
+}
+
+// Unmarshal decodes a MetadataHDRCLL.
+func (m *MetadataHDRCLL) Unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	m.MaxCLL = binary.BigEndian.Uint16(buf)
+	m.MaxFALL = binary.BigEndian.Uint16(buf[2:])
+
+	return nil
+}
+
+// Marshal encodes a MetadataHDRCLL.
+func (m MetadataHDRCLL) Marshal() ([]byte, error) {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint16(buf, m.MaxCLL)
+	binary.BigEndian.PutUint16(buf[2:], m.MaxFALL)
+	return buf, nil
+}
+
+// MetadataHDRMDCV is the content of a metadata OBU of type MetadataTypeHDRMDCV.
+// Specification: AV1 Bitstream & Decoding Process, section 5.8.4
+type MetadataHDRMDCV struct {
+	// 0.16 fixed-point values, in the order red, green, blue
+	PrimaryChromaticityX [3]uint16
+	PrimaryChromaticityY [3]uint16
+
+	// 0.16 fixed-point values
+	WhitePointChromaticityX uint16
+	WhitePointChromaticityY uint16
+
+	// 24.8 fixed-point value, in candelas per square metre
+	LuminanceMax uint32
+
+	// 18.14 fixed-point value, in candelas per square metre
+	LuminanceMin uint32
+}
+
+// Unmarshal decodes a MetadataHDRMDCV.
+func (m *MetadataHDRMDCV) Unmarshal(buf []byte) error {
+	if len(buf) < 24 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	for i := range 3 {
+		m.PrimaryChromaticityX[i] = binary.BigEndian.Uint16(buf[i*4:])
+		m.PrimaryChromaticityY[i] = binary.BigEndian.Uint16(buf[i*4+2:])
+	}
+
+	m.WhitePointChromaticityX = binary.BigEndian.Uint16(buf[12:])
+	m.WhitePointChromaticityY = binary.BigEndian.Uint16(buf[14:])
+	m.LuminanceMax = binary.BigEndian.Uint32(buf[16:])
+	m.LuminanceMin = binary.BigEndian.Uint32(buf[20:])
+
+	return nil
+}
+
+// Marshal encodes a MetadataHDRMDCV.
+func (m MetadataHDRMDCV) Marshal() ([]byte, error) {
+	buf := make([]byte, 24)
+
+	for i := range 3 {
+		binary.BigEndian.PutUint16(buf[i*4:], m.PrimaryChromaticityX[i])
+		binary.BigEndian.PutUint16(buf[i*4+2:], m.PrimaryChromaticityY[i])
+	}
+
+	binary.BigEndian.PutUint16(buf[12:], m.WhitePointChromaticityX)
+	binary.BigEndian.PutUint16(buf[14:], m.WhitePointChromaticityY)
+	binary.BigEndian.PutUint32(buf[16:], m.LuminanceMax)
+	binary.BigEndian.PutUint32(buf[20:], m.LuminanceMin)
+
+	return buf, nil
+}
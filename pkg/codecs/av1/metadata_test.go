@@ -0,0 +1,119 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesMetadata = []struct {
+	name string
+	byts []byte
+	m    Metadata
+}{
+	{
+		"hdr cll",
+		[]byte{0x28, 0x01, 0x03, 0xe8, 0x01, 0x90, 0x80},
+		Metadata{
+			Type:    MetadataTypeHDRCLL,
+			Payload: []byte{0x03, 0xe8, 0x01, 0x90},
+		},
+	},
+	{
+		"itu-t t35",
+		[]byte{0x28, 0x04, 0xb5, 0x00, 0x3c, 0x80},
+		Metadata{
+			Type:    MetadataTypeITUTT35,
+			Payload: []byte{0xb5, 0x00, 0x3c},
+		},
+	},
+}
+
+func TestMetadataUnmarshal(t *testing.T) {
+	for _, ca := range casesMetadata {
+		t.Run(ca.name, func(t *testing.T) {
+			var m Metadata
+			err := m.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.m, m)
+		})
+	}
+}
+
+func TestMetadataMarshal(t *testing.T) {
+	for _, ca := range casesMetadata {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.m.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestMetadataUnmarshalWithSize(t *testing.T) {
+	var m Metadata
+	err := m.Unmarshal([]byte{0x2a, 0x06, 0x01, 0x03, 0xe8, 0x01, 0x90, 0x80})
+	require.NoError(t, err)
+	require.Equal(t, casesMetadata[0].m, m)
+}
+
+func TestMetadataUnmarshalError(t *testing.T) {
+	var m Metadata
+	err := m.Unmarshal([]byte{0x0a, 0x00})
+	require.EqualError(t, err, "OBU is not a metadata OBU")
+}
+
+func TestMetadataHDRCLL(t *testing.T) {
+	var m MetadataHDRCLL
+	err := m.Unmarshal(casesMetadata[0].m.Payload)
+	require.NoError(t, err)
+	require.Equal(t, MetadataHDRCLL{
+		MaxCLL:  1000,
+		MaxFALL: 400,
+	}, m)
+
+	byts, err := m.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, casesMetadata[0].m.Payload, byts)
+}
+
+func TestMetadataHDRMDCV(t *testing.T) {
+	byts := []byte{
+		0xae, 0x14, 0x51, 0xec, 0x43, 0xd7, 0xb0, 0xa4,
+		0x26, 0x66, 0x0f, 0x5c, 0x50, 0x0d, 0x54, 0x39,
+		0x00, 0x03, 0xe8, 0x00, 0x00, 0x00, 0x00, 0x52,
+	}
+
+	var m MetadataHDRMDCV
+	err := m.Unmarshal(byts)
+	require.NoError(t, err)
+	require.Equal(t, MetadataHDRMDCV{
+		PrimaryChromaticityX:    [3]uint16{0xae14, 0x43d7, 0x2666},
+		PrimaryChromaticityY:    [3]uint16{0x51ec, 0xb0a4, 0x0f5c},
+		WhitePointChromaticityX: 0x500d,
+		WhitePointChromaticityY: 0x5439,
+		LuminanceMax:            1000 << 8,
+		LuminanceMin:            0x52,
+	}, m)
+
+	byts2, err := m.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, byts, byts2)
+}
+
+func FuzzMetadataUnmarshal(f *testing.F) {
+	for _, ca := range casesMetadata {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var m Metadata
+		err := m.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = m.Marshal()
+		require.NoError(t, err)
+	})
+}
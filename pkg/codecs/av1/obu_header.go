@@ -9,6 +9,12 @@ import (
 type OBUHeader struct {
 	Type    OBUType
 	HasSize bool
+
+	// extension header, present when HasExtension is true.
+	// Specification: AV1 Bitstream & Decoding Process, section 5.3.3
+	HasExtension bool
+	TemporalID   uint8
+	SpatialID    uint8
 }
 
 // Unmarshal decodes a OBUHeader.
@@ -23,13 +29,46 @@ func (h *OBUHeader) Unmarshal(buf []byte) error {
 	}
 
 	h.Type = OBUType(buf[0] >> 3)
+	h.HasExtension = ((buf[0] >> 2) & 0b1) != 0
+	h.HasSize = ((buf[0] >> 1) & 0b1) != 0
 
-	extensionFlag := ((buf[0] >> 2) & 0b1) != 0
-	if extensionFlag {
-		return fmt.Errorf("extension flag is not supported yet")
-	}
+	if h.HasExtension {
+		if len(buf) < 2 {
+			return fmt.Errorf("not enough bytes")
+		}
 
-	h.HasSize = ((buf[0] >> 1) & 0b1) != 0
+		h.TemporalID = buf[1] >> 5
+		h.SpatialID = (buf[1] >> 3) & 0b11
+	} else {
+		h.TemporalID = 0
+		h.SpatialID = 0
+	}
 
 	return nil
 }
+
+// MarshalSize returns the size of the OBU header.
+func (h OBUHeader) MarshalSize() int {
+	if h.HasExtension {
+		return 2
+	}
+	return 1
+}
+
+// MarshalTo encodes the OBU header.
+// It returns the number of consumed bytes.
+func (h OBUHeader) MarshalTo(buf []byte) int {
+	buf[0] = byte(h.Type) << 3
+
+	if h.HasSize {
+		buf[0] |= 1 << 1
+	}
+
+	if h.HasExtension {
+		buf[0] |= 1 << 2
+		buf[1] = h.TemporalID<<5 | (h.SpatialID&0b11)<<3
+		return 2
+	}
+
+	return 1
+}
@@ -22,6 +22,19 @@ var casesOBUHeader = []struct {
 			HasSize: true,
 		},
 	},
+	{
+		"frame with extension",
+		[]byte{
+			0x36, 0x48, 0x05, 0x10, 0x00, 0x00, 0x00, 0x00,
+		},
+		OBUHeader{
+			Type:         OBUTypeFrame,
+			HasSize:      true,
+			HasExtension: true,
+			TemporalID:   2,
+			SpatialID:    1,
+		},
+	},
 }
 
 func TestOBUHeaderUnmarshal(t *testing.T) {
@@ -35,6 +48,17 @@ func TestOBUHeaderUnmarshal(t *testing.T) {
 	}
 }
 
+func TestOBUHeaderMarshal(t *testing.T) {
+	for _, ca := range casesOBUHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			buf := make([]byte, ca.h.MarshalSize())
+			n := ca.h.MarshalTo(buf)
+			require.Equal(t, len(buf), n)
+			require.Equal(t, ca.byts[:n], buf)
+		})
+	}
+}
+
 func FuzzOBUHeaderUnmarshal(f *testing.F) {
 	for _, ca := range casesOBUHeader {
 		f.Add(ca.byts)
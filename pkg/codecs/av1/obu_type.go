@@ -6,6 +6,13 @@ type OBUType uint8
 // OBU types.
 // Specification: AV1 Bitstream & Decoding Process, section 6.2.2
 const (
-	OBUTypeSequenceHeader    OBUType = 1
-	OBUTypeTemporalDelimiter OBUType = 2
+	OBUTypeSequenceHeader       OBUType = 1
+	OBUTypeTemporalDelimiter    OBUType = 2
+	OBUTypeFrameHeader          OBUType = 3
+	OBUTypeTileGroup            OBUType = 4
+	OBUTypeMetadata             OBUType = 5
+	OBUTypeFrame                OBUType = 6
+	OBUTypeRedundantFrameHeader OBUType = 7
+	OBUTypeTileList             OBUType = 8
+	OBUTypePadding              OBUType = 15
 )
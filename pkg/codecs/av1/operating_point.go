@@ -0,0 +1,83 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// OperatingPointIncludes checks whether an operating point includes the layer
+// with given temporal ID and spatial ID.
+// Specification: AV1 Bitstream & Decoding Process, section 6.4.1
+func (h SequenceHeader) OperatingPointIncludes(op int, temporalID uint8, spatialID uint8) (bool, error) {
+	if op < 0 || op >= len(h.OperatingPointIdc) {
+		return false, fmt.Errorf("invalid operating point: %d", op)
+	}
+
+	idc := h.OperatingPointIdc[op]
+
+	// all layers are included
+	if idc == 0 {
+		return true, nil
+	}
+
+	inTemporalLayer := ((idc >> temporalID) & 1) != 0
+	inSpatialLayer := ((idc >> (spatialID + 8)) & 1) != 0
+
+	return inTemporalLayer && inSpatialLayer, nil
+}
+
+// FilterOperatingPoint returns the OBUs that belong to given operating point.
+// sh is the active sequence header, that is replaced
+// by the one contained in the bitstream, if present.
+// Specification: AV1 Bitstream & Decoding Process, section 7.5
+func (bs Bitstream) FilterOperatingPoint(sh *SequenceHeader, op int) (Bitstream, error) {
+	if op < 0 || (sh != nil && op >= len(sh.OperatingPointIdc)) {
+		return nil, fmt.Errorf("invalid operating point: %d", op)
+	}
+
+	ret := make(Bitstream, 0, len(bs))
+
+	for _, obu := range bs {
+		var h OBUHeader
+		err := h.Unmarshal(obu)
+		if err != nil {
+			return nil, err
+		}
+
+		switch h.Type {
+		case OBUTypeSequenceHeader:
+			var sh2 SequenceHeader
+			err = sh2.Unmarshal(obu)
+			if err != nil {
+				return nil, err
+			}
+			sh = &sh2
+
+			if op >= len(sh.OperatingPointIdc) {
+				return nil, fmt.Errorf("invalid operating point: %d", op)
+			}
+
+		case OBUTypeTemporalDelimiter:
+
+		default:
+			if h.HasExtension {
+				if sh == nil {
+					return nil, fmt.Errorf("sequence header is missing")
+				}
+
+				var ok bool
+				ok, err = sh.OperatingPointIncludes(op, h.TemporalID, h.SpatialID)
+				if err != nil {
+					return nil, err
+				}
+
+				if !ok {
+					continue
+				}
+			}
+		}
+
+		ret = append(ret, obu)
+	}
+
+	return ret, nil
+}
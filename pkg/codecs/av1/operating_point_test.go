@@ -0,0 +1,108 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatingPointIncludes(t *testing.T) {
+	// L3T3
+	sh := SequenceHeader{
+		OperatingPointIdc: []uint16{0x707, 0x303, 0x101},
+	}
+
+	for _, ca := range []struct {
+		op         int
+		temporalID uint8
+		spatialID  uint8
+		included   bool
+	}{
+		{0, 2, 2, true},
+		{1, 1, 1, true},
+		{1, 2, 1, false},
+		{1, 1, 2, false},
+		{2, 0, 0, true},
+		{2, 0, 1, false},
+	} {
+		ok, err := sh.OperatingPointIncludes(ca.op, ca.temporalID, ca.spatialID)
+		require.NoError(t, err)
+		require.Equal(t, ca.included, ok)
+	}
+
+	_, err := sh.OperatingPointIncludes(3, 0, 0)
+	require.EqualError(t, err, "invalid operating point: 3")
+}
+
+func TestBitstreamFilterOperatingPoint(t *testing.T) {
+	// L1T3
+	sh := SequenceHeader{
+		OperatingPointIdc: []uint16{0x107, 0x103, 0x101},
+	}
+
+	bs := Bitstream{
+		{0x10},
+		{0x34, 0x00, 0x01},
+		{0x34, 0x20, 0x02},
+		{0x34, 0x40, 0x03},
+		{0x30, 0x04},
+	}
+
+	for _, ca := range []struct {
+		name string
+		op   int
+		out  Bitstream
+	}{
+		{
+			"all layers",
+			0,
+			bs,
+		},
+		{
+			"two layers",
+			1,
+			Bitstream{
+				{0x10},
+				{0x34, 0x00, 0x01},
+				{0x34, 0x20, 0x02},
+				{0x30, 0x04},
+			},
+		},
+		{
+			"base layer",
+			2,
+			Bitstream{
+				{0x10},
+				{0x34, 0x00, 0x01},
+				{0x30, 0x04},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			out, err := bs.FilterOperatingPoint(&sh, ca.op)
+			require.NoError(t, err)
+			require.Equal(t, ca.out, out)
+		})
+	}
+
+	t.Run("sequence header in bitstream", func(t *testing.T) {
+		bs2 := append(Bitstream{casesSequenceHeader[0].byts}, bs...)
+		out, err := bs2.FilterOperatingPoint(&sh, 0)
+		require.NoError(t, err)
+		require.Equal(t, bs2, out)
+	})
+
+	t.Run("invalid operating point", func(t *testing.T) {
+		_, err := Bitstream{{0x10}, {0x30, 0x04}}.FilterOperatingPoint(&sh, 3)
+		require.EqualError(t, err, "invalid operating point: 3")
+
+		bs2 := append(Bitstream{casesSequenceHeader[0].byts}, bs...)
+		_, err = bs2.FilterOperatingPoint(&sh, 1)
+		require.EqualError(t, err, "invalid operating point: 1")
+	})
+
+	t.Run("missing sequence header", func(t *testing.T) {
+		_, err := bs.FilterOperatingPoint(nil, 0)
+		require.EqualError(t, err, "sequence header is missing")
+	})
+}
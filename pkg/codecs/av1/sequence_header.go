@@ -233,6 +233,8 @@ type SequenceHeader struct {
 	DecoderModelPresentForThisOp   []bool
 	InitialDisplayPresentForThisOp []bool
 	InitialDisplayDelayMinus1      []uint8
+	FrameWidthBitsMinus1           uint8
+	FrameHeightBitsMinus1          uint8
 	MaxFrameWidthMinus1            uint32
 	MaxFrameHeightMinus1           uint32
 	FrameIDNumbersPresentFlag      bool
@@ -267,7 +269,7 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 	if err != nil {
 		return err
 	}
-	buf = buf[1:]
+	buf = buf[oh.MarshalSize():]
 
 	if oh.HasSize {
 		var size LEB128
@@ -398,11 +400,11 @@ func (h *SequenceHeader) Unmarshal(buf []byte) error {
 		return err
 	}
 
-	frameWidthBitsMinus1 := int(bits.ReadBitsUnsafe(buf, &pos, 4))
-	frameHeightBitsMinus1 := int(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameWidthBitsMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameHeightBitsMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
 
-	n1 := (frameWidthBitsMinus1 + 1)
-	n2 := (frameHeightBitsMinus1 + 1)
+	n1 := int(h.FrameWidthBitsMinus1) + 1
+	n2 := int(h.FrameHeightBitsMinus1) + 1
 
 	err = bits.HasSpace(buf, pos, n1+n2)
 	if err != nil {
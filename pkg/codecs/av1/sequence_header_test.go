@@ -25,6 +25,8 @@ var casesSequenceHeader = []struct {
 			DecoderModelPresentForThisOp:   []bool{false},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          9,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           803,
 			SeqChooseScreenContentTools:    true,
@@ -56,6 +58,8 @@ var casesSequenceHeader = []struct {
 			DecoderModelPresentForThisOp:   []bool{false},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          9,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           817,
 			Use128x128Superblock:           true,
@@ -97,6 +101,8 @@ var casesSequenceHeader = []struct {
 			DecoderModelPresentForThisOp:   []bool{false},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          10,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           1079,
 			EnableIntraEdgeFilter:          true,
@@ -143,6 +149,8 @@ var casesSequenceHeader = []struct {
 			DecoderModelPresentForThisOp:   []bool{false},
 			InitialDisplayPresentForThisOp: []bool{false},
 			InitialDisplayDelayMinus1:      []uint8{0},
+			FrameWidthBitsMinus1:           10,
+			FrameHeightBitsMinus1:          10,
 			MaxFrameWidthMinus1:            1919,
 			MaxFrameHeightMinus1:           1081,
 			FrameIDNumbersPresentFlag:      true,
@@ -0,0 +1,98 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// TemporalUnitInfo contains informations about a temporal unit.
+type TemporalUnitInfo struct {
+	// active sequence header.
+	SequenceHeader *SequenceHeader
+
+	// whether the temporal unit contains a sequence header.
+	HasSequenceHeader bool
+
+	// headers of frames contained in the temporal unit.
+	// Redundant frame headers are not included.
+	FrameHeaders []FrameHeader
+}
+
+// Unmarshal analyzes a temporal unit.
+// sh is the active sequence header, that is replaced
+// by the one contained in the temporal unit, if present.
+func (i *TemporalUnitInfo) Unmarshal(sh *SequenceHeader, tu [][]byte) error {
+	i.SequenceHeader = sh
+	i.HasSequenceHeader = false
+	i.FrameHeaders = nil
+
+	for _, obu := range tu {
+		var oh OBUHeader
+		err := oh.Unmarshal(obu)
+		if err != nil {
+			return err
+		}
+
+		switch oh.Type {
+		case OBUTypeSequenceHeader:
+			var sh2 SequenceHeader
+			err = sh2.Unmarshal(obu)
+			if err != nil {
+				return err
+			}
+			i.SequenceHeader = &sh2
+			i.HasSequenceHeader = true
+
+		case OBUTypeFrameHeader, OBUTypeFrame:
+			if i.SequenceHeader == nil {
+				return fmt.Errorf("sequence header is missing")
+			}
+
+			var fh FrameHeader
+			err = fh.Unmarshal(i.SequenceHeader, obu)
+			if err != nil {
+				return err
+			}
+			i.FrameHeaders = append(i.FrameHeaders, fh)
+		}
+	}
+
+	return nil
+}
+
+// IsSync checks whether the temporal unit can be used as a random access point.
+// This happens when it contains a sequence header and a shown key frame.
+func (i TemporalUnitInfo) IsSync() bool {
+	if !i.HasSequenceHeader {
+		return false
+	}
+
+	for _, fh := range i.FrameHeaders {
+		if fh.IsSync() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsShown checks whether the temporal unit contains a frame that is shown.
+func (i TemporalUnitInfo) IsShown() bool {
+	for _, fh := range i.FrameHeaders {
+		if fh.ShowExistingFrame || fh.ShowFrame {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSwitchFrame checks whether the temporal unit contains a switch frame.
+func (i TemporalUnitInfo) HasSwitchFrame() bool {
+	for _, fh := range i.FrameHeaders {
+		if !fh.ShowExistingFrame && fh.FrameType == FrameTypeSwitchFrame {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemporalUnitInfoUnmarshal(t *testing.T) {
+	t.Run("key frame", func(t *testing.T) {
+		var info TemporalUnitInfo
+		err := info.Unmarshal(nil, [][]byte{
+			casesSequenceHeader[0].byts,
+			casesFrameHeader[0].byts,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &casesSequenceHeader[0].sh, info.SequenceHeader)
+		require.Equal(t, []FrameHeader{casesFrameHeader[0].fh}, info.FrameHeaders)
+		require.True(t, info.IsSync())
+		require.True(t, info.IsShown())
+		require.False(t, info.HasSwitchFrame())
+	})
+
+	t.Run("inter frame", func(t *testing.T) {
+		var info TemporalUnitInfo
+		err := info.Unmarshal(&casesSequenceHeader[0].sh, [][]byte{
+			casesFrameHeader[1].byts,
+		})
+		require.NoError(t, err)
+		require.False(t, info.IsSync())
+		require.True(t, info.IsShown())
+	})
+
+	t.Run("switch frame", func(t *testing.T) {
+		var info TemporalUnitInfo
+		err := info.Unmarshal(&casesSequenceHeader[1].sh, [][]byte{
+			casesFrameHeader[3].byts,
+		})
+		require.NoError(t, err)
+		require.False(t, info.IsSync())
+		require.True(t, info.HasSwitchFrame())
+	})
+
+	t.Run("not shown", func(t *testing.T) {
+		var info TemporalUnitInfo
+		err := info.Unmarshal(&casesSequenceHeader[0].sh, [][]byte{
+			casesFrameHeader[5].byts,
+		})
+		require.NoError(t, err)
+		require.False(t, info.IsShown())
+	})
+
+	t.Run("missing sequence header", func(t *testing.T) {
+		var info TemporalUnitInfo
+		err := info.Unmarshal(nil, [][]byte{
+			casesFrameHeader[0].byts,
+		})
+		require.EqualError(t, err, "sequence header is missing")
+	})
+}
@@ -0,0 +1,55 @@
+package cea708
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	t35CountryCodeUSA      = 0xB5
+	t35ProviderCodeATSC    = 0x0031
+	a53UserDataTypeCCData  = 0x03
+	a53UserDataPrefixSize  = 1 + 2 + 4 + 1
+	userIdentifierGA94Size = 4
+)
+
+var userIdentifierGA94 = []byte{'G', 'A', '9', '4'}
+
+// IsA53 checks whether the payload of a user_data_registered_itu_t_t35 SEI message
+// contains ATSC A/53 closed captions.
+func IsA53(buf []byte) bool {
+	return len(buf) >= a53UserDataPrefixSize &&
+		buf[0] == t35CountryCodeUSA &&
+		buf[1] == byte(t35ProviderCodeATSC>>8) &&
+		buf[2] == byte(t35ProviderCodeATSC) &&
+		bytes.Equal(buf[3:3+userIdentifierGA94Size], userIdentifierGA94) &&
+		buf[7] == a53UserDataTypeCCData
+}
+
+// UnmarshalA53 decodes a CCData from the payload of a user_data_registered_itu_t_t35 SEI message.
+// Specification: ATSC A/53 Part 4, section 6.2.3
+func (c *CCData) UnmarshalA53(buf []byte) error {
+	if !IsA53(buf) {
+		return fmt.Errorf("payload does not contain ATSC A/53 closed captions")
+	}
+
+	return c.Unmarshal(buf[a53UserDataPrefixSize:])
+}
+
+// MarshalA53 encodes a CCData into the payload of a user_data_registered_itu_t_t35 SEI message.
+func (c CCData) MarshalA53() ([]byte, error) {
+	buf := make([]byte, a53UserDataPrefixSize+c.marshalSize())
+
+	buf[0] = t35CountryCodeUSA
+	buf[1] = byte(t35ProviderCodeATSC >> 8)
+	buf[2] = byte(t35ProviderCodeATSC)
+	copy(buf[3:], userIdentifierGA94)
+	buf[7] = a53UserDataTypeCCData
+
+	_, err := c.marshalTo(buf[a53UserDataPrefixSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
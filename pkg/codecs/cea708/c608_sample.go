@@ -0,0 +1,138 @@
+package cea708
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// C608Sample is a sample of a QuickTime CEA-608 closed caption track ("c608").
+// Specification: QuickTime File Format, Closed Captioning Sample Data
+type C608Sample struct {
+	// CEA-608 byte pairs of field 1 ("cdat" atom).
+	Field1 [][2]byte
+
+	// CEA-608 byte pairs of field 2 ("cdt2" atom).
+	Field2 [][2]byte
+}
+
+// Unmarshal decodes a C608Sample.
+func (s *C608Sample) Unmarshal(buf []byte) error {
+	s.Field1 = nil
+	s.Field2 = nil
+
+	for len(buf) != 0 {
+		if len(buf) < 8 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		size := int(binary.BigEndian.Uint32(buf))
+		typ := string(buf[4:8])
+
+		if size < 8 || size > len(buf) {
+			return fmt.Errorf("invalid atom size: %d", size)
+		}
+
+		content := buf[8:size]
+		buf = buf[size:]
+
+		if (len(content) % 2) != 0 {
+			return fmt.Errorf("invalid '%s' size: %d", typ, len(content))
+		}
+
+		var pairs [][2]byte
+		for i := 0; i < len(content); i += 2 {
+			pairs = append(pairs, [2]byte{content[i], content[i+1]})
+		}
+
+		switch typ {
+		case "cdat":
+			s.Field1 = append(s.Field1, pairs...)
+
+		case "cdt2":
+			s.Field2 = append(s.Field2, pairs...)
+		}
+	}
+
+	return nil
+}
+
+func writeC608Atom(buf []byte, typ string, pairs [][2]byte) int {
+	binary.BigEndian.PutUint32(buf, uint32(8+len(pairs)*2))
+	copy(buf[4:], typ)
+	n := 8
+
+	for _, pair := range pairs {
+		buf[n] = pair[0]
+		buf[n+1] = pair[1]
+		n += 2
+	}
+
+	return n
+}
+
+// Marshal encodes a C608Sample.
+func (s C608Sample) Marshal() ([]byte, error) {
+	size := 0
+	if len(s.Field1) != 0 {
+		size += 8 + len(s.Field1)*2
+	}
+	if len(s.Field2) != 0 {
+		size += 8 + len(s.Field2)*2
+	}
+
+	buf := make([]byte, size)
+	n := 0
+
+	if len(s.Field1) != 0 {
+		n += writeC608Atom(buf[n:], "cdat", s.Field1)
+	}
+
+	if len(s.Field2) != 0 {
+		writeC608Atom(buf[n:], "cdt2", s.Field2)
+	}
+
+	return buf, nil
+}
+
+// FromPackets fills a C608Sample with the valid CEA-608 byte pairs contained into closed caption packets.
+func (s *C608Sample) FromPackets(packets []CCPacket) {
+	s.Field1 = nil
+	s.Field2 = nil
+
+	for _, pkt := range packets {
+		if !pkt.Valid {
+			continue
+		}
+
+		switch pkt.Type {
+		case CCTypeNTSCField1:
+			s.Field1 = append(s.Field1, pkt.Data)
+
+		case CCTypeNTSCField2:
+			s.Field2 = append(s.Field2, pkt.Data)
+		}
+	}
+}
+
+// Packets returns closed caption packets that contain the CEA-608 byte pairs of the sample.
+func (s C608Sample) Packets() []CCPacket {
+	ret := make([]CCPacket, 0, len(s.Field1)+len(s.Field2))
+
+	for _, pair := range s.Field1 {
+		ret = append(ret, CCPacket{
+			Valid: true,
+			Type:  CCTypeNTSCField1,
+			Data:  pair,
+		})
+	}
+
+	for _, pair := range s.Field2 {
+		ret = append(ret, CCPacket{
+			Valid: true,
+			Type:  CCTypeNTSCField2,
+			Data:  pair,
+		})
+	}
+
+	return ret
+}
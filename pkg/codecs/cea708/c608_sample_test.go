@@ -0,0 +1,88 @@
+package cea708
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesC608Sample = []struct {
+	name   string
+	byts   []byte
+	sample C608Sample
+}{
+	{
+		"field 1",
+		[]byte{
+			0x00, 0x00, 0x00, 0x0c, 'c', 'd', 'a', 't',
+			0x94, 0x20, 0x94, 0x20,
+		},
+		C608Sample{
+			Field1: [][2]byte{{0x94, 0x20}, {0x94, 0x20}},
+		},
+	},
+	{
+		"both fields",
+		[]byte{
+			0x00, 0x00, 0x00, 0x0a, 'c', 'd', 'a', 't',
+			0x94, 0x2c, 0x00, 0x00, 0x00, 0x0a, 'c', 'd',
+			't', '2', 0x15, 0x20,
+		},
+		C608Sample{
+			Field1: [][2]byte{{0x94, 0x2c}},
+			Field2: [][2]byte{{0x15, 0x20}},
+		},
+	},
+}
+
+func TestC608SampleUnmarshal(t *testing.T) {
+	for _, ca := range casesC608Sample {
+		t.Run(ca.name, func(t *testing.T) {
+			var s C608Sample
+			err := s.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sample, s)
+		})
+	}
+}
+
+func TestC608SampleMarshal(t *testing.T) {
+	for _, ca := range casesC608Sample {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.sample.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestC608SamplePackets(t *testing.T) {
+	var s C608Sample
+	s.FromPackets(casesCCData[0].cc.Packets)
+	require.Equal(t, C608Sample{
+		Field1: [][2]byte{{0x94, 0x20}},
+	}, s)
+
+	require.Equal(t, []CCPacket{{
+		Valid: true,
+		Type:  CCTypeNTSCField1,
+		Data:  [2]byte{0x94, 0x20},
+	}}, s.Packets())
+}
+
+func FuzzC608SampleUnmarshal(f *testing.F) {
+	for _, ca := range casesC608Sample {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var s C608Sample
+		err := s.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = s.Marshal()
+		require.NoError(t, err)
+	})
+}
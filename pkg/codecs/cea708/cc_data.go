@@ -0,0 +1,131 @@
+package cea708
+
+import (
+	"fmt"
+)
+
+// CCType is the type of a closed caption packet.
+type CCType uint8
+
+// closed caption packet types.
+const (
+	CCTypeNTSCField1       CCType = 0
+	CCTypeNTSCField2       CCType = 1
+	CCTypeDTVCCPacketData  CCType = 2
+	CCTypeDTVCCPacketStart CCType = 3
+)
+
+// String implements fmt.Stringer.
+func (t CCType) String() string {
+	switch t {
+	case CCTypeNTSCField1:
+		return "NTSCField1"
+	case CCTypeNTSCField2:
+		return "NTSCField2"
+	case CCTypeDTVCCPacketData:
+		return "DTVCCPacketData"
+	case CCTypeDTVCCPacketStart:
+		return "DTVCCPacketStart"
+	}
+	return fmt.Sprintf("unknown (%d)", uint8(t))
+}
+
+// CCPacket is a closed caption packet.
+// NTSC packets (CCTypeNTSCField1, CCTypeNTSCField2) contain CEA-608 byte pairs,
+// while DTVCC packets contain CEA-708 data.
+type CCPacket struct {
+	Valid bool
+	Type  CCType
+	Data  [2]byte
+}
+
+// CCData is a cc_data structure.
+// Specification: CEA-708-E, section 4.4
+type CCData struct {
+	ProcessEMDataFlag  bool
+	ProcessCCDataFlag  bool
+	AdditionalDataFlag bool
+	EMData             uint8
+	Packets            []CCPacket
+}
+
+// Unmarshal decodes a CCData.
+func (c *CCData) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	c.ProcessEMDataFlag = (buf[0] & 0x80) != 0
+	c.ProcessCCDataFlag = (buf[0] & 0x40) != 0
+	c.AdditionalDataFlag = (buf[0] & 0x20) != 0
+	ccCount := int(buf[0] & 0x1F)
+	c.EMData = buf[1]
+	buf = buf[2:]
+
+	if len(buf) < ccCount*3 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	c.Packets = make([]CCPacket, ccCount)
+
+	for i := range c.Packets {
+		c.Packets[i] = CCPacket{
+			Valid: (buf[i*3] & 0x04) != 0,
+			Type:  CCType(buf[i*3] & 0x03),
+			Data:  [2]byte{buf[i*3+1], buf[i*3+2]},
+		}
+	}
+
+	// marker_bits are not checked since they are omitted by some encoders
+
+	return nil
+}
+
+func (c CCData) marshalSize() int {
+	return 2 + len(c.Packets)*3 + 1
+}
+
+func (c CCData) marshalTo(buf []byte) (int, error) {
+	if len(c.Packets) > 31 {
+		return 0, fmt.Errorf("too many packets")
+	}
+
+	buf[0] = byte(len(c.Packets))
+	if c.ProcessEMDataFlag {
+		buf[0] |= 0x80
+	}
+	if c.ProcessCCDataFlag {
+		buf[0] |= 0x40
+	}
+	if c.AdditionalDataFlag {
+		buf[0] |= 0x20
+	}
+
+	buf[1] = c.EMData
+	n := 2
+
+	for _, pkt := range c.Packets {
+		buf[n] = 0xF8 | byte(pkt.Type&0x03)
+		if pkt.Valid {
+			buf[n] |= 0x04
+		}
+		buf[n+1] = pkt.Data[0]
+		buf[n+2] = pkt.Data[1]
+		n += 3
+	}
+
+	buf[n] = 0xFF // marker_bits
+	n++
+
+	return n, nil
+}
+
+// Marshal encodes a CCData.
+func (c CCData) Marshal() ([]byte, error) {
+	buf := make([]byte, c.marshalSize())
+	_, err := c.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,130 @@
+package cea708
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesCCData = []struct {
+	name string
+	byts []byte
+	cc   CCData
+}{
+	{
+		"cea-608",
+		[]byte{
+			0x42, 0xff, 0xfc, 0x94, 0x20, 0xf9, 0x80, 0x80,
+			0xff,
+		},
+		CCData{
+			ProcessCCDataFlag: true,
+			EMData:            0xff,
+			Packets: []CCPacket{
+				{
+					Valid: true,
+					Type:  CCTypeNTSCField1,
+					Data:  [2]byte{0x94, 0x20},
+				},
+				{
+					Type: CCTypeNTSCField2,
+					Data: [2]byte{0x80, 0x80},
+				},
+			},
+		},
+	},
+	{
+		"cea-708",
+		[]byte{
+			0xc3, 0xff, 0xff, 0x02, 0x21, 0xfe, 0x8c, 0x80,
+			0xfa, 0x00, 0x00, 0xff,
+		},
+		CCData{
+			ProcessEMDataFlag: true,
+			ProcessCCDataFlag: true,
+			EMData:            0xff,
+			Packets: []CCPacket{
+				{
+					Valid: true,
+					Type:  CCTypeDTVCCPacketStart,
+					Data:  [2]byte{0x02, 0x21},
+				},
+				{
+					Valid: true,
+					Type:  CCTypeDTVCCPacketData,
+					Data:  [2]byte{0x8c, 0x80},
+				},
+				{
+					Type: CCTypeDTVCCPacketData,
+				},
+			},
+		},
+	},
+}
+
+func TestCCDataUnmarshal(t *testing.T) {
+	for _, ca := range casesCCData {
+		t.Run(ca.name, func(t *testing.T) {
+			var cc CCData
+			err := cc.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.cc, cc)
+		})
+	}
+}
+
+func TestCCDataMarshal(t *testing.T) {
+	for _, ca := range casesCCData {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.cc.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestCCDataMarshalError(t *testing.T) {
+	_, err := CCData{Packets: make([]CCPacket, 32)}.Marshal()
+	require.EqualError(t, err, "too many packets")
+}
+
+func TestCCType(t *testing.T) {
+	require.Equal(t, "DTVCCPacketStart", CCTypeDTVCCPacketStart.String())
+	require.Equal(t, "unknown (5)", CCType(5).String())
+}
+
+func TestA53(t *testing.T) {
+	byts := append([]byte{0xb5, 0x00, 0x31, 0x47, 0x41, 0x39, 0x34, 0x03}, casesCCData[0].byts...)
+	require.True(t, IsA53(byts))
+
+	var cc CCData
+	err := cc.UnmarshalA53(byts)
+	require.NoError(t, err)
+	require.Equal(t, casesCCData[0].cc, cc)
+
+	byts2, err := cc.MarshalA53()
+	require.NoError(t, err)
+	require.Equal(t, byts, byts2)
+
+	require.False(t, IsA53([]byte{0xb5, 0x00, 0x31, 0x44, 0x54, 0x47, 0x31, 0x03}))
+
+	err = cc.UnmarshalA53([]byte{0xb5, 0x00, 0x2f})
+	require.EqualError(t, err, "payload does not contain ATSC A/53 closed captions")
+}
+
+func FuzzCCDataUnmarshal(f *testing.F) {
+	for _, ca := range casesCCData {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var cc CCData
+		err := cc.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = cc.Marshal()
+		require.NoError(t, err)
+	})
+}
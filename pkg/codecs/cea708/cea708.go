@@ -0,0 +1,3 @@
+// Package cea708 contains utilities to work with CEA-608 and CEA-708 closed captions
+// carried in ATSC A/53 user data.
+package cea708
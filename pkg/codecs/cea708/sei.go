@@ -0,0 +1,111 @@
+package cea708
+
+import (
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+)
+
+// ExtractH264 extracts closed captions from the SEI NALUs of a H264 access unit.
+// SEI NALUs and messages that cannot be decoded are skipped.
+// It returns nil when the access unit does not contain closed captions.
+func ExtractH264(au [][]byte) []CCPacket {
+	var ret []CCPacket
+
+	for _, nalu := range au {
+		if len(nalu) < 1 || h264.NALUType(nalu[0]&0x1F) != h264.NALUTypeSEI {
+			continue
+		}
+
+		var sei h264.SEI
+		err := sei.Unmarshal(nalu)
+		if err != nil {
+			continue
+		}
+
+		for _, msg := range sei.Messages {
+			if msg.PayloadType == h264.SEIPayloadTypeUserDataRegisteredITUTT35 && IsA53(msg.Payload) {
+				var cc CCData
+				err = cc.UnmarshalA53(msg.Payload)
+				if err != nil {
+					continue
+				}
+
+				ret = append(ret, cc.Packets...)
+			}
+		}
+	}
+
+	return ret
+}
+
+// ExtractH265 extracts closed captions from the SEI NALUs of a H265 access unit.
+// SEI NALUs and messages that cannot be decoded are skipped.
+// It returns nil when the access unit does not contain closed captions.
+func ExtractH265(au [][]byte) []CCPacket {
+	var ret []CCPacket
+
+	for _, nalu := range au {
+		if len(nalu) < 2 {
+			continue
+		}
+
+		typ := h265.NALUType((nalu[0] >> 1) & 0b111111)
+		if typ != h265.NALUType_PREFIX_SEI_NUT && typ != h265.NALUType_SUFFIX_SEI_NUT {
+			continue
+		}
+
+		var sei h265.SEI
+		err := sei.Unmarshal(nalu)
+		if err != nil {
+			continue
+		}
+
+		for _, msg := range sei.Messages {
+			if msg.PayloadType == h265.SEIPayloadTypeUserDataRegisteredITUTT35 && IsA53(msg.Payload) {
+				var cc CCData
+				err = cc.UnmarshalA53(msg.Payload)
+				if err != nil {
+					continue
+				}
+
+				ret = append(ret, cc.Packets...)
+			}
+		}
+	}
+
+	return ret
+}
+
+// MarshalH264SEI encodes a CCData into a H264 SEI NALU.
+// The NALU can be inserted into a H264 access unit in order to pass closed captions
+// to formats that carry them into the video stream, like MPEG-TS.
+func (c CCData) MarshalH264SEI() ([]byte, error) {
+	payload, err := c.MarshalA53()
+	if err != nil {
+		return nil, err
+	}
+
+	return h264.SEI{
+		Messages: []h264.SEIMessage{{
+			PayloadType: h264.SEIPayloadTypeUserDataRegisteredITUTT35,
+			Payload:     payload,
+		}},
+	}.Marshal()
+}
+
+// MarshalH265SEI encodes a CCData into a H265 prefix SEI NALU.
+// The NALU can be inserted into a H265 access unit in order to pass closed captions
+// to formats that carry them into the video stream, like MPEG-TS.
+func (c CCData) MarshalH265SEI() ([]byte, error) {
+	payload, err := c.MarshalA53()
+	if err != nil {
+		return nil, err
+	}
+
+	return h265.SEI{
+		Messages: []h265.SEIMessage{{
+			PayloadType: h265.SEIPayloadTypeUserDataRegisteredITUTT35,
+			Payload:     payload,
+		}},
+	}.Marshal()
+}
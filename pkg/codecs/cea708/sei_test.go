@@ -0,0 +1,99 @@
+package cea708
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSEIH264 = []byte{
+	0x06, 0x04, 0x11, 0xb5, 0x00, 0x31, 0x47, 0x41,
+	0x39, 0x34, 0x03, 0x42, 0xff, 0xfc, 0x94, 0x20,
+	0xf9, 0x80, 0x80, 0xff, 0x80,
+}
+
+var testSEIH265 = []byte{
+	0x4e, 0x01, 0x04, 0x11, 0xb5, 0x00, 0x31, 0x47,
+	0x41, 0x39, 0x34, 0x03, 0x42, 0xff, 0xfc, 0x94,
+	0x20, 0xf9, 0x80, 0x80, 0xff, 0x80,
+}
+
+func TestExtractH264(t *testing.T) {
+	packets := ExtractH264([][]byte{
+		{0x09, 0xf0},
+		testSEIH264,
+		{0x65, 0x88, 0x84, 0x00},
+	})
+	require.Equal(t, casesCCData[0].cc.Packets, packets)
+
+	packets = ExtractH264([][]byte{
+		{0x65, 0x88, 0x84, 0x00},
+	})
+	require.Nil(t, packets)
+}
+
+func TestExtractH264SkipInvalid(t *testing.T) {
+	invalidCCData := append([]byte(nil), testSEIH264...)
+	invalidCCData[11] = 0x45
+
+	packets := ExtractH264([][]byte{
+		{0x06, 0x05},
+		invalidCCData,
+		testSEIH264,
+	})
+	require.Equal(t, casesCCData[0].cc.Packets, packets)
+}
+
+func TestExtractH265(t *testing.T) {
+	packets := ExtractH265([][]byte{
+		{0x46, 0x01, 0x10},
+		testSEIH265,
+		{0x26, 0x01, 0xaf, 0x08},
+	})
+	require.Equal(t, casesCCData[0].cc.Packets, packets)
+
+	packets = ExtractH265([][]byte{
+		{0x26, 0x01, 0xaf, 0x08},
+	})
+	require.Nil(t, packets)
+}
+
+func TestExtractH265SkipInvalid(t *testing.T) {
+	invalidCCData := append([]byte(nil), testSEIH265...)
+	invalidCCData[12] = 0x45
+
+	packets := ExtractH265([][]byte{
+		{0x4e, 0x01, 0x05},
+		invalidCCData,
+		testSEIH265,
+	})
+	require.Equal(t, casesCCData[0].cc.Packets, packets)
+}
+
+func TestMarshalH264SEI(t *testing.T) {
+	byts, err := casesCCData[0].cc.MarshalH264SEI()
+	require.NoError(t, err)
+	require.Equal(t, testSEIH264, byts)
+}
+
+func TestMarshalH265SEI(t *testing.T) {
+	byts, err := casesCCData[0].cc.MarshalH265SEI()
+	require.NoError(t, err)
+	require.Equal(t, testSEIH265, byts)
+}
+
+func FuzzExtractH264(f *testing.F) {
+	f.Add(testSEIH264)
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		ExtractH264([][]byte{b})
+	})
+}
+
+func FuzzExtractH265(f *testing.F) {
+	f.Add(testSEIH265)
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		ExtractH265([][]byte{b})
+	})
+}
@@ -0,0 +1,351 @@
+package eac3
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// BSI is E-AC-3 bit stream information.
+// It contains fields that follow the ones in SyncInfo.
+// Specification: ETSI TS 102 366 V1.4.1, Annex E.1.2.2
+type BSI struct {
+	// Bsmod: bit stream mode (present only when informational metadata is present)
+	Bsmod uint8
+	// Chanmape: custom channel map exists (dependent substreams only)
+	Chanmape bool
+	// Chanmap: custom channel map. Bit 0 is the most significant bit.
+	Chanmap uint16
+	// FlagEC3ExtensionTypeA: Joint Object Coding (Dolby Atmos) is in use
+	FlagEC3ExtensionTypeA bool
+	// ComplexityIndexTypeA: number of Joint Object Coding objects
+	ComplexityIndexTypeA uint8
+}
+
+// Unmarshal decodes a BSI from a frame.
+func (b *BSI) Unmarshal(frame []byte) error {
+	var s SyncInfo
+	err := s.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	*b = BSI{}
+
+	// skip fields decoded by SyncInfo
+	pos := 16 + 2 + 3 + 11 + 2 + 2 + 3 + 1 + 5
+
+	// one set of parameters for each mono channel in dual mono mode
+	programs := 1
+	if s.Acmod == 0 {
+		programs = 2
+	}
+
+	for range programs {
+		_, err = bits.ReadBits(frame, &pos, 5) // dialnorm
+		if err != nil {
+			return err
+		}
+
+		var compre bool
+		compre, err = bits.ReadFlag(frame, &pos)
+		if err != nil {
+			return err
+		}
+
+		if compre {
+			_, err = bits.ReadBits(frame, &pos, 8) // compr
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.Strmtyp == 1 {
+		b.Chanmape, err = bits.ReadFlag(frame, &pos)
+		if err != nil {
+			return err
+		}
+
+		if b.Chanmape {
+			var tmp uint64
+			tmp, err = bits.ReadBits(frame, &pos, 16)
+			if err != nil {
+				return err
+			}
+			b.Chanmap = uint16(tmp)
+		}
+	}
+
+	err = b.skipMixingMetadata(frame, &pos, &s, programs)
+	if err != nil {
+		return err
+	}
+
+	err = b.readInformationalMetadata(frame, &pos, &s, programs)
+	if err != nil {
+		return err
+	}
+
+	if s.Strmtyp == 0 && s.Numblkscod != 3 {
+		_, err = bits.ReadFlag(frame, &pos) // convsync
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.Strmtyp == 2 {
+		blkid := true
+		if s.Numblkscod != 3 {
+			blkid, err = bits.ReadFlag(frame, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if blkid {
+			_, err = bits.ReadBits(frame, &pos, 6) // frmsizecod
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return b.readAdditionalBSI(frame, &pos)
+}
+
+func (b *BSI) skipMixingMetadata(frame []byte, pos *int, s *SyncInfo, programs int) error {
+	mixmdate, err := bits.ReadFlag(frame, pos)
+	if err != nil {
+		return err
+	}
+
+	if !mixmdate {
+		return nil
+	}
+
+	n := 0
+
+	if s.Acmod > 2 {
+		n += 2 // dmixmod
+	}
+
+	if (s.Acmod&0x1) != 0 && s.Acmod > 2 {
+		n += 6 // ltrtcmixlev, lorocmixlev
+	}
+
+	if (s.Acmod & 0x4) != 0 {
+		n += 6 // ltrtsurmixlev, lorosurmixlev
+	}
+
+	err = bits.HasSpace(frame, *pos, n)
+	if err != nil {
+		return err
+	}
+	*pos += n
+
+	if s.Lfeon {
+		var lfemixlevcode bool
+		lfemixlevcode, err = bits.ReadFlag(frame, pos)
+		if err != nil {
+			return err
+		}
+
+		if lfemixlevcode {
+			_, err = bits.ReadBits(frame, pos, 5) // lfemixlevcod
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.Strmtyp != 0 {
+		return nil
+	}
+
+	// pgmscle, pgmscl2e, extpgmscle
+	for range programs + 1 {
+		var scle bool
+		scle, err = bits.ReadFlag(frame, pos)
+		if err != nil {
+			return err
+		}
+
+		if scle {
+			_, err = bits.ReadBits(frame, pos, 6)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var mixdef uint64
+	mixdef, err = bits.ReadBits(frame, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	switch mixdef {
+	case 1:
+		_, err = bits.ReadBits(frame, pos, 5)
+
+	case 2:
+		_, err = bits.ReadBits(frame, pos, 12)
+
+	case 3:
+		var mixdeflen uint64
+		mixdeflen, err = bits.ReadBits(frame, pos, 5)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(frame, *pos, int(mixdeflen+2)*8)
+		*pos += int(mixdeflen+2) * 8
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.Acmod < 2 {
+		for range programs {
+			var paninfoe bool
+			paninfoe, err = bits.ReadFlag(frame, pos)
+			if err != nil {
+				return err
+			}
+
+			if paninfoe {
+				_, err = bits.ReadBits(frame, pos, 14) // panmean, paninfo
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var frmmixcfginfoe bool
+	frmmixcfginfoe, err = bits.ReadFlag(frame, pos)
+	if err != nil {
+		return err
+	}
+
+	if frmmixcfginfoe {
+		if s.Numblkscod == 0 {
+			_, err = bits.ReadBits(frame, pos, 5)
+			if err != nil {
+				return err
+			}
+		} else {
+			for range s.NumBlocks() {
+				var blkmixcfginfoe bool
+				blkmixcfginfoe, err = bits.ReadFlag(frame, pos)
+				if err != nil {
+					return err
+				}
+
+				if blkmixcfginfoe {
+					_, err = bits.ReadBits(frame, pos, 5)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *BSI) readInformationalMetadata(frame []byte, pos *int, s *SyncInfo, programs int) error {
+	infomdate, err := bits.ReadFlag(frame, pos)
+	if err != nil {
+		return err
+	}
+
+	if !infomdate {
+		return nil
+	}
+
+	err = bits.HasSpace(frame, *pos, 5)
+	if err != nil {
+		return err
+	}
+
+	b.Bsmod = uint8(bits.ReadBitsUnsafe(frame, pos, 3))
+	*pos += 2 // copyrightb, origbs
+
+	n := 0
+
+	if s.Acmod == 2 {
+		n += 4 // dsurmod, dheadphonmod
+	}
+
+	if s.Acmod >= 6 {
+		n += 2 // dsurexmod
+	}
+
+	err = bits.HasSpace(frame, *pos, n)
+	if err != nil {
+		return err
+	}
+	*pos += n
+
+	for range programs {
+		var audprodie bool
+		audprodie, err = bits.ReadFlag(frame, pos)
+		if err != nil {
+			return err
+		}
+
+		if audprodie {
+			_, err = bits.ReadBits(frame, pos, 8) // mixlevel, roomtyp, adconvtyp
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.Fscod < 3 {
+		_, err = bits.ReadFlag(frame, pos) // sourcefscod
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *BSI) readAdditionalBSI(frame []byte, pos *int) error {
+	addbsie, err := bits.ReadFlag(frame, pos)
+	if err != nil {
+		return err
+	}
+
+	if !addbsie {
+		return nil
+	}
+
+	addbsil, err := bits.ReadBits(frame, pos, 6)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(frame, *pos, int(addbsil+1)*8)
+	if err != nil {
+		return err
+	}
+
+	// the first byte of additional bit stream information
+	// carries the Joint Object Coding flag.
+	*pos += 7
+	b.FlagEC3ExtensionTypeA = bits.ReadFlagUnsafe(frame, pos)
+
+	if b.FlagEC3ExtensionTypeA {
+		if addbsil == 0 {
+			return fmt.Errorf("missing complexity index")
+		}
+		b.ComplexityIndexTypeA = uint8(bits.ReadBitsUnsafe(frame, pos, 8))
+	}
+
+	return nil
+}
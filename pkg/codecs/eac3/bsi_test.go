@@ -0,0 +1,96 @@
+package eac3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var bsiCases = []struct {
+	name string
+	enc  []byte
+	dec  BSI
+}{
+	{
+		"5.1",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		BSI{},
+	},
+	{
+		"dependent with channel map",
+		[]byte{
+			0x0b, 0x77, 0x40, 0x0f, 0x34, 0x86, 0xd0, 0x20,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		BSI{
+			Chanmape: true,
+			Chanmap:  0x0200,
+		},
+	},
+	{
+		"joint object coding",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x02,
+			0x08, 0x08, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		BSI{
+			FlagEC3ExtensionTypeA: true,
+			ComplexityIndexTypeA:  16,
+		},
+	},
+	{
+		"mixing metadata",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xd6, 0x49,
+			0x2a, 0x30, 0x55, 0xe6, 0xa8, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		BSI{
+			Bsmod: 2,
+		},
+	},
+	{
+		"dual mono",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x10, 0x86, 0xdb, 0x46,
+			0x0a, 0xbc, 0xd1, 0x20, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		BSI{
+			Bsmod: 1,
+		},
+	},
+}
+
+func TestBSIUnmarshal(t *testing.T) {
+	for _, ca := range bsiCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var b BSI
+			err := b.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, b)
+		})
+	}
+}
+
+func FuzzBSIUnmarshal(f *testing.F) {
+	for _, ca := range bsiCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var bsi BSI
+		bsi.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,319 @@
+package eac3
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// channel count of each chan_loc bit, starting from the most significant one:
+// Lc/Rc, Lrs/Rrs, Cs, Ts, Lsd/Rsd, Lw/Rw, Lvh/Rvh, Cvh, LFE2.
+var chanLocChannels = [9]int{2, 2, 1, 1, 2, 2, 2, 1, 1}
+
+// chanLocFromChanmap converts a chanmap into a chan_loc.
+// Both are numbered starting from the most significant bit.
+func chanLocFromChanmap(chanmap uint16) uint16 {
+	var chanLoc uint16
+
+	// chanmap bits 5 to 12 correspond to chan_loc bits 0 to 7
+	for i := range 8 {
+		if (chanmap & (1 << (15 - 5 - i))) != 0 {
+			chanLoc |= 1 << (8 - i)
+		}
+	}
+
+	// chanmap bit 14 (LFE2) corresponds to chan_loc bit 8
+	if (chanmap & (1 << (15 - 14))) != 0 {
+		chanLoc |= 1
+	}
+
+	return chanLoc
+}
+
+// IndependentSubstream is an independent substream of a Config.
+type IndependentSubstream struct {
+	// Fscod: sample rate code
+	Fscod uint8
+	// Bsid: bitstream identification
+	Bsid uint8
+	// Asvc: associated service
+	Asvc bool
+	// Bsmod: bit stream mode
+	Bsmod uint8
+	// Acmod: audio coding mode
+	Acmod uint8
+	// LfeOn: LFE channel on
+	LfeOn bool
+	// NumDepSub: number of dependent substreams
+	NumDepSub uint8
+	// ChanLoc: channel locations of dependent substreams, numbered starting from the most significant bit.
+	// Only valid when NumDepSub > 0.
+	ChanLoc uint16
+}
+
+// ChannelCount returns the number of audio channels of the substream,
+// including the ones carried by dependent substreams.
+func (s IndependentSubstream) ChannelCount() int {
+	channels := 0
+	if int(s.Acmod) < len(acmodChannels) {
+		channels = acmodChannels[s.Acmod]
+	}
+
+	if s.LfeOn {
+		channels++
+	}
+
+	if s.NumDepSub > 0 {
+		for i, n := range chanLocChannels {
+			if (s.ChanLoc & (1 << (8 - i))) != 0 {
+				channels += n
+			}
+		}
+	}
+
+	return channels
+}
+
+// Config is an E-AC-3 decoder configuration, that is, the content of a EC3SpecificBox (dec3).
+// Specification: ETSI TS 102 366 V1.4.1, Annex F.6
+type Config struct {
+	// DataRate: data rate in kbit/s
+	DataRate uint16
+	// IndependentSubstreams: independent substreams (1-8)
+	IndependentSubstreams []IndependentSubstream
+	// FlagEC3ExtensionTypeA: Joint Object Coding (Dolby Atmos) is in use
+	FlagEC3ExtensionTypeA bool
+	// ComplexityIndexTypeA: number of Joint Object Coding objects
+	ComplexityIndexTypeA uint8
+}
+
+// Unmarshal decodes a Config.
+func (c *Config) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	pos := 0
+
+	c.DataRate = uint16(bits.ReadBitsUnsafe(buf, &pos, 13))
+	numIndSub := int(bits.ReadBitsUnsafe(buf, &pos, 3)) + 1
+
+	c.IndependentSubstreams = make([]IndependentSubstream, numIndSub)
+
+	for i := range c.IndependentSubstreams {
+		err := bits.HasSpace(buf, pos, 24)
+		if err != nil {
+			return err
+		}
+
+		s := &c.IndependentSubstreams[i]
+		s.Fscod = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+		s.Bsid = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+		pos++ // reserved
+		s.Asvc = bits.ReadFlagUnsafe(buf, &pos)
+		s.Bsmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+		s.Acmod = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+		s.LfeOn = bits.ReadFlagUnsafe(buf, &pos)
+		pos += 3 // reserved
+		s.NumDepSub = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+
+		if s.NumDepSub > 0 {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, 9)
+			if err != nil {
+				return err
+			}
+			s.ChanLoc = uint16(tmp)
+		} else {
+			pos++ // reserved
+		}
+	}
+
+	c.FlagEC3ExtensionTypeA = false
+	c.ComplexityIndexTypeA = 0
+
+	// Joint Object Coding information is optional
+	if bits.HasSpace(buf, pos, 16) == nil {
+		pos += 7 // reserved
+		c.FlagEC3ExtensionTypeA = bits.ReadFlagUnsafe(buf, &pos)
+		if c.FlagEC3ExtensionTypeA {
+			c.ComplexityIndexTypeA = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+		}
+	}
+
+	return nil
+}
+
+func (c Config) marshalSize() int {
+	n := 16
+
+	for _, s := range c.IndependentSubstreams {
+		n += 23
+		if s.NumDepSub > 0 {
+			n += 9
+		} else {
+			n++
+		}
+	}
+
+	if c.FlagEC3ExtensionTypeA {
+		n += 16
+	}
+
+	return (n + 7) / 8
+}
+
+func (c Config) marshalTo(buf []byte) (int, error) {
+	if len(c.IndependentSubstreams) < 1 || len(c.IndependentSubstreams) > 8 {
+		return 0, fmt.Errorf("invalid independent substream count: %d", len(c.IndependentSubstreams))
+	}
+
+	pos := 0
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(c.DataRate), 13)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(len(c.IndependentSubstreams)-1), 3)
+
+	for _, s := range c.IndependentSubstreams {
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.Fscod), 2)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.Bsid), 5)
+		bits.WriteBitsUnsafe(buf, &pos, 0, 1) // reserved
+		bits.WriteFlagUnsafe(buf, &pos, s.Asvc)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.Bsmod), 3)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.Acmod), 3)
+		bits.WriteFlagUnsafe(buf, &pos, s.LfeOn)
+		bits.WriteBitsUnsafe(buf, &pos, 0, 3) // reserved
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.NumDepSub), 4)
+
+		if s.NumDepSub > 0 {
+			bits.WriteBitsUnsafe(buf, &pos, uint64(s.ChanLoc), 9)
+		} else {
+			bits.WriteBitsUnsafe(buf, &pos, 0, 1) // reserved
+		}
+	}
+
+	if c.FlagEC3ExtensionTypeA {
+		bits.WriteBitsUnsafe(buf, &pos, 0, 7) // reserved
+		bits.WriteFlagUnsafe(buf, &pos, true)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(c.ComplexityIndexTypeA), 8)
+	}
+
+	return (pos + 7) / 8, nil
+}
+
+// Marshal encodes a Config.
+func (c Config) Marshal() ([]byte, error) {
+	buf := make([]byte, c.marshalSize())
+
+	_, err := c.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// UnmarshalFrames fills a Config with the content of consecutive sync frames
+// that carry all the substreams of a program.
+// Decoding stops when the first independent substream is repeated.
+func (c *Config) UnmarshalFrames(buf []byte) error {
+	*c = Config{}
+
+	bitRate := 0
+
+	for len(buf) != 0 {
+		var s SyncInfo
+		err := s.Unmarshal(buf)
+		if err != nil {
+			return err
+		}
+
+		if len(buf) < s.FrameSize() {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		frame := buf[:s.FrameSize()]
+		buf = buf[s.FrameSize():]
+
+		var b BSI
+		err = b.Unmarshal(frame)
+		if err != nil {
+			return err
+		}
+
+		switch s.Strmtyp {
+		case 0, 2:
+			if int(s.Substreamid) != len(c.IndependentSubstreams) {
+				// start of the next group of substreams
+				if s.Substreamid == 0 {
+					buf = nil
+					continue
+				}
+				return fmt.Errorf("unexpected independent substream ID: %d", s.Substreamid)
+			}
+
+			c.IndependentSubstreams = append(c.IndependentSubstreams, IndependentSubstream{
+				Fscod: s.Fscod,
+				Bsid:  s.Bsid,
+				Bsmod: b.Bsmod,
+				Acmod: s.Acmod,
+				LfeOn: s.Lfeon,
+			})
+
+		case 1:
+			if len(c.IndependentSubstreams) == 0 {
+				return fmt.Errorf("dependent substream without independent substream")
+			}
+
+			ind := &c.IndependentSubstreams[len(c.IndependentSubstreams)-1]
+			if ind.NumDepSub == 15 {
+				return fmt.Errorf("too many dependent substreams")
+			}
+			ind.NumDepSub++
+
+			if b.Chanmape {
+				ind.ChanLoc |= chanLocFromChanmap(b.Chanmap)
+			}
+
+		default:
+			return fmt.Errorf("reserved stream type")
+		}
+
+		if b.FlagEC3ExtensionTypeA && !c.FlagEC3ExtensionTypeA {
+			c.FlagEC3ExtensionTypeA = true
+			c.ComplexityIndexTypeA = b.ComplexityIndexTypeA
+		}
+
+		bitRate += s.FrameSize() * 8 * s.SampleRate() / (s.NumBlocks() * 256)
+	}
+
+	if len(c.IndependentSubstreams) == 0 {
+		return fmt.Errorf("no independent substreams found")
+	}
+
+	// data_rate is a 13-bit field
+	c.DataRate = uint16(min(bitRate/1000, 1<<13-1))
+
+	return nil
+}
+
+// SampleRate returns the sample rate of the first independent substream.
+func (c Config) SampleRate() int {
+	if len(c.IndependentSubstreams) == 0 {
+		return 0
+	}
+
+	fscod := c.IndependentSubstreams[0].Fscod
+	if int(fscod) < len(sampleRates) {
+		return sampleRates[fscod]
+	}
+	return 0
+}
+
+// ChannelCount returns the channel count of the first independent substream,
+// that is the main program, including channels carried by its dependent substreams.
+func (c Config) ChannelCount() int {
+	if len(c.IndependentSubstreams) == 0 {
+		return 0
+	}
+	return c.IndependentSubstreams[0].ChannelCount()
+}
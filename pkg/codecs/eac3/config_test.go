@@ -0,0 +1,231 @@
+package eac3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var configCases = []struct {
+	name         string
+	frames       []byte
+	dec          Config
+	enc          []byte
+	channelCount int
+}{
+	{
+		"5.1",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Config{
+			DataRate: 8,
+			IndependentSubstreams: []IndependentSubstream{{
+				Bsid:  16,
+				Acmod: 7,
+				LfeOn: true,
+			}},
+		},
+		[]byte{0x00, 0x40, 0x20, 0x0f, 0x00},
+		6,
+	},
+	{
+		"7.1",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0b, 0x77, 0x40, 0x0f, 0x34, 0x86, 0xd0, 0x20,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Config{
+			DataRate: 16,
+			IndependentSubstreams: []IndependentSubstream{{
+				Bsid:      16,
+				Acmod:     7,
+				LfeOn:     true,
+				NumDepSub: 1,
+				ChanLoc:   0x080,
+			}},
+		},
+		[]byte{0x00, 0x80, 0x20, 0x0f, 0x02, 0x80},
+		8,
+	},
+	{
+		"joint object coding",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x02,
+			0x08, 0x08, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Config{
+			DataRate: 8,
+			IndependentSubstreams: []IndependentSubstream{{
+				Bsid:  16,
+				Acmod: 7,
+				LfeOn: true,
+			}},
+			FlagEC3ExtensionTypeA: true,
+			ComplexityIndexTypeA:  16,
+		},
+		[]byte{0x00, 0x40, 0x20, 0x0f, 0x00, 0x01, 0x10},
+		6,
+	},
+	{
+		"multiple independent substreams",
+		[]byte{
+			0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0b, 0x77, 0x08, 0x0f, 0x34, 0x86, 0xc8, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Config{
+			DataRate: 16,
+			IndependentSubstreams: []IndependentSubstream{
+				{
+					Bsid:  16,
+					Acmod: 7,
+					LfeOn: true,
+				},
+				{
+					Bsid:  16,
+					Acmod: 2,
+				},
+			},
+		},
+		[]byte{0x00, 0x81, 0x20, 0x0f, 0x00, 0x20, 0x04, 0x00},
+		6,
+	},
+}
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range configCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var c Config
+			err := c.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, c)
+			require.Equal(t, 48000, c.SampleRate())
+			require.Equal(t, ca.channelCount, c.ChannelCount())
+		})
+	}
+}
+
+func TestConfigMarshal(t *testing.T) {
+	for _, ca := range configCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestConfigUnmarshalFrames(t *testing.T) {
+	for _, ca := range configCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var c Config
+			err := c.UnmarshalFrames(ca.frames)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, c)
+		})
+	}
+}
+
+func TestConfigUnmarshalFramesNextGroup(t *testing.T) {
+	// the first independent substream of the next group is ignored
+	buf := append(append([]byte(nil), configCases[1].frames...), configCases[0].frames...)
+
+	var c Config
+	err := c.UnmarshalFrames(buf)
+	require.NoError(t, err)
+	require.Equal(t, configCases[1].dec, c)
+}
+
+func TestConfigUnmarshalFramesErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		frames []byte
+		err    string
+	}{
+		{
+			"dependent substream first",
+			[]byte{
+				0x0b, 0x77, 0x40, 0x0f, 0x34, 0x86, 0xd0, 0x20,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			"dependent substream without independent substream",
+		},
+		{
+			"unexpected substream ID",
+			[]byte{
+				0x0b, 0x77, 0x08, 0x0f, 0x34, 0x86, 0xc8, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			"unexpected independent substream ID: 1",
+		},
+		{
+			"truncated",
+			configCases[0].frames[:20],
+			"not enough bytes",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var c Config
+			err := c.UnmarshalFrames(ca.frames)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzConfigUnmarshal(f *testing.F) {
+	for _, ca := range configCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var c Config
+		err := c.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		c.SampleRate()
+		c.ChannelCount()
+
+		_, err = c.Marshal()
+		require.NoError(t, err)
+	})
+}
+
+func FuzzConfigUnmarshalFrames(f *testing.F) {
+	for _, ca := range configCases {
+		f.Add(ca.frames)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var c Config
+		err := c.UnmarshalFrames(b)
+		if err != nil {
+			return
+		}
+
+		_, err = c.Marshal()
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package flac
+
+// CRC-8 with polynomial 0x07 and initial value 0.
+var crc8Table = func() [256]uint8 {
+	var t [256]uint8
+
+	for i := range t {
+		r := uint8(i)
+		for range 8 {
+			if (r & 0x80) != 0 {
+				r = (r << 1) ^ 0x07
+			} else {
+				r <<= 1
+			}
+		}
+		t[i] = r
+	}
+
+	return t
+}()
+
+// CRC-16 with polynomial 0x8005 and initial value 0.
+var crc16Table = func() [256]uint16 {
+	var t [256]uint16
+
+	for i := range t {
+		r := uint16(i) << 8
+		for range 8 {
+			if (r & 0x8000) != 0 {
+				r = (r << 1) ^ 0x8005
+			} else {
+				r <<= 1
+			}
+		}
+		t[i] = r
+	}
+
+	return t
+}()
+
+func crc8(buf []byte) uint8 {
+	var crc uint8
+	for _, b := range buf {
+		crc = crc8Table[crc^b]
+	}
+	return crc
+}
+
+func crc16Update(crc uint16, buf []byte) uint16 {
+	for _, b := range buf {
+		crc = (crc << 8) ^ crc16Table[byte(crc>>8)^b]
+	}
+	return crc
+}
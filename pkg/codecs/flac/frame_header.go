@@ -0,0 +1,457 @@
+package flac
+
+import (
+	"fmt"
+)
+
+const (
+	maxFrameHeaderSize    = 16
+	maxFrameNumber        = 1<<31 - 1
+	maxSampleNumber       = 1<<36 - 1
+	frameHeaderSyncCode   = 0xFFF8
+	frameHeaderSyncMask   = 0xFFFE
+	channelCountMaxCoded  = 8
+	uncommonBlockSize8    = 6
+	uncommonBlockSize16   = 7
+	uncommonSampleRateK   = 12
+	uncommonSampleRate    = 13
+	uncommonSampleRateDaH = 14
+)
+
+var frameSampleRates = map[uint8]int{
+	1:  88200,
+	2:  176400,
+	3:  192000,
+	4:  8000,
+	5:  16000,
+	6:  22050,
+	7:  24000,
+	8:  32000,
+	9:  44100,
+	10: 48000,
+	11: 96000,
+}
+
+var frameBitDepths = map[uint8]int{
+	1: 8,
+	2: 12,
+	4: 16,
+	5: 20,
+	6: 24,
+	7: 32,
+}
+
+// ChannelAssignment is the channel assignment of a frame.
+// Values from 0 to 7 mean that there are (value + 1) independent channels.
+type ChannelAssignment uint8
+
+// channel assignments.
+const (
+	ChannelAssignmentLeftSide  ChannelAssignment = 8
+	ChannelAssignmentSideRight ChannelAssignment = 9
+	ChannelAssignmentMidSide   ChannelAssignment = 10
+)
+
+// ChannelCount returns the channel count.
+func (a ChannelAssignment) ChannelCount() int {
+	if a < channelCountMaxCoded {
+		return int(a) + 1
+	}
+	return 2
+}
+
+// FrameHeader is a frame header.
+// Specification: RFC9639, section 9.1
+type FrameHeader struct {
+	// whether the block size can change between frames.
+	VariableBlockSize bool
+
+	// number of samples per channel.
+	BlockSize int
+
+	// sample rate. 0 means that it must be taken from STREAMINFO.
+	SampleRate int
+
+	ChannelAssignment ChannelAssignment
+
+	// bit depth. 0 means that it must be taken from STREAMINFO.
+	BitDepth int
+
+	// sample number of the first sample when VariableBlockSize is true,
+	// frame number otherwise.
+	Number uint64
+}
+
+// Unmarshal decodes a FrameHeader.
+func (h *FrameHeader) Unmarshal(buf []byte) error {
+	_, err := h.unmarshal(buf)
+	return err
+}
+
+func (h *FrameHeader) unmarshal(buf []byte) (int, error) {
+	if len(buf) < 5 {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	if (uint16(buf[0])<<8|uint16(buf[1]))&frameHeaderSyncMask != frameHeaderSyncCode {
+		return 0, fmt.Errorf("invalid sync code")
+	}
+
+	h.VariableBlockSize = (buf[1] & 0x01) != 0
+
+	blockSizeCode := buf[2] >> 4
+	sampleRateCode := buf[2] & 0x0F
+	channelCode := buf[3] >> 4
+	bitDepthCode := (buf[3] >> 1) & 0x07
+
+	if (buf[3] & 0x01) != 0 {
+		return 0, fmt.Errorf("reserved bit is set")
+	}
+
+	if channelCode > uint8(ChannelAssignmentMidSide) {
+		return 0, fmt.Errorf("reserved channel assignment: %d", channelCode)
+	}
+	h.ChannelAssignment = ChannelAssignment(channelCode)
+
+	switch {
+	case bitDepthCode == 0:
+		h.BitDepth = 0
+
+	case bitDepthCode == 3:
+		return 0, fmt.Errorf("reserved bit depth")
+
+	default:
+		h.BitDepth = frameBitDepths[bitDepthCode]
+	}
+
+	pos := 4
+
+	num, n, err := readCodedNumber(buf[pos:])
+	if err != nil {
+		return 0, err
+	}
+	pos += n
+
+	if !h.VariableBlockSize && num > maxFrameNumber {
+		return 0, fmt.Errorf("invalid frame number: %d", num)
+	}
+	h.Number = num
+
+	switch {
+	case blockSizeCode == 0:
+		return 0, fmt.Errorf("reserved block size")
+
+	case blockSizeCode == 1:
+		h.BlockSize = 192
+
+	case blockSizeCode <= 5:
+		h.BlockSize = 576 << (blockSizeCode - 2)
+
+	case blockSizeCode == uncommonBlockSize8:
+		if len(buf[pos:]) < 1 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+		h.BlockSize = int(buf[pos]) + 1
+		pos++
+
+	case blockSizeCode == uncommonBlockSize16:
+		if len(buf[pos:]) < 2 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+		h.BlockSize = (int(buf[pos])<<8 | int(buf[pos+1])) + 1
+		pos += 2
+
+	default:
+		h.BlockSize = 256 << (blockSizeCode - 8)
+	}
+
+	switch sampleRateCode {
+	case 0:
+		h.SampleRate = 0
+
+	case uncommonSampleRateK:
+		if len(buf[pos:]) < 1 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+		h.SampleRate = int(buf[pos]) * 1000
+		pos++
+
+	case uncommonSampleRate:
+		if len(buf[pos:]) < 2 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+		h.SampleRate = int(buf[pos])<<8 | int(buf[pos+1])
+		pos += 2
+
+	case uncommonSampleRateDaH:
+		if len(buf[pos:]) < 2 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+		h.SampleRate = (int(buf[pos])<<8 | int(buf[pos+1])) * 10
+		pos += 2
+
+	case 15:
+		return 0, fmt.Errorf("invalid sample rate")
+
+	default:
+		h.SampleRate = frameSampleRates[sampleRateCode]
+	}
+
+	if len(buf[pos:]) < 1 {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	if crc := crc8(buf[:pos]); crc != buf[pos] {
+		return 0, fmt.Errorf("CRC mismatch: expected %02x, got %02x", buf[pos], crc)
+	}
+	pos++
+
+	return pos, nil
+}
+
+func (h FrameHeader) blockSizeCode() (uint8, error) {
+	switch {
+	case h.BlockSize == 192:
+		return 1, nil
+
+	case h.BlockSize >= 576 && h.BlockSize <= 4608 && h.BlockSize%576 == 0 &&
+		isPowerOfTwo(h.BlockSize/576):
+		return uint8(2 + log2(h.BlockSize/576)), nil
+
+	case h.BlockSize >= 256 && h.BlockSize <= 32768 && isPowerOfTwo(h.BlockSize):
+		return uint8(8 + log2(h.BlockSize/256)), nil
+
+	case h.BlockSize >= 1 && h.BlockSize <= 256:
+		return uncommonBlockSize8, nil
+
+	case h.BlockSize >= 1 && h.BlockSize <= 65536:
+		return uncommonBlockSize16, nil
+
+	default:
+		return 0, fmt.Errorf("invalid block size: %d", h.BlockSize)
+	}
+}
+
+func (h FrameHeader) sampleRateCode() (uint8, error) {
+	if h.SampleRate == 0 {
+		return 0, nil
+	}
+
+	for code, rate := range frameSampleRates {
+		if rate == h.SampleRate {
+			return code, nil
+		}
+	}
+
+	switch {
+	case h.SampleRate%1000 == 0 && h.SampleRate <= 255000:
+		return uncommonSampleRateK, nil
+
+	case h.SampleRate <= 65535:
+		return uncommonSampleRate, nil
+
+	case h.SampleRate%10 == 0 && h.SampleRate <= 655350:
+		return uncommonSampleRateDaH, nil
+
+	default:
+		return 0, fmt.Errorf("invalid sample rate: %d", h.SampleRate)
+	}
+}
+
+func (h FrameHeader) bitDepthCode() (uint8, error) {
+	if h.BitDepth == 0 {
+		return 0, nil
+	}
+
+	for code, depth := range frameBitDepths {
+		if depth == h.BitDepth {
+			return code, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid bit depth: %d", h.BitDepth)
+}
+
+func (h FrameHeader) marshalSize() int {
+	n := 4 + codedNumberSize(h.Number) + 1
+
+	blockSizeCode, _ := h.blockSizeCode()
+	switch blockSizeCode {
+	case uncommonBlockSize8:
+		n++
+
+	case uncommonBlockSize16:
+		n += 2
+	}
+
+	sampleRateCode, _ := h.sampleRateCode()
+	switch sampleRateCode {
+	case uncommonSampleRateK:
+		n++
+
+	case uncommonSampleRate, uncommonSampleRateDaH:
+		n += 2
+	}
+
+	return n
+}
+
+func (h FrameHeader) marshalTo(buf []byte) (int, error) {
+	blockSizeCode, err := h.blockSizeCode()
+	if err != nil {
+		return 0, err
+	}
+
+	sampleRateCode, err := h.sampleRateCode()
+	if err != nil {
+		return 0, err
+	}
+
+	bitDepthCode, err := h.bitDepthCode()
+	if err != nil {
+		return 0, err
+	}
+
+	if h.ChannelAssignment > ChannelAssignmentMidSide {
+		return 0, fmt.Errorf("invalid channel assignment: %d", h.ChannelAssignment)
+	}
+
+	if (h.VariableBlockSize && h.Number > maxSampleNumber) ||
+		(!h.VariableBlockSize && h.Number > maxFrameNumber) {
+		return 0, fmt.Errorf("invalid number: %d", h.Number)
+	}
+
+	buf[0] = byte(frameHeaderSyncCode >> 8)
+	buf[1] = byte(frameHeaderSyncCode & 0xFF)
+	if h.VariableBlockSize {
+		buf[1] |= 0x01
+	}
+	buf[2] = blockSizeCode<<4 | sampleRateCode
+	buf[3] = uint8(h.ChannelAssignment)<<4 | bitDepthCode<<1
+	pos := 4
+
+	pos += writeCodedNumber(buf[pos:], h.Number)
+
+	switch blockSizeCode {
+	case uncommonBlockSize8:
+		buf[pos] = byte(h.BlockSize - 1)
+		pos++
+
+	case uncommonBlockSize16:
+		buf[pos] = byte((h.BlockSize - 1) >> 8)
+		buf[pos+1] = byte(h.BlockSize - 1)
+		pos += 2
+	}
+
+	switch sampleRateCode {
+	case uncommonSampleRateK:
+		buf[pos] = byte(h.SampleRate / 1000)
+		pos++
+
+	case uncommonSampleRate:
+		buf[pos] = byte(h.SampleRate >> 8)
+		buf[pos+1] = byte(h.SampleRate)
+		pos += 2
+
+	case uncommonSampleRateDaH:
+		buf[pos] = byte((h.SampleRate / 10) >> 8)
+		buf[pos+1] = byte(h.SampleRate / 10)
+		pos += 2
+	}
+
+	buf[pos] = crc8(buf[:pos])
+	pos++
+
+	return pos, nil
+}
+
+// Marshal encodes a FrameHeader.
+func (h FrameHeader) Marshal() ([]byte, error) {
+	buf := make([]byte, h.marshalSize())
+
+	_, err := h.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func isPowerOfTwo(v int) bool {
+	return v > 0 && (v&(v-1)) == 0
+}
+
+func log2(v int) int {
+	n := 0
+	for v > 1 {
+		v >>= 1
+		n++
+	}
+	return n
+}
+
+// readCodedNumber reads a number coded with the UTF-8-like scheme
+// used by frame and sample numbers.
+func readCodedNumber(buf []byte) (uint64, int, error) {
+	if len(buf) < 1 {
+		return 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	n := 0
+	for b := buf[0]; (b & 0x80) != 0; b <<= 1 {
+		n++
+	}
+
+	switch {
+	case n == 0:
+		return uint64(buf[0]), 1, nil
+
+	case n == 1 || n > 7:
+		return 0, 0, fmt.Errorf("invalid coded number")
+	}
+
+	if len(buf) < n {
+		return 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	v := uint64(buf[0] & (0x7F >> n))
+
+	for _, b := range buf[1:n] {
+		if (b & 0xC0) != 0x80 {
+			return 0, 0, fmt.Errorf("invalid coded number")
+		}
+		v = v<<6 | uint64(b&0x3F)
+	}
+
+	return v, n, nil
+}
+
+func codedNumberSize(v uint64) int {
+	if v < 0x80 {
+		return 1
+	}
+
+	n := 2
+	for v >= 1<<(5*n+1) && n < 7 {
+		n++
+	}
+	return n
+}
+
+func writeCodedNumber(buf []byte, v uint64) int {
+	n := codedNumberSize(v)
+
+	if n == 1 {
+		buf[0] = byte(v)
+		return 1
+	}
+
+	for i := n - 1; i > 0; i-- {
+		buf[i] = 0x80 | byte(v&0x3F)
+		v >>= 6
+	}
+
+	buf[0] = byte(0xFF<<(8-n)) | byte(v)
+
+	return n
+}
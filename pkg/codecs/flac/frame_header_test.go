@@ -0,0 +1,191 @@
+package flac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var frameHeaderCases = []struct {
+	name string
+	dec  FrameHeader
+	enc  []byte
+}{
+	{
+		"fixed, common values",
+		FrameHeader{
+			BlockSize:         4096,
+			SampleRate:        44100,
+			ChannelAssignment: 1,
+			BitDepth:          16,
+			Number:            0,
+		},
+		[]byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2},
+	},
+	{
+		"variable, 16-bit block size",
+		FrameHeader{
+			VariableBlockSize: true,
+			BlockSize:         1000,
+			ChannelAssignment: ChannelAssignmentMidSide,
+			BitDepth:          24,
+			Number:            1000000,
+		},
+		[]byte{0xff, 0xf9, 0x70, 0xac, 0xf3, 0xb4, 0x89, 0x80, 0x03, 0xe7, 0x10},
+	},
+	{
+		"8-bit block size, sample rate in kHz",
+		FrameHeader{
+			BlockSize:  100,
+			SampleRate: 22000,
+			Number:     200,
+		},
+		[]byte{0xff, 0xf8, 0x6c, 0x00, 0xc3, 0x88, 0x63, 0x16, 0x48},
+	},
+	{
+		"sample rate in Hz",
+		FrameHeader{
+			BlockSize:         192,
+			SampleRate:        44110,
+			ChannelAssignment: 5,
+			BitDepth:          32,
+			Number:            5,
+		},
+		[]byte{0xff, 0xf8, 0x1d, 0x5e, 0x05, 0xac, 0x4e, 0x03},
+	},
+	{
+		"sample rate in tens of Hz, max frame number",
+		FrameHeader{
+			BlockSize:         1152,
+			SampleRate:        99990,
+			ChannelAssignment: ChannelAssignmentLeftSide,
+			BitDepth:          8,
+			Number:            0x7FFFFFFF,
+		},
+		[]byte{0xff, 0xf8, 0x3e, 0x82, 0xfd, 0xbf, 0xbf, 0xbf, 0xbf, 0xbf, 0x27, 0x0f, 0x4b},
+	},
+}
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range frameHeaderCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var h FrameHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestFrameHeaderMarshal(t *testing.T) {
+	for _, ca := range frameHeaderCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestFrameHeaderBlockSize(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		size int
+	}{
+		{
+			"192",
+			[]byte{0xff, 0xf8, 0x19, 0x18, 0x00, 0xed},
+			192,
+		},
+		{
+			"4608",
+			[]byte{0xff, 0xf8, 0x59, 0x18, 0x00, 0x6b},
+			4608,
+		},
+		{
+			"4096",
+			[]byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2},
+			4096,
+		},
+		{
+			"8-bit uncommon",
+			[]byte{0xff, 0xf8, 0x69, 0x18, 0x00, 0x3f, 0x02},
+			64,
+		},
+		{
+			"16-bit uncommon with long coded number",
+			[]byte{0xff, 0xf9, 0x79, 0x18, 0xe1, 0x80, 0x80, 0x12, 0x34, 0xff},
+			0x1235,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h FrameHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.size, h.BlockSize)
+		})
+	}
+}
+
+func TestFrameHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"not enough bytes",
+			[]byte{0xff, 0xf8, 0xc9},
+			"not enough bytes",
+		},
+		{
+			"invalid sync code",
+			[]byte{0xff, 0xf0, 0xc9, 0x18, 0x00, 0xc2},
+			"invalid sync code",
+		},
+		{
+			"reserved block size",
+			[]byte{0xff, 0xf8, 0x09, 0x18, 0x00, 0xc2},
+			"reserved block size",
+		},
+		{
+			"reserved bit depth",
+			[]byte{0xff, 0xf8, 0xc9, 0x16, 0x00, 0xc2},
+			"reserved bit depth",
+		},
+		{
+			"reserved channel assignment",
+			[]byte{0xff, 0xf8, 0xc9, 0xb8, 0x00, 0xc2},
+			"reserved channel assignment: 11",
+		},
+		{
+			"crc mismatch",
+			[]byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc3},
+			"CRC mismatch: expected c3, got c2",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h FrameHeader
+			err := h.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzFrameHeaderUnmarshal(f *testing.F) {
+	for _, ca := range frameHeaderCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var h FrameHeader
+		err := h.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = h.Marshal()
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,207 @@
+package flac
+
+import (
+	"fmt"
+)
+
+const (
+	metadataBlockHeaderSize = 4
+	maxMetadataBlockLength  = 1<<24 - 1
+)
+
+// MetadataBlockType is the type of a metadata block.
+type MetadataBlockType uint8
+
+// metadata block types.
+const (
+	MetadataBlockTypeStreamInfo    MetadataBlockType = 0
+	MetadataBlockTypePadding       MetadataBlockType = 1
+	MetadataBlockTypeApplication   MetadataBlockType = 2
+	MetadataBlockTypeSeekTable     MetadataBlockType = 3
+	MetadataBlockTypeVorbisComment MetadataBlockType = 4
+	MetadataBlockTypeCueSheet      MetadataBlockType = 5
+	MetadataBlockTypePicture       MetadataBlockType = 6
+)
+
+// MetadataBlockHeader is a metadata block header.
+// Specification: RFC9639, section 8.1
+type MetadataBlockHeader struct {
+	Last   bool
+	Type   MetadataBlockType
+	Length uint32 // 24-bit
+}
+
+// Unmarshal decodes a MetadataBlockHeader.
+func (h *MetadataBlockHeader) Unmarshal(buf []byte) error {
+	if len(buf) < metadataBlockHeaderSize {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	h.Last = (buf[0] & 0x80) != 0
+	h.Type = MetadataBlockType(buf[0] & 0x7F)
+	h.Length = uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+
+	if h.Type == 127 {
+		return fmt.Errorf("invalid metadata block type")
+	}
+
+	return nil
+}
+
+func (h MetadataBlockHeader) marshalTo(buf []byte) (int, error) {
+	if h.Type > 126 {
+		return 0, fmt.Errorf("invalid metadata block type: %d", h.Type)
+	}
+
+	if h.Length > maxMetadataBlockLength {
+		return 0, fmt.Errorf("metadata block is too big")
+	}
+
+	buf[0] = byte(h.Type)
+	if h.Last {
+		buf[0] |= 0x80
+	}
+	buf[1] = byte(h.Length >> 16)
+	buf[2] = byte(h.Length >> 8)
+	buf[3] = byte(h.Length)
+
+	return metadataBlockHeaderSize, nil
+}
+
+// Marshal encodes a MetadataBlockHeader.
+func (h MetadataBlockHeader) Marshal() ([]byte, error) {
+	buf := make([]byte, metadataBlockHeaderSize)
+
+	_, err := h.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// MetadataBlock is a metadata block.
+type MetadataBlock interface {
+	BlockType() MetadataBlockType
+	Unmarshal(buf []byte) error
+	Marshal() ([]byte, error)
+}
+
+// UnknownMetadataBlock is a metadata block whose content is not decoded.
+type UnknownMetadataBlock struct {
+	Type MetadataBlockType
+	Data []byte
+}
+
+// BlockType implements MetadataBlock.
+func (b UnknownMetadataBlock) BlockType() MetadataBlockType {
+	return b.Type
+}
+
+// Unmarshal decodes an UnknownMetadataBlock.
+func (b *UnknownMetadataBlock) Unmarshal(buf []byte) error {
+	b.Data = buf
+	return nil
+}
+
+// Marshal encodes an UnknownMetadataBlock.
+func (b UnknownMetadataBlock) Marshal() ([]byte, error) {
+	return b.Data, nil
+}
+
+func newMetadataBlock(typ MetadataBlockType) MetadataBlock {
+	switch typ {
+	case MetadataBlockTypeStreamInfo:
+		return &StreamInfo{}
+
+	case MetadataBlockTypePadding:
+		return &Padding{}
+
+	case MetadataBlockTypeSeekTable:
+		return &SeekTable{}
+
+	case MetadataBlockTypeVorbisComment:
+		return &VorbisComment{}
+
+	case MetadataBlockTypePicture:
+		return &Picture{}
+
+	default:
+		return &UnknownMetadataBlock{Type: typ}
+	}
+}
+
+func unmarshalMetadataBlock(typ MetadataBlockType, buf []byte) (MetadataBlock, error) {
+	block := newMetadataBlock(typ)
+
+	err := block.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// MetadataBlocks is a sequence of metadata blocks.
+type MetadataBlocks []MetadataBlock
+
+// Unmarshal decodes MetadataBlocks.
+// Decoding stops after the block marked as last or at the end of the buffer.
+func (bs *MetadataBlocks) Unmarshal(buf []byte) error {
+	*bs = nil
+
+	for len(buf) != 0 {
+		var h MetadataBlockHeader
+		err := h.Unmarshal(buf)
+		if err != nil {
+			return err
+		}
+		buf = buf[metadataBlockHeaderSize:]
+
+		if int(h.Length) > len(buf) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		block, err := unmarshalMetadataBlock(h.Type, buf[:h.Length])
+		if err != nil {
+			return err
+		}
+		buf = buf[h.Length:]
+
+		*bs = append(*bs, block)
+
+		if h.Last {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Marshal encodes MetadataBlocks.
+// The last block is automatically marked as last.
+func (bs MetadataBlocks) Marshal() ([]byte, error) {
+	var buf []byte
+
+	for i, block := range bs {
+		enc, err := block.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		var header [metadataBlockHeaderSize]byte
+		_, err = MetadataBlockHeader{
+			Last:   i == len(bs)-1,
+			Type:   block.BlockType(),
+			Length: uint32(len(enc)),
+		}.marshalTo(header[:])
+		if err != nil {
+			return nil, err
+		}
+
+		buf = append(buf, header[:]...)
+		buf = append(buf, enc...)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,173 @@
+package flac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var metadataBlocksCases = []struct {
+	name string
+	dec  MetadataBlocks
+	enc  []byte
+}{
+	{
+		"stream info only",
+		MetadataBlocks{
+			&StreamInfo{
+				MinBlockSize: 4096,
+				MaxBlockSize: 4096,
+				SampleRate:   44100,
+				ChannelCount: 2,
+				BitDepth:     16,
+			},
+		},
+		[]byte{
+			0x80, 0x00, 0x00, 0x22, // last, STREAMINFO, 34 bytes
+			0x10, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x0a, 0xc4, 0x42, 0xf0, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+	},
+	{
+		"all supported blocks",
+		MetadataBlocks{
+			&SeekTable{
+				Points: []SeekPoint{
+					{SampleNumber: 0, Offset: 0, SampleCount: 4096},
+					{SampleNumber: SeekPointPlaceholder, Offset: 0, SampleCount: 0},
+				},
+			},
+			&VorbisComment{
+				Vendor:   "ven",
+				Comments: []string{"A=b", "TITLE=t"},
+			},
+			&Picture{
+				Type:        PictureTypeFrontCover,
+				MIMEType:    "image/png",
+				Description: "d",
+				Width:       16,
+				Height:      8,
+				ColorDepth:  24,
+				Data:        []byte{1, 2, 3},
+			},
+			&UnknownMetadataBlock{
+				Type: MetadataBlockTypeApplication,
+				Data: []byte{'t', 'e', 's', 't', 5},
+			},
+			&Padding{Size: 3},
+		},
+		[]byte{
+			0x03, 0x00, 0x00, 0x24, // SEEKTABLE, 36 bytes
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x10, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+			0x04, 0x00, 0x00, 0x1d, // VORBIS_COMMENT, 29 bytes
+			0x03, 0x00, 0x00, 0x00, 'v', 'e', 'n',
+			0x02, 0x00, 0x00, 0x00,
+			0x03, 0x00, 0x00, 0x00, 'A', '=', 'b',
+			0x07, 0x00, 0x00, 0x00, 'T', 'I', 'T', 'L', 'E', '=', 't',
+			0x06, 0x00, 0x00, 0x2d, // PICTURE, 45 bytes
+			0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x09, 'i', 'm', 'a', 'g', 'e', '/', 'p', 'n', 'g',
+			0x00, 0x00, 0x00, 0x01, 'd',
+			0x00, 0x00, 0x00, 0x10,
+			0x00, 0x00, 0x00, 0x08,
+			0x00, 0x00, 0x00, 0x18,
+			0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x03, 1, 2, 3,
+			0x02, 0x00, 0x00, 0x05, // APPLICATION, 5 bytes
+			't', 'e', 's', 't', 5,
+			0x81, 0x00, 0x00, 0x03, // last, PADDING, 3 bytes
+			0x00, 0x00, 0x00,
+		},
+	},
+}
+
+func TestMetadataBlocksUnmarshal(t *testing.T) {
+	for _, ca := range metadataBlocksCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var bs MetadataBlocks
+			err := bs.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, bs)
+		})
+	}
+}
+
+func TestMetadataBlocksMarshal(t *testing.T) {
+	for _, ca := range metadataBlocksCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestMetadataBlocksUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"not enough bytes",
+			[]byte{0x81, 0x00, 0x00, 0x03, 0x00},
+			"not enough bytes",
+		},
+		{
+			"invalid type",
+			[]byte{0xff, 0x00, 0x00, 0x00},
+			"invalid metadata block type",
+		},
+		{
+			"invalid seek table",
+			[]byte{0x83, 0x00, 0x00, 0x01, 0x00},
+			"invalid seek table size: 1",
+		},
+		{
+			"invalid picture",
+			[]byte{
+				0x86, 0x00, 0x00, 0x20,
+				0x00, 0x00, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x01,
+			},
+			"invalid picture data size",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var bs MetadataBlocks
+			err := bs.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzMetadataBlocksUnmarshal(f *testing.F) {
+	for _, ca := range metadataBlocksCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var bs MetadataBlocks
+		err := bs.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = bs.Marshal()
+		require.NoError(t, err)
+	})
+}
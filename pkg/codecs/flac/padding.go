@@ -0,0 +1,23 @@
+package flac
+
+// Padding is a PADDING metadata block.
+// Specification: RFC9639, section 8.3
+type Padding struct {
+	Size int
+}
+
+// BlockType implements MetadataBlock.
+func (p Padding) BlockType() MetadataBlockType {
+	return MetadataBlockTypePadding
+}
+
+// Unmarshal decodes a Padding.
+func (p *Padding) Unmarshal(buf []byte) error {
+	p.Size = len(buf)
+	return nil
+}
+
+// Marshal encodes a Padding.
+func (p Padding) Marshal() ([]byte, error) {
+	return make([]byte, p.Size), nil
+}
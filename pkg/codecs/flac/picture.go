@@ -0,0 +1,140 @@
+package flac
+
+import (
+	"fmt"
+)
+
+func readUint32(buf []byte) uint32 {
+	return uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+}
+
+func writeUint32(buf []byte, v uint32) {
+	buf[0] = byte(v >> 24)
+	buf[1] = byte(v >> 16)
+	buf[2] = byte(v >> 8)
+	buf[3] = byte(v)
+}
+
+// PictureType is the type of a picture.
+type PictureType uint32
+
+// picture types.
+const (
+	PictureTypeOther      PictureType = 0
+	PictureTypeFileIcon   PictureType = 1
+	PictureTypeFrontCover PictureType = 3
+	PictureTypeBackCover  PictureType = 4
+)
+
+// Picture is a PICTURE metadata block.
+// Specification: RFC9639, section 8.8
+type Picture struct {
+	Type        PictureType
+	MIMEType    string
+	Description string
+	Width       uint32
+	Height      uint32
+	ColorDepth  uint32
+	ColorCount  uint32 // only for indexed-color pictures
+	Data        []byte
+}
+
+// BlockType implements MetadataBlock.
+func (p Picture) BlockType() MetadataBlockType {
+	return MetadataBlockTypePicture
+}
+
+// Unmarshal decodes a Picture.
+func (p *Picture) Unmarshal(buf []byte) error {
+	if len(buf) < 32 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	p.Type = PictureType(readUint32(buf))
+	pos := 4
+
+	readString := func() (string, error) {
+		if len(buf[pos:]) < 4 {
+			return "", fmt.Errorf("not enough bytes")
+		}
+
+		l := int(readUint32(buf[pos:]))
+		pos += 4
+
+		if l > len(buf[pos:]) {
+			return "", fmt.Errorf("not enough bytes")
+		}
+
+		s := string(buf[pos : pos+l])
+		pos += l
+		return s, nil
+	}
+
+	var err error
+	p.MIMEType, err = readString()
+	if err != nil {
+		return err
+	}
+
+	p.Description, err = readString()
+	if err != nil {
+		return err
+	}
+
+	if len(buf[pos:]) < 20 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	p.Width = readUint32(buf[pos:])
+	p.Height = readUint32(buf[pos+4:])
+	p.ColorDepth = readUint32(buf[pos+8:])
+	p.ColorCount = readUint32(buf[pos+12:])
+	dataLen := int(readUint32(buf[pos+16:]))
+	pos += 20
+
+	if dataLen != len(buf[pos:]) {
+		return fmt.Errorf("invalid picture data size")
+	}
+
+	p.Data = buf[pos:]
+
+	return nil
+}
+
+func (p Picture) marshalSize() int {
+	return 32 + len(p.MIMEType) + len(p.Description) + len(p.Data)
+}
+
+func (p Picture) marshalTo(buf []byte) (int, error) {
+	writeUint32(buf, uint32(p.Type))
+	pos := 4
+
+	writeUint32(buf[pos:], uint32(len(p.MIMEType)))
+	pos += 4
+	pos += copy(buf[pos:], p.MIMEType)
+
+	writeUint32(buf[pos:], uint32(len(p.Description)))
+	pos += 4
+	pos += copy(buf[pos:], p.Description)
+
+	writeUint32(buf[pos:], p.Width)
+	writeUint32(buf[pos+4:], p.Height)
+	writeUint32(buf[pos+8:], p.ColorDepth)
+	writeUint32(buf[pos+12:], p.ColorCount)
+	writeUint32(buf[pos+16:], uint32(len(p.Data)))
+	pos += 20
+
+	pos += copy(buf[pos:], p.Data)
+
+	return pos, nil
+}
+
+// Marshal encodes a Picture.
+func (p Picture) Marshal() ([]byte, error) {
+	buf := make([]byte, p.marshalSize())
+	_, err := p.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,286 @@
+package flac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	readerChunkSize  = 64 * 1024
+	id3v2HeaderSize  = 10
+	frameFooterSize  = 2
+	minFrameSize     = 6 + frameFooterSize
+	maxReaderBufSize = 32 * 1024 * 1024
+)
+
+var streamMarker = []byte{'f', 'L', 'a', 'C'}
+
+// Reader is a reader of native FLAC streams.
+// Specification: RFC9639, section 6
+type Reader struct {
+	R io.Reader
+
+	buf            []byte
+	eof            bool
+	streamInfo     *StreamInfo
+	metadataBlocks []MetadataBlock
+}
+
+// Initialize initializes a Reader.
+// It reads the stream marker and all metadata blocks.
+func (r *Reader) Initialize() error {
+	err := r.skipID3v2()
+	if err != nil {
+		return err
+	}
+
+	err = r.fillTo(len(streamMarker))
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(r.buf[:len(streamMarker)], streamMarker) {
+		return fmt.Errorf("invalid stream marker")
+	}
+	r.buf = r.buf[len(streamMarker):]
+
+	for {
+		err = r.fillTo(metadataBlockHeaderSize)
+		if err != nil {
+			return err
+		}
+
+		var h MetadataBlockHeader
+		err = h.Unmarshal(r.buf)
+		if err != nil {
+			return err
+		}
+
+		err = r.fillTo(metadataBlockHeaderSize + int(h.Length))
+		if err != nil {
+			return err
+		}
+
+		block, err := unmarshalMetadataBlock(h.Type,
+			r.buf[metadataBlockHeaderSize:metadataBlockHeaderSize+int(h.Length)])
+		if err != nil {
+			return fmt.Errorf("invalid metadata block: %w", err)
+		}
+		r.buf = r.buf[metadataBlockHeaderSize+int(h.Length):]
+
+		if r.streamInfo == nil {
+			si, ok := block.(*StreamInfo)
+			if !ok {
+				return fmt.Errorf("first metadata block is not STREAMINFO")
+			}
+			r.streamInfo = si
+		} else {
+			r.metadataBlocks = append(r.metadataBlocks, block)
+		}
+
+		if h.Last {
+			break
+		}
+	}
+
+	return nil
+}
+
+// skipID3v2 skips an ID3v2 tag placed before the stream marker.
+func (r *Reader) skipID3v2() error {
+	err := r.fillTo(id3v2HeaderSize)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(r.buf, []byte{'I', 'D', '3'}) {
+		return nil
+	}
+
+	size := id3v2HeaderSize + (int(r.buf[6]&0x7F)<<21 | int(r.buf[7]&0x7F)<<14 |
+		int(r.buf[8]&0x7F)<<7 | int(r.buf[9]&0x7F))
+
+	// footer
+	if (r.buf[5] & 0x10) != 0 {
+		size += id3v2HeaderSize
+	}
+
+	err = r.fillTo(size)
+	if err != nil {
+		return err
+	}
+
+	r.buf = r.buf[size:]
+	return nil
+}
+
+// fill reads more data into the buffer.
+func (r *Reader) fill() error {
+	if r.eof {
+		return io.EOF
+	}
+
+	if len(r.buf) >= maxReaderBufSize {
+		return fmt.Errorf("frame is too big")
+	}
+
+	buf := make([]byte, len(r.buf), len(r.buf)+readerChunkSize)
+	copy(buf, r.buf)
+
+	n, err := io.ReadFull(r.R, buf[len(buf):cap(buf)])
+	r.buf = buf[:len(buf)+n]
+
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			r.eof = true
+			if n == 0 {
+				return io.EOF
+			}
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// fillTo fills the buffer until it contains at least n bytes.
+func (r *Reader) fillTo(n int) error {
+	for len(r.buf) < n {
+		err := r.fill()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamInfo returns the STREAMINFO metadata block.
+func (r *Reader) StreamInfo() *StreamInfo {
+	return r.streamInfo
+}
+
+// MetadataBlocks returns metadata blocks that follow STREAMINFO.
+func (r *Reader) MetadataBlocks() []MetadataBlock {
+	return r.metadataBlocks
+}
+
+// unmarshalHeaderAt decodes the frame header at position pos of the buffer.
+func (r *Reader) unmarshalHeaderAt(pos int, h *FrameHeader) (int, error) {
+	err := r.fillTo(pos + maxFrameHeaderSize)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, err
+	}
+
+	return h.unmarshal(r.buf[pos:])
+}
+
+// Read reads a frame.
+// Timestamps are expressed in 1/sample_rate seconds.
+func (r *Reader) Read() (int64, []byte, error) {
+	var h FrameHeader
+
+	// find the first frame header
+	for {
+		err := r.fillTo(1)
+		if err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return 0, nil, io.EOF
+			}
+			return 0, nil, err
+		}
+
+		_, err = r.unmarshalHeaderAt(0, &h)
+		if err == nil {
+			break
+		}
+
+		i := bytes.IndexByte(r.buf[1:], 0xFF)
+		if i < 0 {
+			r.buf = r.buf[:0]
+		} else {
+			r.buf = r.buf[1+i:]
+		}
+	}
+
+	size, err := r.findFrameEnd(h.VariableBlockSize)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	frame := append([]byte(nil), r.buf[:size]...)
+	r.buf = r.buf[size:]
+
+	return r.framePTS(&h), frame, nil
+}
+
+// findFrameEnd returns the size of the frame at the beginning of the buffer.
+// The frame ends where a valid frame header begins and the CRC-16 of
+// preceding bytes matches the frame footer.
+func (r *Reader) findFrameEnd(variableBlockSize bool) (int, error) {
+	crc := uint16(0)
+	crcPos := 0
+	pos := minFrameSize
+
+	for {
+		for pos+1 >= len(r.buf) {
+			err := r.fill()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					return 0, err
+				}
+
+				// the last frame ends with the stream
+				if len(r.buf) < minFrameSize {
+					return 0, io.ErrUnexpectedEOF
+				}
+
+				crc = crc16Update(crc, r.buf[crcPos:len(r.buf)-frameFooterSize])
+				if !frameCRCMatches(crc, r.buf[len(r.buf)-frameFooterSize:]) {
+					return 0, fmt.Errorf("CRC mismatch in last frame")
+				}
+
+				return len(r.buf), nil
+			}
+		}
+
+		if r.buf[pos] == 0xFF && (r.buf[pos+1]&0xFE) == 0xF8 &&
+			((r.buf[pos+1]&0x01) != 0) == variableBlockSize {
+			crc = crc16Update(crc, r.buf[crcPos:pos-frameFooterSize])
+			crcPos = pos - frameFooterSize
+
+			if frameCRCMatches(crc, r.buf[pos-frameFooterSize:pos]) {
+				var h FrameHeader
+				_, err := r.unmarshalHeaderAt(pos, &h)
+				if err == nil {
+					return pos, nil
+				}
+			}
+		}
+
+		pos++
+	}
+}
+
+func frameCRCMatches(crc uint16, footer []byte) bool {
+	return crc == uint16(footer[0])<<8|uint16(footer[1])
+}
+
+func (r *Reader) framePTS(h *FrameHeader) int64 {
+	if h.VariableBlockSize {
+		return int64(h.Number)
+	}
+
+	// in fixed block size streams, all frames except the last one
+	// have the block size reported in STREAMINFO.
+	if r.streamInfo.MinBlockSize == r.streamInfo.MaxBlockSize && r.streamInfo.MaxBlockSize != 0 {
+		return int64(h.Number) * int64(r.streamInfo.MaxBlockSize)
+	}
+
+	return int64(h.Number) * int64(h.BlockSize)
+}
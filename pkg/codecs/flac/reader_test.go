@@ -0,0 +1,215 @@
+package flac
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testFrame(h FrameHeader, payload []byte) []byte {
+	buf, err := h.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	buf = append(buf, payload...)
+	crc := crc16Update(0, buf)
+	return append(buf, byte(crc>>8), byte(crc))
+}
+
+var testStreamInfo = StreamInfo{
+	MinBlockSize: 4096,
+	MaxBlockSize: 4096,
+	SampleRate:   44100,
+	ChannelCount: 2,
+	BitDepth:     16,
+}
+
+type readerFrame struct {
+	pts   int64
+	frame []byte
+}
+
+var readerCases = []struct {
+	name   string
+	blocks MetadataBlocks
+	prefix []byte
+	frames []readerFrame
+}{
+	{
+		"fixed block size",
+		MetadataBlocks{&testStreamInfo},
+		nil,
+		[]readerFrame{
+			{
+				0,
+				testFrame(FrameHeader{
+					BlockSize:         4096,
+					SampleRate:        44100,
+					ChannelAssignment: 1,
+					BitDepth:          16,
+					Number:            0,
+				}, []byte{1, 2, 3, 4}),
+			},
+			{
+				4096,
+				// the payload contains a fake frame header
+				testFrame(FrameHeader{
+					BlockSize:         4096,
+					SampleRate:        44100,
+					ChannelAssignment: 1,
+					BitDepth:          16,
+					Number:            1,
+				}, []byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2, 5, 6}),
+			},
+			{
+				8192,
+				testFrame(FrameHeader{
+					BlockSize:         1000,
+					SampleRate:        44100,
+					ChannelAssignment: 1,
+					BitDepth:          16,
+					Number:            2,
+				}, []byte{7, 8}),
+			},
+		},
+	},
+	{
+		"variable block size with metadata and id3",
+		MetadataBlocks{
+			&StreamInfo{
+				MinBlockSize: 100,
+				MaxBlockSize: 1000,
+				SampleRate:   48000,
+				ChannelCount: 1,
+				BitDepth:     24,
+			},
+			&VorbisComment{
+				Vendor:   "test",
+				Comments: []string{},
+			},
+			&Padding{Size: 10},
+		},
+		[]byte{'I', 'D', '3', 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00},
+		[]readerFrame{
+			{
+				0,
+				testFrame(FrameHeader{
+					VariableBlockSize: true,
+					BlockSize:         1000,
+					SampleRate:        48000,
+					BitDepth:          24,
+					Number:            0,
+				}, bytes.Repeat([]byte{0xff}, 100)),
+			},
+			{
+				1000,
+				testFrame(FrameHeader{
+					VariableBlockSize: true,
+					BlockSize:         100,
+					SampleRate:        48000,
+					BitDepth:          24,
+					Number:            1000,
+				}, bytes.Repeat([]byte{0x55}, 100000)),
+			},
+		},
+	},
+}
+
+func TestReader(t *testing.T) {
+	for _, ca := range readerCases {
+		t.Run(ca.name, func(t *testing.T) {
+			buf := append([]byte(nil), ca.prefix...)
+			buf = append(buf, 'f', 'L', 'a', 'C')
+
+			enc, err := ca.blocks.Marshal()
+			require.NoError(t, err)
+			buf = append(buf, enc...)
+
+			for _, f := range ca.frames {
+				buf = append(buf, f.frame...)
+			}
+
+			r := &Reader{R: bytes.NewReader(buf)}
+			err = r.Initialize()
+			require.NoError(t, err)
+
+			require.Equal(t, ca.blocks[0], r.StreamInfo())
+
+			if len(ca.blocks) > 1 {
+				require.Equal(t, []MetadataBlock(ca.blocks[1:]), r.MetadataBlocks())
+			} else {
+				require.Nil(t, r.MetadataBlocks())
+			}
+
+			for _, f := range ca.frames {
+				pts, frame, err2 := r.Read()
+				require.NoError(t, err2)
+				require.Equal(t, f.pts, pts)
+				require.Equal(t, f.frame, frame)
+			}
+
+			_, _, err = r.Read()
+			require.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		buf  []byte
+		err  string
+	}{
+		{
+			"invalid stream marker",
+			[]byte{'f', 'L', 'a', 'X', 0, 0, 0, 0, 0, 0},
+			"invalid stream marker",
+		},
+		{
+			"missing stream info",
+			[]byte{'f', 'L', 'a', 'C', 0x81, 0x00, 0x00, 0x00, 0, 0},
+			"first metadata block is not STREAMINFO",
+		},
+		{
+			"truncated metadata",
+			[]byte{'f', 'L', 'a', 'C', 0x80, 0x00, 0x00, 0x22, 0, 0},
+			"unexpected EOF",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			r := &Reader{R: bytes.NewReader(ca.buf)}
+			err := r.Initialize()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzReader(f *testing.F) {
+	f.Add([]byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2, 1, 2, 3, 4, 0x5a, 0x5a})
+
+	enc, err := MetadataBlocks{&testStreamInfo}.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		buf := append([]byte{'f', 'L', 'a', 'C'}, enc...)
+		buf = append(buf, b...)
+
+		r := &Reader{R: bytes.NewReader(buf)}
+		err := r.Initialize()
+		if err != nil {
+			panic(err)
+		}
+
+		for {
+			_, _, err = r.Read()
+			if err != nil {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package flac
+
+import (
+	"fmt"
+)
+
+const seekPointSize = 18
+
+// SeekPointPlaceholder is the sample number of a placeholder seek point.
+const SeekPointPlaceholder = 0xFFFFFFFFFFFFFFFF
+
+// SeekPoint is a seek point.
+type SeekPoint struct {
+	SampleNumber uint64
+	Offset       uint64 // from the first byte of the first frame
+	SampleCount  uint16
+}
+
+// SeekTable is a SEEKTABLE metadata block.
+// Specification: RFC9639, section 8.5
+type SeekTable struct {
+	Points []SeekPoint
+}
+
+// BlockType implements MetadataBlock.
+func (t SeekTable) BlockType() MetadataBlockType {
+	return MetadataBlockTypeSeekTable
+}
+
+// Unmarshal decodes a SeekTable.
+func (t *SeekTable) Unmarshal(buf []byte) error {
+	if (len(buf) % seekPointSize) != 0 {
+		return fmt.Errorf("invalid seek table size: %d", len(buf))
+	}
+
+	n := len(buf) / seekPointSize
+	t.Points = make([]SeekPoint, n)
+
+	for i := range n {
+		b := buf[i*seekPointSize:]
+		t.Points[i] = SeekPoint{
+			SampleNumber: readUint64(b[0:]),
+			Offset:       readUint64(b[8:]),
+			SampleCount:  uint16(b[16])<<8 | uint16(b[17]),
+		}
+	}
+
+	return nil
+}
+
+func (t SeekTable) marshalSize() int {
+	return len(t.Points) * seekPointSize
+}
+
+func (t SeekTable) marshalTo(buf []byte) (int, error) {
+	n := 0
+
+	for _, p := range t.Points {
+		writeUint64(buf[n:], p.SampleNumber)
+		writeUint64(buf[n+8:], p.Offset)
+		buf[n+16] = byte(p.SampleCount >> 8)
+		buf[n+17] = byte(p.SampleCount)
+		n += seekPointSize
+	}
+
+	return n, nil
+}
+
+// Marshal encodes a SeekTable.
+func (t SeekTable) Marshal() ([]byte, error) {
+	buf := make([]byte, t.marshalSize())
+	_, err := t.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func readUint64(buf []byte) uint64 {
+	return uint64(buf[0])<<56 | uint64(buf[1])<<48 | uint64(buf[2])<<40 | uint64(buf[3])<<32 |
+		uint64(buf[4])<<24 | uint64(buf[5])<<16 | uint64(buf[6])<<8 | uint64(buf[7])
+}
+
+func writeUint64(buf []byte, v uint64) {
+	buf[0] = byte(v >> 56)
+	buf[1] = byte(v >> 48)
+	buf[2] = byte(v >> 40)
+	buf[3] = byte(v >> 32)
+	buf[4] = byte(v >> 24)
+	buf[5] = byte(v >> 16)
+	buf[6] = byte(v >> 8)
+	buf[7] = byte(v)
+}
@@ -19,6 +19,11 @@ type StreamInfo struct {
 	MD5          [16]byte
 }
 
+// BlockType implements MetadataBlock.
+func (s StreamInfo) BlockType() MetadataBlockType {
+	return MetadataBlockTypeStreamInfo
+}
+
 // Unmarshal decodes a StreamInfo.
 func (s *StreamInfo) Unmarshal(buf []byte) error {
 	if len(buf) < 34 {
@@ -0,0 +1,114 @@
+package flac
+
+import (
+	"fmt"
+)
+
+func readLE32(buf []byte) uint32 {
+	return uint32(buf[3])<<24 | uint32(buf[2])<<16 | uint32(buf[1])<<8 | uint32(buf[0])
+}
+
+func writeLE32(buf []byte, v uint32) {
+	buf[0] = byte(v)
+	buf[1] = byte(v >> 8)
+	buf[2] = byte(v >> 16)
+	buf[3] = byte(v >> 24)
+}
+
+// VorbisComment is a VORBIS_COMMENT metadata block.
+// Specification: RFC9639, section 8.6
+type VorbisComment struct {
+	Vendor string
+
+	// user comments, in the "NAME=value" form.
+	Comments []string
+}
+
+// BlockType implements MetadataBlock.
+func (c VorbisComment) BlockType() MetadataBlockType {
+	return MetadataBlockTypeVorbisComment
+}
+
+// Unmarshal decodes a VorbisComment.
+func (c *VorbisComment) Unmarshal(buf []byte) error {
+	if len(buf) < 8 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	vendorLen := int(readLE32(buf))
+	pos := 4
+
+	if vendorLen > len(buf[pos:]) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	c.Vendor = string(buf[pos : pos+vendorLen])
+	pos += vendorLen
+
+	if len(buf[pos:]) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	count := int(readLE32(buf[pos:]))
+	pos += 4
+
+	// each comment takes at least 4 bytes
+	if count > len(buf[pos:])/4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	c.Comments = make([]string, count)
+
+	for i := range count {
+		if len(buf[pos:]) < 4 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		commentLen := int(readLE32(buf[pos:]))
+		pos += 4
+
+		if commentLen > len(buf[pos:]) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		c.Comments[i] = string(buf[pos : pos+commentLen])
+		pos += commentLen
+	}
+
+	return nil
+}
+
+func (c VorbisComment) marshalSize() int {
+	n := 8 + len(c.Vendor)
+	for _, comment := range c.Comments {
+		n += 4 + len(comment)
+	}
+	return n
+}
+
+func (c VorbisComment) marshalTo(buf []byte) (int, error) {
+	writeLE32(buf, uint32(len(c.Vendor)))
+	pos := 4
+	pos += copy(buf[pos:], c.Vendor)
+
+	writeLE32(buf[pos:], uint32(len(c.Comments)))
+	pos += 4
+
+	for _, comment := range c.Comments {
+		writeLE32(buf[pos:], uint32(len(comment)))
+		pos += 4
+		pos += copy(buf[pos:], comment)
+	}
+
+	return pos, nil
+}
+
+// Marshal encodes a VorbisComment.
+func (c VorbisComment) Marshal() ([]byte, error) {
+	buf := make([]byte, c.marshalSize())
+	_, err := c.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,43 @@
+package g722
+
+// Decoder is a G722 decoder.
+// Specification: ITU-T G.722, section 4
+type Decoder struct {
+	lower  lowerBand
+	higher higherBand
+	qmf    qmf
+}
+
+// Initialize initializes a Decoder.
+func (d *Decoder) Initialize() {
+	d.lower.initialize()
+	d.higher.initialize()
+	d.qmf = qmf{}
+}
+
+// Decode decodes G722 codewords into 16-bit LPCM samples.
+// Each codeword produces two samples.
+func (d *Decoder) Decode(enc []byte) []byte {
+	out := make([]byte, len(enc)*4)
+
+	for i, cw := range enc {
+		il := cw & 0x3F
+		ih := cw >> 6
+
+		rl := d.lower.reconstruct(il)
+		d.lower.adapt(il)
+
+		dh := d.higher.difference(ih)
+		rh := limit(d.higher.s+dh, -16384, 16383)
+		d.higher.adapt(ih, dh)
+
+		xout1, xout2 := d.qmf.receive(rl, rh)
+
+		out[i*4] = byte(uint16(xout1) >> 8)
+		out[i*4+1] = byte(uint16(xout1))
+		out[i*4+2] = byte(uint16(xout2) >> 8)
+		out[i*4+3] = byte(uint16(xout2))
+	}
+
+	return out
+}
@@ -0,0 +1,47 @@
+package g722
+
+import (
+	"fmt"
+)
+
+// Encoder is a G722 encoder.
+// Specification: ITU-T G.722, section 3
+type Encoder struct {
+	lower  lowerBand
+	higher higherBand
+	qmf    qmf
+}
+
+// Initialize initializes an Encoder.
+func (e *Encoder) Initialize() {
+	e.lower.initialize()
+	e.higher.initialize()
+	e.qmf = qmf{}
+}
+
+// Encode encodes 16-bit LPCM samples into G722 codewords.
+// Each codeword is produced from two samples.
+func (e *Encoder) Encode(lpcm []byte) ([]byte, error) {
+	if (len(lpcm) % 4) != 0 {
+		return nil, fmt.Errorf("wrong sample size")
+	}
+
+	enc := make([]byte, len(lpcm)/4)
+
+	for i := range enc {
+		xin1 := int32(int16(uint16(lpcm[i*4])<<8 | uint16(lpcm[i*4+1])))
+		xin2 := int32(int16(uint16(lpcm[i*4+2])<<8 | uint16(lpcm[i*4+3])))
+
+		xl, xh := e.qmf.transmit(xin1, xin2)
+
+		il := e.lower.quantize(xl)
+		e.lower.adapt(il)
+
+		ih := e.higher.quantize(xh)
+		e.higher.adapt(ih, e.higher.difference(ih))
+
+		enc[i] = ih<<6 | il
+	}
+
+	return enc, nil
+}
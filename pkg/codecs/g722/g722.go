@@ -0,0 +1,25 @@
+// Package g722 contains utilities to work with the G722 codec.
+package g722
+
+// SampleRate is the sample rate of LPCM samples.
+const SampleRate = 16000
+
+// G722 is a list of 16-bit LPCM samples, sampled at 16khz,
+// that can be encoded/decoded from/to the G722 codec at 64 kbit/s.
+// Each call uses a new codec state.
+// Use Encoder and Decoder to encode/decode a continuous stream.
+type G722 []byte
+
+// Unmarshal decodes G722 samples.
+func (c *G722) Unmarshal(enc []byte) {
+	var d Decoder
+	d.Initialize()
+	*c = d.Decode(enc)
+}
+
+// Marshal encodes 16-bit LPCM samples with the G722 codec.
+func (c G722) Marshal() ([]byte, error) {
+	var e Encoder
+	e.Initialize()
+	return e.Encode(c)
+}
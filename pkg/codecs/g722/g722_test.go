@@ -0,0 +1,91 @@
+package g722_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/g722"
+)
+
+var testLPCM = []byte{
+	0xe4, 0xa8, 0xec, 0x78, 0xf4, 0x48, 0xfc, 0x18,
+	0x03, 0xe8, 0x0b, 0xb8, 0x13, 0x88, 0x1b, 0x58,
+	0xe4, 0xa8, 0xec, 0x78, 0xf4, 0x48, 0xfc, 0x18,
+	0x03, 0xe8, 0x0b, 0xb8, 0x13, 0x88, 0x1b, 0x58,
+}
+
+func TestG722Unmarshal(t *testing.T) {
+	var dec g722.G722
+	dec.Unmarshal([]byte{0x32, 0x86, 0x22, 0x87, 0x24, 0x84, 0x04, 0x9f})
+
+	require.Equal(t,
+		g722.G722{
+			0x00, 0x00, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00,
+			0x00, 0x00, 0xff, 0xff, 0xff, 0xff, 0x00, 0x03,
+			0x00, 0x00, 0xff, 0xf3, 0x00, 0x01, 0x00, 0x28,
+			0xff, 0xfb, 0xff, 0x91, 0x00, 0x0c, 0x00, 0xc6,
+		},
+		dec,
+	)
+}
+
+func TestG722Marshal(t *testing.T) {
+	enc, err := g722.G722(testLPCM).Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x32, 0x86, 0x22, 0x87, 0x24, 0x84, 0x04, 0x9f}, enc)
+}
+
+func TestG722MarshalError(t *testing.T) {
+	_, err := g722.G722([]byte{1, 2, 3}).Marshal()
+	require.EqualError(t, err, "wrong sample size")
+}
+
+func TestG722Stream(t *testing.T) {
+	var e g722.Encoder
+	e.Initialize()
+
+	enc1, err := e.Encode(testLPCM[:16])
+	require.NoError(t, err)
+
+	enc2, err := e.Encode(testLPCM[16:])
+	require.NoError(t, err)
+
+	enc, err := g722.G722(testLPCM).Marshal()
+	require.NoError(t, err)
+	require.Equal(t, enc, append(enc1, enc2...))
+}
+
+func TestG722RoundTrip(t *testing.T) {
+	// delay introduced by the QMF filters, in samples.
+	const delay = 22
+
+	n := g722.SampleRate / 2
+	lpcm := make([]byte, n*2)
+
+	for i := range n {
+		v := int16(10000 * math.Sin(2*math.Pi*1000*float64(i)/g722.SampleRate))
+		lpcm[i*2] = byte(uint16(v) >> 8)
+		lpcm[i*2+1] = byte(uint16(v))
+	}
+
+	enc, err := g722.G722(lpcm).Marshal()
+	require.NoError(t, err)
+	require.Equal(t, n/2, len(enc))
+
+	var dec g722.G722
+	dec.Unmarshal(enc)
+	require.Equal(t, len(lpcm), len(dec))
+
+	var signal, noise float64
+
+	for i := 1000; i < n-delay; i++ {
+		a := float64(int16(uint16(lpcm[i*2])<<8 | uint16(lpcm[i*2+1])))
+		b := float64(int16(uint16(dec[(i+delay)*2])<<8 | uint16(dec[(i+delay)*2+1])))
+		signal += a * a
+		noise += (a - b) * (a - b)
+	}
+
+	require.Greater(t, 10*math.Log10(signal/noise), 30.0)
+}
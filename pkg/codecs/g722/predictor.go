@@ -0,0 +1,91 @@
+package g722
+
+func limit(v int32, lo int32, hi int32) int32 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func sameSign(a int32, b int32) bool {
+	return (a < 0) == (b < 0)
+}
+
+// predictor is the adaptive predictor shared by the two sub-bands.
+// Specification: ITU-T G.722, blocks ADDA, ADDB, UPPOL1, UPPOL2, UPZERO,
+// DELAYA, FILTEP, FILTEZ and PREDIC
+type predictor struct {
+	// pole section
+	al1, al2   int32
+	rlt1, rlt2 int32
+	plt1, plt2 int32
+
+	// zero section
+	bl  [6]int32
+	dlt [6]int32
+
+	sz int32
+	s  int32
+}
+
+// update adapts the predictor to a quantized difference signal.
+func (p *predictor) update(d int32) {
+	plt := d + p.sz                    // ADDA
+	rlt := limit(p.s+d, -32768, 32767) // ADDB
+
+	// UPPOL2
+	wd1 := limit(p.al1*4, -32768, 32767)
+	wd2 := wd1
+	if sameSign(plt, p.plt1) {
+		wd2 = limit(-wd1, -32768, 32767)
+	}
+	wd3 := int32(-128)
+	if sameSign(plt, p.plt2) {
+		wd3 = 128
+	}
+	apl2 := limit((wd2>>7)+wd3+((p.al2*32512)>>15), -12288, 12288)
+
+	// UPPOL1
+	wd1 = -192
+	if sameSign(plt, p.plt1) {
+		wd1 = 192
+	}
+	lim := 15360 - apl2
+	apl1 := limit(wd1+((p.al1*32640)>>15), -lim, lim)
+
+	// UPZERO
+	for i := range p.bl {
+		var wd int32
+		if d != 0 {
+			if sameSign(d, p.dlt[i]) {
+				wd = 128
+			} else {
+				wd = -128
+			}
+		}
+		p.bl[i] = wd + ((p.bl[i] * 32640) >> 15)
+	}
+
+	// DELAYA
+	copy(p.dlt[1:], p.dlt[:5])
+	p.dlt[0] = d
+	p.al1, p.al2 = apl1, apl2
+	p.rlt2, p.rlt1 = p.rlt1, rlt
+	p.plt2, p.plt1 = p.plt1, plt
+
+	// FILTEZ
+	p.sz = 0
+	for i := range p.bl {
+		p.sz += (p.dlt[i] * 2 * p.bl[i]) >> 15
+	}
+
+	// FILTEP
+	sp := ((limit(p.rlt1*2, -32768, 32767) * p.al1) >> 15) +
+		((limit(p.rlt2*2, -32768, 32767) * p.al2) >> 15)
+
+	// PREDIC
+	p.s = limit(sp+p.sz, -32768, 32767)
+}
@@ -0,0 +1,45 @@
+package g722
+
+// QMF coefficients.
+// Specification: ITU-T G.722, Table 11
+var qmfH = [24]int32{
+	3, -11, -11, 53, 12, -156, 32, 362, -210, -805, 951, 3876,
+	3876, 951, -805, -210, 362, 32, -156, 12, 53, -11, -11, 3,
+}
+
+// qmf is a quadrature mirror filter.
+// The same delay line is used by the transmit and the receive filter.
+type qmf struct {
+	// x[0] is the most recent value.
+	x [24]int32
+}
+
+func (q *qmf) push(older int32, newer int32) {
+	copy(q.x[2:], q.x[:22])
+	q.x[1] = older
+	q.x[0] = newer
+}
+
+// accumulate returns the sums of the even and odd taps.
+func (q *qmf) accumulate() (int32, int32) {
+	var xa, xb int32
+	for i := 0; i < 24; i += 2 {
+		xa += q.x[i] * qmfH[i]
+		xb += q.x[i+1] * qmfH[i+1]
+	}
+	return xa, xb
+}
+
+// transmit splits two input samples into a lower and a higher sub-band sample.
+func (q *qmf) transmit(xin1 int32, xin2 int32) (int32, int32) {
+	q.push(xin1, xin2)
+	xa, xb := q.accumulate()
+	return (xa + xb) >> 14, (xa - xb) >> 14
+}
+
+// receive merges a lower and a higher sub-band sample into two output samples.
+func (q *qmf) receive(rl int32, rh int32) (int32, int32) {
+	q.push(rl+rh, rl-rh)
+	xout1, xout2 := q.accumulate()
+	return limit(xout1>>11, -32768, 32767), limit(xout2>>11, -32768, 32767)
+}
@@ -0,0 +1,176 @@
+package g722
+
+// Specification: ITU-T G.722, Table 14
+var ilb = [32]int32{
+	2048, 2093, 2139, 2186, 2233, 2282, 2332, 2383,
+	2435, 2489, 2543, 2599, 2656, 2714, 2774, 2834,
+	2896, 2960, 3025, 3091, 3158, 3228, 3298, 3371,
+	3444, 3520, 3597, 3676, 3756, 3838, 3922, 4008,
+}
+
+// decision levels of the 6-bit quantizer of the lower sub-band,
+// indexed by quantizer interval.
+// Specification: ITU-T G.722, Table 6
+var q6 = [31]int32{
+	0, 35, 72, 110, 150, 190, 233, 276, 323, 370, 422,
+	473, 530, 587, 650, 714, 786, 858, 940, 1023, 1121,
+	1219, 1339, 1458, 1612, 1765, 1980, 2195, 2557, 2919, 0,
+}
+
+// output levels of the 6-bit inverse quantizer of the lower sub-band,
+// indexed by quantizer interval.
+// Specification: ITU-T G.722, Table 6
+var qm6 = [31]int32{
+	0, 136, 432, 728, 1040, 1360, 1688, 2032, 2400, 2776, 3168,
+	3576, 4008, 4464, 4944, 5456, 6000, 6576, 7192, 7856, 8576,
+	9360, 10232, 11192, 12280, 13512, 14984, 16704, 19008, 21904, 24808,
+}
+
+// output levels of the 4-bit inverse quantizer of the lower sub-band,
+// indexed by the 4 most significant bits of the codeword.
+// Specification: ITU-T G.722, Table 7
+var qm4 = [16]int32{
+	0, -20456, -12896, -8968, -6288, -4240, -2584, -1200,
+	20456, 12896, 8968, 6288, 4240, 2584, 1200, 0,
+}
+
+// Specification: ITU-T G.722, Table 8
+var (
+	ril4 = [16]int32{0, 7, 6, 5, 4, 3, 2, 1, 7, 6, 5, 4, 3, 2, 1, 0}
+	wl   = [8]int32{-60, -30, 58, 172, 334, 538, 1198, 3042}
+)
+
+// output levels of the 2-bit inverse quantizer of the higher sub-band.
+// Specification: ITU-T G.722, Table 9
+var qm2 = [4]int32{-7408, -1616, 7408, 1616}
+
+// Specification: ITU-T G.722, Table 10
+var (
+	ih2 = [4]int32{2, 1, 2, 1}
+	wh  = [3]int32{0, -214, 798}
+)
+
+// magnitude returns the magnitude of a difference signal, as computed by QUANTL and QUANTH.
+func magnitude(e int32) int32 {
+	if e >= 0 {
+		return e
+	}
+	return -(e + 1)
+}
+
+// scale computes the quantizer scale factor from a logarithmic one (SCALEL, SCALEH).
+func scale(nb int32, offset int32) int32 {
+	wd1 := ilb[(nb>>6)&31]
+	wd2 := offset - (nb >> 11)
+	if wd2 < 0 {
+		return (wd1 << -wd2) << 2
+	}
+	return (wd1 >> wd2) << 2
+}
+
+// lowerBand is the state of the lower sub-band ADPCM.
+type lowerBand struct {
+	predictor
+	nbl  int32
+	detl int32
+}
+
+func (b *lowerBand) initialize() {
+	*b = lowerBand{detl: 32}
+}
+
+// quantize computes the 6-bit codeword of a lower sub-band sample (SUBTRA, QUANTL).
+func (b *lowerBand) quantize(xl int32) uint8 {
+	el := limit(xl-b.s, -32768, 32767)
+	wd := magnitude(el)
+
+	mil := 1
+	for ; mil < 30; mil++ {
+		if wd < (q6[mil]*b.detl)>>12 {
+			break
+		}
+	}
+
+	if el >= 0 {
+		return uint8(62 - mil)
+	}
+	if mil <= 2 {
+		return uint8(64 - mil)
+	}
+	return uint8(34 - mil)
+}
+
+// reconstruct returns the reconstructed signal of a 6-bit codeword (INVQBL, RECONS, LIMIT).
+func (b *lowerBand) reconstruct(il uint8) int32 {
+	var dl int32
+
+	switch {
+	case il >= 62:
+		dl = -qm6[64-int(il)]
+
+	case il >= 32:
+		dl = qm6[62-int(il)]
+
+	case il >= 4:
+		dl = -qm6[34-int(il)]
+
+	default:
+		dl = -qm6[1]
+	}
+
+	return limit(b.s+((b.detl*dl)>>15), -16384, 16383)
+}
+
+// adapt updates the state with a 6-bit codeword (INVQAL, LOGSCL, SCALEL).
+func (b *lowerBand) adapt(il uint8) {
+	ril := il >> 2
+
+	b.update((b.detl * qm4[ril]) >> 15)
+
+	b.nbl = limit(((b.nbl*32512)>>15)+wl[ril4[ril]], 0, 18432)
+	b.detl = scale(b.nbl, 8)
+}
+
+// higherBand is the state of the higher sub-band ADPCM.
+type higherBand struct {
+	predictor
+	nbh  int32
+	deth int32
+}
+
+func (b *higherBand) initialize() {
+	*b = higherBand{deth: 8}
+}
+
+// quantize computes the 2-bit codeword of a higher sub-band sample (SUBTRA, QUANTH).
+func (b *higherBand) quantize(xh int32) uint8 {
+	eh := limit(xh-b.s, -32768, 32767)
+	small := magnitude(eh) < (564*b.deth)>>12
+
+	switch {
+	case eh >= 0 && small:
+		return 3
+
+	case eh >= 0:
+		return 2
+
+	case small:
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// difference returns the quantized difference signal of a 2-bit codeword (INVQAH).
+func (b *higherBand) difference(ih uint8) int32 {
+	return (b.deth * qm2[ih]) >> 15
+}
+
+// adapt updates the state with a 2-bit codeword (LOGSCH, SCALEH).
+func (b *higherBand) adapt(ih uint8, dh int32) {
+	b.update(dh)
+
+	b.nbh = limit(((b.nbh*32512)>>15)+wh[ih2[ih]], 0, 22528)
+	b.deth = scale(b.nbh, 10)
+}
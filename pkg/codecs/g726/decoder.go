@@ -0,0 +1,69 @@
+package g726
+
+// Decoder is a G726 decoder.
+// Specification: ITU-T G.726
+type Decoder struct {
+	// Bit rate. It can be 16000, 24000, 32000 or 40000.
+	BitRate int
+
+	// Whether codewords are packed in big-endian order (AAL2)
+	// instead of little-endian order (RFC3551).
+	BigEndian bool
+
+	rate  *rate
+	state state
+}
+
+// Initialize initializes a Decoder.
+func (d *Decoder) Initialize() error {
+	var err error
+	d.rate, err = rateFromBitRate(d.BitRate)
+	if err != nil {
+		return err
+	}
+
+	d.state.initialize()
+	return nil
+}
+
+// Decode decodes G726 codewords into 16-bit LPCM samples.
+// Trailing bits that do not form a complete codeword are discarded.
+func (d *Decoder) Decode(enc []byte) []byte {
+	sampleCount := (len(enc) * 8) / d.rate.bits
+	out := make([]byte, sampleCount*2)
+
+	r := unpacker{
+		bigEndian: d.BigEndian,
+		bits:      d.rate.bits,
+		buf:       enc,
+	}
+
+	for i := range sampleCount {
+		code := r.read()
+
+		sezi := d.state.predictorZero()
+		sez := sezi >> 1
+		se := (sezi + d.state.predictorPole()) >> 1
+
+		y := d.state.stepSize()
+
+		sr := d.state.process(d.rate, code, y, sez, se)
+
+		// convert from 14-bit dynamic range
+		sample := uint16(clipInt16(sr << 2))
+		out[i*2] = byte(sample >> 8)
+		out[i*2+1] = byte(sample)
+	}
+
+	return out
+}
+
+func clipInt16(v int32) int32 {
+	if v < -32768 {
+		return -32768
+	}
+	if v > 32767 {
+		return 32767
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package g726
+
+import (
+	"fmt"
+)
+
+// Encoder is a G726 encoder.
+// Specification: ITU-T G.726
+type Encoder struct {
+	// Bit rate. It can be 16000, 24000, 32000 or 40000.
+	BitRate int
+
+	// Whether to pack codewords in big-endian order (AAL2)
+	// instead of little-endian order (RFC3551).
+	BigEndian bool
+
+	rate  *rate
+	state state
+}
+
+// Initialize initializes an Encoder.
+func (e *Encoder) Initialize() error {
+	var err error
+	e.rate, err = rateFromBitRate(e.BitRate)
+	if err != nil {
+		return err
+	}
+
+	e.state.initialize()
+	return nil
+}
+
+// Encode encodes 16-bit LPCM samples into G726 codewords.
+// The number of samples multiplied by the codeword size must be a multiple of 8.
+func (e *Encoder) Encode(lpcm []byte) ([]byte, error) {
+	if (len(lpcm) % 2) != 0 {
+		return nil, fmt.Errorf("wrong sample size")
+	}
+
+	sampleCount := len(lpcm) / 2
+	if ((sampleCount * e.rate.bits) % 8) != 0 {
+		return nil, fmt.Errorf("sample count is not compatible with bit rate")
+	}
+
+	w := packer{
+		bigEndian: e.BigEndian,
+		bits:      e.rate.bits,
+		buf:       make([]byte, 0, (sampleCount*e.rate.bits)/8),
+	}
+
+	for i := range sampleCount {
+		// convert to 14-bit dynamic range
+		sl := int32(int16(uint16(lpcm[i*2])<<8|uint16(lpcm[i*2+1]))) >> 2
+
+		sezi := e.state.predictorZero()
+		sez := sezi >> 1
+		se := (sezi + e.state.predictorPole()) >> 1
+
+		d := sl - se
+
+		y := e.state.stepSize()
+		code := e.state.quantize(e.rate, d, y)
+
+		e.state.process(e.rate, code, y, sez, se)
+
+		w.write(code)
+	}
+
+	return w.buf, nil
+}
@@ -0,0 +1,39 @@
+// Package g726 contains utilities to work with the G726 codec.
+package g726
+
+// SampleRate is the sample rate of LPCM samples.
+const SampleRate = 8000
+
+// G726 is a list of 16-bit LPCM samples, sampled at 8khz,
+// that can be encoded/decoded from/to the G726 codec.
+// Codewords are packed in little-endian order (RFC3551).
+// Each call uses a new codec state.
+// Use Encoder and Decoder to encode/decode a continuous stream.
+type G726 []byte
+
+// Unmarshal decodes G726 codewords with the given bit rate.
+func (c *G726) Unmarshal(bitRate int, enc []byte) error {
+	d := Decoder{
+		BitRate: bitRate,
+	}
+	err := d.Initialize()
+	if err != nil {
+		return err
+	}
+
+	*c = d.Decode(enc)
+	return nil
+}
+
+// Marshal encodes 16-bit LPCM samples with the G726 codec with the given bit rate.
+func (c G726) Marshal(bitRate int) ([]byte, error) {
+	e := Encoder{
+		BitRate: bitRate,
+	}
+	err := e.Initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Encode(c)
+}
@@ -0,0 +1,166 @@
+package g726_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/g726"
+)
+
+var testLPCM = []byte{
+	0xe4, 0xa8, 0xec, 0x78, 0xf4, 0x48, 0xfc, 0x18,
+	0x03, 0xe8, 0x0b, 0xb8, 0x13, 0x88, 0x1b, 0x58,
+	0xe4, 0xa8, 0xec, 0x78, 0xf4, 0x48, 0xfc, 0x18,
+	0x03, 0xe8, 0x0b, 0xb8, 0x13, 0x88, 0x1b, 0x58,
+}
+
+var cases = []struct {
+	name    string
+	bitRate int
+	enc     []byte
+	encBE   []byte
+	dec     []byte
+}{
+	{
+		"16k",
+		16000,
+		[]byte{0xaa, 0x55, 0xaa, 0x55},
+		[]byte{0xaa, 0x55, 0xaa, 0x55},
+		[]byte{
+			0xff, 0xc4, 0xff, 0xc0, 0xff, 0xb8, 0xff, 0xb0,
+			0x00, 0x5c, 0x00, 0x6c, 0x00, 0x84, 0x00, 0xa8,
+			0xff, 0x38, 0xfe, 0xd4, 0xfe, 0x30, 0xfd, 0x00,
+			0x04, 0x3c, 0x07, 0xec, 0x0d, 0x08, 0x13, 0xd0,
+		},
+	},
+	{
+		"24k",
+		24000,
+		[]byte{0x24, 0xb9, 0x6d, 0x24, 0xab, 0x6d},
+		[]byte{0x92, 0x46, 0xdb, 0x92, 0x54, 0xdb},
+		[]byte{
+			0xff, 0xc4, 0xff, 0xb8, 0xff, 0xb0, 0xff, 0xa4,
+			0x00, 0x68, 0x00, 0x84, 0x00, 0xb4, 0x00, 0xec,
+			0xfe, 0xc4, 0xfe, 0x00, 0xfc, 0x6c, 0xfb, 0xe4,
+			0x03, 0x9c, 0x08, 0x1c, 0x0f, 0xbc, 0x1d, 0x8c,
+		},
+	},
+	{
+		"32k",
+		32000,
+		[]byte{0x88, 0x88, 0x77, 0x77, 0x88, 0xed, 0x31, 0x65},
+		[]byte{0x88, 0x88, 0x77, 0x77, 0x88, 0xde, 0x13, 0x56},
+		[]byte{
+			0xff, 0xa8, 0xff, 0x98, 0xff, 0x80, 0xff, 0x4c,
+			0x00, 0xec, 0x01, 0x7c, 0x02, 0x40, 0x03, 0xf4,
+			0xf8, 0x98, 0xee, 0x14, 0xf3, 0xa8, 0xfa, 0x84,
+			0x04, 0x80, 0x0d, 0xe4, 0x15, 0x68, 0x1d, 0xe8,
+		},
+	},
+	{
+		"40k",
+		40000,
+		[]byte{0x10, 0x42, 0xf8, 0xde, 0x7b, 0x10, 0xce, 0x5d, 0x54, 0x63},
+		[]byte{0x84, 0x21, 0x07, 0xbd, 0xef, 0x84, 0x27, 0xb2, 0xa9, 0xac},
+		[]byte{
+			0xff, 0x44, 0xff, 0x2c, 0xff, 0x18, 0xfe, 0xe4,
+			0x01, 0x64, 0x01, 0xd8, 0x02, 0x68, 0x03, 0x74,
+			0xfb, 0x20, 0xf7, 0x84, 0xf4, 0x9c, 0xfc, 0x34,
+			0x03, 0x8c, 0x0b, 0x40, 0x14, 0x18, 0x1b, 0xfc,
+		},
+	},
+}
+
+func TestG726Unmarshal(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec g726.G726
+			err := dec.Unmarshal(ca.bitRate, ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, g726.G726(ca.dec), dec)
+
+			d := g726.Decoder{
+				BitRate:   ca.bitRate,
+				BigEndian: true,
+			}
+			err = d.Initialize()
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, d.Decode(ca.encBE))
+		})
+	}
+}
+
+func TestG726Marshal(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := g726.G726(testLPCM).Marshal(ca.bitRate)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+
+			e := g726.Encoder{
+				BitRate:   ca.bitRate,
+				BigEndian: true,
+			}
+			err = e.Initialize()
+			require.NoError(t, err)
+
+			enc, err = e.Encode(testLPCM)
+			require.NoError(t, err)
+			require.Equal(t, ca.encBE, enc)
+		})
+	}
+}
+
+func TestG726MarshalErrors(t *testing.T) {
+	_, err := g726.G726(testLPCM).Marshal(8000)
+	require.EqualError(t, err, "unsupported bit rate: 8000")
+
+	_, err = g726.G726([]byte{1, 2, 3}).Marshal(32000)
+	require.EqualError(t, err, "wrong sample size")
+
+	_, err = g726.G726([]byte{1, 2, 3, 4}).Marshal(24000)
+	require.EqualError(t, err, "sample count is not compatible with bit rate")
+}
+
+func TestG726RoundTrip(t *testing.T) {
+	n := g726.SampleRate
+	lpcm := make([]byte, n*2)
+
+	for i := range n {
+		v := int16(10000 * math.Sin(2*math.Pi*440*float64(i)/g726.SampleRate))
+		lpcm[i*2] = byte(uint16(v) >> 8)
+		lpcm[i*2+1] = byte(uint16(v))
+	}
+
+	for _, ca := range []struct {
+		bitRate int
+		minSNR  float64
+	}{
+		{16000, 15},
+		{24000, 22},
+		{32000, 30},
+		{40000, 37},
+	} {
+		enc, err := g726.G726(lpcm).Marshal(ca.bitRate)
+		require.NoError(t, err)
+		require.Equal(t, n*ca.bitRate/g726.SampleRate/8, len(enc))
+
+		var dec g726.G726
+		err = dec.Unmarshal(ca.bitRate, enc)
+		require.NoError(t, err)
+		require.Equal(t, len(lpcm), len(dec))
+
+		var signal, noise float64
+
+		for i := 100; i < n; i++ {
+			a := float64(int16(uint16(lpcm[i*2])<<8 | uint16(lpcm[i*2+1])))
+			b := float64(int16(uint16(dec[i*2])<<8 | uint16(dec[i*2+1])))
+			signal += a * a
+			noise += (a - b) * (a - b)
+		}
+
+		require.Greater(t, 10*math.Log10(signal/noise), ca.minSNR)
+	}
+}
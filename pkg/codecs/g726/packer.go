@@ -0,0 +1,67 @@
+package g726
+
+type packer struct {
+	bigEndian bool
+	bits      int
+	buf       []byte
+
+	acc   uint32
+	nbits int
+}
+
+func (p *packer) write(code int32) {
+	if p.bigEndian {
+		p.acc = (p.acc << p.bits) | uint32(code)
+		p.nbits += p.bits
+
+		for p.nbits >= 8 {
+			p.buf = append(p.buf, byte(p.acc>>(p.nbits-8)))
+			p.nbits -= 8
+		}
+	} else {
+		p.acc |= uint32(code) << p.nbits
+		p.nbits += p.bits
+
+		for p.nbits >= 8 {
+			p.buf = append(p.buf, byte(p.acc))
+			p.acc >>= 8
+			p.nbits -= 8
+		}
+	}
+}
+
+type unpacker struct {
+	bigEndian bool
+	bits      int
+	buf       []byte
+
+	pos   int
+	acc   uint32
+	nbits int
+}
+
+func (u *unpacker) read() int32 {
+	mask := uint32(1<<u.bits) - 1
+
+	if u.bigEndian {
+		for u.nbits < u.bits {
+			u.acc = (u.acc << 8) | uint32(u.buf[u.pos])
+			u.pos++
+			u.nbits += 8
+		}
+
+		u.nbits -= u.bits
+		return int32((u.acc >> u.nbits) & mask)
+	}
+
+	for u.nbits < u.bits {
+		u.acc |= uint32(u.buf[u.pos]) << u.nbits
+		u.pos++
+		u.nbits += 8
+	}
+
+	code := int32(u.acc & mask)
+	u.acc >>= u.bits
+	u.nbits -= u.bits
+	return code
+}
@@ -0,0 +1,98 @@
+package g726
+
+import (
+	"fmt"
+)
+
+// rate contains tables of a bit rate.
+// Specification: ITU-T G.726, section 4.2
+type rate struct {
+	bits     int
+	signMask int32
+	qtab     []int32
+	dqlntab  []int32
+	witab    []int32
+	fitab    []int32
+}
+
+var rate16 = rate{
+	bits:     2,
+	signMask: 0x02,
+	qtab:     []int32{261},
+	dqlntab:  []int32{116, 365, 365, 116},
+	witab:    []int32{-704, 14048, 14048, -704},
+	fitab:    []int32{0, 0xE00, 0xE00, 0},
+}
+
+var rate24 = rate{
+	bits:     3,
+	signMask: 0x04,
+	qtab:     []int32{8, 218, 331},
+	dqlntab:  []int32{-2048, 135, 273, 373, 373, 273, 135, -2048},
+	witab:    []int32{-128, 960, 4384, 18624, 18624, 4384, 960, -128},
+	fitab:    []int32{0, 0x200, 0x400, 0xE00, 0xE00, 0x400, 0x200, 0},
+}
+
+var rate32 = rate{
+	bits:     4,
+	signMask: 0x08,
+	qtab:     []int32{-124, 80, 178, 246, 300, 349, 400},
+	dqlntab: []int32{
+		-2048, 4, 135, 213, 273, 323, 373, 425,
+		425, 373, 323, 273, 213, 135, 4, -2048,
+	},
+	witab: []int32{
+		-384, 576, 1312, 2048, 3584, 6336, 11360, 35904,
+		35904, 11360, 6336, 3584, 2048, 1312, 576, -384,
+	},
+	fitab: []int32{
+		0, 0, 0, 0x200, 0x200, 0x200, 0x600, 0xE00,
+		0xE00, 0x600, 0x200, 0x200, 0x200, 0, 0, 0,
+	},
+}
+
+var rate40 = rate{
+	bits:     5,
+	signMask: 0x10,
+	qtab: []int32{
+		-122, -16, 68, 139, 198, 250, 298, 339,
+		378, 413, 445, 475, 502, 528, 553,
+	},
+	dqlntab: []int32{
+		-2048, -66, 28, 104, 169, 224, 274, 318,
+		358, 395, 429, 459, 488, 514, 539, 566,
+		566, 539, 514, 488, 459, 429, 395, 358,
+		318, 274, 224, 169, 104, 28, -66, -2048,
+	},
+	witab: []int32{
+		448, 448, 768, 1248, 1280, 1312, 1856, 3200,
+		4512, 5728, 7008, 8960, 11456, 14080, 16928, 22272,
+		22272, 16928, 14080, 11456, 8960, 7008, 5728, 4512,
+		3200, 1856, 1312, 1280, 1248, 768, 448, 448,
+	},
+	fitab: []int32{
+		0, 0, 0, 0, 0, 0x200, 0x200, 0x200,
+		0x200, 0x200, 0x400, 0x600, 0x800, 0xA00, 0xC00, 0xC00,
+		0xC00, 0xC00, 0xA00, 0x800, 0x600, 0x400, 0x200, 0x200,
+		0x200, 0x200, 0x200, 0, 0, 0, 0, 0,
+	},
+}
+
+func rateFromBitRate(bitRate int) (*rate, error) {
+	switch bitRate {
+	case 16000:
+		return &rate16, nil
+
+	case 24000:
+		return &rate24, nil
+
+	case 32000:
+		return &rate32, nil
+
+	case 40000:
+		return &rate40, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported bit rate: %d", bitRate)
+	}
+}
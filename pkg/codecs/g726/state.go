@@ -0,0 +1,384 @@
+// The adaptive predictor and the quantizer scale factor adaptation follow
+// the structure of the G.721/G.723 reference implementation released by
+// Sun Microsystems, Inc. (g72x.c), that is provided with the following notice:
+//
+// This source code is a product of Sun Microsystems, Inc. and is provided
+// for unrestricted use. Users may copy or modify this source code without
+// charge.
+//
+// SUN SOURCE CODE IS PROVIDED AS IS WITH NO WARRANTIES OF ANY KIND INCLUDING
+// THE WARRANTIES OF DESIGN, MERCHANTIBILITY AND FITNESS FOR A PARTICULAR
+// PURPOSE, OR ARISING FROM A COURSE OF DEALING, USAGE OR TRADE PRACTICE.
+
+package g726
+
+var power2 = [15]int32{
+	1, 2, 4, 8, 0x10, 0x20, 0x40, 0x80,
+	0x100, 0x200, 0x400, 0x800, 0x1000, 0x2000, 0x4000,
+}
+
+// floating point representation of a negative zero, 0xFC20 as int16.
+const negativeZero = -0x3E0
+
+func quan(val int32, table []int32) int32 {
+	for i, v := range table {
+		if val < v {
+			return int32(i)
+		}
+	}
+	return int32(len(table))
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// fmult multiplies a predictor coefficient with a floating point value.
+// Specification: ITU-T G.726, section 4.2.4 (FMULT)
+func fmult(an int32, srn int32) int32 {
+	var anmag int32
+	if an > 0 {
+		anmag = an
+	} else {
+		anmag = (-an) & 0x1FFF
+	}
+
+	anexp := quan(anmag, power2[:]) - 6
+
+	var anmant int32
+	switch {
+	case anmag == 0:
+		anmant = 32
+	case anexp >= 0:
+		anmant = anmag >> anexp
+	default:
+		anmant = anmag << -anexp
+	}
+
+	wanexp := anexp + ((srn >> 6) & 0xF) - 13
+	wanmant := (anmant*(srn&0x3F) + 0x30) >> 4
+
+	var retval int32
+	if wanexp >= 0 {
+		retval = (wanmant << wanexp) & 0x7FFF
+	} else {
+		retval = wanmant >> -wanexp
+	}
+
+	if (an ^ srn) < 0 {
+		return -retval
+	}
+	return retval
+}
+
+// float converts a value into the 4-bit exponent, 6-bit mantissa floating point format.
+func float(v int32) int32 {
+	switch {
+	case v == 0:
+		return 0x20
+
+	case v > 0:
+		exp := quan(v, power2[:])
+		return (exp << 6) + ((v << 6) >> exp)
+
+	case v > -32768:
+		mag := -v
+		exp := quan(mag, power2[:])
+		return (exp << 6) + ((mag << 6) >> exp) - 0x400
+
+	default:
+		return negativeZero
+	}
+}
+
+// state is the state of the adaptive predictor and quantizer.
+// Specification: ITU-T G.726, section 4
+type state struct {
+	yl  int32    // locked or steady state step size multiplier
+	yu  int32    // unlocked or non-steady state step size multiplier
+	dms int32    // short term energy estimate
+	dml int32    // long term energy estimate
+	ap  int32    // linear weighting coefficient of yl and yu
+	a   [2]int32 // coefficients of pole portion of prediction filter
+	b   [6]int32 // coefficients of zero portion of prediction filter
+	pk  [2]int32 // signs of previous two samples of a partially reconstructed signal
+	dq  [6]int32 // previous 6 samples of the quantized difference signal
+	sr  [2]int32 // previous 2 samples of the quantized difference signal
+	td  bool     // delayed tone detect
+}
+
+func (s *state) initialize() {
+	*s = state{
+		yl: 34816,
+		yu: 544,
+		sr: [2]int32{32, 32},
+		dq: [6]int32{32, 32, 32, 32, 32, 32},
+	}
+}
+
+func (s *state) predictorZero() int32 {
+	sezi := int32(0)
+	for i := range 6 {
+		sezi += fmult(s.b[i]>>2, s.dq[i])
+	}
+	return sezi
+}
+
+func (s *state) predictorPole() int32 {
+	return fmult(s.a[1]>>2, s.sr[1]) + fmult(s.a[0]>>2, s.sr[0])
+}
+
+func (s *state) stepSize() int32 {
+	if s.ap >= 256 {
+		return s.yu
+	}
+
+	y := s.yl >> 6
+	dif := s.yu - y
+	al := s.ap >> 2
+
+	if dif > 0 {
+		y += (dif * al) >> 6
+	} else if dif < 0 {
+		y += (dif*al + 0x3F) >> 6
+	}
+
+	return y
+}
+
+func (s *state) update(
+	r *rate,
+	y int32,
+	wi int32,
+	fi int32,
+	dq int32,
+	sr int32,
+	dqsez int32,
+) {
+	var pk0 int32
+	if dqsez < 0 {
+		pk0 = 1
+	}
+
+	mag := dq & 0x7FFF
+
+	// TRANS
+	ylint := s.yl >> 15
+	ylfrac := (s.yl >> 10) & 0x1F
+	thr1 := (32 + ylfrac) << ylint
+	thr2 := thr1
+	if ylint > 9 {
+		thr2 = 31 << 10
+	}
+	dqthr := (thr2 + (thr2 >> 1)) >> 1
+	tr := s.td && mag > dqthr
+
+	// FUNCTW, FILTD, LIMB
+	s.yu = y + ((wi - y) >> 5)
+	if s.yu < 544 {
+		s.yu = 544
+	} else if s.yu > 5120 {
+		s.yu = 5120
+	}
+
+	// FILTE
+	s.yl += s.yu + ((-s.yl) >> 6)
+
+	var a2p int32
+
+	if tr {
+		s.a = [2]int32{}
+		s.b = [6]int32{}
+	} else {
+		// UPA2
+		pks1 := pk0 ^ s.pk[0]
+
+		a2p = s.a[1] - (s.a[1] >> 7)
+
+		if dqsez != 0 {
+			fa1 := -s.a[0]
+			if pks1 != 0 {
+				fa1 = s.a[0]
+			}
+
+			switch {
+			case fa1 < -8191:
+				a2p -= 0x100
+			case fa1 > 8191:
+				a2p += 0xFF
+			default:
+				a2p += fa1 >> 5
+			}
+
+			// LIMC
+			if (pk0 ^ s.pk[1]) != 0 {
+				switch {
+				case a2p <= -12160:
+					a2p = -12288
+				case a2p >= 12416:
+					a2p = 12288
+				default:
+					a2p -= 0x80
+				}
+			} else {
+				switch {
+				case a2p <= -12416:
+					a2p = -12288
+				case a2p >= 12160:
+					a2p = 12288
+				default:
+					a2p += 0x80
+				}
+			}
+		}
+
+		s.a[1] = a2p
+
+		// UPA1
+		s.a[0] -= s.a[0] >> 8
+		if dqsez != 0 {
+			if pks1 == 0 {
+				s.a[0] += 192
+			} else {
+				s.a[0] -= 192
+			}
+		}
+
+		// LIMD
+		a1ul := 15360 - a2p
+		if s.a[0] < -a1ul {
+			s.a[0] = -a1ul
+		} else if s.a[0] > a1ul {
+			s.a[0] = a1ul
+		}
+
+		// UPB
+		for i := range 6 {
+			if r.bits == 5 {
+				s.b[i] -= s.b[i] >> 9
+			} else {
+				s.b[i] -= s.b[i] >> 8
+			}
+
+			if (dq & 0x7FFF) != 0 {
+				if (dq ^ s.dq[i]) >= 0 {
+					s.b[i] += 128
+				} else {
+					s.b[i] -= 128
+				}
+			}
+		}
+	}
+
+	// FLOAT A
+	copy(s.dq[1:], s.dq[:5])
+	switch {
+	case mag == 0:
+		if dq >= 0 {
+			s.dq[0] = 0x20
+		} else {
+			s.dq[0] = negativeZero
+		}
+
+	default:
+		exp := quan(mag, power2[:])
+		s.dq[0] = (exp << 6) + ((mag << 6) >> exp)
+		if dq < 0 {
+			s.dq[0] -= 0x400
+		}
+	}
+
+	// FLOAT B
+	s.sr[1] = s.sr[0]
+	s.sr[0] = float(sr)
+
+	// DELAY A
+	s.pk[1] = s.pk[0]
+	s.pk[0] = pk0
+
+	// TONE
+	s.td = !tr && a2p < -11776
+
+	// FILTA, FILTB
+	s.dms += (fi - s.dms) >> 5
+	s.dml += ((fi << 2) - s.dml) >> 7
+
+	// SUBTC
+	switch {
+	case tr:
+		s.ap = 256
+	case y < 1536, s.td, abs((s.dms<<2)-s.dml) >= (s.dml >> 3):
+		s.ap += (0x200 - s.ap) >> 4
+	default:
+		s.ap += (-s.ap) >> 4
+	}
+}
+
+// quantize quantizes the difference signal.
+// Specification: ITU-T G.726, section 4.2.2
+func (s *state) quantize(r *rate, d int32, y int32) int32 {
+	dqm := abs(d)
+	exp := quan(dqm>>1, power2[:])
+	mant := ((dqm << 7) >> exp) & 0x7F
+	dl := (exp << 7) + mant
+	dln := dl - (y >> 2)
+
+	i := quan(dln, r.qtab)
+	size := int32(len(r.qtab))
+
+	switch {
+	case d < 0:
+		return (size << 1) + 1 - i
+
+	// with 2 bits, the ones' complement of 0 is a negative code
+	case i == 0 && r.bits != 2:
+		return (size << 1) + 1
+
+	default:
+		return i
+	}
+}
+
+// reconstruct reconstructs the quantized difference signal.
+// Specification: ITU-T G.726, section 4.2.3
+func reconstruct(sign bool, dqln int32, y int32) int32 {
+	dql := dqln + (y >> 2)
+
+	if dql < 0 {
+		if sign {
+			return -0x8000
+		}
+		return 0
+	}
+
+	dex := (dql >> 7) & 15
+	dqt := 128 + (dql & 127)
+	dq := (dqt << 7) >> (14 - dex)
+
+	if sign {
+		return dq - 0x8000
+	}
+	return dq
+}
+
+// process runs the common part of encoder and decoder, given code i.
+// It returns the reconstructed signal.
+func (s *state) process(r *rate, i int32, y int32, sez int32, se int32) int32 {
+	dq := reconstruct((i&r.signMask) != 0, r.dqlntab[i], y)
+
+	var sr int32
+	if dq < 0 {
+		sr = se - (dq & 0x3FFF)
+	} else {
+		sr = se + dq
+	}
+
+	dqsez := sr + sez - se
+
+	s.update(r, y, r.witab[i], r.fitab[i], dq, sr, dqsez)
+
+	return sr
+}
@@ -34,3 +34,57 @@ func EmulationPreventionRemove(nalu []byte) []byte {
 
 	return ret
 }
+
+// EmulationPreventionAdd adds emulation prevention bytes to a NALU.
+// Specification: ITU-T Rec. H.264, section 7.4.1
+func EmulationPreventionAdd(nalu []byte) []byte {
+	// 0x00 0x00 0x00 -> 0x00 0x00 0x03 0x00
+	// 0x00 0x00 0x01 -> 0x00 0x00 0x03 0x01
+	// 0x00 0x00 0x02 -> 0x00 0x00 0x03 0x02
+	// 0x00 0x00 0x03 -> 0x00 0x00 0x03 0x03
+	// 0x00 0x00 (end) -> 0x00 0x00 0x03
+
+	n := len(nalu)
+	zeros := 0
+
+	for _, b := range nalu {
+		if zeros == 2 && b <= 3 {
+			n++
+			zeros = 0
+		}
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+
+	if zeros == 2 {
+		n++
+	}
+
+	ret := make([]byte, 0, n)
+	zeros = 0
+
+	for _, b := range nalu {
+		if zeros == 2 && b <= 3 {
+			ret = append(ret, 3)
+			zeros = 0
+		}
+
+		ret = append(ret, b)
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+
+	if zeros == 2 {
+		ret = append(ret, 3)
+	}
+
+	return ret
+}
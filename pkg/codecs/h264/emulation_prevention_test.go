@@ -66,3 +66,66 @@ func FuzzEmulationPreventionRemove(f *testing.F) {
 		EmulationPreventionRemove(b)
 	})
 }
+
+var casesEmulationPreventionAdd = []struct {
+	name   string
+	unproc []byte
+	proc   []byte
+}{
+	{
+		"base",
+		[]byte{
+			0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x01,
+			0x02, 0x00, 0x00, 0x02,
+			0x03, 0x00, 0x00, 0x03,
+			0x04, 0x00, 0x00, 0x04,
+		},
+		[]byte{
+			0x00, 0x00, 0x03, 0x00,
+			0x01, 0x00, 0x00, 0x03, 0x01,
+			0x02, 0x00, 0x00, 0x03, 0x02,
+			0x03, 0x00, 0x00, 0x03, 0x03,
+			0x04, 0x00, 0x00, 0x04,
+		},
+	},
+	{
+		"double emulation byte",
+		[]byte{
+			0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		[]byte{
+			0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00,
+		},
+	},
+	{
+		"terminal emulation byte",
+		[]byte{
+			0x00, 0x00,
+		},
+		[]byte{
+			0x00, 0x00, 0x03,
+		},
+	},
+}
+
+func TestEmulationPreventionAdd(t *testing.T) {
+	for _, ca := range casesEmulationPreventionAdd {
+		t.Run(ca.name, func(t *testing.T) {
+			proc := EmulationPreventionAdd(ca.unproc)
+			require.Equal(t, ca.proc, proc)
+		})
+	}
+}
+
+func FuzzEmulationPreventionAdd(f *testing.F) {
+	for _, ca := range casesEmulationPreventionAdd {
+		f.Add(ca.unproc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		require.Equal(t, b, EmulationPreventionRemove(EmulationPreventionAdd(b)))
+	})
+}
@@ -0,0 +1,690 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+const (
+	maxSliceGroups       = 8
+	maxPicSizeInMapUnits = 139264 // level 6.2
+)
+
+func scalingListDelta(next int32, last int32) int32 {
+	d := next - last
+	if d > 127 {
+		d -= 256
+	} else if d < -128 {
+		d += 256
+	}
+	return d
+}
+
+// scalingListStop returns the number of entries that must be written
+// before the remaining ones can be inferred by a zero next_scale.
+func scalingListStop(scalingList []int32) int {
+	n := len(scalingList)
+	for n > 1 && scalingList[n-1] == scalingList[n-2] {
+		n--
+	}
+	return n
+}
+
+func scalingListMarshalSize(scalingList []int32, useDefaultScalingMatrixFlag bool) int {
+	if useDefaultScalingMatrixFlag {
+		return bits.GolombSignedSize(-8)
+	}
+
+	n := 0
+	lastScale := int32(8)
+	stop := scalingListStop(scalingList)
+
+	for j := range stop {
+		n += bits.GolombSignedSize(scalingListDelta(scalingList[j], lastScale))
+		lastScale = scalingList[j]
+	}
+
+	if stop != len(scalingList) {
+		n += bits.GolombSignedSize(scalingListDelta(0, lastScale))
+	}
+
+	return n
+}
+
+func writeScalingList(buf []byte, pos *int, scalingList []int32, useDefaultScalingMatrixFlag bool) {
+	if useDefaultScalingMatrixFlag {
+		bits.WriteGolombSignedUnsafe(buf, pos, -8)
+		return
+	}
+
+	lastScale := int32(8)
+	stop := scalingListStop(scalingList)
+
+	for j := range stop {
+		bits.WriteGolombSignedUnsafe(buf, pos, scalingListDelta(scalingList[j], lastScale))
+		lastScale = scalingList[j]
+	}
+
+	if stop != len(scalingList) {
+		bits.WriteGolombSignedUnsafe(buf, pos, scalingListDelta(0, lastScale))
+	}
+}
+
+func checkScalingLists(
+	presentFlag []bool,
+	scalingList4x4 [][]int32,
+	useDefaultScalingMatrix4x4Flag []bool,
+	scalingList8x8 [][]int32,
+	useDefaultScalingMatrix8x8Flag []bool,
+) error {
+	n4x4 := 0
+	n8x8 := 0
+
+	for i, present := range presentFlag {
+		if present {
+			if i < 6 {
+				n4x4++
+			} else {
+				n8x8++
+			}
+		}
+	}
+
+	if len(scalingList4x4) != n4x4 || len(useDefaultScalingMatrix4x4Flag) != n4x4 ||
+		len(scalingList8x8) != n8x8 || len(useDefaultScalingMatrix8x8Flag) != n8x8 {
+		return fmt.Errorf("scaling lists do not match scaling list present flags")
+	}
+
+	for _, l := range scalingList4x4 {
+		if len(l) != 16 {
+			return fmt.Errorf("4x4 scaling lists must have 16 entries")
+		}
+	}
+
+	for _, l := range scalingList8x8 {
+		if len(l) != 64 {
+			return fmt.Errorf("8x8 scaling lists must have 64 entries")
+		}
+	}
+
+	return nil
+}
+
+func scalingListsMarshalSize(
+	presentFlag []bool,
+	scalingList4x4 [][]int32,
+	useDefaultScalingMatrix4x4Flag []bool,
+	scalingList8x8 [][]int32,
+	useDefaultScalingMatrix8x8Flag []bool,
+) int {
+	n := len(presentFlag)
+
+	for i, l := range scalingList4x4 {
+		n += scalingListMarshalSize(l, useDefaultScalingMatrix4x4Flag[i])
+	}
+
+	for i, l := range scalingList8x8 {
+		n += scalingListMarshalSize(l, useDefaultScalingMatrix8x8Flag[i])
+	}
+
+	return n
+}
+
+func writeScalingLists(
+	buf []byte,
+	pos *int,
+	presentFlag []bool,
+	scalingList4x4 [][]int32,
+	useDefaultScalingMatrix4x4Flag []bool,
+	scalingList8x8 [][]int32,
+	useDefaultScalingMatrix8x8Flag []bool,
+) {
+	i4x4 := 0
+	i8x8 := 0
+
+	for i, present := range presentFlag {
+		bits.WriteFlagUnsafe(buf, pos, present)
+
+		if present {
+			if i < 6 {
+				writeScalingList(buf, pos, scalingList4x4[i4x4], useDefaultScalingMatrix4x4Flag[i4x4])
+				i4x4++
+			} else {
+				writeScalingList(buf, pos, scalingList8x8[i8x8], useDefaultScalingMatrix8x8Flag[i8x8])
+				i8x8++
+			}
+		}
+	}
+}
+
+func moreRBSPData(buf []byte, pos int) bool {
+	// search for the rbsp_stop_one_bit
+	i := len(buf) - 1
+	for i >= 0 && buf[i] == 0 {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+
+	stopPos := i*8 + 7
+	for (buf[i] >> (7 - (stopPos & 0x07)) & 0x01) == 0 {
+		stopPos--
+	}
+
+	return pos < stopPos
+}
+
+func ceilLog2(v uint32) int {
+	n := 0
+	for (uint32(1) << n) < v {
+		n++
+	}
+	return n
+}
+
+// PPS_SliceGroups are the slice group parameters of a PPS.
+type PPS_SliceGroups struct { //nolint:revive
+	NumSliceGroupsMinus1 uint32
+	SliceGroupMapType    uint32
+
+	// SliceGroupMapType == 0
+	RunLengthMinus1 []uint32
+
+	// SliceGroupMapType == 2
+	TopLeft     []uint32
+	BottomRight []uint32
+
+	// SliceGroupMapType == 3, 4, 5
+	SliceGroupChangeDirectionFlag bool
+	SliceGroupChangeRateMinus1    uint32
+
+	// SliceGroupMapType == 6
+	PicSizeInMapUnitsMinus1 uint32
+	SliceGroupID            []uint32
+}
+
+func (g *PPS_SliceGroups) unmarshal(buf []byte, pos *int) error {
+	var err error
+	g.SliceGroupMapType, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	switch g.SliceGroupMapType {
+	case 0:
+		g.RunLengthMinus1 = make([]uint32, g.NumSliceGroupsMinus1+1)
+
+		for i := range g.RunLengthMinus1 {
+			g.RunLengthMinus1[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+	case 1:
+
+	case 2:
+		g.TopLeft = make([]uint32, g.NumSliceGroupsMinus1)
+		g.BottomRight = make([]uint32, g.NumSliceGroupsMinus1)
+
+		for i := range g.TopLeft {
+			g.TopLeft[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			g.BottomRight[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+	case 3, 4, 5:
+		g.SliceGroupChangeDirectionFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		g.SliceGroupChangeRateMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+	case 6:
+		g.PicSizeInMapUnitsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if g.PicSizeInMapUnitsMinus1 >= maxPicSizeInMapUnits {
+			return fmt.Errorf("pic_size_in_map_units_minus1 exceeds %d", maxPicSizeInMapUnits-1)
+		}
+
+		n := ceilLog2(g.NumSliceGroupsMinus1 + 1)
+
+		err = bits.HasSpace(buf, *pos, n*int(g.PicSizeInMapUnitsMinus1+1))
+		if err != nil {
+			return err
+		}
+
+		g.SliceGroupID = make([]uint32, g.PicSizeInMapUnitsMinus1+1)
+
+		for i := range g.SliceGroupID {
+			g.SliceGroupID[i] = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+		}
+
+	default:
+		return fmt.Errorf("invalid slice_group_map_type: %d", g.SliceGroupMapType)
+	}
+
+	return nil
+}
+
+func (g PPS_SliceGroups) marshalSizeBits() int {
+	n := bits.GolombUnsignedSize(g.SliceGroupMapType)
+
+	switch g.SliceGroupMapType {
+	case 0:
+		for _, v := range g.RunLengthMinus1 {
+			n += bits.GolombUnsignedSize(v)
+		}
+
+	case 2:
+		for i := range g.TopLeft {
+			n += bits.GolombUnsignedSize(g.TopLeft[i]) + bits.GolombUnsignedSize(g.BottomRight[i])
+		}
+
+	case 3, 4, 5:
+		n += 1 + bits.GolombUnsignedSize(g.SliceGroupChangeRateMinus1)
+
+	case 6:
+		n += bits.GolombUnsignedSize(g.PicSizeInMapUnitsMinus1) +
+			ceilLog2(g.NumSliceGroupsMinus1+1)*len(g.SliceGroupID)
+	}
+
+	return n
+}
+
+func (g PPS_SliceGroups) check() error {
+	if g.NumSliceGroupsMinus1 == 0 || g.NumSliceGroupsMinus1 >= maxSliceGroups {
+		return fmt.Errorf("invalid NumSliceGroupsMinus1")
+	}
+
+	switch g.SliceGroupMapType {
+	case 0:
+		if len(g.RunLengthMinus1) != int(g.NumSliceGroupsMinus1+1) {
+			return fmt.Errorf("RunLengthMinus1 must have %d entries", g.NumSliceGroupsMinus1+1)
+		}
+
+	case 1, 3, 4, 5:
+
+	case 2:
+		if len(g.TopLeft) != int(g.NumSliceGroupsMinus1) || len(g.BottomRight) != int(g.NumSliceGroupsMinus1) {
+			return fmt.Errorf("TopLeft and BottomRight must have %d entries", g.NumSliceGroupsMinus1)
+		}
+
+	case 6:
+		if len(g.SliceGroupID) != int(g.PicSizeInMapUnitsMinus1+1) {
+			return fmt.Errorf("SliceGroupID must have %d entries", g.PicSizeInMapUnitsMinus1+1)
+		}
+
+	default:
+		return fmt.Errorf("invalid slice_group_map_type: %d", g.SliceGroupMapType)
+	}
+
+	return nil
+}
+
+func (g PPS_SliceGroups) marshalTo(buf []byte, pos *int) {
+	bits.WriteGolombUnsignedUnsafe(buf, pos, g.SliceGroupMapType)
+
+	switch g.SliceGroupMapType {
+	case 0:
+		for _, v := range g.RunLengthMinus1 {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, v)
+		}
+
+	case 2:
+		for i := range g.TopLeft {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, g.TopLeft[i])
+			bits.WriteGolombUnsignedUnsafe(buf, pos, g.BottomRight[i])
+		}
+
+	case 3, 4, 5:
+		bits.WriteFlagUnsafe(buf, pos, g.SliceGroupChangeDirectionFlag)
+		bits.WriteGolombUnsignedUnsafe(buf, pos, g.SliceGroupChangeRateMinus1)
+
+	case 6:
+		bits.WriteGolombUnsignedUnsafe(buf, pos, g.PicSizeInMapUnitsMinus1)
+		n := ceilLog2(g.NumSliceGroupsMinus1 + 1)
+
+		for _, v := range g.SliceGroupID {
+			if n != 0 {
+				bits.WriteBitsUnsafe(buf, pos, uint64(v), n)
+			}
+		}
+	}
+}
+
+// PPS is a H264 picture parameter set.
+// Specification: ITU-T Rec. H.264, 7.3.2.2
+type PPS struct {
+	ID                                    uint32
+	SPSID                                 uint32
+	EntropyCodingModeFlag                 bool
+	BottomFieldPicOrderInFramePresentFlag bool
+	SliceGroups                           *PPS_SliceGroups
+	NumRefIdxL0DefaultActiveMinus1        uint32
+	NumRefIdxL1DefaultActiveMinus1        uint32
+	WeightedPredFlag                      bool
+	WeightedBipredIdc                     uint8
+	PicInitQPMinus26                      int32
+	PicInitQSMinus26                      int32
+	ChromaQPIndexOffset                   int32
+	DeblockingFilterControlPresentFlag    bool
+	ConstrainedIntraPredFlag              bool
+	RedundantPicCntPresentFlag            bool
+
+	// only when more_rbsp_data() is true
+	Transform8x8ModeFlag bool
+
+	// picScalingMatrixPresentFlag == true
+	PicScalingListPresentFlag      []bool
+	ScalingList4x4                 [][]int32
+	UseDefaultScalingMatrix4x4Flag []bool
+	ScalingList8x8                 [][]int32
+	UseDefaultScalingMatrix8x8Flag []bool
+
+	// equal to ChromaQPIndexOffset when not present
+	SecondChromaQPIndexOffset int32
+}
+
+// Unmarshal decodes a PPS from bytes.
+// Scaling lists are decoded by assuming that chroma_format_idc of the SPS is not 3.
+// Use UnmarshalWithSPS to decode a PPS of a 4:4:4 stream.
+func (p *PPS) Unmarshal(buf []byte) error {
+	return p.unmarshal(buf, 1)
+}
+
+// UnmarshalWithSPS decodes a PPS from bytes, using the SPS it refers to.
+func (p *PPS) UnmarshalWithSPS(buf []byte, sps *SPS) error {
+	return p.unmarshal(buf, sps.ChromaFormatIdc)
+}
+
+func (p *PPS) unmarshal(buf []byte, chromaFormatIdc uint32) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType(buf[0]&0x1F) != NALUTypePPS {
+		return fmt.Errorf("not a PPS")
+	}
+
+	buf = EmulationPreventionRemove(buf[1:])
+	pos := 0
+
+	var err error
+	p.ID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.SPSID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.EntropyCodingModeFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.BottomFieldPicOrderInFramePresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	numSliceGroupsMinus1, err := bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if numSliceGroupsMinus1 >= maxSliceGroups {
+		return fmt.Errorf("num_slice_groups_minus1 exceeds %d", maxSliceGroups-1)
+	}
+
+	if numSliceGroupsMinus1 > 0 {
+		p.SliceGroups = &PPS_SliceGroups{
+			NumSliceGroupsMinus1: numSliceGroupsMinus1,
+		}
+		err = p.SliceGroups.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.SliceGroups = nil
+	}
+
+	p.NumRefIdxL0DefaultActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.NumRefIdxL1DefaultActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if p.NumRefIdxL0DefaultActiveMinus1 > 31 || p.NumRefIdxL1DefaultActiveMinus1 > 31 {
+		return fmt.Errorf("invalid num_ref_idx_default_active_minus1")
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	p.WeightedPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.WeightedBipredIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+
+	p.PicInitQPMinus26, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.PicInitQSMinus26, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.ChromaQPIndexOffset, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	p.DeblockingFilterControlPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.ConstrainedIntraPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.RedundantPicCntPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	p.Transform8x8ModeFlag = false
+	p.PicScalingListPresentFlag = nil
+	p.ScalingList4x4 = nil
+	p.UseDefaultScalingMatrix4x4Flag = nil
+	p.ScalingList8x8 = nil
+	p.UseDefaultScalingMatrix8x8Flag = nil
+
+	if !moreRBSPData(buf, pos) {
+		p.SecondChromaQPIndexOffset = p.ChromaQPIndexOffset
+		return nil
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.Transform8x8ModeFlag = bits.ReadFlagUnsafe(buf, &pos)
+	picScalingMatrixPresentFlag := bits.ReadFlagUnsafe(buf, &pos)
+
+	if picScalingMatrixPresentFlag {
+		lim := 6
+		if p.Transform8x8ModeFlag {
+			if chromaFormatIdc != 3 {
+				lim += 2
+			} else {
+				lim += 6
+			}
+		}
+
+		p.PicScalingListPresentFlag = make([]bool, lim)
+
+		for i := range lim {
+			p.PicScalingListPresentFlag[i], err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if p.PicScalingListPresentFlag[i] {
+				if i < 6 {
+					var scalingList []int32
+					var useDefaultScalingMatrixFlag bool
+					scalingList, useDefaultScalingMatrixFlag, err = readScalingList(buf, &pos, 16)
+					if err != nil {
+						return err
+					}
+
+					p.ScalingList4x4 = append(p.ScalingList4x4, scalingList)
+					p.UseDefaultScalingMatrix4x4Flag = append(p.UseDefaultScalingMatrix4x4Flag,
+						useDefaultScalingMatrixFlag)
+				} else {
+					var scalingList []int32
+					var useDefaultScalingMatrixFlag bool
+					scalingList, useDefaultScalingMatrixFlag, err = readScalingList(buf, &pos, 64)
+					if err != nil {
+						return err
+					}
+
+					p.ScalingList8x8 = append(p.ScalingList8x8, scalingList)
+					p.UseDefaultScalingMatrix8x8Flag = append(p.UseDefaultScalingMatrix8x8Flag,
+						useDefaultScalingMatrixFlag)
+				}
+			}
+		}
+	}
+
+	p.SecondChromaQPIndexOffset, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p PPS) hasExtension() bool {
+	return p.Transform8x8ModeFlag ||
+		p.PicScalingListPresentFlag != nil ||
+		p.SecondChromaQPIndexOffset != p.ChromaQPIndexOffset
+}
+
+func (p PPS) marshalSizeBits() int {
+	n := bits.GolombUnsignedSize(p.ID) +
+		bits.GolombUnsignedSize(p.SPSID) +
+		2
+
+	if p.SliceGroups != nil {
+		n += bits.GolombUnsignedSize(p.SliceGroups.NumSliceGroupsMinus1) +
+			p.SliceGroups.marshalSizeBits()
+	} else {
+		n++
+	}
+
+	n += bits.GolombUnsignedSize(p.NumRefIdxL0DefaultActiveMinus1) +
+		bits.GolombUnsignedSize(p.NumRefIdxL1DefaultActiveMinus1) +
+		3 +
+		bits.GolombSignedSize(p.PicInitQPMinus26) +
+		bits.GolombSignedSize(p.PicInitQSMinus26) +
+		bits.GolombSignedSize(p.ChromaQPIndexOffset) +
+		3
+
+	if p.hasExtension() {
+		n += 2 +
+			scalingListsMarshalSize(p.PicScalingListPresentFlag, p.ScalingList4x4, p.UseDefaultScalingMatrix4x4Flag,
+				p.ScalingList8x8, p.UseDefaultScalingMatrix8x8Flag) +
+			bits.GolombSignedSize(p.SecondChromaQPIndexOffset)
+	}
+
+	return n + 1 // rbsp_stop_one_bit
+}
+
+func (p PPS) checkScalingLists() error {
+	if p.PicScalingListPresentFlag != nil {
+		l := len(p.PicScalingListPresentFlag)
+		if (!p.Transform8x8ModeFlag && l != 6) || (p.Transform8x8ModeFlag && l != 8 && l != 12) {
+			return fmt.Errorf("invalid PicScalingListPresentFlag length")
+		}
+	}
+
+	return checkScalingLists(p.PicScalingListPresentFlag, p.ScalingList4x4, p.UseDefaultScalingMatrix4x4Flag,
+		p.ScalingList8x8, p.UseDefaultScalingMatrix8x8Flag)
+}
+
+// Marshal encodes a PPS.
+func (p PPS) Marshal() ([]byte, error) {
+	err := p.checkScalingLists()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.SliceGroups != nil {
+		err = p.SliceGroups.check()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	n := p.marshalSizeBits()
+	buf := make([]byte, (n+7)/8)
+	pos := 0
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.ID)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.SPSID)
+	bits.WriteFlagUnsafe(buf, &pos, p.EntropyCodingModeFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.BottomFieldPicOrderInFramePresentFlag)
+
+	if p.SliceGroups != nil {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, p.SliceGroups.NumSliceGroupsMinus1)
+		p.SliceGroups.marshalTo(buf, &pos)
+	} else {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, 0)
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.NumRefIdxL0DefaultActiveMinus1)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.NumRefIdxL1DefaultActiveMinus1)
+	bits.WriteFlagUnsafe(buf, &pos, p.WeightedPredFlag)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(p.WeightedBipredIdc), 2)
+	bits.WriteGolombSignedUnsafe(buf, &pos, p.PicInitQPMinus26)
+	bits.WriteGolombSignedUnsafe(buf, &pos, p.PicInitQSMinus26)
+	bits.WriteGolombSignedUnsafe(buf, &pos, p.ChromaQPIndexOffset)
+	bits.WriteFlagUnsafe(buf, &pos, p.DeblockingFilterControlPresentFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.ConstrainedIntraPredFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.RedundantPicCntPresentFlag)
+
+	if p.hasExtension() {
+		bits.WriteFlagUnsafe(buf, &pos, p.Transform8x8ModeFlag)
+		bits.WriteFlagUnsafe(buf, &pos, p.PicScalingListPresentFlag != nil)
+
+		writeScalingLists(buf, &pos, p.PicScalingListPresentFlag, p.ScalingList4x4, p.UseDefaultScalingMatrix4x4Flag,
+			p.ScalingList8x8, p.UseDefaultScalingMatrix8x8Flag)
+
+		bits.WriteGolombSignedUnsafe(buf, &pos, p.SecondChromaQPIndexOffset)
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, true) // rbsp_stop_one_bit
+
+	return append([]byte{0x60 | byte(NALUTypePPS)}, EmulationPreventionAdd(buf)...), nil
+}
@@ -0,0 +1,185 @@
+package h264
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPPS = []struct {
+	name string
+	byts []byte
+	pps  PPS
+}{
+	{
+		"baseline",
+		[]byte{
+			0x68, 0xce, 0x3c, 0x80,
+		},
+		PPS{
+			DeblockingFilterControlPresentFlag: true,
+		},
+	},
+	{
+		"high",
+		[]byte{
+			0x68, 0xeb, 0xe3, 0xcb, 0x22, 0xc0,
+		},
+		PPS{
+			EntropyCodingModeFlag:              true,
+			NumRefIdxL0DefaultActiveMinus1:     2,
+			WeightedPredFlag:                   true,
+			WeightedBipredIdc:                  2,
+			PicInitQPMinus26:                   -3,
+			ChromaQPIndexOffset:                -2,
+			DeblockingFilterControlPresentFlag: true,
+			Transform8x8ModeFlag:               true,
+			SecondChromaQPIndexOffset:          -2,
+		},
+	},
+	{
+		"scaling matrix",
+		[]byte{
+			0x68, 0x5b, 0x8c, 0x93, 0x94, 0x74, 0x76, 0x10,
+			0xe2, 0x31, 0x51, 0x40, 0x84, 0x47, 0x80,
+		},
+		PPS{
+			ID:                                 1,
+			EntropyCodingModeFlag:              true,
+			ChromaQPIndexOffset:                2,
+			DeblockingFilterControlPresentFlag: true,
+			Transform8x8ModeFlag:               true,
+			PicScalingListPresentFlag:          []bool{true, false, false, false, false, false, true, false},
+			ScalingList4x4: [][]int32{{
+				6, 13, 13, 20, 20, 20, 28, 28,
+				28, 28, 32, 32, 32, 37, 37, 42,
+			}},
+			UseDefaultScalingMatrix4x4Flag: []bool{false},
+			ScalingList8x8:                 [][]int32{slices.Repeat([]int32{8}, 64)},
+			UseDefaultScalingMatrix8x8Flag: []bool{true},
+			SecondChromaQPIndexOffset:      -3,
+		},
+	},
+	{
+		"slice groups",
+		[]byte{
+			0x68, 0xc6, 0x73, 0x0d, 0x26, 0x05, 0xea,
+		},
+		PPS{
+			SliceGroups: &PPS_SliceGroups{
+				NumSliceGroupsMinus1:    2,
+				SliceGroupMapType:       6,
+				PicSizeInMapUnitsMinus1: 5,
+				SliceGroupID:            []uint32{0, 1, 2, 2, 1, 0},
+			},
+			PicInitQPMinus26:         -5,
+			ConstrainedIntraPredFlag: true,
+		},
+	},
+}
+
+func TestPPSUnmarshal(t *testing.T) {
+	for _, ca := range casesPPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var pps PPS
+			err := pps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pps, pps)
+		})
+	}
+}
+
+func TestPPSUnmarshalWithSPS(t *testing.T) {
+	var pps PPS
+	err := pps.UnmarshalWithSPS([]byte{
+		0x68, 0xeb, 0xec, 0xb3, 0x00, 0x02, 0xc0,
+	}, &SPS{ChromaFormatIdc: 3})
+	require.NoError(t, err)
+	require.Equal(t, PPS{
+		EntropyCodingModeFlag:              true,
+		NumRefIdxL0DefaultActiveMinus1:     2,
+		DeblockingFilterControlPresentFlag: true,
+		ChromaQPIndexOffset:                -2,
+		WeightedPredFlag:                   true,
+		WeightedBipredIdc:                  2,
+		Transform8x8ModeFlag:               true,
+		PicScalingListPresentFlag:          make([]bool, 12),
+		SecondChromaQPIndexOffset:          -2,
+	}, pps)
+}
+
+func TestPPSMarshal(t *testing.T) {
+	for _, ca := range casesPPS {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.pps.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestPPSMarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		pps  PPS
+		err  string
+	}{
+		{
+			"invalid slice group count",
+			PPS{
+				SliceGroups: &PPS_SliceGroups{},
+			},
+			"invalid NumSliceGroupsMinus1",
+		},
+		{
+			"invalid slice group rectangles",
+			PPS{
+				SliceGroups: &PPS_SliceGroups{
+					NumSliceGroupsMinus1: 2,
+					SliceGroupMapType:    2,
+					TopLeft:              []uint32{1, 2},
+					BottomRight:          []uint32{3},
+				},
+			},
+			"TopLeft and BottomRight must have 2 entries",
+		},
+		{
+			"invalid slice group map type",
+			PPS{
+				SliceGroups: &PPS_SliceGroups{
+					NumSliceGroupsMinus1: 1,
+					SliceGroupMapType:    7,
+				},
+			},
+			"invalid slice_group_map_type: 7",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ca.pps.Marshal()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzPPSUnmarshal(f *testing.F) {
+	for _, ca := range casesPPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var pps PPS
+		err := pps.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		byts, err := pps.Marshal()
+		require.NoError(t, err)
+
+		var pps2 PPS
+		err = pps2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, pps, pps2)
+	})
+}
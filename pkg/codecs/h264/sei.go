@@ -0,0 +1,111 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/internal/sei"
+)
+
+// SEIPayloadType is the type of a SEI message payload.
+// Specification: ITU-T Rec. H.264, D.1.1
+type SEIPayloadType uint32
+
+// SEI payload types.
+const (
+	SEIPayloadTypeBufferingPeriod              SEIPayloadType = 0
+	SEIPayloadTypePicTiming                    SEIPayloadType = 1
+	SEIPayloadTypePanScanRect                  SEIPayloadType = 2
+	SEIPayloadTypeFillerPayload                SEIPayloadType = 3
+	SEIPayloadTypeUserDataRegisteredITUTT35    SEIPayloadType = 4
+	SEIPayloadTypeUserDataUnregistered         SEIPayloadType = 5
+	SEIPayloadTypeRecoveryPoint                SEIPayloadType = 6
+	SEIPayloadTypeMasteringDisplayColourVolume SEIPayloadType = 137
+	SEIPayloadTypeContentLightLevelInfo        SEIPayloadType = 144
+)
+
+var seiPayloadTypeLabels = map[SEIPayloadType]string{
+	SEIPayloadTypeBufferingPeriod:              "BufferingPeriod",
+	SEIPayloadTypePicTiming:                    "PicTiming",
+	SEIPayloadTypePanScanRect:                  "PanScanRect",
+	SEIPayloadTypeFillerPayload:                "FillerPayload",
+	SEIPayloadTypeUserDataRegisteredITUTT35:    "UserDataRegisteredITUTT35",
+	SEIPayloadTypeUserDataUnregistered:         "UserDataUnregistered",
+	SEIPayloadTypeRecoveryPoint:                "RecoveryPoint",
+	SEIPayloadTypeMasteringDisplayColourVolume: "MasteringDisplayColourVolume",
+	SEIPayloadTypeContentLightLevelInfo:        "ContentLightLevelInfo",
+}
+
+// String implements fmt.Stringer.
+func (t SEIPayloadType) String() string {
+	if l, ok := seiPayloadTypeLabels[t]; ok {
+		return l
+	}
+	return fmt.Sprintf("unknown (%d)", uint32(t))
+}
+
+// SEIMessage is a SEI message.
+// Specification: ITU-T Rec. H.264, 7.3.2.3.1
+type SEIMessage struct {
+	PayloadType SEIPayloadType
+	Payload     []byte
+}
+
+func unmarshalSEIMessages(buf []byte) ([]SEIMessage, error) {
+	msgs, err := sei.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]SEIMessage, len(msgs))
+	for i, msg := range msgs {
+		messages[i] = SEIMessage{
+			PayloadType: SEIPayloadType(msg.PayloadType),
+			Payload:     msg.Payload,
+		}
+	}
+
+	return messages, nil
+}
+
+func marshalSEIMessages(messages []SEIMessage) ([]byte, error) {
+	msgs := make([]sei.Message, len(messages))
+	for i, msg := range messages {
+		msgs[i] = sei.Message{
+			PayloadType: uint32(msg.PayloadType),
+			Payload:     msg.Payload,
+		}
+	}
+
+	return sei.Marshal(msgs)
+}
+
+// SEI is a supplemental enhancement information NALU.
+// Specification: ITU-T Rec. H.264, 7.3.2.3
+type SEI struct {
+	Messages []SEIMessage
+}
+
+// Unmarshal decodes a SEI from bytes.
+func (s *SEI) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType(buf[0]&0x1F) != NALUTypeSEI {
+		return fmt.Errorf("not a SEI")
+	}
+
+	var err error
+	s.Messages, err = unmarshalSEIMessages(EmulationPreventionRemove(buf[1:]))
+	return err
+}
+
+// Marshal encodes a SEI into bytes.
+func (s SEI) Marshal() ([]byte, error) {
+	buf, err := marshalSEIMessages(s.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{byte(NALUTypeSEI)}, EmulationPreventionAdd(buf)...), nil
+}
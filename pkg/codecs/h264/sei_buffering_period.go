@@ -0,0 +1,82 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// SEIBufferingPeriod_CpbRemovalDelay is the initial CPB removal delay of a SchedSelIdx.
+type SEIBufferingPeriod_CpbRemovalDelay struct { //nolint:revive
+	InitialCpbRemovalDelay       uint32
+	InitialCpbRemovalDelayOffset uint32
+}
+
+func readCpbRemovalDelays(buf []byte, pos *int, hrd *SPS_HRD) ([]SEIBufferingPeriod_CpbRemovalDelay, error) {
+	le := int(hrd.InitialCpbRemovalDelayLengthMinus1) + 1
+
+	err := bits.HasSpace(buf, *pos, int(hrd.CpbCntMinus1+1)*le*2)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]SEIBufferingPeriod_CpbRemovalDelay, hrd.CpbCntMinus1+1)
+
+	for i := range ret {
+		ret[i].InitialCpbRemovalDelay = uint32(bits.ReadBitsUnsafe(buf, pos, le))
+		ret[i].InitialCpbRemovalDelayOffset = uint32(bits.ReadBitsUnsafe(buf, pos, le))
+	}
+
+	return ret, nil
+}
+
+// SEIBufferingPeriod is a buffering_period SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.2
+type SEIBufferingPeriod struct {
+	SeqParameterSetID uint32
+
+	// SPS.VUI.NalHRD != nil
+	NalCpbRemovalDelays []SEIBufferingPeriod_CpbRemovalDelay
+
+	// SPS.VUI.VclHRD != nil
+	VclCpbRemovalDelays []SEIBufferingPeriod_CpbRemovalDelay
+}
+
+// Unmarshal decodes a SEIBufferingPeriod from a SEI message payload.
+// The SPS referenced by the payload is needed in order to decode it.
+func (b *SEIBufferingPeriod) Unmarshal(buf []byte, sps *SPS) error {
+	pos := 0
+
+	var err error
+	b.SeqParameterSetID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if b.SeqParameterSetID != sps.ID {
+		return fmt.Errorf("buffering period refers to SPS %d, but SPS %d was provided", b.SeqParameterSetID, sps.ID)
+	}
+
+	b.NalCpbRemovalDelays = nil
+	b.VclCpbRemovalDelays = nil
+
+	if sps.VUI == nil {
+		return nil
+	}
+
+	if sps.VUI.NalHRD != nil {
+		b.NalCpbRemovalDelays, err = readCpbRemovalDelays(buf, &pos, sps.VUI.NalHRD)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sps.VUI.VclHRD != nil {
+		b.VclCpbRemovalDelays, err = readCpbRemovalDelays(buf, &pos, sps.VUI.VclHRD)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
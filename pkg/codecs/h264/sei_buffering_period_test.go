@@ -0,0 +1,44 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSEIBufferingPeriodUnmarshal(t *testing.T) {
+	var bp SEIBufferingPeriod
+	err := bp.Unmarshal([]byte{
+		0x80, 0xaf, 0xc8, 0x00, 0x01, 0xf4, 0x00, 0x57,
+		0xe4, 0x00, 0x00, 0xfa, 0x40,
+	}, &casesSPS[8].sps)
+	require.NoError(t, err)
+	require.Equal(t, SEIBufferingPeriod{
+		NalCpbRemovalDelays: []SEIBufferingPeriod_CpbRemovalDelay{{
+			InitialCpbRemovalDelay:       90000,
+			InitialCpbRemovalDelayOffset: 1000,
+		}},
+		VclCpbRemovalDelays: []SEIBufferingPeriod_CpbRemovalDelay{{
+			InitialCpbRemovalDelay:       45000,
+			InitialCpbRemovalDelayOffset: 500,
+		}},
+	}, bp)
+}
+
+func TestSEIBufferingPeriodUnmarshalWrongSPS(t *testing.T) {
+	var bp SEIBufferingPeriod
+	err := bp.Unmarshal([]byte{0x40}, &casesSPS[8].sps)
+	require.EqualError(t, err, "buffering period refers to SPS 1, but SPS 0 was provided")
+}
+
+func FuzzSEIBufferingPeriodUnmarshal(f *testing.F) {
+	f.Add([]byte{
+		0x80, 0xaf, 0xc8, 0x00, 0x01, 0xf4, 0x00, 0x57,
+		0xe4, 0x00, 0x00, 0xfa, 0x40,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var bp SEIBufferingPeriod
+		bp.Unmarshal(b, &casesSPS[8].sps) //nolint:errcheck
+	})
+}
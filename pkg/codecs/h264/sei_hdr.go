@@ -0,0 +1,84 @@
+package h264
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SEIMasteringDisplayColourVolume is a mastering_display_colour_volume SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.29
+type SEIMasteringDisplayColourVolume struct {
+	// in units of 0.00002
+	DisplayPrimariesX [3]uint16
+	DisplayPrimariesY [3]uint16
+	WhitePointX       uint16
+	WhitePointY       uint16
+
+	// in units of 0.0001 candelas per square metre
+	MaxDisplayMasteringLuminance uint32
+	MinDisplayMasteringLuminance uint32
+}
+
+// Unmarshal decodes a SEIMasteringDisplayColourVolume from a SEI message payload.
+func (m *SEIMasteringDisplayColourVolume) Unmarshal(buf []byte) error {
+	if len(buf) < 24 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	for i := range 3 {
+		m.DisplayPrimariesX[i] = binary.BigEndian.Uint16(buf[i*4:])
+		m.DisplayPrimariesY[i] = binary.BigEndian.Uint16(buf[i*4+2:])
+	}
+
+	m.WhitePointX = binary.BigEndian.Uint16(buf[12:])
+	m.WhitePointY = binary.BigEndian.Uint16(buf[14:])
+	m.MaxDisplayMasteringLuminance = binary.BigEndian.Uint32(buf[16:])
+	m.MinDisplayMasteringLuminance = binary.BigEndian.Uint32(buf[20:])
+
+	return nil
+}
+
+// Marshal encodes a SEIMasteringDisplayColourVolume into a SEI message payload.
+func (m SEIMasteringDisplayColourVolume) Marshal() ([]byte, error) {
+	buf := make([]byte, 24)
+
+	for i := range 3 {
+		binary.BigEndian.PutUint16(buf[i*4:], m.DisplayPrimariesX[i])
+		binary.BigEndian.PutUint16(buf[i*4+2:], m.DisplayPrimariesY[i])
+	}
+
+	binary.BigEndian.PutUint16(buf[12:], m.WhitePointX)
+	binary.BigEndian.PutUint16(buf[14:], m.WhitePointY)
+	binary.BigEndian.PutUint32(buf[16:], m.MaxDisplayMasteringLuminance)
+	binary.BigEndian.PutUint32(buf[20:], m.MinDisplayMasteringLuminance)
+
+	return buf, nil
+}
+
+// SEIContentLightLevelInfo is a content_light_level_info SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.31
+type SEIContentLightLevelInfo struct {
+	// in units of candelas per square metre
+	MaxContentLightLevel    uint16
+	MaxPicAverageLightLevel uint16
+}
+
+// Unmarshal decodes a SEIContentLightLevelInfo from a SEI message payload.
+func (c *SEIContentLightLevelInfo) Unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	c.MaxContentLightLevel = binary.BigEndian.Uint16(buf)
+	c.MaxPicAverageLightLevel = binary.BigEndian.Uint16(buf[2:])
+
+	return nil
+}
+
+// Marshal encodes a SEIContentLightLevelInfo into a SEI message payload.
+func (c SEIContentLightLevelInfo) Marshal() ([]byte, error) {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint16(buf, c.MaxContentLightLevel)
+	binary.BigEndian.PutUint16(buf[2:], c.MaxPicAverageLightLevel)
+	return buf, nil
+}
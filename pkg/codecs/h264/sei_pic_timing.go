@@ -0,0 +1,192 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// number of clock timestamps for each pic_struct.
+// Specification: ITU-T Rec. H.264, Table D-1
+var numClockTS = [9]int{1, 1, 1, 2, 2, 3, 3, 2, 3}
+
+func readSigned(buf []byte, pos *int, n int) int32 {
+	v := bits.ReadBitsUnsafe(buf, pos, n)
+	if (v & (1 << (n - 1))) != 0 {
+		return int32(int64(v) - (1 << n))
+	}
+	return int32(v)
+}
+
+// SEIPicTiming_ClockTimestamp is a clock timestamp of a pic_timing SEI payload.
+type SEIPicTiming_ClockTimestamp struct { //nolint:revive
+	CtType             uint8
+	NuitFieldBasedFlag bool
+	CountingType       uint8
+	FullTimestampFlag  bool
+	DiscontinuityFlag  bool
+	CntDroppedFlag     bool
+	NFrames            uint8
+
+	// FullTimestampFlag == false
+	SecondsFlag bool
+	MinutesFlag bool
+	HoursFlag   bool
+
+	SecondsValue uint8
+	MinutesValue uint8
+	HoursValue   uint8
+	TimeOffset   int32
+}
+
+func (c *SEIPicTiming_ClockTimestamp) unmarshal(buf []byte, pos *int, timeOffsetLength int) error {
+	err := bits.HasSpace(buf, *pos, 2+1+5+1+1+1+8+1)
+	if err != nil {
+		return err
+	}
+
+	c.CtType = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	c.NuitFieldBasedFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.CountingType = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	c.FullTimestampFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.DiscontinuityFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.CntDroppedFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.NFrames = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+
+	if c.FullTimestampFlag {
+		err = bits.HasSpace(buf, *pos, 6+6+5)
+		if err != nil {
+			return err
+		}
+
+		c.SecondsValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+		c.MinutesValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+		c.HoursValue = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	} else {
+		c.SecondsFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if c.SecondsFlag {
+			err = bits.HasSpace(buf, *pos, 6+1)
+			if err != nil {
+				return err
+			}
+
+			c.SecondsValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+			c.MinutesFlag = bits.ReadFlagUnsafe(buf, pos)
+
+			if c.MinutesFlag {
+				err = bits.HasSpace(buf, *pos, 6+1)
+				if err != nil {
+					return err
+				}
+
+				c.MinutesValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+				c.HoursFlag = bits.ReadFlagUnsafe(buf, pos)
+
+				if c.HoursFlag {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, 5)
+					if err != nil {
+						return err
+					}
+					c.HoursValue = uint8(tmp)
+				}
+			}
+		}
+	}
+
+	if timeOffsetLength > 0 {
+		err = bits.HasSpace(buf, *pos, timeOffsetLength)
+		if err != nil {
+			return err
+		}
+
+		c.TimeOffset = readSigned(buf, pos, timeOffsetLength)
+	}
+
+	return nil
+}
+
+// SEIPicTiming is a pic_timing SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.3
+type SEIPicTiming struct {
+	// SPS.VUI.NalHRD != nil || SPS.VUI.VclHRD != nil
+	CpbRemovalDelay uint32
+	DpbOutputDelay  uint32
+
+	// SPS.VUI.PicStructPresentFlag == true
+	PicStruct uint8
+
+	// SPS.VUI.PicStructPresentFlag == true.
+	// It contains a nil entry when clock_timestamp_flag is false.
+	ClockTimestamps []*SEIPicTiming_ClockTimestamp
+}
+
+// Unmarshal decodes a SEIPicTiming from a SEI message payload.
+// The active SPS is needed in order to decode it.
+func (t *SEIPicTiming) Unmarshal(buf []byte, sps *SPS) error {
+	*t = SEIPicTiming{}
+
+	if sps.VUI == nil {
+		return nil
+	}
+
+	pos := 0
+
+	hrd := sps.VUI.NalHRD
+	if hrd == nil {
+		hrd = sps.VUI.VclHRD
+	}
+
+	timeOffsetLength := 24
+
+	if hrd != nil {
+		cpbRemovalDelayLength := int(hrd.CpbRemovalDelayLengthMinus1) + 1
+		dpbOutputDelayLength := int(hrd.DpbOutputDelayLengthMinus1) + 1
+
+		err := bits.HasSpace(buf, pos, cpbRemovalDelayLength+dpbOutputDelayLength)
+		if err != nil {
+			return err
+		}
+
+		t.CpbRemovalDelay = uint32(bits.ReadBitsUnsafe(buf, &pos, cpbRemovalDelayLength))
+		t.DpbOutputDelay = uint32(bits.ReadBitsUnsafe(buf, &pos, dpbOutputDelayLength))
+
+		timeOffsetLength = int(hrd.TimeOffsetLength)
+	}
+
+	if sps.VUI.PicStructPresentFlag {
+		tmp, err := bits.ReadBits(buf, &pos, 4)
+		if err != nil {
+			return err
+		}
+		t.PicStruct = uint8(tmp)
+
+		if t.PicStruct >= uint8(len(numClockTS)) {
+			return fmt.Errorf("invalid pic_struct: %d", t.PicStruct)
+		}
+
+		t.ClockTimestamps = make([]*SEIPicTiming_ClockTimestamp, numClockTS[t.PicStruct])
+
+		for i := range t.ClockTimestamps {
+			var clockTimestampFlag bool
+			clockTimestampFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if clockTimestampFlag {
+				t.ClockTimestamps[i] = &SEIPicTiming_ClockTimestamp{}
+				err = t.ClockTimestamps[i].unmarshal(buf, &pos, timeOffsetLength)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
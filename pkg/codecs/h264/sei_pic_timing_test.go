@@ -0,0 +1,88 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSEIPicTimingUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		sps  SPS
+		pt   SEIPicTiming
+	}{
+		{
+			"full timestamp",
+			[]byte{
+				0x00, 0x02, 0x10, 0x20, 0x10, 0x32, 0x2e, 0x14,
+				0x00, 0x00, 0x01,
+			},
+			casesSPS[7].sps,
+			SEIPicTiming{
+				CpbRemovalDelay: 2,
+				DpbOutputDelay:  4,
+				ClockTimestamps: []*SEIPicTiming_ClockTimestamp{{
+					FullTimestampFlag: true,
+					NFrames:           12,
+					SecondsValue:      34,
+					MinutesValue:      56,
+					HoursValue:        10,
+				}},
+			},
+		},
+		{
+			"partial timestamp",
+			[]byte{
+				0x00, 0x01, 0x08, 0xd6, 0x46, 0x07, 0x15, 0xff,
+				0xff, 0xfb,
+			},
+			casesSPS[7].sps,
+			SEIPicTiming{
+				CpbRemovalDelay: 1,
+				DpbOutputDelay:  2,
+				PicStruct:       3,
+				ClockTimestamps: []*SEIPicTiming_ClockTimestamp{
+					nil,
+					{
+						CtType:             1,
+						NuitFieldBasedFlag: true,
+						CountingType:       4,
+						DiscontinuityFlag:  true,
+						CntDroppedFlag:     true,
+						NFrames:            3,
+						SecondsFlag:        true,
+						SecondsValue:       5,
+						TimeOffset:         -3,
+					},
+				},
+			},
+		},
+		{
+			"no vui",
+			[]byte{},
+			casesSPS[0].sps,
+			SEIPicTiming{},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var pt SEIPicTiming
+			err := pt.Unmarshal(ca.byts, &ca.sps)
+			require.NoError(t, err)
+			require.Equal(t, ca.pt, pt)
+		})
+	}
+}
+
+func FuzzSEIPicTimingUnmarshal(f *testing.F) {
+	f.Add([]byte{
+		0x00, 0x02, 0x10, 0x20, 0x10, 0x32, 0x2e, 0x14,
+		0x00, 0x00, 0x01,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pt SEIPicTiming
+		pt.Unmarshal(b, &casesSPS[7].sps) //nolint:errcheck
+	})
+}
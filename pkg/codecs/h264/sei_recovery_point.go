@@ -0,0 +1,55 @@
+package h264
+
+import (
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// SEIRecoveryPoint is a recovery_point SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.8
+type SEIRecoveryPoint struct {
+	RecoveryFrameCnt      uint32
+	ExactMatchFlag        bool
+	BrokenLinkFlag        bool
+	ChangingSliceGroupIdc uint8
+}
+
+// Unmarshal decodes a SEIRecoveryPoint from a SEI message payload.
+func (r *SEIRecoveryPoint) Unmarshal(buf []byte) error {
+	pos := 0
+
+	var err error
+	r.RecoveryFrameCnt, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	r.ExactMatchFlag = bits.ReadFlagUnsafe(buf, &pos)
+	r.BrokenLinkFlag = bits.ReadFlagUnsafe(buf, &pos)
+	r.ChangingSliceGroupIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+
+	return nil
+}
+
+// Marshal encodes a SEIRecoveryPoint into a SEI message payload.
+func (r SEIRecoveryPoint) Marshal() ([]byte, error) {
+	n := bits.GolombUnsignedSize(r.RecoveryFrameCnt) + 4
+	buf := make([]byte, (n+7)/8)
+	pos := 0
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, r.RecoveryFrameCnt)
+	bits.WriteFlagUnsafe(buf, &pos, r.ExactMatchFlag)
+	bits.WriteFlagUnsafe(buf, &pos, r.BrokenLinkFlag)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(r.ChangingSliceGroupIdc), 2)
+
+	// payload byte alignment
+	if (pos % 8) != 0 {
+		bits.WriteFlagUnsafe(buf, &pos, true)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,251 @@
+package h264
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSEI = []struct {
+	name string
+	byts []byte
+	sei  SEI
+}{
+	{
+		"x264 user data unregistered",
+		[]byte{
+			0x06, 0x05, 0x1f, 0xdc, 0x45, 0xe9, 0xbd, 0xe6,
+			0xd9, 0x48, 0xb7, 0x96, 0x2c, 0xd8, 0x20, 0xd9,
+			0x23, 0xee, 0xef, 0x78, 0x32, 0x36, 0x34, 0x20,
+			0x2d, 0x20, 0x63, 0x6f, 0x72, 0x65, 0x20, 0x31,
+			0x36, 0x34, 0x80,
+		},
+		SEI{
+			Messages: []SEIMessage{{
+				PayloadType: SEIPayloadTypeUserDataUnregistered,
+				Payload: []byte{
+					0xdc, 0x45, 0xe9, 0xbd, 0xe6, 0xd9, 0x48, 0xb7,
+					0x96, 0x2c, 0xd8, 0x20, 0xd9, 0x23, 0xee, 0xef,
+					0x78, 0x32, 0x36, 0x34, 0x20, 0x2d, 0x20, 0x63,
+					0x6f, 0x72, 0x65, 0x20, 0x31, 0x36, 0x34,
+				},
+			}},
+		},
+	},
+	{
+		"recovery point + pic timing",
+		[]byte{
+			0x06, 0x06, 0x01, 0xc4, 0x01, 0x04, 0x00, 0x00,
+			0x03, 0x02, 0x10, 0x80,
+		},
+		SEI{
+			Messages: []SEIMessage{
+				{
+					PayloadType: SEIPayloadTypeRecoveryPoint,
+					Payload:     []byte{0xc4},
+				},
+				{
+					PayloadType: SEIPayloadTypePicTiming,
+					Payload:     []byte{0x00, 0x00, 0x02, 0x10},
+				},
+			},
+		},
+	},
+	{
+		"long payload",
+		append(append([]byte{
+			0x06, 0xff, 0x01, 0xff, 0x2d,
+		}, bytes.Repeat([]byte{0x01}, 300)...), 0x80),
+		SEI{
+			Messages: []SEIMessage{{
+				PayloadType: 256,
+				Payload:     bytes.Repeat([]byte{0x01}, 300),
+			}},
+		},
+	},
+}
+
+func TestSEIUnmarshal(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			var sei SEI
+			err := sei.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sei, sei)
+		})
+	}
+}
+
+func TestSEIMarshal(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.sei.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestSEIPayloadType(t *testing.T) {
+	require.Equal(t, "RecoveryPoint", SEIPayloadTypeRecoveryPoint.String())
+	require.Equal(t, "unknown (200)", SEIPayloadType(200).String())
+}
+
+func TestSEIUserDataUnregistered(t *testing.T) {
+	var u SEIUserDataUnregistered
+	err := u.Unmarshal(casesSEI[0].sei.Messages[0].Payload)
+	require.NoError(t, err)
+	require.Equal(t, SEIUserDataUnregistered{
+		UUID: [16]byte{
+			0xdc, 0x45, 0xe9, 0xbd, 0xe6, 0xd9, 0x48, 0xb7,
+			0x96, 0x2c, 0xd8, 0x20, 0xd9, 0x23, 0xee, 0xef,
+		},
+		Payload: []byte("x264 - core 164"),
+	}, u)
+
+	byts, err := u.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, casesSEI[0].sei.Messages[0].Payload, byts)
+}
+
+func TestSEIUserDataRegisteredITUTT35(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		u    SEIUserDataRegisteredITUTT35
+	}{
+		{
+			"atsc",
+			[]byte{0xb5, 0x00, 0x31, 0x47, 0x41, 0x39, 0x34},
+			SEIUserDataRegisteredITUTT35{
+				CountryCode: 0xb5,
+				Payload:     []byte{0x00, 0x31, 0x47, 0x41, 0x39, 0x34},
+			},
+		},
+		{
+			"extension",
+			[]byte{0xff, 0x12, 0x01, 0x02},
+			SEIUserDataRegisteredITUTT35{
+				CountryCode:          0xff,
+				CountryCodeExtension: 0x12,
+				Payload:              []byte{0x01, 0x02},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var u SEIUserDataRegisteredITUTT35
+			err := u.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.u, u)
+
+			byts, err := u.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestSEIRecoveryPoint(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		r    SEIRecoveryPoint
+	}{
+		{
+			"exact match",
+			[]byte{0xc4},
+			SEIRecoveryPoint{
+				ExactMatchFlag: true,
+			},
+		},
+		{
+			"frame count",
+			[]byte{0x05, 0x2b},
+			SEIRecoveryPoint{
+				RecoveryFrameCnt:      40,
+				BrokenLinkFlag:        true,
+				ChangingSliceGroupIdc: 1,
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var r SEIRecoveryPoint
+			err := r.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.r, r)
+
+			byts, err := r.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func FuzzSEIUnmarshal(f *testing.F) {
+	for _, ca := range casesSEI {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var sei SEI
+		err := sei.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		byts, err := sei.Marshal()
+		require.NoError(t, err)
+
+		var sei2 SEI
+		err = sei2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, sei, sei2)
+	})
+}
+
+func TestSEIMasteringDisplayColourVolume(t *testing.T) {
+	byts := []byte{
+		0x33, 0xc2, 0x86, 0xc4, 0x1d, 0x4c, 0x0b, 0xb8,
+		0x84, 0xd0, 0x3e, 0x80, 0x3d, 0x13, 0x40, 0x42,
+		0x00, 0x98, 0x96, 0x80, 0x00, 0x00, 0x00, 0x32,
+	}
+
+	var m SEIMasteringDisplayColourVolume
+	err := m.Unmarshal(byts)
+	require.NoError(t, err)
+	require.Equal(t, SEIMasteringDisplayColourVolume{
+		DisplayPrimariesX:            [3]uint16{13250, 7500, 34000},
+		DisplayPrimariesY:            [3]uint16{34500, 3000, 16000},
+		WhitePointX:                  15635,
+		WhitePointY:                  16450,
+		MaxDisplayMasteringLuminance: 10000000,
+		MinDisplayMasteringLuminance: 50,
+	}, m)
+
+	byts2, err := m.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, byts, byts2)
+
+	err = m.Unmarshal(byts[:23])
+	require.EqualError(t, err, "not enough bytes")
+}
+
+func TestSEIContentLightLevelInfo(t *testing.T) {
+	byts := []byte{0x03, 0xe8, 0x01, 0x90}
+
+	var c SEIContentLightLevelInfo
+	err := c.Unmarshal(byts)
+	require.NoError(t, err)
+	require.Equal(t, SEIContentLightLevelInfo{
+		MaxContentLightLevel:    1000,
+		MaxPicAverageLightLevel: 400,
+	}, c)
+
+	byts2, err := c.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, byts, byts2)
+
+	err = c.Unmarshal(byts[:3])
+	require.EqualError(t, err, "not enough bytes")
+}
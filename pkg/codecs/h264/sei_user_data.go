@@ -0,0 +1,73 @@
+package h264
+
+import (
+	"fmt"
+)
+
+// SEIUserDataRegisteredITUTT35 is a user_data_registered_itu_t_t35 SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.6
+type SEIUserDataRegisteredITUTT35 struct {
+	CountryCode uint8
+
+	// CountryCode == 0xFF
+	CountryCodeExtension uint8
+
+	Payload []byte
+}
+
+// Unmarshal decodes a SEIUserDataRegisteredITUTT35 from a SEI message payload.
+func (u *SEIUserDataRegisteredITUTT35) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	u.CountryCode = buf[0]
+	buf = buf[1:]
+
+	if u.CountryCode == 0xFF {
+		if len(buf) < 1 {
+			return fmt.Errorf("not enough bits")
+		}
+
+		u.CountryCodeExtension = buf[0]
+		buf = buf[1:]
+	} else {
+		u.CountryCodeExtension = 0
+	}
+
+	u.Payload = buf
+
+	return nil
+}
+
+// Marshal encodes a SEIUserDataRegisteredITUTT35 into a SEI message payload.
+func (u SEIUserDataRegisteredITUTT35) Marshal() ([]byte, error) {
+	if u.CountryCode == 0xFF {
+		return append([]byte{u.CountryCode, u.CountryCodeExtension}, u.Payload...), nil
+	}
+	return append([]byte{u.CountryCode}, u.Payload...), nil
+}
+
+// SEIUserDataUnregistered is a user_data_unregistered SEI payload.
+// Specification: ITU-T Rec. H.264, D.1.7
+type SEIUserDataUnregistered struct {
+	UUID    [16]byte
+	Payload []byte
+}
+
+// Unmarshal decodes a SEIUserDataUnregistered from a SEI message payload.
+func (u *SEIUserDataUnregistered) Unmarshal(buf []byte) error {
+	if len(buf) < 16 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	copy(u.UUID[:], buf[:16])
+	u.Payload = buf[16:]
+
+	return nil
+}
+
+// Marshal encodes a SEIUserDataUnregistered into a SEI message payload.
+func (u SEIUserDataUnregistered) Marshal() ([]byte, error) {
+	return append(u.UUID[:], u.Payload...), nil
+}
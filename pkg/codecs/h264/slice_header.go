@@ -0,0 +1,710 @@
+package h264
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+const (
+	maxRefIdxActive         = 32
+	maxRefPicListOperations = 64
+	maxMemoryManagementOps  = 64
+)
+
+// SliceType is a slice type.
+// Specification: ITU-T Rec. H.264, Table 7-6
+type SliceType uint32
+
+// slice types.
+const (
+	SliceTypeP  SliceType = 0
+	SliceTypeB  SliceType = 1
+	SliceTypeI  SliceType = 2
+	SliceTypeSP SliceType = 3
+	SliceTypeSI SliceType = 4
+)
+
+// String implements fmt.Stringer.
+func (t SliceType) String() string {
+	switch t {
+	case SliceTypeP:
+		return "P"
+	case SliceTypeB:
+		return "B"
+	case SliceTypeI:
+		return "I"
+	case SliceTypeSP:
+		return "SP"
+	case SliceTypeSI:
+		return "SI"
+	}
+	return fmt.Sprintf("unknown (%d)", uint32(t))
+}
+
+// SliceHeader_RefPicListModificationOperation is an operation of a reference picture list modification.
+type SliceHeader_RefPicListModificationOperation struct { //nolint:revive
+	ModificationOfPicNumsIdc uint32
+
+	// ModificationOfPicNumsIdc == 0 || ModificationOfPicNumsIdc == 1
+	AbsDiffPicNumMinus1 uint32
+
+	// ModificationOfPicNumsIdc == 2
+	LongTermPicNum uint32
+}
+
+func readRefPicListModificationOperations(
+	buf []byte,
+	pos *int,
+) ([]SliceHeader_RefPicListModificationOperation, error) {
+	var ops []SliceHeader_RefPicListModificationOperation
+
+	for {
+		idc, err := bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if idc == 3 {
+			return ops, nil
+		}
+
+		if len(ops) >= maxRefPicListOperations {
+			return nil, fmt.Errorf("modification operations exceed %d", maxRefPicListOperations)
+		}
+
+		op := SliceHeader_RefPicListModificationOperation{
+			ModificationOfPicNumsIdc: idc,
+		}
+
+		switch idc {
+		case 0, 1:
+			op.AbsDiffPicNumMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return nil, err
+			}
+
+		case 2:
+			op.LongTermPicNum, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid modification_of_pic_nums_idc: %d", idc)
+		}
+
+		ops = append(ops, op)
+	}
+}
+
+// SliceHeader_RefPicListModification is the reference picture list modification of a slice header.
+type SliceHeader_RefPicListModification struct { //nolint:revive
+	RefPicListModificationFlagL0 bool
+	OperationsL0                 []SliceHeader_RefPicListModificationOperation
+	RefPicListModificationFlagL1 bool
+	OperationsL1                 []SliceHeader_RefPicListModificationOperation
+}
+
+func (m *SliceHeader_RefPicListModification) unmarshal(buf []byte, pos *int, sliceType SliceType) error {
+	var err error
+
+	if sliceType != SliceTypeI && sliceType != SliceTypeSI {
+		m.RefPicListModificationFlagL0, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if m.RefPicListModificationFlagL0 {
+			m.OperationsL0, err = readRefPicListModificationOperations(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if sliceType == SliceTypeB {
+		m.RefPicListModificationFlagL1, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if m.RefPicListModificationFlagL1 {
+			m.OperationsL1, err = readRefPicListModificationOperations(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SliceHeader_PredWeight contains the prediction weights of a reference picture.
+type SliceHeader_PredWeight struct { //nolint:revive
+	LumaWeightFlag bool
+
+	// LumaWeightFlag == true
+	LumaWeight int32
+	LumaOffset int32
+
+	ChromaWeightFlag bool
+
+	// ChromaWeightFlag == true
+	ChromaWeight [2]int32
+	ChromaOffset [2]int32
+}
+
+func (w *SliceHeader_PredWeight) unmarshal(buf []byte, pos *int, chromaArrayType uint32) error {
+	var err error
+	w.LumaWeightFlag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if w.LumaWeightFlag {
+		w.LumaWeight, err = bits.ReadGolombSigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		w.LumaOffset, err = bits.ReadGolombSigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if chromaArrayType != 0 {
+		w.ChromaWeightFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if w.ChromaWeightFlag {
+			for j := range 2 {
+				w.ChromaWeight[j], err = bits.ReadGolombSigned(buf, pos)
+				if err != nil {
+					return err
+				}
+
+				w.ChromaOffset[j], err = bits.ReadGolombSigned(buf, pos)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// SliceHeader_PredWeightTable is the prediction weight table of a slice header.
+type SliceHeader_PredWeightTable struct { //nolint:revive
+	LumaLog2WeightDenom   uint32
+	ChromaLog2WeightDenom uint32
+	L0                    []SliceHeader_PredWeight
+	L1                    []SliceHeader_PredWeight
+}
+
+func (t *SliceHeader_PredWeightTable) unmarshal(
+	buf []byte,
+	pos *int,
+	h *SliceHeader,
+	chromaArrayType uint32,
+) error {
+	var err error
+	t.LumaLog2WeightDenom, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if t.LumaLog2WeightDenom > 7 {
+		return fmt.Errorf("invalid luma_log2_weight_denom: %d", t.LumaLog2WeightDenom)
+	}
+
+	if chromaArrayType != 0 {
+		t.ChromaLog2WeightDenom, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if t.ChromaLog2WeightDenom > 7 {
+			return fmt.Errorf("invalid chroma_log2_weight_denom: %d", t.ChromaLog2WeightDenom)
+		}
+	}
+
+	t.L0 = make([]SliceHeader_PredWeight, h.NumRefIdxL0ActiveMinus1+1)
+
+	for i := range t.L0 {
+		err = t.L0[i].unmarshal(buf, pos, chromaArrayType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if h.SliceType == SliceTypeB {
+		t.L1 = make([]SliceHeader_PredWeight, h.NumRefIdxL1ActiveMinus1+1)
+
+		for i := range t.L1 {
+			err = t.L1[i].unmarshal(buf, pos, chromaArrayType)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SliceHeader_MemoryManagementControlOperation is a memory management control operation.
+type SliceHeader_MemoryManagementControlOperation struct { //nolint:revive
+	MemoryManagementControlOperation uint32
+
+	// MemoryManagementControlOperation == 1 || MemoryManagementControlOperation == 3
+	DifferenceOfPicNumsMinus1 uint32
+
+	// MemoryManagementControlOperation == 2
+	LongTermPicNum uint32
+
+	// MemoryManagementControlOperation == 3 || MemoryManagementControlOperation == 6
+	LongTermFrameIdx uint32
+
+	// MemoryManagementControlOperation == 4
+	MaxLongTermFrameIdxPlus1 uint32
+}
+
+// SliceHeader_DecRefPicMarking is the decoded reference picture marking of a slice header.
+type SliceHeader_DecRefPicMarking struct { //nolint:revive
+	// IDRPicFlag == true
+	NoOutputOfPriorPicsFlag bool
+	LongTermReferenceFlag   bool
+
+	// IDRPicFlag == false
+	AdaptiveRefPicMarkingModeFlag bool
+	Operations                    []SliceHeader_MemoryManagementControlOperation
+}
+
+func (m *SliceHeader_DecRefPicMarking) unmarshal(buf []byte, pos *int, idrPicFlag bool) error {
+	var err error
+
+	if idrPicFlag {
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		m.NoOutputOfPriorPicsFlag = bits.ReadFlagUnsafe(buf, pos)
+		m.LongTermReferenceFlag = bits.ReadFlagUnsafe(buf, pos)
+		return nil
+	}
+
+	m.AdaptiveRefPicMarkingModeFlag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !m.AdaptiveRefPicMarkingModeFlag {
+		return nil
+	}
+
+	for {
+		var mmco uint32
+		mmco, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if mmco == 0 {
+			return nil
+		}
+
+		if len(m.Operations) >= maxMemoryManagementOps {
+			return fmt.Errorf("memory management control operations exceed %d", maxMemoryManagementOps)
+		}
+
+		op := SliceHeader_MemoryManagementControlOperation{
+			MemoryManagementControlOperation: mmco,
+		}
+
+		switch mmco {
+		case 1:
+			op.DifferenceOfPicNumsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+		case 2:
+			op.LongTermPicNum, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+		case 3:
+			op.DifferenceOfPicNumsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			op.LongTermFrameIdx, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+		case 4:
+			op.MaxLongTermFrameIdxPlus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+		case 5:
+
+		case 6:
+			op.LongTermFrameIdx, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("invalid memory_management_control_operation: %d", mmco)
+		}
+
+		m.Operations = append(m.Operations, op)
+	}
+}
+
+// SliceHeader is a H264 slice header.
+// Specification: ITU-T Rec. H.264, 7.3.3
+type SliceHeader struct {
+	// derived from the NALU header
+	NalRefIdc  uint8
+	IDRPicFlag bool
+
+	FirstMbInSlice uint32
+
+	// slice_type % 5
+	SliceType SliceType
+
+	PPSID uint32
+
+	// SeparateColourPlaneFlag == true
+	ColourPlaneID uint8
+
+	FrameNum uint32
+
+	// FrameMbsOnlyFlag == false
+	FieldPicFlag bool
+
+	// FieldPicFlag == true
+	BottomFieldFlag bool
+
+	// IDRPicFlag == true
+	IDRPicID uint32
+
+	// PicOrderCntType == 0
+	PicOrderCntLsb         uint32
+	DeltaPicOrderCntBottom int32
+
+	// PicOrderCntType == 1 && DeltaPicOrderAlwaysZeroFlag == false
+	DeltaPicOrderCnt [2]int32
+
+	// RedundantPicCntPresentFlag == true
+	RedundantPicCnt uint32
+
+	// SliceType == SliceTypeB
+	DirectSpatialMvPredFlag bool
+
+	// SliceType == SliceTypeP || SliceType == SliceTypeSP || SliceType == SliceTypeB
+	NumRefIdxActiveOverrideFlag bool
+
+	// equal to the PPS default values when not overridden
+	NumRefIdxL0ActiveMinus1 uint32
+	NumRefIdxL1ActiveMinus1 uint32
+
+	RefPicListModification SliceHeader_RefPicListModification
+
+	// WeightedPredFlag == true && (SliceType == SliceTypeP || SliceType == SliceTypeSP) ||
+	// WeightedBipredIdc == 1 && SliceType == SliceTypeB
+	PredWeightTable *SliceHeader_PredWeightTable
+
+	// NalRefIdc != 0
+	DecRefPicMarking *SliceHeader_DecRefPicMarking
+
+	// EntropyCodingModeFlag == true && SliceType != SliceTypeI && SliceType != SliceTypeSI
+	CabacInitIdc uint32
+
+	SliceQPDelta int32
+
+	// SliceType == SliceTypeSP
+	SPForSwitchFlag bool
+
+	// SliceType == SliceTypeSP || SliceType == SliceTypeSI
+	SliceQSDelta int32
+
+	// DeblockingFilterControlPresentFlag == true
+	DisableDeblockingFilterIdc uint32
+	SliceAlphaC0OffsetDiv2     int32
+	SliceBetaOffsetDiv2        int32
+
+	// NumSliceGroupsMinus1 > 0 && SliceGroupMapType >= 3 && SliceGroupMapType <= 5
+	SliceGroupChangeCycle uint32
+}
+
+// Unmarshal decodes a SliceHeader from a VCL NALU, using the active SPS and PPS.
+func (h *SliceHeader) Unmarshal(buf []byte, sps *SPS, pps *PPS) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	typ := NALUType(buf[0] & 0x1F)
+
+	switch typ {
+	case NALUTypeNonIDR, NALUTypeDataPartitionA, NALUTypeIDR:
+
+	case NALUTypeSliceExtension, NALUTypeSliceExtensionDepth:
+		return fmt.Errorf("slice extensions are not supported")
+
+	default:
+		return fmt.Errorf("not a slice")
+	}
+
+	*h = SliceHeader{
+		NalRefIdc:  (buf[0] >> 5) & 0x03,
+		IDRPicFlag: typ == NALUTypeIDR,
+	}
+
+	buf = EmulationPreventionRemove(buf[1:])
+	pos := 0
+
+	var err error
+	h.FirstMbInSlice, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if tmp > 9 {
+		return fmt.Errorf("invalid slice_type: %d", tmp)
+	}
+	h.SliceType = SliceType(tmp % 5)
+
+	h.PPSID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if h.PPSID != pps.ID {
+		return fmt.Errorf("slice refers to PPS %d, but PPS %d was provided", h.PPSID, pps.ID)
+	}
+
+	if pps.SPSID != sps.ID {
+		return fmt.Errorf("PPS refers to SPS %d, but SPS %d was provided", pps.SPSID, sps.ID)
+	}
+
+	if sps.SeparateColourPlaneFlag {
+		var tmp2 uint64
+		tmp2, err = bits.ReadBits(buf, &pos, 2)
+		if err != nil {
+			return err
+		}
+		h.ColourPlaneID = uint8(tmp2)
+	}
+
+	tmp2, err := bits.ReadBits(buf, &pos, int(sps.Log2MaxFrameNumMinus4+4))
+	if err != nil {
+		return err
+	}
+	h.FrameNum = uint32(tmp2)
+
+	if !sps.FrameMbsOnlyFlag {
+		h.FieldPicFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.FieldPicFlag {
+			h.BottomFieldFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if h.IDRPicFlag {
+		h.IDRPicID, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case sps.PicOrderCntType == 0:
+		tmp2, err = bits.ReadBits(buf, &pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+		if err != nil {
+			return err
+		}
+		h.PicOrderCntLsb = uint32(tmp2)
+
+		if pps.BottomFieldPicOrderInFramePresentFlag && !h.FieldPicFlag {
+			h.DeltaPicOrderCntBottom, err = bits.ReadGolombSigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+	case sps.PicOrderCntType == 1 && !sps.DeltaPicOrderAlwaysZeroFlag:
+		h.DeltaPicOrderCnt[0], err = bits.ReadGolombSigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if pps.BottomFieldPicOrderInFramePresentFlag && !h.FieldPicFlag {
+			h.DeltaPicOrderCnt[1], err = bits.ReadGolombSigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if pps.RedundantPicCntPresentFlag {
+		h.RedundantPicCnt, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if h.SliceType == SliceTypeB {
+		h.DirectSpatialMvPredFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if h.SliceType == SliceTypeP || h.SliceType == SliceTypeSP || h.SliceType == SliceTypeB {
+		h.NumRefIdxActiveOverrideFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.NumRefIdxActiveOverrideFlag {
+			h.NumRefIdxL0ActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if h.SliceType == SliceTypeB {
+				h.NumRefIdxL1ActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			h.NumRefIdxL0ActiveMinus1 = pps.NumRefIdxL0DefaultActiveMinus1
+			if h.SliceType == SliceTypeB {
+				h.NumRefIdxL1ActiveMinus1 = pps.NumRefIdxL1DefaultActiveMinus1
+			}
+		}
+
+		if h.NumRefIdxL0ActiveMinus1 >= maxRefIdxActive || h.NumRefIdxL1ActiveMinus1 >= maxRefIdxActive {
+			return fmt.Errorf("invalid num_ref_idx_active_minus1")
+		}
+	}
+
+	err = h.RefPicListModification.unmarshal(buf, &pos, h.SliceType)
+	if err != nil {
+		return err
+	}
+
+	chromaArrayType := sps.ChromaFormatIdc
+	if sps.SeparateColourPlaneFlag {
+		chromaArrayType = 0
+	}
+
+	if (pps.WeightedPredFlag && (h.SliceType == SliceTypeP || h.SliceType == SliceTypeSP)) ||
+		(pps.WeightedBipredIdc == 1 && h.SliceType == SliceTypeB) {
+		h.PredWeightTable = &SliceHeader_PredWeightTable{}
+		err = h.PredWeightTable.unmarshal(buf, &pos, h, chromaArrayType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if h.NalRefIdc != 0 {
+		h.DecRefPicMarking = &SliceHeader_DecRefPicMarking{}
+		err = h.DecRefPicMarking.unmarshal(buf, &pos, h.IDRPicFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pps.EntropyCodingModeFlag && h.SliceType != SliceTypeI && h.SliceType != SliceTypeSI {
+		h.CabacInitIdc, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.CabacInitIdc > 2 {
+			return fmt.Errorf("invalid cabac_init_idc: %d", h.CabacInitIdc)
+		}
+	}
+
+	h.SliceQPDelta, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if h.SliceType == SliceTypeSP || h.SliceType == SliceTypeSI {
+		if h.SliceType == SliceTypeSP {
+			h.SPForSwitchFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		h.SliceQSDelta, err = bits.ReadGolombSigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pps.DeblockingFilterControlPresentFlag {
+		h.DisableDeblockingFilterIdc, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.DisableDeblockingFilterIdc > 2 {
+			return fmt.Errorf("invalid disable_deblocking_filter_idc: %d", h.DisableDeblockingFilterIdc)
+		}
+
+		if h.DisableDeblockingFilterIdc != 1 {
+			h.SliceAlphaC0OffsetDiv2, err = bits.ReadGolombSigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			h.SliceBetaOffsetDiv2, err = bits.ReadGolombSigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if pps.SliceGroups != nil && pps.SliceGroups.NumSliceGroupsMinus1 > 0 &&
+		pps.SliceGroups.SliceGroupMapType >= 3 && pps.SliceGroups.SliceGroupMapType <= 5 {
+		picSizeInMapUnits := (sps.PicWidthInMbsMinus1 + 1) * (sps.PicHeightInMapUnitsMinus1 + 1)
+		sliceGroupChangeRate := pps.SliceGroups.SliceGroupChangeRateMinus1 + 1
+		n := ceilLog2((picSizeInMapUnits+sliceGroupChangeRate-1)/sliceGroupChangeRate + 1)
+
+		tmp2, err = bits.ReadBits(buf, &pos, n)
+		if err != nil {
+			return err
+		}
+		h.SliceGroupChangeCycle = uint32(tmp2)
+	}
+
+	return nil
+}
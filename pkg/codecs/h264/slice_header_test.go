@@ -0,0 +1,167 @@
+package h264
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSliceHeader = []struct {
+	name  string
+	sps   SPS
+	pps   PPS
+	byts  []byte
+	slice SliceHeader
+}{
+	{
+		"idr",
+		SPS{
+			ChromaFormatIdc:             1,
+			Log2MaxPicOrderCntLsbMinus4: 2,
+			FrameMbsOnlyFlag:            true,
+		},
+		PPS{
+			EntropyCodingModeFlag:              true,
+			DeblockingFilterControlPresentFlag: true,
+		},
+		[]byte{
+			0x65, 0x88, 0x84, 0x04, 0xfe,
+		},
+		SliceHeader{
+			NalRefIdc:  3,
+			IDRPicFlag: true,
+			SliceType:  SliceTypeI,
+			DecRefPicMarking: &SliceHeader_DecRefPicMarking{
+				LongTermReferenceFlag: true,
+			},
+			SliceQPDelta: -3,
+		},
+	},
+	{
+		"p with weights and memory management",
+		SPS{
+			ChromaFormatIdc:             1,
+			Log2MaxPicOrderCntLsbMinus4: 2,
+			FrameMbsOnlyFlag:            true,
+		},
+		PPS{
+			EntropyCodingModeFlag:              true,
+			WeightedPredFlag:                   true,
+			DeblockingFilterControlPresentFlag: true,
+		},
+		[]byte{
+			0x41, 0x9a, 0x22, 0x57, 0x68, 0x87, 0x3c, 0x04,
+			0x61, 0x48, 0x1e, 0x10, 0x08, 0xc7, 0x53, 0xe8,
+			0x8a,
+		},
+		SliceHeader{
+			NalRefIdc:                   2,
+			SliceType:                   SliceTypeP,
+			FrameNum:                    1,
+			PicOrderCntLsb:              4,
+			NumRefIdxActiveOverrideFlag: true,
+			NumRefIdxL0ActiveMinus1:     1,
+			RefPicListModification: SliceHeader_RefPicListModification{
+				RefPicListModificationFlagL0: true,
+				OperationsL0: []SliceHeader_RefPicListModificationOperation{
+					{
+						ModificationOfPicNumsIdc: 0,
+						AbsDiffPicNumMinus1:      0,
+					},
+					{
+						ModificationOfPicNumsIdc: 2,
+						LongTermPicNum:           1,
+					},
+				},
+			},
+			PredWeightTable: &SliceHeader_PredWeightTable{
+				LumaLog2WeightDenom:   6,
+				ChromaLog2WeightDenom: 6,
+				L0: []SliceHeader_PredWeight{
+					{
+						LumaWeightFlag: true,
+						LumaWeight:     70,
+						LumaOffset:     -2,
+					},
+					{
+						ChromaWeightFlag: true,
+						ChromaWeight:     [2]int32{60, 70},
+						ChromaOffset:     [2]int32{1, -1},
+					},
+				},
+			},
+			DecRefPicMarking: &SliceHeader_DecRefPicMarking{
+				AdaptiveRefPicMarkingModeFlag: true,
+				Operations: []SliceHeader_MemoryManagementControlOperation{
+					{
+						MemoryManagementControlOperation: 1,
+					},
+					{
+						MemoryManagementControlOperation: 6,
+					},
+				},
+			},
+			CabacInitIdc:               1,
+			SliceQPDelta:               2,
+			DisableDeblockingFilterIdc: 1,
+		},
+	},
+	{
+		"b non-reference with slice groups",
+		SPS{
+			ChromaFormatIdc:           1,
+			PicOrderCntType:           1,
+			PicWidthInMbsMinus1:       19,
+			PicHeightInMapUnitsMinus1: 14,
+		},
+		PPS{
+			ID:                                    1,
+			BottomFieldPicOrderInFramePresentFlag: true,
+			SliceGroups: &PPS_SliceGroups{
+				NumSliceGroupsMinus1:       1,
+				SliceGroupMapType:          4,
+				SliceGroupChangeRateMinus1: 9,
+			},
+			NumRefIdxL0DefaultActiveMinus1: 2,
+			RedundantPicCntPresentFlag:     true,
+		},
+		[]byte{
+			0x01, 0x16, 0x91, 0x0a, 0x94, 0x4f,
+		},
+		SliceHeader{
+			FirstMbInSlice:          10,
+			SliceType:               SliceTypeB,
+			PPSID:                   1,
+			FrameNum:                2,
+			DeltaPicOrderCnt:        [2]int32{-2, 1},
+			RedundantPicCnt:         1,
+			DirectSpatialMvPredFlag: true,
+			NumRefIdxL0ActiveMinus1: 2,
+			SliceGroupChangeCycle:   7,
+		},
+	},
+}
+
+func TestSliceHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesSliceHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h SliceHeader
+			err := h.Unmarshal(ca.byts, &ca.sps, &ca.pps)
+			require.NoError(t, err)
+			require.Equal(t, ca.slice, h)
+		})
+	}
+}
+
+func FuzzSliceHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesSliceHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		for _, ca := range casesSliceHeader {
+			var h SliceHeader
+			h.Unmarshal(b, &ca.sps, &ca.pps) //nolint:errcheck
+		}
+	})
+}
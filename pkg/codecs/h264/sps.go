@@ -23,6 +23,10 @@ func readScalingList(buf []byte, pos *int, size int) ([]int32, bool, error) {
 				return nil, false, err
 			}
 
+			if deltaScale < -128 || deltaScale > 127 {
+				return nil, false, fmt.Errorf("invalid delta_scale: %d", deltaScale)
+			}
+
 			nextScale = (lastScale + deltaScale + 256) % 256
 			useDefaultScalingMatrixFlag = (j == 0 && nextScale == 0)
 		}
@@ -106,6 +110,44 @@ func (h *SPS_HRD) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+func (h SPS_HRD) marshalSizeBits() int {
+	n := bits.GolombUnsignedSize(h.CpbCntMinus1) + 8
+
+	for i := range h.BitRateValueMinus1 {
+		n += bits.GolombUnsignedSize(h.BitRateValueMinus1[i]) +
+			bits.GolombUnsignedSize(h.CpbSizeValueMinus1[i]) +
+			1
+	}
+
+	return n + 5 + 5 + 5 + 5
+}
+
+func (h SPS_HRD) marshalTo(buf []byte, pos *int) error {
+	if h.CpbCntMinus1 > 31 ||
+		len(h.BitRateValueMinus1) != int(h.CpbCntMinus1+1) ||
+		len(h.CpbSizeValueMinus1) != int(h.CpbCntMinus1+1) ||
+		len(h.CbrFlag) != int(h.CpbCntMinus1+1) {
+		return fmt.Errorf("invalid cpb_cnt_minus1")
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, pos, h.CpbCntMinus1)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.BitRateScale), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.CpbSizeScale), 4)
+
+	for i := range h.BitRateValueMinus1 {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, h.BitRateValueMinus1[i])
+		bits.WriteGolombUnsignedUnsafe(buf, pos, h.CpbSizeValueMinus1[i])
+		bits.WriteFlagUnsafe(buf, pos, h.CbrFlag[i])
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.InitialCpbRemovalDelayLengthMinus1), 5)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.CpbRemovalDelayLengthMinus1), 5)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.DpbOutputDelayLengthMinus1), 5)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.TimeOffsetLength), 5)
+
+	return nil
+}
+
 // SPS_TimingInfo is a timing info.
 type SPS_TimingInfo struct { //nolint:revive
 	NumUnitsInTick     uint32
@@ -126,6 +168,12 @@ func (t *SPS_TimingInfo) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+func (t SPS_TimingInfo) marshalTo(buf []byte, pos *int) {
+	bits.WriteBitsUnsafe(buf, pos, uint64(t.NumUnitsInTick), 32)
+	bits.WriteBitsUnsafe(buf, pos, uint64(t.TimeScale), 32)
+	bits.WriteFlagUnsafe(buf, pos, t.FixedFrameRateFlag)
+}
+
 // SPS_BitstreamRestriction are bitstream restriction infos.
 type SPS_BitstreamRestriction struct { //nolint:revive
 	MotionVectorsOverPicBoundariesFlag bool
@@ -177,6 +225,26 @@ func (r *SPS_BitstreamRestriction) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+func (r SPS_BitstreamRestriction) marshalSizeBits() int {
+	return 1 +
+		bits.GolombUnsignedSize(r.MaxBytesPerPicDenom) +
+		bits.GolombUnsignedSize(r.MaxBitsPerMbDenom) +
+		bits.GolombUnsignedSize(r.Log2MaxMvLengthHorizontal) +
+		bits.GolombUnsignedSize(r.Log2MaxMvLengthVertical) +
+		bits.GolombUnsignedSize(r.MaxNumReorderFrames) +
+		bits.GolombUnsignedSize(r.MaxDecFrameBuffering)
+}
+
+func (r SPS_BitstreamRestriction) marshalTo(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, r.MotionVectorsOverPicBoundariesFlag)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MaxBytesPerPicDenom)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MaxBitsPerMbDenom)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.Log2MaxMvLengthHorizontal)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.Log2MaxMvLengthVertical)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MaxNumReorderFrames)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MaxDecFrameBuffering)
+}
+
 // SPS_VUI is a video usability information.
 type SPS_VUI struct { //nolint:revive
 	AspectRatioInfoPresentFlag bool
@@ -366,6 +434,148 @@ func (v *SPS_VUI) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+func (v SPS_VUI) marshalSizeBits() int {
+	n := 1
+
+	if v.AspectRatioInfoPresentFlag {
+		n += 8
+
+		if v.AspectRatioIdc == 255 {
+			n += 32
+		}
+	}
+
+	n++
+
+	if v.OverscanInfoPresentFlag {
+		n++
+	}
+
+	n++
+
+	if v.VideoSignalTypePresentFlag {
+		n += 5
+
+		if v.ColourDescriptionPresentFlag {
+			n += 24
+		}
+	}
+
+	n++
+
+	if v.ChromaLocInfoPresentFlag {
+		n += bits.GolombUnsignedSize(v.ChromaSampleLocTypeTopField) +
+			bits.GolombUnsignedSize(v.ChromaSampleLocTypeBottomField)
+	}
+
+	n++
+
+	if v.TimingInfo != nil {
+		n += 32 + 32 + 1
+	}
+
+	n++
+
+	if v.NalHRD != nil {
+		n += v.NalHRD.marshalSizeBits()
+	}
+
+	n++
+
+	if v.VclHRD != nil {
+		n += v.VclHRD.marshalSizeBits()
+	}
+
+	if v.NalHRD != nil || v.VclHRD != nil {
+		n++
+	}
+
+	n += 2
+
+	if v.BitstreamRestriction != nil {
+		n += v.BitstreamRestriction.marshalSizeBits()
+	}
+
+	return n
+}
+
+func (v SPS_VUI) marshalTo(buf []byte, pos *int) error {
+	bits.WriteFlagUnsafe(buf, pos, v.AspectRatioInfoPresentFlag)
+
+	if v.AspectRatioInfoPresentFlag {
+		bits.WriteBitsUnsafe(buf, pos, uint64(v.AspectRatioIdc), 8)
+
+		if v.AspectRatioIdc == 255 { // Extended_SAR
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.SarWidth), 16)
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.SarHeight), 16)
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.OverscanInfoPresentFlag)
+
+	if v.OverscanInfoPresentFlag {
+		bits.WriteFlagUnsafe(buf, pos, v.OverscanAppropriateFlag)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.VideoSignalTypePresentFlag)
+
+	if v.VideoSignalTypePresentFlag {
+		bits.WriteBitsUnsafe(buf, pos, uint64(v.VideoFormat), 3)
+		bits.WriteFlagUnsafe(buf, pos, v.VideoFullRangeFlag)
+		bits.WriteFlagUnsafe(buf, pos, v.ColourDescriptionPresentFlag)
+
+		if v.ColourDescriptionPresentFlag {
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.ColourPrimaries), 8)
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.TransferCharacteristics), 8)
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.MatrixCoefficients), 8)
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.ChromaLocInfoPresentFlag)
+
+	if v.ChromaLocInfoPresentFlag {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, v.ChromaSampleLocTypeTopField)
+		bits.WriteGolombUnsignedUnsafe(buf, pos, v.ChromaSampleLocTypeBottomField)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.TimingInfo != nil)
+
+	if v.TimingInfo != nil {
+		v.TimingInfo.marshalTo(buf, pos)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.NalHRD != nil)
+
+	if v.NalHRD != nil {
+		err := v.NalHRD.marshalTo(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.VclHRD != nil)
+
+	if v.VclHRD != nil {
+		err := v.VclHRD.marshalTo(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if v.NalHRD != nil || v.VclHRD != nil {
+		bits.WriteFlagUnsafe(buf, pos, v.LowDelayHrdFlag)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.PicStructPresentFlag)
+	bits.WriteFlagUnsafe(buf, pos, v.BitstreamRestriction != nil)
+
+	if v.BitstreamRestriction != nil {
+		v.BitstreamRestriction.marshalTo(buf, pos)
+	}
+
+	return nil
+}
+
 // SPS_FrameCropping is the frame cropping part of a SPS.
 type SPS_FrameCropping struct { //nolint:revive
 	LeftOffset   uint32
@@ -399,6 +609,20 @@ func (c *SPS_FrameCropping) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+func (c SPS_FrameCropping) marshalSizeBits() int {
+	return bits.GolombUnsignedSize(c.LeftOffset) +
+		bits.GolombUnsignedSize(c.RightOffset) +
+		bits.GolombUnsignedSize(c.TopOffset) +
+		bits.GolombUnsignedSize(c.BottomOffset)
+}
+
+func (c SPS_FrameCropping) marshalTo(buf []byte, pos *int) {
+	bits.WriteGolombUnsignedUnsafe(buf, pos, c.LeftOffset)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, c.RightOffset)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, c.TopOffset)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, c.BottomOffset)
+}
+
 // SPS is a H264 sequence parameter set.
 // Specification: ITU-T Rec. H.264, 7.3.2.1.1
 type SPS struct {
@@ -419,7 +643,10 @@ type SPS struct {
 	BitDepthChromaMinus8            uint32
 	QpprimeYZeroTransformBypassFlag bool
 
-	// seqScalingListPresentFlag == true
+	// seq_scaling_matrix_present_flag == true
+	SeqScalingListPresentFlag []bool
+
+	// SeqScalingListPresentFlag[i] == true
 	ScalingList4x4                 [][]int32
 	UseDefaultScalingMatrix4x4Flag []bool
 	ScalingList8x8                 [][]int32
@@ -451,6 +678,14 @@ type SPS struct {
 	VUI                    *SPS_VUI
 }
 
+func (s SPS) hasChromaFormatInfo() bool {
+	switch s.ProfileIdc {
+	case 100, 110, 122, 244, 44, 83, 86, 118, 128, 138, 139, 134, 135:
+		return true
+	}
+	return false
+}
+
 // Unmarshal decodes a SPS from bytes.
 func (s *SPS) Unmarshal(buf []byte) error {
 	if len(buf) < 1 {
@@ -485,8 +720,7 @@ func (s *SPS) Unmarshal(buf []byte) error {
 		return err
 	}
 
-	switch s.ProfileIdc {
-	case 100, 110, 122, 244, 44, 83, 86, 118, 128, 138, 139, 134, 135:
+	if s.hasChromaFormatInfo() {
 		s.ChromaFormatIdc, err = bits.ReadGolombUnsigned(buf, &pos)
 		if err != nil {
 			return err
@@ -530,14 +764,15 @@ func (s *SPS) Unmarshal(buf []byte) error {
 				lim = 12
 			}
 
+			s.SeqScalingListPresentFlag = make([]bool, lim)
+
 			for i := 0; i < lim; i++ {
-				var seqScalingListPresentFlag bool
-				seqScalingListPresentFlag, err = bits.ReadFlag(buf, &pos)
+				s.SeqScalingListPresentFlag[i], err = bits.ReadFlag(buf, &pos)
 				if err != nil {
 					return err
 				}
 
-				if seqScalingListPresentFlag {
+				if s.SeqScalingListPresentFlag[i] {
 					if i < 6 {
 						var scalingList []int32
 						var useDefaultScalingMatrixFlag bool
@@ -564,8 +799,7 @@ func (s *SPS) Unmarshal(buf []byte) error {
 				}
 			}
 		}
-
-	default:
+	} else {
 		s.ChromaFormatIdc = 1
 		s.SeparateColourPlaneFlag = false
 		s.BitDepthLumaMinus8 = 0
@@ -717,6 +951,186 @@ func (s *SPS) Unmarshal(buf []byte) error {
 	return nil
 }
 
+func (s SPS) marshalSizeBits() int {
+	n := 24 + bits.GolombUnsignedSize(s.ID)
+
+	if s.hasChromaFormatInfo() {
+		n += bits.GolombUnsignedSize(s.ChromaFormatIdc)
+
+		if s.ChromaFormatIdc == 3 {
+			n++
+		}
+
+		n += bits.GolombUnsignedSize(s.BitDepthLumaMinus8) +
+			bits.GolombUnsignedSize(s.BitDepthChromaMinus8) +
+			2 +
+			scalingListsMarshalSize(s.SeqScalingListPresentFlag, s.ScalingList4x4, s.UseDefaultScalingMatrix4x4Flag,
+				s.ScalingList8x8, s.UseDefaultScalingMatrix8x8Flag)
+	}
+
+	n += bits.GolombUnsignedSize(s.Log2MaxFrameNumMinus4) +
+		bits.GolombUnsignedSize(s.PicOrderCntType)
+
+	switch s.PicOrderCntType {
+	case 0:
+		n += bits.GolombUnsignedSize(s.Log2MaxPicOrderCntLsbMinus4)
+
+	case 1:
+		n += 1 +
+			bits.GolombSignedSize(s.OffsetForNonRefPic) +
+			bits.GolombSignedSize(s.OffsetForTopToBottomField) +
+			bits.GolombUnsignedSize(uint32(len(s.OffsetForRefFrames)))
+
+		for _, v := range s.OffsetForRefFrames {
+			n += bits.GolombSignedSize(v)
+		}
+	}
+
+	n += bits.GolombUnsignedSize(s.MaxNumRefFrames) +
+		1 +
+		bits.GolombUnsignedSize(s.PicWidthInMbsMinus1) +
+		bits.GolombUnsignedSize(s.PicHeightInMapUnitsMinus1) +
+		1
+
+	if !s.FrameMbsOnlyFlag {
+		n++
+	}
+
+	n += 2
+
+	if s.FrameCropping != nil {
+		n += s.FrameCropping.marshalSizeBits()
+	}
+
+	n++
+
+	if s.VUI != nil {
+		n += s.VUI.marshalSizeBits()
+	}
+
+	return n + 1 // rbsp_stop_one_bit
+}
+
+// Marshal encodes a SPS.
+func (s SPS) Marshal() ([]byte, error) {
+	if s.hasChromaFormatInfo() {
+		if s.SeqScalingListPresentFlag != nil {
+			l := len(s.SeqScalingListPresentFlag)
+			if (s.ChromaFormatIdc != 3 && l != 8) || (s.ChromaFormatIdc == 3 && l != 12) {
+				return nil, fmt.Errorf("invalid SeqScalingListPresentFlag length")
+			}
+		}
+
+		err := checkScalingLists(s.SeqScalingListPresentFlag, s.ScalingList4x4, s.UseDefaultScalingMatrix4x4Flag,
+			s.ScalingList8x8, s.UseDefaultScalingMatrix8x8Flag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.PicOrderCntType > 2 {
+		return nil, fmt.Errorf("invalid pic_order_cnt_type: %d", s.PicOrderCntType)
+	}
+
+	if len(s.OffsetForRefFrames) > maxRefFrames {
+		return nil, fmt.Errorf("num_ref_frames_in_pic_order_cnt_cycle exceeds %d", maxRefFrames)
+	}
+
+	n := s.marshalSizeBits()
+	buf := make([]byte, (n+7)/8)
+
+	buf[0] = s.ProfileIdc
+
+	if s.ConstraintSet0Flag {
+		buf[1] |= 1 << 7
+	}
+	if s.ConstraintSet1Flag {
+		buf[1] |= 1 << 6
+	}
+	if s.ConstraintSet2Flag {
+		buf[1] |= 1 << 5
+	}
+	if s.ConstraintSet3Flag {
+		buf[1] |= 1 << 4
+	}
+	if s.ConstraintSet4Flag {
+		buf[1] |= 1 << 3
+	}
+	if s.ConstraintSet5Flag {
+		buf[1] |= 1 << 2
+	}
+
+	buf[2] = s.LevelIdc
+
+	pos := 24
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.ID)
+
+	if s.hasChromaFormatInfo() {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.ChromaFormatIdc)
+
+		if s.ChromaFormatIdc == 3 {
+			bits.WriteFlagUnsafe(buf, &pos, s.SeparateColourPlaneFlag)
+		}
+
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.BitDepthLumaMinus8)
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.BitDepthChromaMinus8)
+		bits.WriteFlagUnsafe(buf, &pos, s.QpprimeYZeroTransformBypassFlag)
+		bits.WriteFlagUnsafe(buf, &pos, s.SeqScalingListPresentFlag != nil)
+
+		writeScalingLists(buf, &pos, s.SeqScalingListPresentFlag, s.ScalingList4x4, s.UseDefaultScalingMatrix4x4Flag,
+			s.ScalingList8x8, s.UseDefaultScalingMatrix8x8Flag)
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2MaxFrameNumMinus4)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.PicOrderCntType)
+
+	switch s.PicOrderCntType {
+	case 0:
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2MaxPicOrderCntLsbMinus4)
+
+	case 1:
+		bits.WriteFlagUnsafe(buf, &pos, s.DeltaPicOrderAlwaysZeroFlag)
+		bits.WriteGolombSignedUnsafe(buf, &pos, s.OffsetForNonRefPic)
+		bits.WriteGolombSignedUnsafe(buf, &pos, s.OffsetForTopToBottomField)
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, uint32(len(s.OffsetForRefFrames)))
+
+		for _, v := range s.OffsetForRefFrames {
+			bits.WriteGolombSignedUnsafe(buf, &pos, v)
+		}
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.MaxNumRefFrames)
+	bits.WriteFlagUnsafe(buf, &pos, s.GapsInFrameNumValueAllowedFlag)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.PicWidthInMbsMinus1)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.PicHeightInMapUnitsMinus1)
+	bits.WriteFlagUnsafe(buf, &pos, s.FrameMbsOnlyFlag)
+
+	if !s.FrameMbsOnlyFlag {
+		bits.WriteFlagUnsafe(buf, &pos, s.MbAdaptiveFrameFieldFlag)
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, s.Direct8x8InferenceFlag)
+	bits.WriteFlagUnsafe(buf, &pos, s.FrameCropping != nil)
+
+	if s.FrameCropping != nil {
+		s.FrameCropping.marshalTo(buf, &pos)
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, s.VUI != nil)
+
+	if s.VUI != nil {
+		err := s.VUI.marshalTo(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, true) // rbsp_stop_one_bit
+
+	return append([]byte{0x60 | byte(NALUTypeSPS)}, EmulationPreventionAdd(buf)...), nil
+}
+
 // Width returns the video width.
 func (s SPS) Width() int {
 	var subWidthC uint32
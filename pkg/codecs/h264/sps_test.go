@@ -170,7 +170,7 @@ var casesSPS = []struct {
 			0x67, 0x64, 0x00, 0x29, 0xac, 0x13, 0x31, 0x40,
 			0x78, 0x04, 0x47, 0xde, 0x03, 0xea, 0x02, 0x02,
 			0x03, 0xe0, 0x00, 0x00, 0x03, 0x00, 0x20, 0x00,
-			0x00, 0x06, 0x52, // 0x80,
+			0x00, 0x06, 0x52, 0x80,
 		},
 		SPS{
 			ProfileIdc:                  100,
@@ -246,6 +246,9 @@ var casesSPS = []struct {
 			ProfileIdc:      100,
 			LevelIdc:        50,
 			ChromaFormatIdc: 1,
+			SeqScalingListPresentFlag: []bool{
+				true, true, true, true, true, true, false, false,
+			},
 			ScalingList4x4: [][]int32{
 				{
 					16, 16, 16, 16, 16, 16, 16, 16,
@@ -496,6 +499,27 @@ func TestSPSUnmarshal(t *testing.T) {
 	}
 }
 
+func TestSPSUnmarshalMissingStopBit(t *testing.T) {
+	for _, ca := range casesSPS {
+		if ca.name == "1920x1080" {
+			var sps SPS
+			err := sps.Unmarshal(ca.byts[:len(ca.byts)-1])
+			require.NoError(t, err)
+			require.Equal(t, ca.sps, sps)
+		}
+	}
+}
+
+func TestSPSMarshal(t *testing.T) {
+	for _, ca := range casesSPS {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.sps.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
 func BenchmarkSPSUnmarshal(b *testing.B) {
 	for b.Loop() {
 		var sps SPS
@@ -516,7 +540,7 @@ func FuzzSPSUnmarshal(f *testing.F) {
 		f.Add(ca.byts)
 	}
 
-	f.Fuzz(func(_ *testing.T, b []byte) {
+	f.Fuzz(func(t *testing.T, b []byte) {
 		var sps SPS
 		err := sps.Unmarshal(b)
 		if err != nil {
@@ -526,5 +550,13 @@ func FuzzSPSUnmarshal(f *testing.F) {
 		sps.Width()
 		sps.Height()
 		sps.FPS()
+
+		byts, err := sps.Marshal()
+		require.NoError(t, err)
+
+		var sps2 SPS
+		err = sps2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, sps, sps2)
 	})
 }
@@ -48,6 +48,10 @@ const (
 	NALUType_AggregationUnit   NALUType = 48 //nolint:revive
 	NALUType_FragmentationUnit NALUType = 49 //nolint:revive
 	NALUType_PACI              NALUType = 50 //nolint:revive
+
+	// additional NALU types for Dolby Vision
+	NALUType_DolbyVisionRPU NALUType = 62 //nolint:revive
+	NALUType_DolbyVisionEL  NALUType = 63 //nolint:revive
 )
 
 var naluTypeLabels = map[NALUType]string{
@@ -89,6 +93,10 @@ var naluTypeLabels = map[NALUType]string{
 	NALUType_AggregationUnit:   "AggregationUnit",
 	NALUType_FragmentationUnit: "FragmentationUnit",
 	NALUType_PACI:              "PACI",
+
+	// additional NALU types for Dolby Vision
+	NALUType_DolbyVisionRPU: "DolbyVisionRPU",
+	NALUType_DolbyVisionEL:  "DolbyVisionEL",
 }
 
 // String implements fmt.Stringer.
@@ -10,4 +10,5 @@ import (
 func TestNALUType(t *testing.T) {
 	require.NotEqual(t, true, strings.HasPrefix(NALUType(10).String(), "unknown"))
 	require.Equal(t, true, strings.HasPrefix(NALUType(60).String(), "unknown"))
+	require.Equal(t, "DolbyVisionRPU", NALUType(62).String())
 }
@@ -7,6 +7,175 @@ import (
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 )
 
+const (
+	maxTileColumns = 20
+	maxTileRows    = 22
+)
+
+// PPS_Tiles are the tile parameters of a PPS.
+type PPS_Tiles struct { //nolint:revive
+	NumTileColumnsMinus1 uint32
+	NumTileRowsMinus1    uint32
+	UniformSpacingFlag   bool
+
+	// UniformSpacingFlag == false
+	ColumnWidthMinus1 []uint32
+	RowHeightMinus1   []uint32
+
+	LoopFilterAcrossTilesEnabledFlag bool
+}
+
+func (t *PPS_Tiles) unmarshal(buf []byte, pos *int) error {
+	var err error
+	t.NumTileColumnsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	t.NumTileRowsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if t.NumTileColumnsMinus1 >= maxTileColumns || t.NumTileRowsMinus1 >= maxTileRows {
+		return fmt.Errorf("invalid tile count")
+	}
+
+	t.UniformSpacingFlag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if !t.UniformSpacingFlag {
+		t.ColumnWidthMinus1 = make([]uint32, t.NumTileColumnsMinus1)
+
+		for i := range t.ColumnWidthMinus1 {
+			t.ColumnWidthMinus1[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		t.RowHeightMinus1 = make([]uint32, t.NumTileRowsMinus1)
+
+		for i := range t.RowHeightMinus1 {
+			t.RowHeightMinus1[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	t.LoopFilterAcrossTilesEnabledFlag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t PPS_Tiles) marshalSizeBits() int {
+	n := bits.GolombUnsignedSize(t.NumTileColumnsMinus1) +
+		bits.GolombUnsignedSize(t.NumTileRowsMinus1) +
+		1
+
+	if !t.UniformSpacingFlag {
+		for _, v := range t.ColumnWidthMinus1 {
+			n += bits.GolombUnsignedSize(v)
+		}
+
+		for _, v := range t.RowHeightMinus1 {
+			n += bits.GolombUnsignedSize(v)
+		}
+	}
+
+	return n + 1
+}
+
+func (t PPS_Tiles) marshalTo(buf []byte, pos *int) error {
+	if t.NumTileColumnsMinus1 >= maxTileColumns || t.NumTileRowsMinus1 >= maxTileRows {
+		return fmt.Errorf("invalid tile count")
+	}
+
+	if !t.UniformSpacingFlag && (len(t.ColumnWidthMinus1) != int(t.NumTileColumnsMinus1) ||
+		len(t.RowHeightMinus1) != int(t.NumTileRowsMinus1)) {
+		return fmt.Errorf("tile sizes do not match tile count")
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, pos, t.NumTileColumnsMinus1)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, t.NumTileRowsMinus1)
+	bits.WriteFlagUnsafe(buf, pos, t.UniformSpacingFlag)
+
+	if !t.UniformSpacingFlag {
+		for _, v := range t.ColumnWidthMinus1 {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, v)
+		}
+
+		for _, v := range t.RowHeightMinus1 {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, v)
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, t.LoopFilterAcrossTilesEnabledFlag)
+
+	return nil
+}
+
+// PPS_DeblockingFilterControl is the deblocking filter control of a PPS.
+type PPS_DeblockingFilterControl struct { //nolint:revive
+	OverrideEnabledFlag bool
+	DisabledFlag        bool
+
+	// DisabledFlag == false
+	BetaOffsetDiv2 int32
+	TcOffsetDiv2   int32
+}
+
+func (c *PPS_DeblockingFilterControl) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 2)
+	if err != nil {
+		return err
+	}
+
+	c.OverrideEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.DisabledFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	if !c.DisabledFlag {
+		c.BetaOffsetDiv2, err = bits.ReadGolombSigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		c.TcOffsetDiv2, err = bits.ReadGolombSigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c PPS_DeblockingFilterControl) marshalSizeBits() int {
+	n := 2
+
+	if !c.DisabledFlag {
+		n += bits.GolombSignedSize(c.BetaOffsetDiv2) +
+			bits.GolombSignedSize(c.TcOffsetDiv2)
+	}
+
+	return n
+}
+
+func (c PPS_DeblockingFilterControl) marshalTo(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, c.OverrideEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.DisabledFlag)
+
+	if !c.DisabledFlag {
+		bits.WriteGolombSignedUnsafe(buf, pos, c.BetaOffsetDiv2)
+		bits.WriteGolombSignedUnsafe(buf, pos, c.TcOffsetDiv2)
+	}
+}
+
 // PPS is a H265 picture parameter set.
 // Specification: ITU-T Rec. H.265, 7.3.2.3.1
 type PPS struct {
@@ -15,6 +184,32 @@ type PPS struct {
 	DependentSliceSegmentsEnabledFlag bool
 	OutputFlagPresentFlag             bool
 	NumExtraSliceHeaderBits           uint8
+	SignDataHidingEnabledFlag         bool
+	CabacInitPresentFlag              bool
+	NumRefIdxL0DefaultActiveMinus1    uint32
+	NumRefIdxL1DefaultActiveMinus1    uint32
+	InitQPMinus26                     int32
+	ConstrainedIntraPredFlag          bool
+	TransformSkipEnabledFlag          bool
+	CuQPDeltaEnabledFlag              bool
+
+	// CuQPDeltaEnabledFlag == true
+	DiffCuQPDeltaDepth uint32
+
+	CbQPOffset                             int32
+	CrQPOffset                             int32
+	SliceChromaQPOffsetsPresentFlag        bool
+	WeightedPredFlag                       bool
+	WeightedBipredFlag                     bool
+	TransquantBypassEnabledFlag            bool
+	Tiles                                  *PPS_Tiles
+	EntropyCodingSyncEnabledFlag           bool
+	LoopFilterAcrossSlicesEnabledFlag      bool
+	DeblockingFilterControl                *PPS_DeblockingFilterControl
+	ScalingListData                        *SPS_ScalingListData
+	ListsModificationPresentFlag           bool
+	Log2ParallelMergeLevelMinus2           uint32
+	SliceSegmentHeaderExtensionPresentFlag bool
 }
 
 // Unmarshal decodes a PPS.
@@ -50,5 +245,250 @@ func (p *PPS) Unmarshal(buf []byte) error {
 	p.OutputFlagPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
 	p.NumExtraSliceHeaderBits = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
 
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.SignDataHidingEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.CabacInitPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	p.NumRefIdxL0DefaultActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.NumRefIdxL1DefaultActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if p.NumRefIdxL0DefaultActiveMinus1 > 14 || p.NumRefIdxL1DefaultActiveMinus1 > 14 {
+		return fmt.Errorf("invalid num_ref_idx_default_active_minus1")
+	}
+
+	p.InitQPMinus26, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	p.ConstrainedIntraPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.TransformSkipEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.CuQPDeltaEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if p.CuQPDeltaEnabledFlag {
+		p.DiffCuQPDeltaDepth, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.DiffCuQPDeltaDepth = 0
+	}
+
+	p.CbQPOffset, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.CrQPOffset, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 6)
+	if err != nil {
+		return err
+	}
+
+	p.SliceChromaQPOffsetsPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.WeightedPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.WeightedBipredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.TransquantBypassEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	tilesEnabledFlag := bits.ReadFlagUnsafe(buf, &pos)
+	p.EntropyCodingSyncEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if tilesEnabledFlag {
+		p.Tiles = &PPS_Tiles{}
+		err = p.Tiles.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.Tiles = nil
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	p.LoopFilterAcrossSlicesEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	deblockingFilterControlPresentFlag := bits.ReadFlagUnsafe(buf, &pos)
+
+	if deblockingFilterControlPresentFlag {
+		p.DeblockingFilterControl = &PPS_DeblockingFilterControl{}
+		err = p.DeblockingFilterControl.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.DeblockingFilterControl = nil
+	}
+
+	scalingListDataPresentFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if scalingListDataPresentFlag {
+		p.ScalingListData = &SPS_ScalingListData{}
+		err = p.ScalingListData.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.ScalingListData = nil
+	}
+
+	p.ListsModificationPresentFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.Log2ParallelMergeLevelMinus2, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.SliceSegmentHeaderExtensionPresentFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	// PPS extensions are not parsed
+
 	return nil
 }
+
+func (p PPS) marshalSizeBits() int {
+	n := 16 +
+		bits.GolombUnsignedSize(p.ID) +
+		bits.GolombUnsignedSize(p.SPSID) +
+		7 +
+		bits.GolombUnsignedSize(p.NumRefIdxL0DefaultActiveMinus1) +
+		bits.GolombUnsignedSize(p.NumRefIdxL1DefaultActiveMinus1) +
+		bits.GolombSignedSize(p.InitQPMinus26) +
+		3
+
+	if p.CuQPDeltaEnabledFlag {
+		n += bits.GolombUnsignedSize(p.DiffCuQPDeltaDepth)
+	}
+
+	n += bits.GolombSignedSize(p.CbQPOffset) +
+		bits.GolombSignedSize(p.CrQPOffset) +
+		6
+
+	if p.Tiles != nil {
+		n += p.Tiles.marshalSizeBits()
+	}
+
+	n += 2
+
+	if p.DeblockingFilterControl != nil {
+		n += p.DeblockingFilterControl.marshalSizeBits()
+	}
+
+	n++
+
+	if p.ScalingListData != nil {
+		n += p.ScalingListData.marshalSizeBits()
+	}
+
+	n += 1 +
+		bits.GolombUnsignedSize(p.Log2ParallelMergeLevelMinus2) +
+		2
+
+	return n + 1 // rbsp_stop_one_bit
+}
+
+// Marshal encodes a PPS.
+func (p PPS) Marshal() ([]byte, error) {
+	if p.NumRefIdxL0DefaultActiveMinus1 > 14 || p.NumRefIdxL1DefaultActiveMinus1 > 14 {
+		return nil, fmt.Errorf("invalid num_ref_idx_default_active_minus1")
+	}
+
+	if p.NumExtraSliceHeaderBits > 7 {
+		return nil, fmt.Errorf("invalid NumExtraSliceHeaderBits")
+	}
+
+	n := p.marshalSizeBits()
+	buf := make([]byte, (n+7)/8)
+
+	buf[0] = byte(NALUType_PPS_NUT) << 1
+	buf[1] = 1 // nuh_temporal_id_plus1
+
+	pos := 16
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.ID)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.SPSID)
+	bits.WriteFlagUnsafe(buf, &pos, p.DependentSliceSegmentsEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.OutputFlagPresentFlag)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(p.NumExtraSliceHeaderBits), 3)
+	bits.WriteFlagUnsafe(buf, &pos, p.SignDataHidingEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.CabacInitPresentFlag)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.NumRefIdxL0DefaultActiveMinus1)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.NumRefIdxL1DefaultActiveMinus1)
+	bits.WriteGolombSignedUnsafe(buf, &pos, p.InitQPMinus26)
+	bits.WriteFlagUnsafe(buf, &pos, p.ConstrainedIntraPredFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.TransformSkipEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.CuQPDeltaEnabledFlag)
+
+	if p.CuQPDeltaEnabledFlag {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, p.DiffCuQPDeltaDepth)
+	}
+
+	bits.WriteGolombSignedUnsafe(buf, &pos, p.CbQPOffset)
+	bits.WriteGolombSignedUnsafe(buf, &pos, p.CrQPOffset)
+	bits.WriteFlagUnsafe(buf, &pos, p.SliceChromaQPOffsetsPresentFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.WeightedPredFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.WeightedBipredFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.TransquantBypassEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.Tiles != nil)
+	bits.WriteFlagUnsafe(buf, &pos, p.EntropyCodingSyncEnabledFlag)
+
+	if p.Tiles != nil {
+		err := p.Tiles.marshalTo(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, p.LoopFilterAcrossSlicesEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, p.DeblockingFilterControl != nil)
+
+	if p.DeblockingFilterControl != nil {
+		p.DeblockingFilterControl.marshalTo(buf, &pos)
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, p.ScalingListData != nil)
+
+	if p.ScalingListData != nil {
+		err := p.ScalingListData.marshalTo(buf, &pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, p.ListsModificationPresentFlag)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, p.Log2ParallelMergeLevelMinus2)
+	bits.WriteFlagUnsafe(buf, &pos, p.SliceSegmentHeaderExtensionPresentFlag)
+	bits.WriteFlagUnsafe(buf, &pos, false) // pps_extension_present_flag
+	bits.WriteFlagUnsafe(buf, &pos, true)  // rbsp_stop_one_bit
+
+	return h264.EmulationPreventionAdd(buf), nil
+}
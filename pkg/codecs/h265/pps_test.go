@@ -16,7 +16,48 @@ var casesPPS = []struct {
 		[]byte{
 			0x44, 0x01, 0xc1, 0x72, 0xb4, 0x62, 0x40,
 		},
-		PPS{},
+		PPS{
+			SignDataHidingEnabledFlag:         true,
+			CuQPDeltaEnabledFlag:              true,
+			DiffCuQPDeltaDepth:                1,
+			WeightedPredFlag:                  true,
+			EntropyCodingSyncEnabledFlag:      true,
+			LoopFilterAcrossSlicesEnabledFlag: true,
+		},
+	},
+	{
+		"deblocking filter control",
+		[]byte{
+			0x44, 0x01, 0xc0, 0x3c, 0xf0, 0x1b, 0x64,
+		},
+		PPS{
+			NumRefIdxL0DefaultActiveMinus1: 2,
+			CuQPDeltaEnabledFlag:           true,
+			DeblockingFilterControl: &PPS_DeblockingFilterControl{
+				OverrideEnabledFlag: true,
+			},
+			ListsModificationPresentFlag: true,
+		},
+	},
+	{
+		"tiles",
+		[]byte{
+			0x44, 0x01, 0xc0, 0xe3, 0x0f, 0x09, 0xc1, 0x40,
+			0xa6, 0x08, 0x40,
+		},
+		PPS{
+			CabacInitPresentFlag: true,
+			InitQPMinus26:        6,
+			CuQPDeltaEnabledFlag: true,
+			Tiles: &PPS_Tiles{
+				NumTileColumnsMinus1:             2,
+				ColumnWidthMinus1:                []uint32{19, 19},
+				RowHeightMinus1:                  []uint32{},
+				LoopFilterAcrossTilesEnabledFlag: true,
+			},
+			LoopFilterAcrossSlicesEnabledFlag: true,
+			Log2ParallelMergeLevelMinus2:      3,
+		},
 	},
 }
 
@@ -31,13 +72,34 @@ func TestPPSUnmarshal(t *testing.T) {
 	}
 }
 
+func TestPPSMarshal(t *testing.T) {
+	for _, ca := range casesPPS {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.pps.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
 func FuzzPPSUnmarshal(f *testing.F) {
 	for _, ca := range casesPPS {
 		f.Add(ca.byts)
 	}
 
-	f.Fuzz(func(_ *testing.T, b []byte) {
+	f.Fuzz(func(t *testing.T, b []byte) {
 		var pps PPS
-		pps.Unmarshal(b) //nolint:errcheck
+		err := pps.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		byts, err := pps.Marshal()
+		require.NoError(t, err)
+
+		var pps2 PPS
+		err = pps2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, pps, pps2)
 	})
 }
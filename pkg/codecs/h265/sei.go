@@ -0,0 +1,138 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/internal/sei"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+// SEIPayloadType is the type of a SEI message payload.
+// Specification: ITU-T Rec. H.265, D.2.1
+type SEIPayloadType uint32
+
+// SEI payload types.
+const (
+	SEIPayloadTypeBufferingPeriod              SEIPayloadType = 0
+	SEIPayloadTypePicTiming                    SEIPayloadType = 1
+	SEIPayloadTypePanScanRect                  SEIPayloadType = 2
+	SEIPayloadTypeFillerPayload                SEIPayloadType = 3
+	SEIPayloadTypeUserDataRegisteredITUTT35    SEIPayloadType = 4
+	SEIPayloadTypeUserDataUnregistered         SEIPayloadType = 5
+	SEIPayloadTypeRecoveryPoint                SEIPayloadType = 6
+	SEIPayloadTypeActiveParameterSets          SEIPayloadType = 129
+	SEIPayloadTypeDecodedPictureHash           SEIPayloadType = 132
+	SEIPayloadTypeTimeCode                     SEIPayloadType = 136
+	SEIPayloadTypeMasteringDisplayColourVolume SEIPayloadType = 137
+	SEIPayloadTypeContentLightLevelInfo        SEIPayloadType = 144
+)
+
+var seiPayloadTypeLabels = map[SEIPayloadType]string{
+	SEIPayloadTypeBufferingPeriod:              "BufferingPeriod",
+	SEIPayloadTypePicTiming:                    "PicTiming",
+	SEIPayloadTypePanScanRect:                  "PanScanRect",
+	SEIPayloadTypeFillerPayload:                "FillerPayload",
+	SEIPayloadTypeUserDataRegisteredITUTT35:    "UserDataRegisteredITUTT35",
+	SEIPayloadTypeUserDataUnregistered:         "UserDataUnregistered",
+	SEIPayloadTypeRecoveryPoint:                "RecoveryPoint",
+	SEIPayloadTypeActiveParameterSets:          "ActiveParameterSets",
+	SEIPayloadTypeDecodedPictureHash:           "DecodedPictureHash",
+	SEIPayloadTypeTimeCode:                     "TimeCode",
+	SEIPayloadTypeMasteringDisplayColourVolume: "MasteringDisplayColourVolume",
+	SEIPayloadTypeContentLightLevelInfo:        "ContentLightLevelInfo",
+}
+
+// String implements fmt.Stringer.
+func (t SEIPayloadType) String() string {
+	if l, ok := seiPayloadTypeLabels[t]; ok {
+		return l
+	}
+	return fmt.Sprintf("unknown (%d)", uint32(t))
+}
+
+// SEIMessage is a SEI message.
+// Specification: ITU-T Rec. H.265, 7.3.5
+type SEIMessage struct {
+	PayloadType SEIPayloadType
+	Payload     []byte
+}
+
+func unmarshalSEIMessages(buf []byte) ([]SEIMessage, error) {
+	msgs, err := sei.Unmarshal(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]SEIMessage, len(msgs))
+	for i, msg := range msgs {
+		messages[i] = SEIMessage{
+			PayloadType: SEIPayloadType(msg.PayloadType),
+			Payload:     msg.Payload,
+		}
+	}
+
+	return messages, nil
+}
+
+func marshalSEIMessages(messages []SEIMessage) ([]byte, error) {
+	msgs := make([]sei.Message, len(messages))
+	for i, msg := range messages {
+		msgs[i] = sei.Message{
+			PayloadType: uint32(msg.PayloadType),
+			Payload:     msg.Payload,
+		}
+	}
+
+	return sei.Marshal(msgs)
+}
+
+// SEI is a supplemental enhancement information NALU.
+// Specification: ITU-T Rec. H.265, 7.3.2.4
+type SEI struct {
+	// whether the NALU is a suffix SEI instead of a prefix SEI.
+	Suffix bool
+
+	Messages []SEIMessage
+}
+
+// Unmarshal decodes a SEI from bytes.
+func (s *SEI) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	switch NALUType((buf[0] >> 1) & 0b111111) {
+	case NALUType_PREFIX_SEI_NUT:
+		s.Suffix = false
+
+	case NALUType_SUFFIX_SEI_NUT:
+		s.Suffix = true
+
+	default:
+		return fmt.Errorf("not a SEI")
+	}
+
+	var err error
+	s.Messages, err = unmarshalSEIMessages(h264.EmulationPreventionRemove(buf[2:]))
+	return err
+}
+
+// Marshal encodes a SEI into bytes.
+func (s SEI) Marshal() ([]byte, error) {
+	buf, err := marshalSEIMessages(s.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := NALUType_PREFIX_SEI_NUT
+	if s.Suffix {
+		typ = NALUType_SUFFIX_SEI_NUT
+	}
+
+	header := []byte{
+		byte(typ) << 1,
+		1, // nuh_temporal_id_plus1
+	}
+
+	return append(header, h264.EmulationPreventionAdd(buf)...), nil
+}
@@ -0,0 +1,144 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// SEIBufferingPeriod_CpbRemovalDelay is the initial CPB removal delay of a CPB.
+type SEIBufferingPeriod_CpbRemovalDelay struct { //nolint:revive
+	InitialCpbRemovalDelay  uint32
+	InitialCpbRemovalOffset uint32
+
+	// HRD.SubPicHRDParamsPresentFlag == true || IrapCpbParamsPresentFlag == true
+	InitialAltCpbRemovalDelay  uint32
+	InitialAltCpbRemovalOffset uint32
+}
+
+// SEIBufferingPeriod is a buffering_period SEI payload.
+// Specification: ITU-T Rec. H.265, D.2.2
+type SEIBufferingPeriod struct {
+	SeqParameterSetID        uint32
+	IrapCpbParamsPresentFlag bool
+
+	// IrapCpbParamsPresentFlag == true
+	CpbDelayOffset uint32
+	DpbDelayOffset uint32
+
+	ConcatenationFlag            bool
+	AuCpbRemovalDelayDeltaMinus1 uint32
+
+	// HRD.NalHRDParametersPresentFlag == true
+	NalCpbRemovalDelays []SEIBufferingPeriod_CpbRemovalDelay
+
+	// HRD.VclHRDParametersPresentFlag == true
+	VclCpbRemovalDelays []SEIBufferingPeriod_CpbRemovalDelay
+}
+
+func (b *SEIBufferingPeriod) readCpbRemovalDelays(
+	buf []byte,
+	pos *int,
+	hrd *SPS_HRD,
+) ([]SEIBufferingPeriod_CpbRemovalDelay, error) {
+	le := int(hrd.InitialCpbRemovalDelayLengthMinus1) + 1
+	alt := hrd.SubPicHRDParamsPresentFlag || b.IrapCpbParamsPresentFlag
+
+	// CpbCnt of the highest sub-layer
+	cpbCnt := int(hrd.SubLayers[len(hrd.SubLayers)-1].CpbCntMinus1) + 1
+
+	n := cpbCnt * le * 2
+	if alt {
+		n *= 2
+	}
+
+	err := bits.HasSpace(buf, *pos, n)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]SEIBufferingPeriod_CpbRemovalDelay, cpbCnt)
+
+	for i := range ret {
+		ret[i].InitialCpbRemovalDelay = uint32(bits.ReadBitsUnsafe(buf, pos, le))
+		ret[i].InitialCpbRemovalOffset = uint32(bits.ReadBitsUnsafe(buf, pos, le))
+
+		if alt {
+			ret[i].InitialAltCpbRemovalDelay = uint32(bits.ReadBitsUnsafe(buf, pos, le))
+			ret[i].InitialAltCpbRemovalOffset = uint32(bits.ReadBitsUnsafe(buf, pos, le))
+		}
+	}
+
+	return ret, nil
+}
+
+// Unmarshal decodes a SEIBufferingPeriod from a SEI message payload.
+// The SPS referenced by the payload is needed in order to decode it.
+func (b *SEIBufferingPeriod) Unmarshal(buf []byte, sps *SPS) error {
+	*b = SEIBufferingPeriod{}
+	pos := 0
+
+	var err error
+	b.SeqParameterSetID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if b.SeqParameterSetID != uint32(sps.ID) {
+		return fmt.Errorf("buffering period refers to SPS %d, but SPS %d was provided", b.SeqParameterSetID, sps.ID)
+	}
+
+	hrd := spsHRD(sps)
+	if hrd == nil {
+		return fmt.Errorf("SPS does not contain HRD parameters")
+	}
+
+	if !hrd.SubPicHRDParamsPresentFlag {
+		b.IrapCpbParamsPresentFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	auCpbRemovalDelayLength := int(hrd.AuCpbRemovalDelayLengthMinus1) + 1
+
+	if b.IrapCpbParamsPresentFlag {
+		dpbOutputDelayLength := int(hrd.DpbOutputDelayLengthMinus1) + 1
+
+		err = bits.HasSpace(buf, pos, auCpbRemovalDelayLength+dpbOutputDelayLength)
+		if err != nil {
+			return err
+		}
+
+		b.CpbDelayOffset = uint32(bits.ReadBitsUnsafe(buf, &pos, auCpbRemovalDelayLength))
+		b.DpbDelayOffset = uint32(bits.ReadBitsUnsafe(buf, &pos, dpbOutputDelayLength))
+	}
+
+	err = bits.HasSpace(buf, pos, 1+auCpbRemovalDelayLength)
+	if err != nil {
+		return err
+	}
+
+	b.ConcatenationFlag = bits.ReadFlagUnsafe(buf, &pos)
+	b.AuCpbRemovalDelayDeltaMinus1 = uint32(bits.ReadBitsUnsafe(buf, &pos, auCpbRemovalDelayLength))
+
+	if len(hrd.SubLayers) == 0 {
+		return fmt.Errorf("HRD does not contain sub-layers")
+	}
+
+	if hrd.NalHRDParametersPresentFlag {
+		b.NalCpbRemovalDelays, err = b.readCpbRemovalDelays(buf, &pos, hrd)
+		if err != nil {
+			return err
+		}
+	}
+
+	if hrd.VclHRDParametersPresentFlag {
+		b.VclCpbRemovalDelays, err = b.readCpbRemovalDelays(buf, &pos, hrd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
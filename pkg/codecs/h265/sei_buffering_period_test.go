@@ -0,0 +1,48 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSEIBufferingPeriodUnmarshal(t *testing.T) {
+	var bp SEIBufferingPeriod
+	err := bp.Unmarshal([]byte{
+		0xa0, 0x00, 0x00, 0x00, 0x2b, 0xf2, 0x00, 0x00,
+		0x7d, 0x00, 0x15, 0xf9, 0x00, 0x00, 0x3e, 0x90,
+	}, &casesSPS[5].sps)
+	require.NoError(t, err)
+	require.Equal(t, SEIBufferingPeriod{
+		ConcatenationFlag: true,
+		NalCpbRemovalDelays: []SEIBufferingPeriod_CpbRemovalDelay{{
+			InitialCpbRemovalDelay:  90000,
+			InitialCpbRemovalOffset: 1000,
+		}},
+		VclCpbRemovalDelays: []SEIBufferingPeriod_CpbRemovalDelay{{
+			InitialCpbRemovalDelay:  45000,
+			InitialCpbRemovalOffset: 500,
+		}},
+	}, bp)
+}
+
+func TestSEIBufferingPeriodUnmarshalErrors(t *testing.T) {
+	var bp SEIBufferingPeriod
+	err := bp.Unmarshal([]byte{0x40}, &casesSPS[5].sps)
+	require.EqualError(t, err, "buffering period refers to SPS 1, but SPS 0 was provided")
+
+	err = bp.Unmarshal([]byte{0x80}, &casesSPS[0].sps)
+	require.EqualError(t, err, "SPS does not contain HRD parameters")
+}
+
+func FuzzSEIBufferingPeriodUnmarshal(f *testing.F) {
+	f.Add([]byte{
+		0xa0, 0x00, 0x00, 0x00, 0x2b, 0xf2, 0x00, 0x00,
+		0x7d, 0x00, 0x15, 0xf9, 0x00, 0x00, 0x3e, 0x90,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var bp SEIBufferingPeriod
+		bp.Unmarshal(b, &casesSPS[5].sps) //nolint:errcheck
+	})
+}
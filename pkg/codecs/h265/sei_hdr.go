@@ -0,0 +1,15 @@
+package h265
+
+import (
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+// SEIMasteringDisplayColourVolume is a mastering_display_colour_volume SEI payload.
+// Its syntax is the same as in H264.
+// Specification: ITU-T Rec. H.265, D.2.28
+type SEIMasteringDisplayColourVolume = h264.SEIMasteringDisplayColourVolume
+
+// SEIContentLightLevelInfo is a content_light_level_info SEI payload.
+// Its syntax is the same as in H264.
+// Specification: ITU-T Rec. H.265, D.2.35
+type SEIContentLightLevelInfo = h264.SEIContentLightLevelInfo
@@ -0,0 +1,147 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+func spsHRD(sps *SPS) *SPS_HRD {
+	if sps.VUI == nil || sps.VUI.TimingInfo == nil || sps.VUI.TimingInfo.HRD == nil {
+		return nil
+	}
+
+	hrd := sps.VUI.TimingInfo.HRD
+	if !hrd.NalHRDParametersPresentFlag && !hrd.VclHRDParametersPresentFlag {
+		return nil
+	}
+
+	return hrd
+}
+
+// SEIPicTiming is a pic_timing SEI payload.
+// Specification: ITU-T Rec. H.265, D.2.3
+type SEIPicTiming struct {
+	// SPS.VUI.FrameFieldInfoPresentFlag == true
+	PicStruct      uint8
+	SourceScanType uint8
+	DuplicateFlag  bool
+
+	// HRD.NalHRDParametersPresentFlag == true || HRD.VclHRDParametersPresentFlag == true
+	AuCpbRemovalDelayMinus1 uint32
+	PicDpbOutputDelay       uint32
+
+	// HRD.SubPicHRDParamsPresentFlag == true
+	PicDpbOutputDuDelay uint32
+
+	// HRD.SubPicHRDParamsPresentFlag == true && HRD.SubPicCpbParamsInPicTimingSEIFlag == true
+	NumDecodingUnitsMinus1      uint32
+	DuCommonCpbRemovalDelayFlag bool
+
+	// DuCommonCpbRemovalDelayFlag == true
+	DuCommonCpbRemovalDelayIncrementMinus1 uint32
+
+	NumNalusInDuMinus1 []uint32
+
+	// DuCommonCpbRemovalDelayFlag == false
+	DuCpbRemovalDelayIncrementMinus1 []uint32
+}
+
+// Unmarshal decodes a SEIPicTiming from a SEI message payload.
+// The active SPS is needed in order to decode it.
+func (t *SEIPicTiming) Unmarshal(buf []byte, sps *SPS) error {
+	*t = SEIPicTiming{}
+	pos := 0
+
+	if sps.VUI != nil && sps.VUI.FrameFieldInfoPresentFlag {
+		err := bits.HasSpace(buf, pos, 4+2+1)
+		if err != nil {
+			return err
+		}
+
+		t.PicStruct = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+		t.SourceScanType = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+		t.DuplicateFlag = bits.ReadFlagUnsafe(buf, &pos)
+	}
+
+	hrd := spsHRD(sps)
+	if hrd == nil {
+		return nil
+	}
+
+	auCpbRemovalDelayLength := int(hrd.AuCpbRemovalDelayLengthMinus1) + 1
+	dpbOutputDelayLength := int(hrd.DpbOutputDelayLengthMinus1) + 1
+
+	err := bits.HasSpace(buf, pos, auCpbRemovalDelayLength+dpbOutputDelayLength)
+	if err != nil {
+		return err
+	}
+
+	t.AuCpbRemovalDelayMinus1 = uint32(bits.ReadBitsUnsafe(buf, &pos, auCpbRemovalDelayLength))
+	t.PicDpbOutputDelay = uint32(bits.ReadBitsUnsafe(buf, &pos, dpbOutputDelayLength))
+
+	if !hrd.SubPicHRDParamsPresentFlag {
+		return nil
+	}
+
+	dpbOutputDelayDuLength := int(hrd.DpbOutputDelayDuLengthMinus1) + 1
+
+	err = bits.HasSpace(buf, pos, dpbOutputDelayDuLength)
+	if err != nil {
+		return err
+	}
+
+	t.PicDpbOutputDuDelay = uint32(bits.ReadBitsUnsafe(buf, &pos, dpbOutputDelayDuLength))
+
+	if !hrd.SubPicCpbParamsInPicTimingSEIFlag {
+		return nil
+	}
+
+	t.NumDecodingUnitsMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	// each decoding unit takes at least one bit
+	if int(t.NumDecodingUnitsMinus1) >= (len(buf)*8 - pos) {
+		return fmt.Errorf("invalid num_decoding_units_minus1: %d", t.NumDecodingUnitsMinus1)
+	}
+
+	t.DuCommonCpbRemovalDelayFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	duCpbRemovalDelayIncrementLength := int(hrd.DuCpbRemovalDelayIncrementLengthMinus1) + 1
+
+	if t.DuCommonCpbRemovalDelayFlag {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, duCpbRemovalDelayIncrementLength)
+		if err != nil {
+			return err
+		}
+		t.DuCommonCpbRemovalDelayIncrementMinus1 = uint32(tmp)
+	} else {
+		t.DuCpbRemovalDelayIncrementMinus1 = make([]uint32, t.NumDecodingUnitsMinus1)
+	}
+
+	t.NumNalusInDuMinus1 = make([]uint32, t.NumDecodingUnitsMinus1+1)
+
+	for i := range t.NumNalusInDuMinus1 {
+		t.NumNalusInDuMinus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if !t.DuCommonCpbRemovalDelayFlag && i < int(t.NumDecodingUnitsMinus1) {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, &pos, duCpbRemovalDelayIncrementLength)
+			if err != nil {
+				return err
+			}
+			t.DuCpbRemovalDelayIncrementMinus1[i] = uint32(tmp)
+		}
+	}
+
+	return nil
+}
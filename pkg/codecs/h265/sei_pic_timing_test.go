@@ -0,0 +1,84 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSPSSubPicHRD = SPS{
+	VUI: &SPS_VUI{
+		FrameFieldInfoPresentFlag: true,
+		TimingInfo: &SPS_TimingInfo{
+			HRD: &SPS_HRD{
+				NalHRDParametersPresentFlag:            true,
+				SubPicHRDParamsPresentFlag:             true,
+				DuCpbRemovalDelayIncrementLengthMinus1: 7,
+				SubPicCpbParamsInPicTimingSEIFlag:      true,
+				DpbOutputDelayDuLengthMinus1:           7,
+				InitialCpbRemovalDelayLengthMinus1:     23,
+				AuCpbRemovalDelayLengthMinus1:          15,
+				DpbOutputDelayLengthMinus1:             5,
+				SubLayers: []SPS_HRDSubLayer{{
+					NalHRDParameters: []SPS_SubLayerHRDParameters{{}},
+				}},
+			},
+		},
+	},
+}
+
+func TestSEIPicTimingUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		sps  SPS
+		pt   SEIPicTiming
+	}{
+		{
+			"hrd",
+			[]byte{0x00, 0x03, 0x0a},
+			casesSPS[4].sps,
+			SEIPicTiming{
+				AuCpbRemovalDelayMinus1: 3,
+				PicDpbOutputDelay:       2,
+			},
+		},
+		{
+			"frame field info + sub-picture hrd",
+			[]byte{0x14, 0x00, 0x0a, 0x20, 0x4a, 0x41, 0xdc},
+			testSPSSubPicHRD,
+			SEIPicTiming{
+				PicStruct:                        1,
+				SourceScanType:                   1,
+				AuCpbRemovalDelayMinus1:          5,
+				PicDpbOutputDelay:                4,
+				PicDpbOutputDuDelay:              9,
+				NumDecodingUnitsMinus1:           1,
+				NumNalusInDuMinus1:               []uint32{0, 2},
+				DuCpbRemovalDelayIncrementMinus1: []uint32{7},
+			},
+		},
+		{
+			"no hrd",
+			[]byte{},
+			casesSPS[0].sps,
+			SEIPicTiming{},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var pt SEIPicTiming
+			err := pt.Unmarshal(ca.byts, &ca.sps)
+			require.NoError(t, err)
+			require.Equal(t, ca.pt, pt)
+		})
+	}
+}
+
+func FuzzSEIPicTimingUnmarshal(f *testing.F) {
+	f.Add([]byte{0x14, 0x00, 0x0a, 0x20, 0x4a, 0x41, 0xdc})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pt SEIPicTiming
+		pt.Unmarshal(b, &testSPSSubPicHRD) //nolint:errcheck
+	})
+}
@@ -0,0 +1,52 @@
+package h265
+
+import (
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// SEIRecoveryPoint is a recovery_point SEI payload.
+// Specification: ITU-T Rec. H.265, D.2.8
+type SEIRecoveryPoint struct {
+	RecoveryPocCnt int32
+	ExactMatchFlag bool
+	BrokenLinkFlag bool
+}
+
+// Unmarshal decodes a SEIRecoveryPoint from a SEI message payload.
+func (r *SEIRecoveryPoint) Unmarshal(buf []byte) error {
+	pos := 0
+
+	var err error
+	r.RecoveryPocCnt, err = bits.ReadGolombSigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	r.ExactMatchFlag = bits.ReadFlagUnsafe(buf, &pos)
+	r.BrokenLinkFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
+
+// Marshal encodes a SEIRecoveryPoint into a SEI message payload.
+func (r SEIRecoveryPoint) Marshal() ([]byte, error) {
+	n := bits.GolombSignedSize(r.RecoveryPocCnt) + 2
+	buf := make([]byte, (n+7)/8)
+	pos := 0
+
+	bits.WriteGolombSignedUnsafe(buf, &pos, r.RecoveryPocCnt)
+	bits.WriteFlagUnsafe(buf, &pos, r.ExactMatchFlag)
+	bits.WriteFlagUnsafe(buf, &pos, r.BrokenLinkFlag)
+
+	// payload byte alignment
+	if (pos % 8) != 0 {
+		bits.WriteFlagUnsafe(buf, &pos, true)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,162 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSEI = []struct {
+	name string
+	byts []byte
+	sei  SEI
+}{
+	{
+		"prefix user data unregistered",
+		[]byte{
+			0x4e, 0x01, 0x05, 0x14, 0x2c, 0xa2, 0xde, 0x09,
+			0xb5, 0x17, 0x47, 0xdb, 0xbb, 0x55, 0xa4, 0xfe,
+			0x7f, 0xc2, 0xfc, 0x4e, 0x78, 0x32, 0x36, 0x35,
+			0x80,
+		},
+		SEI{
+			Messages: []SEIMessage{{
+				PayloadType: SEIPayloadTypeUserDataUnregistered,
+				Payload: []byte{
+					0x2c, 0xa2, 0xde, 0x09, 0xb5, 0x17, 0x47, 0xdb,
+					0xbb, 0x55, 0xa4, 0xfe, 0x7f, 0xc2, 0xfc, 0x4e,
+					0x78, 0x32, 0x36, 0x35,
+				},
+			}},
+		},
+	},
+	{
+		"suffix decoded picture hash",
+		[]byte{
+			0x50, 0x01, 0x84, 0x07, 0x02, 0x12, 0x34, 0x56,
+			0x78, 0x9a, 0xbc, 0x80,
+		},
+		SEI{
+			Suffix: true,
+			Messages: []SEIMessage{{
+				PayloadType: SEIPayloadTypeDecodedPictureHash,
+				Payload:     []byte{0x02, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc},
+			}},
+		},
+	},
+	{
+		"recovery point + time code",
+		[]byte{
+			0x4e, 0x01, 0x06, 0x01, 0xd0, 0x88, 0x06, 0x60,
+			0x40, 0x64, 0x5c, 0x28, 0x10, 0x80,
+		},
+		SEI{
+			Messages: []SEIMessage{
+				{
+					PayloadType: SEIPayloadTypeRecoveryPoint,
+					Payload:     []byte{0xd0},
+				},
+				{
+					PayloadType: SEIPayloadTypeTimeCode,
+					Payload:     []byte{0x60, 0x40, 0x64, 0x5c, 0x28, 0x10},
+				},
+			},
+		},
+	},
+}
+
+func TestSEIUnmarshal(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			var sei SEI
+			err := sei.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sei, sei)
+		})
+	}
+}
+
+func TestSEIMarshal(t *testing.T) {
+	for _, ca := range casesSEI {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.sei.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestSEIPayloadType(t *testing.T) {
+	require.Equal(t, "TimeCode", SEIPayloadTypeTimeCode.String())
+	require.Equal(t, "unknown (200)", SEIPayloadType(200).String())
+}
+
+func TestSEIUserDataUnregistered(t *testing.T) {
+	var u SEIUserDataUnregistered
+	err := u.Unmarshal(casesSEI[0].sei.Messages[0].Payload)
+	require.NoError(t, err)
+	require.Equal(t, SEIUserDataUnregistered{
+		UUID: [16]byte{
+			0x2c, 0xa2, 0xde, 0x09, 0xb5, 0x17, 0x47, 0xdb,
+			0xbb, 0x55, 0xa4, 0xfe, 0x7f, 0xc2, 0xfc, 0x4e,
+		},
+		Payload: []byte("x265"),
+	}, u)
+}
+
+func TestSEIRecoveryPoint(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		r    SEIRecoveryPoint
+	}{
+		{
+			"exact match",
+			[]byte{0xd0},
+			SEIRecoveryPoint{
+				ExactMatchFlag: true,
+			},
+		},
+		{
+			"negative count",
+			[]byte{0x3b},
+			SEIRecoveryPoint{
+				RecoveryPocCnt: -3,
+				BrokenLinkFlag: true,
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var r SEIRecoveryPoint
+			err := r.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.r, r)
+
+			byts, err := r.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func FuzzSEIUnmarshal(f *testing.F) {
+	for _, ca := range casesSEI {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var sei SEI
+		err := sei.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		byts, err := sei.Marshal()
+		require.NoError(t, err)
+
+		var sei2 SEI
+		err = sei2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, sei, sei2)
+	})
+}
@@ -0,0 +1,250 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+func readSigned(buf []byte, pos *int, n int) int32 {
+	v := bits.ReadBitsUnsafe(buf, pos, n)
+	if (v & (1 << (n - 1))) != 0 {
+		return int32(int64(v) - (1 << n))
+	}
+	return int32(v)
+}
+
+// SEITimeCode_ClockTimestamp is a clock timestamp of a time_code SEI payload.
+type SEITimeCode_ClockTimestamp struct { //nolint:revive
+	UnitsFieldBasedFlag bool
+	CountingType        uint8
+	FullTimestampFlag   bool
+	DiscontinuityFlag   bool
+	CntDroppedFlag      bool
+	NFrames             uint16
+
+	// FullTimestampFlag == false
+	SecondsFlag bool
+	MinutesFlag bool
+	HoursFlag   bool
+
+	SecondsValue     uint8
+	MinutesValue     uint8
+	HoursValue       uint8
+	TimeOffsetLength uint8
+	TimeOffsetValue  int32
+}
+
+func (c *SEITimeCode_ClockTimestamp) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 1+5+1+1+1+9+1)
+	if err != nil {
+		return err
+	}
+
+	c.UnitsFieldBasedFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.CountingType = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	c.FullTimestampFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.DiscontinuityFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.CntDroppedFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.NFrames = uint16(bits.ReadBitsUnsafe(buf, pos, 9))
+
+	if c.FullTimestampFlag {
+		err = bits.HasSpace(buf, *pos, 6+6+5)
+		if err != nil {
+			return err
+		}
+
+		c.SecondsValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+		c.MinutesValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+		c.HoursValue = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	} else {
+		c.SecondsFlag = bits.ReadFlagUnsafe(buf, pos)
+
+		if c.SecondsFlag {
+			err = bits.HasSpace(buf, *pos, 6+1)
+			if err != nil {
+				return err
+			}
+
+			c.SecondsValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+			c.MinutesFlag = bits.ReadFlagUnsafe(buf, pos)
+
+			if c.MinutesFlag {
+				err = bits.HasSpace(buf, *pos, 6+1)
+				if err != nil {
+					return err
+				}
+
+				c.MinutesValue = uint8(bits.ReadBitsUnsafe(buf, pos, 6))
+				c.HoursFlag = bits.ReadFlagUnsafe(buf, pos)
+
+				if c.HoursFlag {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, 5)
+					if err != nil {
+						return err
+					}
+					c.HoursValue = uint8(tmp)
+				}
+			}
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 5)
+	if err != nil {
+		return err
+	}
+	c.TimeOffsetLength = uint8(tmp)
+
+	if c.TimeOffsetLength > 0 {
+		err = bits.HasSpace(buf, *pos, int(c.TimeOffsetLength))
+		if err != nil {
+			return err
+		}
+
+		c.TimeOffsetValue = readSigned(buf, pos, int(c.TimeOffsetLength))
+	} else {
+		c.TimeOffsetValue = 0
+	}
+
+	return nil
+}
+
+func (c SEITimeCode_ClockTimestamp) marshalSizeBits() int {
+	n := 1 + 5 + 1 + 1 + 1 + 9
+
+	switch {
+	case c.FullTimestampFlag:
+		n += 6 + 6 + 5
+
+	case c.SecondsFlag && c.MinutesFlag && c.HoursFlag:
+		n += 1 + 6 + 1 + 6 + 1 + 5
+
+	case c.SecondsFlag && c.MinutesFlag:
+		n += 1 + 6 + 1 + 6 + 1
+
+	case c.SecondsFlag:
+		n += 1 + 6 + 1
+
+	default:
+		n++
+	}
+
+	return n + 5 + int(c.TimeOffsetLength)
+}
+
+func (c SEITimeCode_ClockTimestamp) marshalTo(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, c.UnitsFieldBasedFlag)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.CountingType), 5)
+	bits.WriteFlagUnsafe(buf, pos, c.FullTimestampFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.DiscontinuityFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.CntDroppedFlag)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.NFrames), 9)
+
+	if c.FullTimestampFlag {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.SecondsValue), 6)
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.MinutesValue), 6)
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.HoursValue), 5)
+	} else {
+		bits.WriteFlagUnsafe(buf, pos, c.SecondsFlag)
+
+		if c.SecondsFlag {
+			bits.WriteBitsUnsafe(buf, pos, uint64(c.SecondsValue), 6)
+			bits.WriteFlagUnsafe(buf, pos, c.MinutesFlag)
+
+			if c.MinutesFlag {
+				bits.WriteBitsUnsafe(buf, pos, uint64(c.MinutesValue), 6)
+				bits.WriteFlagUnsafe(buf, pos, c.HoursFlag)
+
+				if c.HoursFlag {
+					bits.WriteBitsUnsafe(buf, pos, uint64(c.HoursValue), 5)
+				}
+			}
+		}
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.TimeOffsetLength), 5)
+
+	if c.TimeOffsetLength > 0 {
+		mask := uint64(1)<<c.TimeOffsetLength - 1
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.TimeOffsetValue)&mask, int(c.TimeOffsetLength))
+	}
+}
+
+// SEITimeCode is a time_code SEI payload.
+// Specification: ITU-T Rec. H.265, D.2.27
+type SEITimeCode struct {
+	// It contains a nil entry when clock_timestamp_flag is false.
+	ClockTimestamps []*SEITimeCode_ClockTimestamp
+}
+
+// Unmarshal decodes a SEITimeCode from a SEI message payload.
+func (t *SEITimeCode) Unmarshal(buf []byte) error {
+	pos := 0
+
+	numClockTS, err := bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+
+	t.ClockTimestamps = make([]*SEITimeCode_ClockTimestamp, numClockTS)
+
+	for i := range t.ClockTimestamps {
+		var clockTimestampFlag bool
+		clockTimestampFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if clockTimestampFlag {
+			t.ClockTimestamps[i] = &SEITimeCode_ClockTimestamp{}
+			err = t.ClockTimestamps[i].unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal encodes a SEITimeCode into a SEI message payload.
+func (t SEITimeCode) Marshal() ([]byte, error) {
+	if len(t.ClockTimestamps) > 3 {
+		return nil, fmt.Errorf("too many clock timestamps")
+	}
+
+	n := 2
+
+	for _, c := range t.ClockTimestamps {
+		n++
+
+		if c != nil {
+			if c.TimeOffsetLength > 31 {
+				return nil, fmt.Errorf("invalid time_offset_length: %d", c.TimeOffsetLength)
+			}
+
+			n += c.marshalSizeBits()
+		}
+	}
+
+	buf := make([]byte, (n+7)/8)
+	pos := 0
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(len(t.ClockTimestamps)), 2)
+
+	for _, c := range t.ClockTimestamps {
+		bits.WriteFlagUnsafe(buf, &pos, c != nil)
+
+		if c != nil {
+			c.marshalTo(buf, &pos)
+		}
+	}
+
+	// payload byte alignment
+	if (pos % 8) != 0 {
+		bits.WriteFlagUnsafe(buf, &pos, true)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,91 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSEITimeCode = []struct {
+	name string
+	byts []byte
+	tc   SEITimeCode
+}{
+	{
+		"full timestamp",
+		[]byte{0x60, 0x40, 0x64, 0x5c, 0x28, 0x10},
+		SEITimeCode{
+			ClockTimestamps: []*SEITimeCode_ClockTimestamp{{
+				FullTimestampFlag: true,
+				NFrames:           12,
+				SecondsValue:      34,
+				MinutesValue:      56,
+				HoursValue:        10,
+			}},
+		},
+	},
+	{
+		"partial timestamp with offset",
+		[]byte{0x99, 0x10, 0x0e, 0x2c, 0x62, 0x3f, 0xa0},
+		SEITimeCode{
+			ClockTimestamps: []*SEITimeCode_ClockTimestamp{
+				nil,
+				{
+					UnitsFieldBasedFlag: true,
+					CountingType:        4,
+					DiscontinuityFlag:   true,
+					NFrames:             3,
+					SecondsFlag:         true,
+					SecondsValue:        5,
+					MinutesFlag:         true,
+					MinutesValue:        6,
+					TimeOffsetLength:    8,
+					TimeOffsetValue:     -2,
+				},
+			},
+		},
+	},
+}
+
+func TestSEITimeCodeUnmarshal(t *testing.T) {
+	for _, ca := range casesSEITimeCode {
+		t.Run(ca.name, func(t *testing.T) {
+			var tc SEITimeCode
+			err := tc.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.tc, tc)
+		})
+	}
+}
+
+func TestSEITimeCodeMarshal(t *testing.T) {
+	for _, ca := range casesSEITimeCode {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.tc.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func FuzzSEITimeCodeUnmarshal(f *testing.F) {
+	for _, ca := range casesSEITimeCode {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var tc SEITimeCode
+		err := tc.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		byts, err := tc.Marshal()
+		require.NoError(t, err)
+
+		var tc2 SEITimeCode
+		err = tc2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, tc, tc2)
+	})
+}
@@ -0,0 +1,15 @@
+package h265
+
+import (
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+// SEIUserDataRegisteredITUTT35 is a user_data_registered_itu_t_t35 SEI payload.
+// Its syntax is the same as in H264.
+// Specification: ITU-T Rec. H.265, D.2.5
+type SEIUserDataRegisteredITUTT35 = h264.SEIUserDataRegisteredITUTT35
+
+// SEIUserDataUnregistered is a user_data_unregistered SEI payload.
+// Its syntax is the same as in H264.
+// Specification: ITU-T Rec. H.265, D.2.6
+type SEIUserDataUnregistered = h264.SEIUserDataUnregistered
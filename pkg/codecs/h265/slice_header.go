@@ -0,0 +1,489 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+const (
+	maxLongTermPics = 32
+)
+
+func ceilLog2(v uint32) int {
+	n := 0
+	for (uint32(1) << n) < v {
+		n++
+	}
+	return n
+}
+
+// SliceType is a slice type.
+// Specification: ITU-T Rec. H.265, Table 7-7
+type SliceType uint32
+
+// slice types.
+const (
+	SliceTypeB SliceType = 0
+	SliceTypeP SliceType = 1
+	SliceTypeI SliceType = 2
+)
+
+// String implements fmt.Stringer.
+func (t SliceType) String() string {
+	switch t {
+	case SliceTypeB:
+		return "B"
+	case SliceTypeP:
+		return "P"
+	case SliceTypeI:
+		return "I"
+	}
+	return fmt.Sprintf("unknown (%d)", uint32(t))
+}
+
+// SliceHeader_LongTermRefPics are the long-term reference pictures of a slice header.
+type SliceHeader_LongTermRefPics struct { //nolint:revive
+	NumLongTermSps         uint32
+	NumLongTermPics        uint32
+	LtIdxSps               []uint32
+	PocLsbLt               []uint32
+	UsedByCurrPicLtFlag    []bool
+	DeltaPocMsbPresentFlag []bool
+	DeltaPocMsbCycleLt     []uint32
+}
+
+func (r *SliceHeader_LongTermRefPics) unmarshal(buf []byte, pos *int, sps *SPS) error {
+	var err error
+	numLongTermRefPicsSPS := uint32(len(sps.LtRefPicPocLsbSps))
+
+	if numLongTermRefPicsSPS > 0 {
+		r.NumLongTermSps, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if r.NumLongTermSps > numLongTermRefPicsSPS {
+			return fmt.Errorf("invalid num_long_term_sps")
+		}
+	}
+
+	r.NumLongTermPics, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if r.NumLongTermPics > maxLongTermPics {
+		return fmt.Errorf("num_long_term_pics exceeds %d", maxLongTermPics)
+	}
+
+	n := r.NumLongTermSps + r.NumLongTermPics
+
+	if r.NumLongTermSps > 0 {
+		r.LtIdxSps = make([]uint32, r.NumLongTermSps)
+	}
+
+	if n > 0 {
+		r.PocLsbLt = make([]uint32, n)
+		r.UsedByCurrPicLtFlag = make([]bool, n)
+		r.DeltaPocMsbPresentFlag = make([]bool, n)
+		r.DeltaPocMsbCycleLt = make([]uint32, n)
+	}
+
+	for i := range n {
+		if i < r.NumLongTermSps {
+			if numLongTermRefPicsSPS > 1 {
+				var tmp uint64
+				tmp, err = bits.ReadBits(buf, pos, ceilLog2(numLongTermRefPicsSPS))
+				if err != nil {
+					return err
+				}
+				r.LtIdxSps[i] = uint32(tmp)
+
+				if r.LtIdxSps[i] >= numLongTermRefPicsSPS {
+					return fmt.Errorf("invalid lt_idx_sps")
+				}
+			}
+
+			r.PocLsbLt[i] = sps.LtRefPicPocLsbSps[r.LtIdxSps[i]]
+			r.UsedByCurrPicLtFlag[i] = sps.UsedByCurrPicLtSpsFlag[r.LtIdxSps[i]]
+		} else {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+			if err != nil {
+				return err
+			}
+			r.PocLsbLt[i] = uint32(tmp)
+
+			r.UsedByCurrPicLtFlag[i], err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		r.DeltaPocMsbPresentFlag[i], err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if r.DeltaPocMsbPresentFlag[i] {
+			r.DeltaPocMsbCycleLt[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SliceHeader_RefPicListsModification is the reference picture lists modification of a slice header.
+type SliceHeader_RefPicListsModification struct { //nolint:revive
+	RefPicListModificationFlagL0 bool
+	ListEntryL0                  []uint32
+	RefPicListModificationFlagL1 bool
+	ListEntryL1                  []uint32
+}
+
+func (m *SliceHeader_RefPicListsModification) unmarshal(
+	buf []byte,
+	pos *int,
+	h *SliceHeader,
+	numPicTotalCurr uint32,
+) error {
+	n := ceilLog2(numPicTotalCurr)
+
+	var err error
+	m.RefPicListModificationFlagL0, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if m.RefPicListModificationFlagL0 {
+		err = bits.HasSpace(buf, *pos, n*int(h.NumRefIdxL0ActiveMinus1+1))
+		if err != nil {
+			return err
+		}
+
+		m.ListEntryL0 = make([]uint32, h.NumRefIdxL0ActiveMinus1+1)
+
+		for i := range m.ListEntryL0 {
+			m.ListEntryL0[i] = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+		}
+	}
+
+	if h.SliceType == SliceTypeB {
+		m.RefPicListModificationFlagL1, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if m.RefPicListModificationFlagL1 {
+			err = bits.HasSpace(buf, *pos, n*int(h.NumRefIdxL1ActiveMinus1+1))
+			if err != nil {
+				return err
+			}
+
+			m.ListEntryL1 = make([]uint32, h.NumRefIdxL1ActiveMinus1+1)
+
+			for i := range m.ListEntryL1 {
+				m.ListEntryL1[i] = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+			}
+		}
+	}
+
+	return nil
+}
+
+// SliceHeader is a H265 slice segment header.
+// The header is decoded up to ref_pic_lists_modification().
+// Specification: ITU-T Rec. H.265, 7.3.6.1
+type SliceHeader struct {
+	// derived from the NALU header
+	IDRPicFlag bool
+	CRAPicFlag bool
+
+	FirstSliceSegmentInPicFlag bool
+	NoOutputOfPriorPicsFlag    bool
+	PPSID                      uint32
+	DependentSliceSegmentFlag  bool
+	SegmentAddress             uint32
+
+	// the following fields are filled only when DependentSliceSegmentFlag is false
+	SliceType     SliceType
+	PicOutputFlag bool
+	ColourPlaneID uint8
+
+	// IDRPicFlag == false
+	PicOrderCntLsb              uint32
+	ShortTermRefPicSetSPSFlag   bool
+	ShortTermRefPicSet          *SPS_ShortTermRefPicSet
+	ShortTermRefPicSetIdx       uint32
+	LongTermRefPics             *SliceHeader_LongTermRefPics
+	SliceTemporalMvpEnabledFlag bool
+
+	SaoLumaFlag   bool
+	SaoChromaFlag bool
+
+	// SliceType == SliceTypeP || SliceType == SliceTypeB
+	NumRefIdxActiveOverrideFlag bool
+	NumRefIdxL0ActiveMinus1     uint32
+	NumRefIdxL1ActiveMinus1     uint32
+	RefPicListsModification     *SliceHeader_RefPicListsModification
+}
+
+// Unmarshal decodes a SliceHeader from a VCL NALU, using the active SPS and PPS.
+func (h *SliceHeader) Unmarshal(buf []byte, sps *SPS, pps *PPS) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	typ := NALUType((buf[0] >> 1) & 0b111111)
+	if typ > NALUType_RSV_IRAP_VCL23 {
+		return fmt.Errorf("not a VCL NALU")
+	}
+
+	*h = SliceHeader{
+		IDRPicFlag:    typ == NALUType_IDR_W_RADL || typ == NALUType_IDR_N_LP,
+		CRAPicFlag:    typ == NALUType_CRA_NUT,
+		PicOutputFlag: true,
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[1:])
+	pos := 8
+
+	var err error
+	h.FirstSliceSegmentInPicFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if typ >= NALUType_BLA_W_LP && typ <= NALUType_RSV_IRAP_VCL23 {
+		h.NoOutputOfPriorPicsFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	h.PPSID, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if h.PPSID != pps.ID {
+		return fmt.Errorf("slice refers to PPS %d, but PPS %d was provided", h.PPSID, pps.ID)
+	}
+
+	if pps.SPSID != uint32(sps.ID) {
+		return fmt.Errorf("PPS refers to SPS %d, but SPS %d was provided", pps.SPSID, sps.ID)
+	}
+
+	if !h.FirstSliceSegmentInPicFlag {
+		if pps.DependentSliceSegmentsEnabledFlag {
+			h.DependentSliceSegmentFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		minCbLog2SizeY := sps.Log2MinLumaCodingBlockSizeMinus3 + 3
+		ctbLog2SizeY := minCbLog2SizeY + sps.Log2DiffMaxMinLumaCodingBlockSize
+		if ctbLog2SizeY > 6 {
+			return fmt.Errorf("invalid CTB size")
+		}
+
+		ctbSizeY := uint32(1) << ctbLog2SizeY
+		picWidthInCtbsY := (sps.PicWidthInLumaSamples + ctbSizeY - 1) / ctbSizeY
+		picHeightInCtbsY := (sps.PicHeightInLumaSamples + ctbSizeY - 1) / ctbSizeY
+		picSizeInCtbsY := picWidthInCtbsY * picHeightInCtbsY
+
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, ceilLog2(picSizeInCtbsY))
+		if err != nil {
+			return err
+		}
+		h.SegmentAddress = uint32(tmp)
+
+		if h.SegmentAddress >= picSizeInCtbsY {
+			return fmt.Errorf("invalid slice_segment_address: %d", h.SegmentAddress)
+		}
+	}
+
+	if h.DependentSliceSegmentFlag {
+		return nil
+	}
+
+	err = bits.HasSpace(buf, pos, int(pps.NumExtraSliceHeaderBits))
+	if err != nil {
+		return err
+	}
+	pos += int(pps.NumExtraSliceHeaderBits) // slice_reserved_flag
+
+	tmp, err := bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+	h.SliceType = SliceType(tmp)
+
+	if h.SliceType > SliceTypeI {
+		return fmt.Errorf("invalid slice_type: %d", h.SliceType)
+	}
+
+	if pps.OutputFlagPresentFlag {
+		h.PicOutputFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sps.SeparateColourPlaneFlag {
+		var tmp2 uint64
+		tmp2, err = bits.ReadBits(buf, &pos, 2)
+		if err != nil {
+			return err
+		}
+		h.ColourPlaneID = uint8(tmp2)
+	}
+
+	var numPicTotalCurr uint32
+
+	if !h.IDRPicFlag {
+		var tmp2 uint64
+		tmp2, err = bits.ReadBits(buf, &pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+		if err != nil {
+			return err
+		}
+		h.PicOrderCntLsb = uint32(tmp2)
+
+		h.ShortTermRefPicSetSPSFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		numShortTermRefPicSets := uint32(len(sps.ShortTermRefPicSets))
+
+		var rps *SPS_ShortTermRefPicSet
+
+		if !h.ShortTermRefPicSetSPSFlag {
+			h.ShortTermRefPicSet = &SPS_ShortTermRefPicSet{}
+			err = h.ShortTermRefPicSet.unmarshal(buf, &pos, numShortTermRefPicSets,
+				numShortTermRefPicSets, sps.ShortTermRefPicSets)
+			if err != nil {
+				return err
+			}
+
+			rps = h.ShortTermRefPicSet
+		} else {
+			if numShortTermRefPicSets == 0 {
+				return fmt.Errorf("SPS does not contain short-term reference picture sets")
+			}
+
+			if numShortTermRefPicSets > 1 {
+				tmp2, err = bits.ReadBits(buf, &pos, ceilLog2(numShortTermRefPicSets))
+				if err != nil {
+					return err
+				}
+				h.ShortTermRefPicSetIdx = uint32(tmp2)
+
+				if h.ShortTermRefPicSetIdx >= numShortTermRefPicSets {
+					return fmt.Errorf("invalid short_term_ref_pic_set_idx")
+				}
+			}
+
+			rps = sps.ShortTermRefPicSets[h.ShortTermRefPicSetIdx]
+		}
+
+		for _, used := range rps.UsedByCurrPicS0Flag {
+			if used {
+				numPicTotalCurr++
+			}
+		}
+
+		for _, used := range rps.UsedByCurrPicS1Flag {
+			if used {
+				numPicTotalCurr++
+			}
+		}
+
+		if sps.LongTermRefPicsPresentFlag {
+			h.LongTermRefPics = &SliceHeader_LongTermRefPics{}
+			err = h.LongTermRefPics.unmarshal(buf, &pos, sps)
+			if err != nil {
+				return err
+			}
+
+			for _, used := range h.LongTermRefPics.UsedByCurrPicLtFlag {
+				if used {
+					numPicTotalCurr++
+				}
+			}
+		}
+
+		if sps.TemporalMvpEnabledFlag {
+			h.SliceTemporalMvpEnabledFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if sps.SampleAdaptiveOffsetEnabledFlag {
+		h.SaoLumaFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		chromaArrayType := sps.ChromaFormatIdc
+		if sps.SeparateColourPlaneFlag {
+			chromaArrayType = 0
+		}
+
+		if chromaArrayType != 0 {
+			h.SaoChromaFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if h.SliceType == SliceTypeP || h.SliceType == SliceTypeB {
+		h.NumRefIdxActiveOverrideFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.NumRefIdxActiveOverrideFlag {
+			h.NumRefIdxL0ActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if h.SliceType == SliceTypeB {
+				h.NumRefIdxL1ActiveMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+				if err != nil {
+					return err
+				}
+			}
+
+			if h.NumRefIdxL0ActiveMinus1 > 14 || h.NumRefIdxL1ActiveMinus1 > 14 {
+				return fmt.Errorf("invalid num_ref_idx_active_minus1")
+			}
+		} else {
+			h.NumRefIdxL0ActiveMinus1 = pps.NumRefIdxL0DefaultActiveMinus1
+			if h.SliceType == SliceTypeB {
+				h.NumRefIdxL1ActiveMinus1 = pps.NumRefIdxL1DefaultActiveMinus1
+			}
+		}
+
+		if pps.ListsModificationPresentFlag && numPicTotalCurr > 1 {
+			h.RefPicListsModification = &SliceHeader_RefPicListsModification{}
+			err = h.RefPicListsModification.unmarshal(buf, &pos, h, numPicTotalCurr)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
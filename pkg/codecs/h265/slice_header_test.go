@@ -0,0 +1,205 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSliceHeader = []struct {
+	name  string
+	sps   []byte
+	pps   []byte
+	byts  []byte
+	slice SliceHeader
+}{
+	{
+		"idr",
+		[]byte{
+			0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+			0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+			0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+			0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+			0xe0, 0x80,
+		},
+		[]byte{
+			0x44, 0x01, 0xc1, 0x72, 0xb4, 0x62, 0x40,
+		},
+		[]byte{
+			0x26, 0x01, 0xaf, 0x08, 0x42, 0x23, 0x48, 0x8a,
+			0x43, 0xe2,
+		},
+		SliceHeader{
+			IDRPicFlag:                 true,
+			FirstSliceSegmentInPicFlag: true,
+			SliceType:                  SliceTypeI,
+			PicOutputFlag:              true,
+			SaoLumaFlag:                true,
+			SaoChromaFlag:              true,
+		},
+	},
+	{
+		"b with explicit rps",
+		[]byte{
+			0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+			0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+			0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+			0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+			0xe0, 0x80,
+		},
+		[]byte{
+			0x44, 0x01, 0xc1, 0x72, 0xb4, 0x62, 0x40,
+		},
+		[]byte{
+			0x00, 0x01, 0xe0, 0x24, 0xff, 0xfa, 0x24, 0x0a,
+			0x42, 0x25, 0x8c, 0x18, 0xe6, 0x1c, 0xea, 0x5a,
+			0x5d, 0x07, 0xc1, 0x8f,
+		},
+		SliceHeader{
+			FirstSliceSegmentInPicFlag: true,
+			SliceType:                  SliceTypeB,
+			PicOutputFlag:              true,
+			PicOrderCntLsb:             1,
+			ShortTermRefPicSet: &SPS_ShortTermRefPicSet{
+				NumNegativePics:     1,
+				NumPositivePics:     2,
+				DeltaPocS0:          []int32{-1},
+				UsedByCurrPicS0Flag: []bool{true},
+				DeltaPocS1:          []int32{1, 2},
+				UsedByCurrPicS1Flag: []bool{true, true},
+			},
+			SliceTemporalMvpEnabledFlag: true,
+			SaoLumaFlag:                 true,
+			SaoChromaFlag:               true,
+			NumRefIdxActiveOverrideFlag: true,
+			NumRefIdxL1ActiveMinus1:     1,
+		},
+	},
+	{
+		"b with long term ref pics",
+		[]byte{
+			0x42, 0x01, 0x01, 0x01, 0x40, 0x00, 0x00, 0x03,
+			0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x7b, 0xa0, 0x03, 0xc0, 0x80, 0x11, 0x07,
+			0xcb, 0xb1, 0x1e, 0xe4, 0x6c, 0x0a, 0x9f, 0xa6,
+			0xb9, 0x97, 0x92, 0xcf, 0x60, 0x2d, 0x40, 0x40,
+			0x40, 0x45, 0x00, 0x00, 0x03, 0x00, 0x01, 0x00,
+			0x00, 0x03, 0x00, 0x3c, 0x60, 0x35, 0xef, 0x7e,
+			0x00, 0x02, 0x62, 0x58, 0x00, 0x26, 0x17, 0x20,
+		},
+		[]byte{
+			0x44, 0x01, 0xc0, 0x3c, 0xf0, 0x1b, 0x64,
+		},
+		[]byte{
+			0x02, 0x01, 0xe2, 0x0a, 0x4f, 0xdd, 0x1e, 0xb7,
+			0xb7, 0xa1, 0x80, 0xad, 0xc7, 0x3c, 0x2e, 0x33,
+		},
+		SliceHeader{
+			FirstSliceSegmentInPicFlag: true,
+			SliceType:                  SliceTypeB,
+			PicOutputFlag:              true,
+			PicOrderCntLsb:             4,
+			ShortTermRefPicSet: &SPS_ShortTermRefPicSet{
+				NumNegativePics:     1,
+				DeltaPocS0:          []int32{-4},
+				UsedByCurrPicS0Flag: []bool{true},
+			},
+			LongTermRefPics:             &SliceHeader_LongTermRefPics{},
+			SliceTemporalMvpEnabledFlag: true,
+			NumRefIdxActiveOverrideFlag: true,
+		},
+	},
+	{
+		"p, not first slice",
+		[]byte{
+			0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+			0x00, 0xb0, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x96, 0xa0, 0x03, 0xc0, 0x80, 0x11, 0x07,
+			0xcb, 0xeb, 0xb9, 0x32, 0x4b, 0xa9, 0x48, 0x28,
+			0x30, 0x28, 0x17, 0x68, 0x50, 0x94,
+		},
+		[]byte{
+			0x44, 0x01, 0xc0, 0xe3, 0x0f, 0x09, 0xc1, 0x40,
+			0xa6, 0x08, 0x40,
+		},
+		[]byte{
+			0x02, 0x01, 0x40, 0xa2, 0x1f, 0xcc, 0x1a, 0xf0,
+			0x90, 0x80, 0x18, 0x17, 0x0a, 0xbe, 0xc8, 0xf9,
+		},
+		SliceHeader{
+			SegmentAddress:              20,
+			SliceType:                   SliceTypeP,
+			PicOutputFlag:               true,
+			PicOrderCntLsb:              1,
+			ShortTermRefPicSetSPSFlag:   true,
+			SliceTemporalMvpEnabledFlag: true,
+			SaoLumaFlag:                 true,
+			SaoChromaFlag:               true,
+			NumRefIdxActiveOverrideFlag: true,
+		},
+	},
+}
+
+func TestSliceHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesSliceHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var sps SPS
+			err := sps.Unmarshal(ca.sps)
+			require.NoError(t, err)
+
+			var pps PPS
+			err = pps.Unmarshal(ca.pps)
+			require.NoError(t, err)
+
+			var h SliceHeader
+			err = h.Unmarshal(ca.byts, &sps, &pps)
+			require.NoError(t, err)
+			require.Equal(t, ca.slice, h)
+		})
+	}
+}
+
+func TestSliceHeaderUnmarshalErrors(t *testing.T) {
+	ca := casesSliceHeader[3]
+
+	var sps SPS
+	err := sps.Unmarshal(ca.sps)
+	require.NoError(t, err)
+
+	var pps PPS
+	err = pps.Unmarshal(ca.pps)
+	require.NoError(t, err)
+
+	var h SliceHeader
+	err = h.Unmarshal([]byte{0x02, 0x01, 0x7f, 0xfa}, &sps, &pps)
+	require.EqualError(t, err, "invalid slice_segment_address: 2047")
+
+	pps.SPSID = 1
+	err = h.Unmarshal(ca.byts, &sps, &pps)
+	require.EqualError(t, err, "PPS refers to SPS 1, but SPS 0 was provided")
+}
+
+func FuzzSliceHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesSliceHeader {
+		f.Add(ca.sps, ca.pps, ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, spsBuf []byte, ppsBuf []byte, b []byte) {
+		var sps SPS
+		err := sps.Unmarshal(spsBuf)
+		if err != nil {
+			return
+		}
+
+		var pps PPS
+		err = pps.Unmarshal(ppsBuf)
+		if err != nil {
+			return
+		}
+
+		var h SliceHeader
+		h.Unmarshal(b, &sps, &pps) //nolint:errcheck
+	})
+}
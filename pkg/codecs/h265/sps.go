@@ -8,9 +8,10 @@ import (
 )
 
 const (
-	maxNegativePics     = 255
-	maxPositivePics     = 255
-	maxShortTermRefPics = 64
+	maxNegativePics       = 255
+	maxPositivePics       = 255
+	maxShortTermRefPics   = 64
+	maxLongTermRefPicsSPS = 32
 )
 
 var subWidthC = []uint32{
@@ -32,6 +33,7 @@ type SPS_ScalingListData struct { //nolint:revive
 	ScalingListPredModeFlag      [4][6]bool
 	ScalingListPredmatrixIDDelta [4][6]uint32
 	ScalingListDcCoefMinus8      [4][6]int32
+	ScalingListDeltaCoef         [4][6][]int32
 }
 
 func (d *SPS_ScalingListData) unmarshal(buf []byte, pos *int) error {
@@ -63,13 +65,91 @@ func (d *SPS_ScalingListData) unmarshal(buf []byte, pos *int) error {
 					if err != nil {
 						return err
 					}
+
+					if d.ScalingListDcCoefMinus8[sizeID-2][matrixID] < -7 ||
+						d.ScalingListDcCoefMinus8[sizeID-2][matrixID] > 247 {
+						return fmt.Errorf("invalid scaling_list_dc_coef_minus8")
+					}
 				}
 
-				for range coefNum {
-					_, err = bits.ReadGolombSigned(buf, pos) // scalingListDeltaCoef
+				d.ScalingListDeltaCoef[sizeID][matrixID] = make([]int32, coefNum)
+
+				for i := range coefNum {
+					d.ScalingListDeltaCoef[sizeID][matrixID][i], err = bits.ReadGolombSigned(buf, pos)
 					if err != nil {
 						return err
 					}
+
+					if d.ScalingListDeltaCoef[sizeID][matrixID][i] < -128 ||
+						d.ScalingListDeltaCoef[sizeID][matrixID][i] > 127 {
+						return fmt.Errorf("invalid scaling_list_delta_coef")
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d SPS_ScalingListData) marshalSizeBits() int {
+	n := 0
+
+	for sizeID := range 4 {
+		var matrixIDIncr int
+		if sizeID == 3 {
+			matrixIDIncr = 3
+		} else {
+			matrixIDIncr = 1
+		}
+
+		for matrixID := 0; matrixID < 6; matrixID += matrixIDIncr {
+			n++
+
+			if !d.ScalingListPredModeFlag[sizeID][matrixID] {
+				n += bits.GolombUnsignedSize(d.ScalingListPredmatrixIDDelta[sizeID][matrixID])
+			} else {
+				if sizeID > 1 {
+					n += bits.GolombSignedSize(d.ScalingListDcCoefMinus8[sizeID-2][matrixID])
+				}
+
+				for _, v := range d.ScalingListDeltaCoef[sizeID][matrixID] {
+					n += bits.GolombSignedSize(v)
+				}
+			}
+		}
+	}
+
+	return n
+}
+
+func (d SPS_ScalingListData) marshalTo(buf []byte, pos *int) error {
+	for sizeID := range 4 {
+		var matrixIDIncr int
+		if sizeID == 3 {
+			matrixIDIncr = 3
+		} else {
+			matrixIDIncr = 1
+		}
+
+		for matrixID := 0; matrixID < 6; matrixID += matrixIDIncr {
+			bits.WriteFlagUnsafe(buf, pos, d.ScalingListPredModeFlag[sizeID][matrixID])
+
+			if !d.ScalingListPredModeFlag[sizeID][matrixID] {
+				bits.WriteGolombUnsignedUnsafe(buf, pos, d.ScalingListPredmatrixIDDelta[sizeID][matrixID])
+			} else {
+				coefNum := min(64, 1<<(4+(sizeID<<1)))
+
+				if len(d.ScalingListDeltaCoef[sizeID][matrixID]) != coefNum {
+					return fmt.Errorf("scaling list %d/%d must have %d coefficients", sizeID, matrixID, coefNum)
+				}
+
+				if sizeID > 1 {
+					bits.WriteGolombSignedUnsafe(buf, pos, d.ScalingListDcCoefMinus8[sizeID-2][matrixID])
+				}
+
+				for _, v := range d.ScalingListDeltaCoef[sizeID][matrixID] {
+					bits.WriteGolombSignedUnsafe(buf, pos, v)
 				}
 			}
 		}
@@ -111,6 +191,406 @@ func (w *SPS_Window) unmarshal(buf []byte, pos *int) error {
 	return nil
 }
 
+func (w SPS_Window) marshalSizeBits() int {
+	return bits.GolombUnsignedSize(w.LeftOffset) +
+		bits.GolombUnsignedSize(w.RightOffset) +
+		bits.GolombUnsignedSize(w.TopOffset) +
+		bits.GolombUnsignedSize(w.BottomOffset)
+}
+
+func (w SPS_Window) marshalTo(buf []byte, pos *int) {
+	bits.WriteGolombUnsignedUnsafe(buf, pos, w.LeftOffset)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, w.RightOffset)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, w.TopOffset)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, w.BottomOffset)
+}
+
+// SPS_SubLayerHRDParameters are the HRD parameters of a CPB of a sub-layer.
+type SPS_SubLayerHRDParameters struct { //nolint:revive
+	BitRateValueMinus1 uint32
+	CpbSizeValueMinus1 uint32
+
+	// SubPicHRDParamsPresentFlag == true
+	CpbSizeDuValueMinus1 uint32
+	BitRateDuValueMinus1 uint32
+
+	CbrFlag bool
+}
+
+func readSubLayerHRDParameters(
+	buf []byte,
+	pos *int,
+	cpbCnt uint32,
+	subPicHRDParamsPresentFlag bool,
+) ([]SPS_SubLayerHRDParameters, error) {
+	params := make([]SPS_SubLayerHRDParameters, cpbCnt)
+
+	for i := range params {
+		var err error
+		params[i].BitRateValueMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		params[i].CpbSizeValueMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if subPicHRDParamsPresentFlag {
+			params[i].CpbSizeDuValueMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			params[i].BitRateDuValueMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		params[i].CbrFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return params, nil
+}
+
+func subLayerHRDParametersMarshalSize(params []SPS_SubLayerHRDParameters, subPicHRDParamsPresentFlag bool) int {
+	n := 0
+
+	for _, p := range params {
+		n += bits.GolombUnsignedSize(p.BitRateValueMinus1) +
+			bits.GolombUnsignedSize(p.CpbSizeValueMinus1)
+
+		if subPicHRDParamsPresentFlag {
+			n += bits.GolombUnsignedSize(p.CpbSizeDuValueMinus1) +
+				bits.GolombUnsignedSize(p.BitRateDuValueMinus1)
+		}
+
+		n++
+	}
+
+	return n
+}
+
+func writeSubLayerHRDParameters(
+	buf []byte,
+	pos *int,
+	params []SPS_SubLayerHRDParameters,
+	subPicHRDParamsPresentFlag bool,
+) {
+	for _, p := range params {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, p.BitRateValueMinus1)
+		bits.WriteGolombUnsignedUnsafe(buf, pos, p.CpbSizeValueMinus1)
+
+		if subPicHRDParamsPresentFlag {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, p.CpbSizeDuValueMinus1)
+			bits.WriteGolombUnsignedUnsafe(buf, pos, p.BitRateDuValueMinus1)
+		}
+
+		bits.WriteFlagUnsafe(buf, pos, p.CbrFlag)
+	}
+}
+
+// SPS_HRDSubLayer are the HRD parameters of a sub-layer.
+type SPS_HRDSubLayer struct { //nolint:revive
+	FixedPicRateGeneralFlag bool
+
+	// equal to true when FixedPicRateGeneralFlag is true
+	FixedPicRateWithinCvsFlag bool
+
+	// FixedPicRateWithinCvsFlag == true
+	ElementalDurationInTcMinus1 uint32
+
+	// FixedPicRateWithinCvsFlag == false
+	LowDelayHRDFlag bool
+
+	// LowDelayHRDFlag == false
+	CpbCntMinus1 uint32
+
+	// NalHRDParametersPresentFlag == true
+	NalHRDParameters []SPS_SubLayerHRDParameters
+
+	// VclHRDParametersPresentFlag == true
+	VclHRDParameters []SPS_SubLayerHRDParameters
+}
+
+// SPS_HRD are the hypothetical reference decoder parameters.
+// Specification: ITU-T Rec. H.265, E.2.2
+type SPS_HRD struct { //nolint:revive
+	NalHRDParametersPresentFlag bool
+	VclHRDParametersPresentFlag bool
+
+	// NalHRDParametersPresentFlag == true || VclHRDParametersPresentFlag == true
+	SubPicHRDParamsPresentFlag bool
+
+	// SubPicHRDParamsPresentFlag == true
+	TickDivisorMinus2                      uint8
+	DuCpbRemovalDelayIncrementLengthMinus1 uint8
+	SubPicCpbParamsInPicTimingSEIFlag      bool
+	DpbOutputDelayDuLengthMinus1           uint8
+
+	// NalHRDParametersPresentFlag == true || VclHRDParametersPresentFlag == true
+	BitRateScale uint8
+	CpbSizeScale uint8
+
+	// SubPicHRDParamsPresentFlag == true
+	CpbSizeDuScale uint8
+
+	// NalHRDParametersPresentFlag == true || VclHRDParametersPresentFlag == true
+	InitialCpbRemovalDelayLengthMinus1 uint8
+	AuCpbRemovalDelayLengthMinus1      uint8
+	DpbOutputDelayLengthMinus1         uint8
+
+	SubLayers []SPS_HRDSubLayer
+}
+
+func (h *SPS_HRD) unmarshal(buf []byte, pos *int, commonInfPresentFlag bool, maxSubLayersMinus1 uint8) error {
+	if commonInfPresentFlag {
+		err := bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		h.NalHRDParametersPresentFlag = bits.ReadFlagUnsafe(buf, pos)
+		h.VclHRDParametersPresentFlag = bits.ReadFlagUnsafe(buf, pos)
+
+		if h.NalHRDParametersPresentFlag || h.VclHRDParametersPresentFlag {
+			h.SubPicHRDParamsPresentFlag, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if h.SubPicHRDParamsPresentFlag {
+				err = bits.HasSpace(buf, *pos, 8+5+1+5)
+				if err != nil {
+					return err
+				}
+
+				h.TickDivisorMinus2 = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+				h.DuCpbRemovalDelayIncrementLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+				h.SubPicCpbParamsInPicTimingSEIFlag = bits.ReadFlagUnsafe(buf, pos)
+				h.DpbOutputDelayDuLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+			}
+
+			err = bits.HasSpace(buf, *pos, 4+4)
+			if err != nil {
+				return err
+			}
+
+			h.BitRateScale = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+			h.CpbSizeScale = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+
+			if h.SubPicHRDParamsPresentFlag {
+				var tmp uint64
+				tmp, err = bits.ReadBits(buf, pos, 4)
+				if err != nil {
+					return err
+				}
+				h.CpbSizeDuScale = uint8(tmp)
+			}
+
+			err = bits.HasSpace(buf, *pos, 5+5+5)
+			if err != nil {
+				return err
+			}
+
+			h.InitialCpbRemovalDelayLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+			h.AuCpbRemovalDelayLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+			h.DpbOutputDelayLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+		}
+	}
+
+	h.SubLayers = make([]SPS_HRDSubLayer, maxSubLayersMinus1+1)
+
+	for i := range h.SubLayers {
+		l := &h.SubLayers[i]
+
+		var err error
+		l.FixedPicRateGeneralFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if !l.FixedPicRateGeneralFlag {
+			l.FixedPicRateWithinCvsFlag, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			l.FixedPicRateWithinCvsFlag = true
+		}
+
+		if l.FixedPicRateWithinCvsFlag {
+			l.ElementalDurationInTcMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			l.LowDelayHRDFlag, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !l.LowDelayHRDFlag {
+			l.CpbCntMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if l.CpbCntMinus1 > 31 {
+				return fmt.Errorf("invalid cpb_cnt_minus1")
+			}
+		}
+
+		if h.NalHRDParametersPresentFlag {
+			l.NalHRDParameters, err = readSubLayerHRDParameters(buf, pos, l.CpbCntMinus1+1,
+				h.SubPicHRDParamsPresentFlag)
+			if err != nil {
+				return err
+			}
+		}
+
+		if h.VclHRDParametersPresentFlag {
+			l.VclHRDParameters, err = readSubLayerHRDParameters(buf, pos, l.CpbCntMinus1+1,
+				h.SubPicHRDParamsPresentFlag)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h SPS_HRD) marshalSizeBits(commonInfPresentFlag bool) int {
+	n := 0
+
+	if commonInfPresentFlag {
+		n += 2
+
+		if h.NalHRDParametersPresentFlag || h.VclHRDParametersPresentFlag {
+			n++
+
+			if h.SubPicHRDParamsPresentFlag {
+				n += 8 + 5 + 1 + 5
+			}
+
+			n += 4 + 4
+
+			if h.SubPicHRDParamsPresentFlag {
+				n += 4
+			}
+
+			n += 5 + 5 + 5
+		}
+	}
+
+	for _, l := range h.SubLayers {
+		n++
+
+		if !l.FixedPicRateGeneralFlag {
+			n++
+		}
+
+		if l.FixedPicRateWithinCvsFlag {
+			n += bits.GolombUnsignedSize(l.ElementalDurationInTcMinus1)
+		} else {
+			n++
+		}
+
+		if !l.LowDelayHRDFlag {
+			n += bits.GolombUnsignedSize(l.CpbCntMinus1)
+		}
+
+		n += subLayerHRDParametersMarshalSize(l.NalHRDParameters, h.SubPicHRDParamsPresentFlag) +
+			subLayerHRDParametersMarshalSize(l.VclHRDParameters, h.SubPicHRDParamsPresentFlag)
+	}
+
+	return n
+}
+
+func (h SPS_HRD) marshalTo(buf []byte, pos *int, commonInfPresentFlag bool, maxSubLayersMinus1 uint8) error {
+	if len(h.SubLayers) != int(maxSubLayersMinus1)+1 {
+		return fmt.Errorf("HRD sub-layer count does not match max_sub_layers_minus1")
+	}
+
+	for _, l := range h.SubLayers {
+		if l.FixedPicRateGeneralFlag && !l.FixedPicRateWithinCvsFlag {
+			return fmt.Errorf("FixedPicRateWithinCvsFlag must be true when FixedPicRateGeneralFlag is true")
+		}
+
+		if l.FixedPicRateWithinCvsFlag && l.LowDelayHRDFlag {
+			return fmt.Errorf("LowDelayHRDFlag can't be set when FixedPicRateWithinCvsFlag is true")
+		}
+
+		if (l.LowDelayHRDFlag && l.CpbCntMinus1 != 0) || l.CpbCntMinus1 > 31 {
+			return fmt.Errorf("invalid CpbCntMinus1")
+		}
+
+		if (h.NalHRDParametersPresentFlag && len(l.NalHRDParameters) != int(l.CpbCntMinus1)+1) ||
+			(!h.NalHRDParametersPresentFlag && len(l.NalHRDParameters) != 0) ||
+			(h.VclHRDParametersPresentFlag && len(l.VclHRDParameters) != int(l.CpbCntMinus1)+1) ||
+			(!h.VclHRDParametersPresentFlag && len(l.VclHRDParameters) != 0) {
+			return fmt.Errorf("sub-layer HRD parameters do not match CpbCntMinus1")
+		}
+	}
+
+	if commonInfPresentFlag {
+		bits.WriteFlagUnsafe(buf, pos, h.NalHRDParametersPresentFlag)
+		bits.WriteFlagUnsafe(buf, pos, h.VclHRDParametersPresentFlag)
+
+		if h.NalHRDParametersPresentFlag || h.VclHRDParametersPresentFlag {
+			bits.WriteFlagUnsafe(buf, pos, h.SubPicHRDParamsPresentFlag)
+
+			if h.SubPicHRDParamsPresentFlag {
+				bits.WriteBitsUnsafe(buf, pos, uint64(h.TickDivisorMinus2), 8)
+				bits.WriteBitsUnsafe(buf, pos, uint64(h.DuCpbRemovalDelayIncrementLengthMinus1), 5)
+				bits.WriteFlagUnsafe(buf, pos, h.SubPicCpbParamsInPicTimingSEIFlag)
+				bits.WriteBitsUnsafe(buf, pos, uint64(h.DpbOutputDelayDuLengthMinus1), 5)
+			}
+
+			bits.WriteBitsUnsafe(buf, pos, uint64(h.BitRateScale), 4)
+			bits.WriteBitsUnsafe(buf, pos, uint64(h.CpbSizeScale), 4)
+
+			if h.SubPicHRDParamsPresentFlag {
+				bits.WriteBitsUnsafe(buf, pos, uint64(h.CpbSizeDuScale), 4)
+			}
+
+			bits.WriteBitsUnsafe(buf, pos, uint64(h.InitialCpbRemovalDelayLengthMinus1), 5)
+			bits.WriteBitsUnsafe(buf, pos, uint64(h.AuCpbRemovalDelayLengthMinus1), 5)
+			bits.WriteBitsUnsafe(buf, pos, uint64(h.DpbOutputDelayLengthMinus1), 5)
+		}
+	}
+
+	for _, l := range h.SubLayers {
+		bits.WriteFlagUnsafe(buf, pos, l.FixedPicRateGeneralFlag)
+
+		if !l.FixedPicRateGeneralFlag {
+			bits.WriteFlagUnsafe(buf, pos, l.FixedPicRateWithinCvsFlag)
+		}
+
+		if l.FixedPicRateWithinCvsFlag {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, l.ElementalDurationInTcMinus1)
+		} else {
+			bits.WriteFlagUnsafe(buf, pos, l.LowDelayHRDFlag)
+		}
+
+		if !l.LowDelayHRDFlag {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, l.CpbCntMinus1)
+		}
+
+		writeSubLayerHRDParameters(buf, pos, l.NalHRDParameters, h.SubPicHRDParamsPresentFlag)
+		writeSubLayerHRDParameters(buf, pos, l.VclHRDParameters, h.SubPicHRDParamsPresentFlag)
+	}
+
+	return nil
+}
+
 // SPS_TimingInfo is a timing info.
 type SPS_TimingInfo struct { //nolint:revive
 	NumUnitsInTick              uint32
@@ -119,9 +599,11 @@ type SPS_TimingInfo struct { //nolint:revive
 
 	// POCProportionalToTimingFlag == true
 	NumTicksPOCDiffOneMinus1 uint32
+
+	HRD *SPS_HRD
 }
 
-func (t *SPS_TimingInfo) unmarshal(buf []byte, pos *int) error {
+func (t *SPS_TimingInfo) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
 	err := bits.HasSpace(buf, *pos, 32+32+1)
 	if err != nil {
 		return err
@@ -138,9 +620,128 @@ func (t *SPS_TimingInfo) unmarshal(buf []byte, pos *int) error {
 		}
 	}
 
+	hrdParametersPresentFlag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if hrdParametersPresentFlag {
+		t.HRD = &SPS_HRD{}
+		err = t.HRD.unmarshal(buf, pos, true, maxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	} else {
+		t.HRD = nil
+	}
+
+	return nil
+}
+
+func (t SPS_TimingInfo) marshalSizeBits() int {
+	n := 32 + 32 + 1
+
+	if t.POCProportionalToTimingFlag {
+		n += bits.GolombUnsignedSize(t.NumTicksPOCDiffOneMinus1)
+	}
+
+	n++
+
+	if t.HRD != nil {
+		n += t.HRD.marshalSizeBits(true)
+	}
+
+	return n
+}
+
+func (t SPS_TimingInfo) marshalTo(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	bits.WriteBitsUnsafe(buf, pos, uint64(t.NumUnitsInTick), 32)
+	bits.WriteBitsUnsafe(buf, pos, uint64(t.TimeScale), 32)
+	bits.WriteFlagUnsafe(buf, pos, t.POCProportionalToTimingFlag)
+
+	if t.POCProportionalToTimingFlag {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, t.NumTicksPOCDiffOneMinus1)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, t.HRD != nil)
+
+	if t.HRD != nil {
+		return t.HRD.marshalTo(buf, pos, true, maxSubLayersMinus1)
+	}
+
+	return nil
+}
+
+// SPS_BitstreamRestriction are bitstream restriction infos.
+type SPS_BitstreamRestriction struct { //nolint:revive
+	TilesFixedStructureFlag            bool
+	MotionVectorsOverPicBoundariesFlag bool
+	RestrictedRefPicListsFlag          bool
+	MinSpatialSegmentationIdc          uint32
+	MaxBytesPerPicDenom                uint32
+	MaxBitsPerMinCuDenom               uint32
+	Log2MaxMvLengthHorizontal          uint32
+	Log2MaxMvLengthVertical            uint32
+}
+
+func (r *SPS_BitstreamRestriction) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 3)
+	if err != nil {
+		return err
+	}
+
+	r.TilesFixedStructureFlag = bits.ReadFlagUnsafe(buf, pos)
+	r.MotionVectorsOverPicBoundariesFlag = bits.ReadFlagUnsafe(buf, pos)
+	r.RestrictedRefPicListsFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	r.MinSpatialSegmentationIdc, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	r.MaxBytesPerPicDenom, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	r.MaxBitsPerMinCuDenom, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	r.Log2MaxMvLengthHorizontal, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	r.Log2MaxMvLengthVertical, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (r SPS_BitstreamRestriction) marshalSizeBits() int {
+	return 3 +
+		bits.GolombUnsignedSize(r.MinSpatialSegmentationIdc) +
+		bits.GolombUnsignedSize(r.MaxBytesPerPicDenom) +
+		bits.GolombUnsignedSize(r.MaxBitsPerMinCuDenom) +
+		bits.GolombUnsignedSize(r.Log2MaxMvLengthHorizontal) +
+		bits.GolombUnsignedSize(r.Log2MaxMvLengthVertical)
+}
+
+func (r SPS_BitstreamRestriction) marshalTo(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, r.TilesFixedStructureFlag)
+	bits.WriteFlagUnsafe(buf, pos, r.MotionVectorsOverPicBoundariesFlag)
+	bits.WriteFlagUnsafe(buf, pos, r.RestrictedRefPicListsFlag)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MinSpatialSegmentationIdc)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MaxBytesPerPicDenom)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.MaxBitsPerMinCuDenom)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.Log2MaxMvLengthHorizontal)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.Log2MaxMvLengthVertical)
+}
+
 // SPS_VUI is a video usability information.
 type SPS_VUI struct { //nolint:revive
 	AspectRatioInfoPresentFlag bool
@@ -177,9 +778,10 @@ type SPS_VUI struct { //nolint:revive
 	FrameFieldInfoPresentFlag   bool
 	DefaultDisplayWindow        *SPS_Window
 	TimingInfo                  *SPS_TimingInfo
+	BitstreamRestriction        *SPS_BitstreamRestriction
 }
 
-func (v *SPS_VUI) unmarshal(buf []byte, pos *int) error {
+func (v *SPS_VUI) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
 	var err error
 	v.AspectRatioInfoPresentFlag, err = bits.ReadFlag(buf, pos)
 	if err != nil {
@@ -298,7 +900,7 @@ func (v *SPS_VUI) unmarshal(buf []byte, pos *int) error {
 
 	if timingInfoPresentFlag {
 		v.TimingInfo = &SPS_TimingInfo{}
-		err = v.TimingInfo.unmarshal(buf, pos)
+		err = v.TimingInfo.unmarshal(buf, pos, maxSubLayersMinus1)
 		if err != nil {
 			return err
 		}
@@ -306,9 +908,162 @@ func (v *SPS_VUI) unmarshal(buf []byte, pos *int) error {
 		v.TimingInfo = nil
 	}
 
+	bitstreamRestrictionFlag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if bitstreamRestrictionFlag {
+		v.BitstreamRestriction = &SPS_BitstreamRestriction{}
+		err = v.BitstreamRestriction.unmarshal(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		v.BitstreamRestriction = nil
+	}
+
 	return nil
 }
 
+func (v SPS_VUI) marshalSizeBits() int {
+	n := 1
+
+	if v.AspectRatioInfoPresentFlag {
+		n += 8
+
+		if v.AspectRatioIdc == 255 {
+			n += 32
+		}
+	}
+
+	n++
+
+	if v.OverscanInfoPresentFlag {
+		n++
+	}
+
+	n++
+
+	if v.VideoSignalTypePresentFlag {
+		n += 5
+
+		if v.ColourDescriptionPresentFlag {
+			n += 24
+		}
+	}
+
+	n++
+
+	if v.ChromaLocInfoPresentFlag {
+		n += bits.GolombUnsignedSize(v.ChromaSampleLocTypeTopField) +
+			bits.GolombUnsignedSize(v.ChromaSampleLocTypeBottomField)
+	}
+
+	n += 4
+
+	if v.DefaultDisplayWindow != nil {
+		n += v.DefaultDisplayWindow.marshalSizeBits()
+	}
+
+	n++
+
+	if v.TimingInfo != nil {
+		n += v.TimingInfo.marshalSizeBits()
+	}
+
+	n++
+
+	if v.BitstreamRestriction != nil {
+		n += v.BitstreamRestriction.marshalSizeBits()
+	}
+
+	return n
+}
+
+func (v SPS_VUI) marshalTo(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	bits.WriteFlagUnsafe(buf, pos, v.AspectRatioInfoPresentFlag)
+
+	if v.AspectRatioInfoPresentFlag {
+		bits.WriteBitsUnsafe(buf, pos, uint64(v.AspectRatioIdc), 8)
+
+		if v.AspectRatioIdc == 255 { // EXTENDED_SAR
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.SarWidth), 16)
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.SarHeight), 16)
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.OverscanInfoPresentFlag)
+
+	if v.OverscanInfoPresentFlag {
+		bits.WriteFlagUnsafe(buf, pos, v.OverscanAppropriateFlag)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.VideoSignalTypePresentFlag)
+
+	if v.VideoSignalTypePresentFlag {
+		bits.WriteBitsUnsafe(buf, pos, uint64(v.VideoFormat), 3)
+		bits.WriteFlagUnsafe(buf, pos, v.VideoFullRangeFlag)
+		bits.WriteFlagUnsafe(buf, pos, v.ColourDescriptionPresentFlag)
+
+		if v.ColourDescriptionPresentFlag {
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.ColourPrimaries), 8)
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.TransferCharacteristics), 8)
+			bits.WriteBitsUnsafe(buf, pos, uint64(v.MatrixCoefficients), 8)
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.ChromaLocInfoPresentFlag)
+
+	if v.ChromaLocInfoPresentFlag {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, v.ChromaSampleLocTypeTopField)
+		bits.WriteGolombUnsignedUnsafe(buf, pos, v.ChromaSampleLocTypeBottomField)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.NeutralChromaIndicationFlag)
+	bits.WriteFlagUnsafe(buf, pos, v.FieldSeqFlag)
+	bits.WriteFlagUnsafe(buf, pos, v.FrameFieldInfoPresentFlag)
+	bits.WriteFlagUnsafe(buf, pos, v.DefaultDisplayWindow != nil)
+
+	if v.DefaultDisplayWindow != nil {
+		v.DefaultDisplayWindow.marshalTo(buf, pos)
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.TimingInfo != nil)
+
+	if v.TimingInfo != nil {
+		err := v.TimingInfo.marshalTo(buf, pos, maxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, v.BitstreamRestriction != nil)
+
+	if v.BitstreamRestriction != nil {
+		v.BitstreamRestriction.marshalTo(buf, pos)
+	}
+
+	return nil
+}
+
+// SPS_ProfileTierLevelSubLayer is the profile and level of a sub-layer.
+type SPS_ProfileTierLevelSubLayer struct { //nolint:revive
+	// SubLayerProfilePresentFlag == true
+	ProfileSpace             uint8
+	TierFlag                 uint8
+	ProfileIdc               uint8
+	ProfileCompatibilityFlag [32]bool
+	ProgressiveSourceFlag    bool
+	InterlacedSourceFlag     bool
+	NonPackedConstraintFlag  bool
+	FrameOnlyConstraintFlag  bool
+	ConstraintFlags          uint64 // the 44 bits that follow FrameOnlyConstraintFlag
+
+	// SubLayerLevelPresentFlag == true
+	LevelIdc uint8
+}
+
 // SPS_ProfileTierLevel is a profile level tier of a SPS.
 type SPS_ProfileTierLevel struct { //nolint:revive
 	GeneralProfileSpace                 uint8
@@ -332,83 +1087,221 @@ type SPS_ProfileTierLevel struct { //nolint:revive
 	GeneralLevelIdc                     uint8
 	SubLayerProfilePresentFlag          []bool
 	SubLayerLevelPresentFlag            []bool
+	SubLayers                           []SPS_ProfileTierLevelSubLayer
+}
+
+func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, profilePresentFlag bool,
+	maxSubLayersMinus1 uint8,
+) error {
+	if profilePresentFlag {
+		err := bits.HasSpace(buf, *pos, 8+32+13+35)
+		if err != nil {
+			return err
+		}
+
+		p.GeneralProfileSpace = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		p.GeneralTierFlag = uint8(bits.ReadBitsUnsafe(buf, pos, 1))
+		p.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+		for j := range 32 {
+			p.GeneralProfileCompatibilityFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+		}
+
+		p.GeneralProgressiveSourceFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralInterlacedSourceFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralNonPackedConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralFrameOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralMax12bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralMax10bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralMax8bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralMax422ChromeConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralMax420ChromaConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralMaxMonochromeConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralIntraConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralOnePictureOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralLowerBitRateConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+
+		if p.GeneralProfileIdc == 5 ||
+			p.GeneralProfileIdc == 9 ||
+			p.GeneralProfileIdc == 10 ||
+			p.GeneralProfileIdc == 11 ||
+			p.GeneralProfileCompatibilityFlag[5] ||
+			p.GeneralProfileCompatibilityFlag[9] ||
+			p.GeneralProfileCompatibilityFlag[10] ||
+			p.GeneralProfileCompatibilityFlag[11] {
+			p.GeneralMax14BitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+			*pos += 34
+		} else {
+			*pos += 35
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+	p.GeneralLevelIdc = uint8(tmp)
+
+	if maxSubLayersMinus1 > 0 {
+		p.SubLayerProfilePresentFlag = make([]bool, maxSubLayersMinus1)
+		p.SubLayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
+
+		err = bits.HasSpace(buf, *pos, 8*2)
+		if err != nil {
+			return err
+		}
+
+		for j := range maxSubLayersMinus1 {
+			p.SubLayerProfilePresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+			p.SubLayerLevelPresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+		}
+
+		*pos += int(8-maxSubLayersMinus1) * 2
+
+		p.SubLayers = make([]SPS_ProfileTierLevelSubLayer, maxSubLayersMinus1)
+
+		for i := range p.SubLayers {
+			l := &p.SubLayers[i]
+
+			if p.SubLayerProfilePresentFlag[i] {
+				err = bits.HasSpace(buf, *pos, 88)
+				if err != nil {
+					return err
+				}
+
+				l.ProfileSpace = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+				l.TierFlag = uint8(bits.ReadBitsUnsafe(buf, pos, 1))
+				l.ProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+				for j := range 32 {
+					l.ProfileCompatibilityFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+				}
+
+				l.ProgressiveSourceFlag = bits.ReadFlagUnsafe(buf, pos)
+				l.InterlacedSourceFlag = bits.ReadFlagUnsafe(buf, pos)
+				l.NonPackedConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+				l.FrameOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+				l.ConstraintFlags = bits.ReadBitsUnsafe(buf, pos, 44)
+			}
+
+			if p.SubLayerLevelPresentFlag[i] {
+				tmp, err = bits.ReadBits(buf, pos, 8)
+				if err != nil {
+					return err
+				}
+				l.LevelIdc = uint8(tmp)
+			}
+		}
+	} else {
+		p.SubLayerProfilePresentFlag = nil
+		p.SubLayerLevelPresentFlag = nil
+		p.SubLayers = nil
+	}
+
+	return nil
 }
 
-func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
-	err := bits.HasSpace(buf, *pos, 8+32+12+34+8)
-	if err != nil {
-		return err
+func (p SPS_ProfileTierLevel) marshalSizeBits(profilePresentFlag bool, maxSubLayersMinus1 uint8) int {
+	n := 8
+
+	if profilePresentFlag {
+		n += 8 + 32 + 13 + 35
 	}
 
-	p.GeneralProfileSpace = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
-	p.GeneralTierFlag = uint8(bits.ReadBitsUnsafe(buf, pos, 1))
-	p.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	if maxSubLayersMinus1 > 0 {
+		n += 8 * 2
+
+		for i := range p.SubLayers {
+			if p.SubLayerProfilePresentFlag[i] {
+				n += 88
+			}
 
-	for j := range 32 {
-		p.GeneralProfileCompatibilityFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+			if p.SubLayerLevelPresentFlag[i] {
+				n += 8
+			}
+		}
 	}
 
-	p.GeneralProgressiveSourceFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralInterlacedSourceFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralNonPackedConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralFrameOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax12bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax10bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax8bitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax422ChromeConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMax420ChromaConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralMaxMonochromeConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralIntraConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralOnePictureOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-	p.GeneralLowerBitRateConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+	return n
+}
 
-	if p.GeneralProfileIdc == 5 ||
-		p.GeneralProfileIdc == 9 ||
-		p.GeneralProfileIdc == 10 ||
-		p.GeneralProfileIdc == 11 ||
-		p.GeneralProfileCompatibilityFlag[5] ||
-		p.GeneralProfileCompatibilityFlag[9] ||
-		p.GeneralProfileCompatibilityFlag[10] ||
-		p.GeneralProfileCompatibilityFlag[11] {
-		p.GeneralMax14BitConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
-		*pos += 34
-	} else {
-		*pos += 35
+func (p SPS_ProfileTierLevel) marshalTo(buf []byte, pos *int, profilePresentFlag bool,
+	maxSubLayersMinus1 uint8,
+) error {
+	if len(p.SubLayerProfilePresentFlag) != int(maxSubLayersMinus1) ||
+		len(p.SubLayerLevelPresentFlag) != int(maxSubLayersMinus1) ||
+		len(p.SubLayers) != int(maxSubLayersMinus1) {
+		return fmt.Errorf("sub-layer flags do not match max_sub_layers_minus1")
 	}
 
-	p.GeneralLevelIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
-
-	if maxSubLayersMinus1 > 0 {
-		p.SubLayerProfilePresentFlag = make([]bool, maxSubLayersMinus1)
-		p.SubLayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
+	if profilePresentFlag {
+		bits.WriteBitsUnsafe(buf, pos, uint64(p.GeneralProfileSpace), 2)
+		bits.WriteBitsUnsafe(buf, pos, uint64(p.GeneralTierFlag), 1)
+		bits.WriteBitsUnsafe(buf, pos, uint64(p.GeneralProfileIdc), 5)
 
-		err = bits.HasSpace(buf, *pos, int(2*maxSubLayersMinus1))
-		if err != nil {
-			return err
+		for j := range 32 {
+			bits.WriteFlagUnsafe(buf, pos, p.GeneralProfileCompatibilityFlag[j])
 		}
 
-		for j := range maxSubLayersMinus1 {
-			p.SubLayerProfilePresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
-			p.SubLayerLevelPresentFlag[j] = bits.ReadFlagUnsafe(buf, pos)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralProgressiveSourceFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralInterlacedSourceFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralNonPackedConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralFrameOnlyConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralMax12bitConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralMax10bitConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralMax8bitConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralMax422ChromeConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralMax420ChromaConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralMaxMonochromeConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralIntraConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralOnePictureOnlyConstraintFlag)
+		bits.WriteFlagUnsafe(buf, pos, p.GeneralLowerBitRateConstraintFlag)
+
+		if p.GeneralProfileIdc == 5 ||
+			p.GeneralProfileIdc == 9 ||
+			p.GeneralProfileIdc == 10 ||
+			p.GeneralProfileIdc == 11 ||
+			p.GeneralProfileCompatibilityFlag[5] ||
+			p.GeneralProfileCompatibilityFlag[9] ||
+			p.GeneralProfileCompatibilityFlag[10] ||
+			p.GeneralProfileCompatibilityFlag[11] {
+			bits.WriteFlagUnsafe(buf, pos, p.GeneralMax14BitConstraintFlag)
+			*pos += 34
+		} else {
+			*pos += 35
 		}
 	}
 
+	bits.WriteBitsUnsafe(buf, pos, uint64(p.GeneralLevelIdc), 8)
+
 	if maxSubLayersMinus1 > 0 {
-		err = bits.HasSpace(buf, *pos, int(8-maxSubLayersMinus1)*2)
-		if err != nil {
-			return err
+		for j := range maxSubLayersMinus1 {
+			bits.WriteFlagUnsafe(buf, pos, p.SubLayerProfilePresentFlag[j])
+			bits.WriteFlagUnsafe(buf, pos, p.SubLayerLevelPresentFlag[j])
 		}
 
-		*pos += int(8-maxSubLayersMinus1) * 2
-	}
+		*pos += int(8-maxSubLayersMinus1) * 2 // reserved_zero_2bits
 
-	for i := range maxSubLayersMinus1 {
-		if p.SubLayerProfilePresentFlag[i] {
-			return fmt.Errorf("SubLayerProfilePresentFlag not supported yet")
-		}
+		for i, l := range p.SubLayers {
+			if p.SubLayerProfilePresentFlag[i] {
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.ProfileSpace), 2)
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.TierFlag), 1)
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.ProfileIdc), 5)
+
+				for j := range 32 {
+					bits.WriteFlagUnsafe(buf, pos, l.ProfileCompatibilityFlag[j])
+				}
+
+				bits.WriteFlagUnsafe(buf, pos, l.ProgressiveSourceFlag)
+				bits.WriteFlagUnsafe(buf, pos, l.InterlacedSourceFlag)
+				bits.WriteFlagUnsafe(buf, pos, l.NonPackedConstraintFlag)
+				bits.WriteFlagUnsafe(buf, pos, l.FrameOnlyConstraintFlag)
+				bits.WriteBitsUnsafe(buf, pos, l.ConstraintFlags, 44)
+			}
 
-		if p.SubLayerLevelPresentFlag[i] {
-			return fmt.Errorf("SubLayerLevelPresentFlag not supported yet")
+			if p.SubLayerLevelPresentFlag[i] {
+				bits.WriteBitsUnsafe(buf, pos, uint64(l.LevelIdc), 8)
+			}
 		}
 	}
 
@@ -418,15 +1311,20 @@ func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus
 // SPS_ShortTermRefPicSet is a short-term reference picture set.
 type SPS_ShortTermRefPicSet struct { //nolint:revive
 	InterRefPicSetPredictionFlag bool
-	DeltaIdxMinus1               uint32
-	DeltaRpsSign                 bool
-	AbsDeltaRpsMinus1            uint32
-	NumNegativePics              uint32
-	NumPositivePics              uint32
-	DeltaPocS0                   []int32
-	UsedByCurrPicS0Flag          []bool
-	DeltaPocS1                   []int32
-	UsedByCurrPicS1Flag          []bool
+
+	// InterRefPicSetPredictionFlag == true
+	DeltaIdxMinus1    uint32
+	DeltaRpsSign      bool
+	AbsDeltaRpsMinus1 uint32
+	UsedByCurrPicFlag []bool
+	UseDeltaFlag      []bool
+
+	NumNegativePics     uint32
+	NumPositivePics     uint32
+	DeltaPocS0          []int32
+	UsedByCurrPicS0Flag []bool
+	DeltaPocS1          []int32
+	UsedByCurrPicS1Flag []bool
 }
 
 func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32,
@@ -459,6 +1357,10 @@ func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32
 			return err
 		}
 
+		if r.AbsDeltaRpsMinus1 > 32767 {
+			return fmt.Errorf("invalid abs_delta_rps_minus1")
+		}
+
 		var s int32
 		if r.DeltaRpsSign {
 			s = 1
@@ -493,6 +1395,9 @@ func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32
 			}
 		}
 
+		r.UsedByCurrPicFlag = usedByCurrPicFlag
+		r.UseDeltaFlag = useDeltaFlag
+
 		i := uint32(0)
 
 		for j := (int32(refRPS.NumPositivePics) - 1); j >= 0; j-- {
@@ -574,6 +1479,10 @@ func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32
 					return err
 				}
 
+				if deltaPocS0Minus1 > 32767 {
+					return fmt.Errorf("invalid delta_poc_s0_minus1")
+				}
+
 				if i == 0 {
 					r.DeltaPocS0[i] = -int32(deltaPocS0Minus1 + 1)
 				} else {
@@ -602,6 +1511,10 @@ func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32
 					return err
 				}
 
+				if deltaPocS1Minus1 > 32767 {
+					return fmt.Errorf("invalid delta_poc_s1_minus1")
+				}
+
 				if i == 0 {
 					r.DeltaPocS1[i] = int32(deltaPocS1Minus1) + 1
 				} else {
@@ -619,6 +1532,165 @@ func (r *SPS_ShortTermRefPicSet) unmarshal(buf []byte, pos *int, stRpsIdx uint32
 	return nil
 }
 
+func (r SPS_ShortTermRefPicSet) marshalSizeBits(stRpsIdx uint32, numShortTermRefPicSets uint32) int {
+	n := 0
+
+	if stRpsIdx != 0 {
+		n++
+	}
+
+	if r.InterRefPicSetPredictionFlag {
+		if stRpsIdx == numShortTermRefPicSets {
+			n += bits.GolombUnsignedSize(r.DeltaIdxMinus1)
+		}
+
+		n += 1 + bits.GolombUnsignedSize(r.AbsDeltaRpsMinus1)
+
+		for _, used := range r.UsedByCurrPicFlag {
+			n++
+
+			if !used {
+				n++
+			}
+		}
+	} else {
+		n += bits.GolombUnsignedSize(r.NumNegativePics) +
+			bits.GolombUnsignedSize(r.NumPositivePics)
+
+		prev := int32(0)
+		for _, v := range r.DeltaPocS0 {
+			n += bits.GolombUnsignedSize(uint32(prev-v-1)) + 1
+			prev = v
+		}
+
+		prev = 0
+		for _, v := range r.DeltaPocS1 {
+			n += bits.GolombUnsignedSize(uint32(v-prev-1)) + 1
+			prev = v
+		}
+	}
+
+	return n
+}
+
+func (r SPS_ShortTermRefPicSet) marshalTo(buf []byte, pos *int, stRpsIdx uint32,
+	numShortTermRefPicSets uint32, shortTermRefPicSets []*SPS_ShortTermRefPicSet,
+) error {
+	if stRpsIdx != 0 {
+		bits.WriteFlagUnsafe(buf, pos, r.InterRefPicSetPredictionFlag)
+	} else if r.InterRefPicSetPredictionFlag {
+		return fmt.Errorf("the first short-term reference picture set can't be predicted")
+	}
+
+	if r.InterRefPicSetPredictionFlag {
+		refRpsIdx := stRpsIdx - (r.DeltaIdxMinus1 + 1)
+		if refRpsIdx >= uint32(len(shortTermRefPicSets)) {
+			return fmt.Errorf("invalid refRpsIdx")
+		}
+
+		refRPS := shortTermRefPicSets[refRpsIdx]
+		numDeltaPocs := refRPS.NumNegativePics + refRPS.NumPositivePics
+
+		if len(r.UsedByCurrPicFlag) != int(numDeltaPocs+1) || len(r.UseDeltaFlag) != int(numDeltaPocs+1) {
+			return fmt.Errorf("UsedByCurrPicFlag and UseDeltaFlag must have %d entries", numDeltaPocs+1)
+		}
+
+		if stRpsIdx == numShortTermRefPicSets {
+			bits.WriteGolombUnsignedUnsafe(buf, pos, r.DeltaIdxMinus1)
+		}
+
+		bits.WriteFlagUnsafe(buf, pos, r.DeltaRpsSign)
+		bits.WriteGolombUnsignedUnsafe(buf, pos, r.AbsDeltaRpsMinus1)
+
+		for j, used := range r.UsedByCurrPicFlag {
+			bits.WriteFlagUnsafe(buf, pos, used)
+
+			if !used {
+				bits.WriteFlagUnsafe(buf, pos, r.UseDeltaFlag[j])
+			}
+		}
+
+		return nil
+	}
+
+	if len(r.DeltaPocS0) != int(r.NumNegativePics) || len(r.UsedByCurrPicS0Flag) != int(r.NumNegativePics) ||
+		len(r.DeltaPocS1) != int(r.NumPositivePics) || len(r.UsedByCurrPicS1Flag) != int(r.NumPositivePics) {
+		return fmt.Errorf("reference picture lists do not match NumNegativePics and NumPositivePics")
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.NumNegativePics)
+	bits.WriteGolombUnsignedUnsafe(buf, pos, r.NumPositivePics)
+
+	prev := int32(0)
+	for i, v := range r.DeltaPocS0 {
+		if v >= prev {
+			return fmt.Errorf("DeltaPocS0 must be strictly decreasing and negative")
+		}
+
+		bits.WriteGolombUnsignedUnsafe(buf, pos, uint32(prev-v-1))
+		bits.WriteFlagUnsafe(buf, pos, r.UsedByCurrPicS0Flag[i])
+		prev = v
+	}
+
+	prev = 0
+	for i, v := range r.DeltaPocS1 {
+		if v <= prev {
+			return fmt.Errorf("DeltaPocS1 must be strictly increasing and positive")
+		}
+
+		bits.WriteGolombUnsignedUnsafe(buf, pos, uint32(v-prev-1))
+		bits.WriteFlagUnsafe(buf, pos, r.UsedByCurrPicS1Flag[i])
+		prev = v
+	}
+
+	return nil
+}
+
+// SPS_RangeExtension is the range extension of a SPS.
+// Specification: ITU-T Rec. H.265, 7.3.2.2.2
+type SPS_RangeExtension struct { //nolint:revive
+	TransformSkipRotationEnabledFlag    bool
+	TransformSkipContextEnabledFlag     bool
+	ImplicitRdpcmEnabledFlag            bool
+	ExplicitRdpcmEnabledFlag            bool
+	ExtendedPrecisionProcessingFlag     bool
+	IntraSmoothingDisabledFlag          bool
+	HighPrecisionOffsetsEnabledFlag     bool
+	PersistentRiceAdaptationEnabledFlag bool
+	CabacBypassAlignmentEnabledFlag     bool
+}
+
+func (e *SPS_RangeExtension) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 9)
+	if err != nil {
+		return err
+	}
+
+	e.TransformSkipRotationEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.TransformSkipContextEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.ImplicitRdpcmEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.ExplicitRdpcmEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.ExtendedPrecisionProcessingFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.IntraSmoothingDisabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.HighPrecisionOffsetsEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.PersistentRiceAdaptationEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+	e.CabacBypassAlignmentEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	return nil
+}
+
+func (e SPS_RangeExtension) marshalTo(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, e.TransformSkipRotationEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.TransformSkipContextEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.ImplicitRdpcmEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.ExplicitRdpcmEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.ExtendedPrecisionProcessingFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.IntraSmoothingDisabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.HighPrecisionOffsetsEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.PersistentRiceAdaptationEnabledFlag)
+	bits.WriteFlagUnsafe(buf, pos, e.CabacBypassAlignmentEnabledFlag)
+}
+
 // SPS is a H265 sequence parameter set.
 // Specification: ITU-T Rec. H.265, 7.3.2.2.1
 type SPS struct {
@@ -658,11 +1730,19 @@ type SPS struct {
 	Log2DiffMaxMinPcmLumaCodingBlockSize uint32
 	PcmLoopFilterDisabledFlag            bool
 
-	ShortTermRefPicSets             []*SPS_ShortTermRefPicSet
-	LongTermRefPicsPresentFlag      bool
+	ShortTermRefPicSets        []*SPS_ShortTermRefPicSet
+	LongTermRefPicsPresentFlag bool
+
+	// LongTermRefPicsPresentFlag == true
+	LtRefPicPocLsbSps      []uint32
+	UsedByCurrPicLtSpsFlag []bool
+
 	TemporalMvpEnabledFlag          bool
 	StrongIntraSmoothingEnabledFlag bool
 	VUI                             *SPS_VUI
+
+	// other extensions are not decoded and are not encoded by Marshal
+	RangeExtension *SPS_RangeExtension
 }
 
 // Unmarshal decodes a SPS from bytes.
@@ -687,7 +1767,7 @@ func (s *SPS) Unmarshal(buf []byte) error {
 	s.MaxSubLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
 	s.TemporalIDNestingFlag = bits.ReadFlagUnsafe(buf, &pos)
 
-	err = s.ProfileTierLevel.unmarshal(buf, &pos, s.MaxSubLayersMinus1)
+	err = s.ProfileTierLevel.unmarshal(buf, &pos, true, s.MaxSubLayersMinus1)
 	if err != nil {
 		return err
 	}
@@ -755,6 +1835,10 @@ func (s *SPS) Unmarshal(buf []byte) error {
 		return err
 	}
 
+	if s.Log2MaxPicOrderCntLsbMinus4 > 12 {
+		return fmt.Errorf("invalid log2_max_pic_order_cnt_lsb_minus4")
+	}
+
 	s.SubLayerOrderingInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
 	if err != nil {
 		return err
@@ -914,9 +1998,32 @@ func (s *SPS) Unmarshal(buf []byte) error {
 			return err
 		}
 
+		if numLongTermRefPicsSPS > maxLongTermRefPicsSPS {
+			return fmt.Errorf("num_long_term_ref_pics_sps exceeds %d", maxLongTermRefPicsSPS)
+		}
+
 		if numLongTermRefPicsSPS > 0 {
-			return fmt.Errorf("long term ref pics inside SPS are not supported yet")
+			s.LtRefPicPocLsbSps = make([]uint32, numLongTermRefPicsSPS)
+			s.UsedByCurrPicLtSpsFlag = make([]bool, numLongTermRefPicsSPS)
+
+			n := int(s.Log2MaxPicOrderCntLsbMinus4 + 4)
+
+			err = bits.HasSpace(buf, pos, (n+1)*int(numLongTermRefPicsSPS))
+			if err != nil {
+				return err
+			}
+
+			for i := range numLongTermRefPicsSPS {
+				s.LtRefPicPocLsbSps[i] = uint32(bits.ReadBitsUnsafe(buf, &pos, n))
+				s.UsedByCurrPicLtSpsFlag[i] = bits.ReadFlagUnsafe(buf, &pos)
+			}
+		} else {
+			s.LtRefPicPocLsbSps = nil
+			s.UsedByCurrPicLtSpsFlag = nil
 		}
+	} else {
+		s.LtRefPicPocLsbSps = nil
+		s.UsedByCurrPicLtSpsFlag = nil
 	}
 
 	s.TemporalMvpEnabledFlag, err = bits.ReadFlag(buf, &pos)
@@ -936,7 +2043,7 @@ func (s *SPS) Unmarshal(buf []byte) error {
 
 	if vuiParametersPresentFlag {
 		s.VUI = &SPS_VUI{}
-		err = s.VUI.unmarshal(buf, &pos)
+		err = s.VUI.unmarshal(buf, &pos, s.MaxSubLayersMinus1)
 		if err != nil {
 			return err
 		}
@@ -944,9 +2051,276 @@ func (s *SPS) Unmarshal(buf []byte) error {
 		s.VUI = nil
 	}
 
+	s.RangeExtension = nil
+
+	extensionPresentFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if extensionPresentFlag {
+		var rangeExtensionFlag bool
+		rangeExtensionFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(buf, pos, 7) // multilayer, 3d, scc and 4bits
+		if err != nil {
+			return err
+		}
+		pos += 7
+
+		if rangeExtensionFlag {
+			s.RangeExtension = &SPS_RangeExtension{}
+			err = s.RangeExtension.unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+func (s SPS) marshalSizeBits() int {
+	n := 16 + 8 +
+		s.ProfileTierLevel.marshalSizeBits(true, s.MaxSubLayersMinus1) +
+		bits.GolombUnsignedSize(uint32(s.ID)) +
+		bits.GolombUnsignedSize(s.ChromaFormatIdc)
+
+	if s.ChromaFormatIdc == 3 {
+		n++
+	}
+
+	n += bits.GolombUnsignedSize(s.PicWidthInLumaSamples) +
+		bits.GolombUnsignedSize(s.PicHeightInLumaSamples) +
+		1
+
+	if s.ConformanceWindow != nil {
+		n += s.ConformanceWindow.marshalSizeBits()
+	}
+
+	n += bits.GolombUnsignedSize(s.BitDepthLumaMinus8) +
+		bits.GolombUnsignedSize(s.BitDepthChromaMinus8) +
+		bits.GolombUnsignedSize(s.Log2MaxPicOrderCntLsbMinus4) +
+		1
+
+	for i := s.firstSubLayerOrderingInfo(); i <= s.MaxSubLayersMinus1; i++ {
+		n += bits.GolombUnsignedSize(s.MaxDecPicBufferingMinus1[i]) +
+			bits.GolombUnsignedSize(s.MaxNumReorderPics[i]) +
+			bits.GolombUnsignedSize(s.MaxLatencyIncreasePlus1[i])
+	}
+
+	n += bits.GolombUnsignedSize(s.Log2MinLumaCodingBlockSizeMinus3) +
+		bits.GolombUnsignedSize(s.Log2DiffMaxMinLumaCodingBlockSize) +
+		bits.GolombUnsignedSize(s.Log2MinLumaTransformBlockSizeMinus2) +
+		bits.GolombUnsignedSize(s.Log2DiffMaxMinLumaTransformBlockSize) +
+		bits.GolombUnsignedSize(s.MaxTransformHierarchyDepthInter) +
+		bits.GolombUnsignedSize(s.MaxTransformHierarchyDepthIntra) +
+		1
+
+	if s.ScalingListEnabledFlag {
+		n++
+
+		if s.ScalingListData != nil {
+			n += s.ScalingListData.marshalSizeBits()
+		}
+	}
+
+	n += 3
+
+	if s.PcmEnabledFlag {
+		n += 8 +
+			bits.GolombUnsignedSize(s.Log2MinPcmLumaCodingBlockSizeMinus3) +
+			bits.GolombUnsignedSize(s.Log2DiffMaxMinPcmLumaCodingBlockSize) +
+			1
+	}
+
+	numShortTermRefPicSets := uint32(len(s.ShortTermRefPicSets))
+	n += bits.GolombUnsignedSize(numShortTermRefPicSets)
+
+	for i, rps := range s.ShortTermRefPicSets {
+		n += rps.marshalSizeBits(uint32(i), numShortTermRefPicSets)
+	}
+
+	n++
+
+	if s.LongTermRefPicsPresentFlag {
+		n += bits.GolombUnsignedSize(uint32(len(s.LtRefPicPocLsbSps))) +
+			int(s.Log2MaxPicOrderCntLsbMinus4+4+1)*len(s.LtRefPicPocLsbSps)
+	}
+
+	n += 3
+
+	if s.VUI != nil {
+		n += s.VUI.marshalSizeBits()
+	}
+
+	n++
+
+	if s.RangeExtension != nil {
+		n += 1 + 7 + 9
+	}
+
+	return n + 1 // rbsp_stop_one_bit
+}
+
+func (s SPS) firstSubLayerOrderingInfo() uint8 {
+	if s.SubLayerOrderingInfoPresentFlag {
+		return 0
+	}
+	return s.MaxSubLayersMinus1
+}
+
+// Marshal encodes a SPS.
+func (s SPS) Marshal() ([]byte, error) {
+	if s.ChromaFormatIdc > 3 {
+		return nil, fmt.Errorf("invalid ChromaFormatIdc")
+	}
+
+	if s.MaxSubLayersMinus1 > 7 || s.VPSID > 15 {
+		return nil, fmt.Errorf("invalid VPSID or MaxSubLayersMinus1")
+	}
+
+	if len(s.MaxDecPicBufferingMinus1) != int(s.MaxSubLayersMinus1)+1 ||
+		len(s.MaxNumReorderPics) != int(s.MaxSubLayersMinus1)+1 ||
+		len(s.MaxLatencyIncreasePlus1) != int(s.MaxSubLayersMinus1)+1 {
+		return nil, fmt.Errorf("sub-layer ordering infos do not match MaxSubLayersMinus1")
+	}
+
+	if len(s.ShortTermRefPicSets) > maxShortTermRefPics {
+		return nil, fmt.Errorf("num_short_term_ref_pic_sets exceeds %d", maxShortTermRefPics)
+	}
+
+	if len(s.LtRefPicPocLsbSps) > maxLongTermRefPicsSPS ||
+		len(s.UsedByCurrPicLtSpsFlag) != len(s.LtRefPicPocLsbSps) {
+		return nil, fmt.Errorf("invalid long-term reference pictures")
+	}
+
+	if s.Log2MaxPicOrderCntLsbMinus4 > 12 {
+		return nil, fmt.Errorf("invalid Log2MaxPicOrderCntLsbMinus4")
+	}
+
+	n := s.marshalSizeBits()
+	buf := make([]byte, (n+7)/8)
+
+	buf[0] = byte(NALUType_SPS_NUT) << 1
+	buf[1] = 1 // nuh_temporal_id_plus1
+
+	pos := 16
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(s.VPSID), 4)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(s.MaxSubLayersMinus1), 3)
+	bits.WriteFlagUnsafe(buf, &pos, s.TemporalIDNestingFlag)
+
+	err := s.ProfileTierLevel.marshalTo(buf, &pos, true, s.MaxSubLayersMinus1)
+	if err != nil {
+		return nil, err
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, uint32(s.ID))
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.ChromaFormatIdc)
+
+	if s.ChromaFormatIdc == 3 {
+		bits.WriteFlagUnsafe(buf, &pos, s.SeparateColourPlaneFlag)
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.PicWidthInLumaSamples)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.PicHeightInLumaSamples)
+	bits.WriteFlagUnsafe(buf, &pos, s.ConformanceWindow != nil)
+
+	if s.ConformanceWindow != nil {
+		s.ConformanceWindow.marshalTo(buf, &pos)
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.BitDepthLumaMinus8)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.BitDepthChromaMinus8)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2MaxPicOrderCntLsbMinus4)
+	bits.WriteFlagUnsafe(buf, &pos, s.SubLayerOrderingInfoPresentFlag)
+
+	for i := s.firstSubLayerOrderingInfo(); i <= s.MaxSubLayersMinus1; i++ {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.MaxDecPicBufferingMinus1[i])
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.MaxNumReorderPics[i])
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.MaxLatencyIncreasePlus1[i])
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2MinLumaCodingBlockSizeMinus3)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2DiffMaxMinLumaCodingBlockSize)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2MinLumaTransformBlockSizeMinus2)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2DiffMaxMinLumaTransformBlockSize)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.MaxTransformHierarchyDepthInter)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, s.MaxTransformHierarchyDepthIntra)
+	bits.WriteFlagUnsafe(buf, &pos, s.ScalingListEnabledFlag)
+
+	if s.ScalingListEnabledFlag {
+		bits.WriteFlagUnsafe(buf, &pos, s.ScalingListData != nil)
+
+		if s.ScalingListData != nil {
+			err = s.ScalingListData.marshalTo(buf, &pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, s.AmpEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, s.SampleAdaptiveOffsetEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, s.PcmEnabledFlag)
+
+	if s.PcmEnabledFlag {
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.PcmSampleBitDepthLumaMinus1), 4)
+		bits.WriteBitsUnsafe(buf, &pos, uint64(s.PcmSampleBitDepthChromaMinus1), 4)
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2MinPcmLumaCodingBlockSizeMinus3)
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, s.Log2DiffMaxMinPcmLumaCodingBlockSize)
+		bits.WriteFlagUnsafe(buf, &pos, s.PcmLoopFilterDisabledFlag)
+	}
+
+	numShortTermRefPicSets := uint32(len(s.ShortTermRefPicSets))
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, numShortTermRefPicSets)
+
+	for i, rps := range s.ShortTermRefPicSets {
+		err = rps.marshalTo(buf, &pos, uint32(i), numShortTermRefPicSets, s.ShortTermRefPicSets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, s.LongTermRefPicsPresentFlag)
+
+	if s.LongTermRefPicsPresentFlag {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, uint32(len(s.LtRefPicPocLsbSps)))
+
+		for i, v := range s.LtRefPicPocLsbSps {
+			bits.WriteBitsUnsafe(buf, &pos, uint64(v), int(s.Log2MaxPicOrderCntLsbMinus4+4))
+			bits.WriteFlagUnsafe(buf, &pos, s.UsedByCurrPicLtSpsFlag[i])
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, s.TemporalMvpEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, s.StrongIntraSmoothingEnabledFlag)
+	bits.WriteFlagUnsafe(buf, &pos, s.VUI != nil)
+
+	if s.VUI != nil {
+		err = s.VUI.marshalTo(buf, &pos, s.MaxSubLayersMinus1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, s.RangeExtension != nil)
+
+	if s.RangeExtension != nil {
+		bits.WriteFlagUnsafe(buf, &pos, true) // sps_range_extension_flag
+		pos += 7
+		s.RangeExtension.marshalTo(buf, &pos)
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, true) // rbsp_stop_one_bit
+
+	return h264.EmulationPreventionAdd(buf), nil
+}
+
 // Width returns the video width.
 func (s SPS) Width() int {
 	width := s.PicWidthInLumaSamples
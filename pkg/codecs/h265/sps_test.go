@@ -292,6 +292,18 @@ var casesSPS = []struct {
 				TimingInfo: &SPS_TimingInfo{
 					NumUnitsInTick: 1,
 					TimeScale:      60,
+					HRD: &SPS_HRD{
+						NalHRDParametersPresentFlag:        true,
+						InitialCpbRemovalDelayLengthMinus1: 23,
+						AuCpbRemovalDelayLengthMinus1:      15,
+						DpbOutputDelayLengthMinus1:         5,
+						SubLayers: []SPS_HRDSubLayer{{
+							NalHRDParameters: []SPS_SubLayerHRDParameters{{
+								BitRateValueMinus1: 117186,
+								CpbSizeValueMinus1: 312499,
+							}},
+						}},
+					},
 				},
 			},
 		},
@@ -362,6 +374,25 @@ var casesSPS = []struct {
 				TimingInfo: &SPS_TimingInfo{
 					NumUnitsInTick: 1000,
 					TimeScale:      17000,
+					HRD: &SPS_HRD{
+						NalHRDParametersPresentFlag:        true,
+						VclHRDParametersPresentFlag:        true,
+						BitRateScale:                       4,
+						CpbSizeScale:                       3,
+						InitialCpbRemovalDelayLengthMinus1: 23,
+						AuCpbRemovalDelayLengthMinus1:      23,
+						DpbOutputDelayLengthMinus1:         5,
+						SubLayers: []SPS_HRDSubLayer{{
+							NalHRDParameters: []SPS_SubLayerHRDParameters{{
+								BitRateValueMinus1: 2928,
+								CpbSizeValueMinus1: 26366,
+							}},
+							VclHRDParameters: []SPS_SubLayerHRDParameters{{
+								BitRateValueMinus1: 2928,
+								CpbSizeValueMinus1: 26366,
+							}},
+						}},
+					},
 				},
 			},
 		},
@@ -516,6 +547,98 @@ var casesSPS = []struct {
 					{0, 0, 0, 0, 0, 0},
 					{0, 0, 0, 0, 0, 0},
 				},
+				ScalingListDeltaCoef: [4][6][]int32{
+					{
+						{
+							-2, 4, 0, 4, 0, 0, 4, 0, 0, 0, 18, 0, 0, 36, 0, 55,
+						},
+						{
+							-2, 10, 0, 14, 0, 0, 18, 0, 0, 0, 24, 0, 0, 55, 0, 0,
+						},
+						nil,
+						{
+							1, 6, 0, 5, 0, 0, 5, 0, 0, 0, 11, 0, 0, 60, 0, 31,
+						},
+						{
+							8, 16, 0, 20, 0, 0, 22, 0, 0, 0, 53, 0, 0, 0, 0, 0,
+						},
+						nil,
+					},
+					{
+						{
+							-2, 2, 0, 2, 0, 0, 2, 0, 0, 0, 1, 0, 1, 0, 0, 0,
+							0, 1, 1, 0, 0, 0, 1, 1, 1, 0, 0, 0, -1, 1, 1, 1,
+							1, 0, 0, 0, 0, 2, 4, 4, 0, -4, 0, 2, 6, 6, 6, -6,
+							-6, 6, 22, 32, 0, -32, 0, 32, 31, -31, 0, 31, 0, 0, 0, 0,
+						},
+						{
+							-2, 6, 0, 4, 0, 0, 8, 0, 0, 0, 6, 0, 0, 0, 0, 6,
+							0, 0, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 9, 0, 0, 0,
+							0, 0, 0, 0, 12, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0, 0,
+							0, 24, 0, 0, 0, 0, 31, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						nil,
+						{
+							1, 4, 0, 2, -1, 1, 2, 0, 0, 0, 2, 0, 0, 0, 0, 2,
+							0, 1, 0, -1, 0, 2, 0, 1, 0, 0, -1, 0, 2, 0, 1, 1,
+							0, -1, -1, 0, 3, 0, 4, 0, 0, -4, 0, 4, 16, 16, 0, -16,
+							-16, 32, 32, 0, 0, -32, 63, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						{
+							8, 8, 0, 8, 0, 0, 8, 0, 0, 0, 12, 0, 0, 0, 0, 8,
+							0, 0, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0,
+							0, 0, 0, 0, 10, 0, 0, 0, 0, 0, 0, 10, 0, 0, 0, 0,
+							0, 31, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						nil,
+					},
+					{
+						{
+							2, 0, 0, 2, 0, 0, 2, 0, 0, 0, 1, 0, 1, 0, 0, 0,
+							0, 1, 1, 0, 0, 0, 1, 1, 1, 0, 0, 0, -1, 1, 1, 1,
+							1, 0, 0, 0, 0, 2, 4, 4, 0, -4, 0, 2, 6, 6, 6, -6,
+							-6, 6, 22, 32, 0, -32, 0, 32, 31, -31, 0, 31, 0, 0, 0, 0,
+						},
+						{
+							4, 2, 0, 4, 0, 0, 8, 0, 0, 0, 6, 0, 0, 0, 0, 6,
+							0, 0, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 9, 0, 0, 0,
+							0, 0, 0, 0, 12, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0, 0,
+							0, 24, 0, 0, 0, 0, 31, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						nil,
+						{
+							2, 2, 0, 2, -1, 1, 2, 0, 0, 0, 2, 0, 0, 0, 0, 2,
+							0, 1, 0, -1, 0, 2, 0, 1, 0, 0, -1, 0, 2, 0, 1, 1,
+							0, -1, -1, 0, 3, 0, 4, 0, 0, -4, 0, 4, 16, 16, 0, -16,
+							-16, 32, 32, 0, 0, -32, 63, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						{
+							0, 8, 0, 8, 0, 0, 8, 0, 0, 0, 12, 0, 0, 0, 0, 8,
+							0, 0, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0,
+							0, 0, 0, 0, 10, 0, 0, 0, 0, 0, 0, 10, 0, 0, 0, 0,
+							0, 31, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						nil,
+					},
+					{
+						{
+							2, 0, 0, 2, 0, 0, 2, 0, 0, 0, 1, 0, 1, 0, 0, 0,
+							0, 1, 1, 0, 0, 0, 1, 1, 1, 0, 0, 0, -1, 1, 1, 1,
+							1, 0, 0, 0, 0, 2, 4, 4, 0, -4, 0, 2, 6, 6, 6, -6,
+							-6, 6, 22, 32, 0, -32, 0, 32, 31, -31, 0, 31, 0, 0, 0, 0,
+						},
+						nil,
+						nil,
+						{
+							2, 2, 0, 2, -1, 1, 2, 0, 0, 0, 2, 0, 0, 0, 0, 2,
+							0, 1, 0, -1, 0, 2, 0, 1, 0, 0, -1, 0, 2, 0, 1, 1,
+							0, -1, -1, 0, 3, 0, 4, 0, 0, -4, 0, 4, 16, 16, 0, -16,
+							-16, 32, 32, 0, 0, -32, 63, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+						},
+						nil,
+						nil,
+					},
+				},
 			},
 			SampleAdaptiveOffsetEnabledFlag: true,
 			ShortTermRefPicSets: []*SPS_ShortTermRefPicSet{
@@ -536,6 +659,29 @@ var casesSPS = []struct {
 				TimingInfo: &SPS_TimingInfo{
 					NumUnitsInTick: 100,
 					TimeScale:      3000,
+					HRD: &SPS_HRD{
+						NalHRDParametersPresentFlag:        true,
+						VclHRDParametersPresentFlag:        true,
+						InitialCpbRemovalDelayLengthMinus1: 23,
+						AuCpbRemovalDelayLengthMinus1:      15,
+						DpbOutputDelayLengthMinus1:         5,
+						SubLayers: []SPS_HRDSubLayer{{
+							FixedPicRateWithinCvsFlag: true,
+							NalHRDParameters: []SPS_SubLayerHRDParameters{{
+								BitRateValueMinus1: 63999,
+								CpbSizeValueMinus1: 255999,
+							}},
+							VclHRDParameters: []SPS_SubLayerHRDParameters{{
+								BitRateValueMinus1: 63999,
+								CpbSizeValueMinus1: 255999,
+							}},
+						}},
+					},
+				},
+				BitstreamRestriction: &SPS_BitstreamRestriction{
+					MotionVectorsOverPicBoundariesFlag: true,
+					Log2MaxMvLengthHorizontal:          15,
+					Log2MaxMvLengthVertical:            15,
 				},
 			},
 		},
@@ -559,12 +705,22 @@ func TestSPSUnmarshal(t *testing.T) {
 	}
 }
 
+func TestSPSMarshal(t *testing.T) {
+	for _, ca := range casesSPS {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.sps.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
 func FuzzSPSUnmarshal(f *testing.F) {
 	for _, ca := range casesSPS {
 		f.Add(ca.byts)
 	}
 
-	f.Fuzz(func(_ *testing.T, b []byte) {
+	f.Fuzz(func(t *testing.T, b []byte) {
 		var sps SPS
 		err := sps.Unmarshal(b)
 		if err != nil {
@@ -574,5 +730,13 @@ func FuzzSPSUnmarshal(f *testing.F) {
 		sps.Width()
 		sps.Height()
 		sps.FPS()
+
+		byts, err := sps.Marshal()
+		require.NoError(t, err)
+
+		var sps2 SPS
+		err = sps2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, sps, sps2)
 	})
 }
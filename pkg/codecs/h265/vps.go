@@ -0,0 +1,657 @@
+package h265
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+const (
+	maxLayerSets         = 1024
+	maxScalabilityTypes  = 16
+	maxLayerIDInNuh      = 62
+	multiviewScalability = 1
+)
+
+// VPS_HRD are the HRD parameters of a layer set.
+type VPS_HRD struct { //nolint:revive
+	LayerSetIdx uint32
+
+	// always true for the first HRD.
+	// When false, common infos are copied from the previous HRD.
+	CprmsPresentFlag bool
+
+	Parameters SPS_HRD
+}
+
+// VPS_TimingInfo is a timing info of a VPS.
+type VPS_TimingInfo struct { //nolint:revive
+	NumUnitsInTick              uint32
+	TimeScale                   uint32
+	POCProportionalToTimingFlag bool
+
+	// POCProportionalToTimingFlag == true
+	NumTicksPOCDiffOneMinus1 uint32
+
+	HRDs []VPS_HRD
+}
+
+func (t *VPS_TimingInfo) unmarshal(buf []byte, pos *int, baseLayerInternalFlag bool,
+	numLayerSetsMinus1 uint32, maxSubLayersMinus1 uint8,
+) error {
+	err := bits.HasSpace(buf, *pos, 32+32+1)
+	if err != nil {
+		return err
+	}
+
+	t.NumUnitsInTick = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	t.TimeScale = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	t.POCProportionalToTimingFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	if t.POCProportionalToTimingFlag {
+		t.NumTicksPOCDiffOneMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		t.NumTicksPOCDiffOneMinus1 = 0
+	}
+
+	numHRDParameters, err := bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if numHRDParameters > numLayerSetsMinus1+1 {
+		return fmt.Errorf("invalid vps_num_hrd_parameters")
+	}
+
+	if numHRDParameters == 0 {
+		t.HRDs = nil
+		return nil
+	}
+
+	t.HRDs = make([]VPS_HRD, numHRDParameters)
+
+	for i := range t.HRDs {
+		h := &t.HRDs[i]
+
+		h.LayerSetIdx, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if h.LayerSetIdx > numLayerSetsMinus1 || (!baseLayerInternalFlag && h.LayerSetIdx == 0) {
+			return fmt.Errorf("invalid hrd_layer_set_idx")
+		}
+
+		if i > 0 {
+			h.CprmsPresentFlag, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if !h.CprmsPresentFlag {
+				h.Parameters = t.HRDs[i-1].Parameters
+			}
+		} else {
+			h.CprmsPresentFlag = true
+		}
+
+		err = h.Parameters.unmarshal(buf, pos, h.CprmsPresentFlag, maxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t VPS_TimingInfo) marshalSizeBits() int {
+	n := 32 + 32 + 1
+
+	if t.POCProportionalToTimingFlag {
+		n += bits.GolombUnsignedSize(t.NumTicksPOCDiffOneMinus1)
+	}
+
+	n += bits.GolombUnsignedSize(uint32(len(t.HRDs)))
+
+	for i, h := range t.HRDs {
+		n += bits.GolombUnsignedSize(h.LayerSetIdx)
+
+		if i > 0 {
+			n++
+		}
+
+		n += h.Parameters.marshalSizeBits(h.CprmsPresentFlag)
+	}
+
+	return n
+}
+
+func (t VPS_TimingInfo) marshalTo(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	bits.WriteBitsUnsafe(buf, pos, uint64(t.NumUnitsInTick), 32)
+	bits.WriteBitsUnsafe(buf, pos, uint64(t.TimeScale), 32)
+	bits.WriteFlagUnsafe(buf, pos, t.POCProportionalToTimingFlag)
+
+	if t.POCProportionalToTimingFlag {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, t.NumTicksPOCDiffOneMinus1)
+	}
+
+	bits.WriteGolombUnsignedUnsafe(buf, pos, uint32(len(t.HRDs)))
+
+	for i, h := range t.HRDs {
+		bits.WriteGolombUnsignedUnsafe(buf, pos, h.LayerSetIdx)
+
+		if i > 0 {
+			bits.WriteFlagUnsafe(buf, pos, h.CprmsPresentFlag)
+		} else if !h.CprmsPresentFlag {
+			return fmt.Errorf("CprmsPresentFlag of the first HRD must be true")
+		}
+
+		err := h.Parameters.marshalTo(buf, pos, h.CprmsPresentFlag, maxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VPS_Extension is the multi-layer extension of a VPS.
+// Specification: ITU-T Rec. H.265, F.7.3.2.1.1
+type VPS_Extension struct { //nolint:revive
+	// VPS.MaxLayersMinus1 > 0 && VPS.BaseLayerInternalFlag == true
+	// general profile fields are not present.
+	ProfileTierLevel *SPS_ProfileTierLevel
+
+	SplittingFlag        bool
+	ScalabilityMaskFlag  [maxScalabilityTypes]bool
+	DimensionIDLenMinus1 []uint8
+
+	NuhLayerIDPresentFlag bool
+
+	// one entry for each layer. Inferred values are filled too.
+	LayerIDInNuh []uint8
+	DimensionID  [][]uint8
+
+	ViewIDLen uint8
+
+	// ViewIDLen > 0
+	ViewIDVal []uint16
+
+	// one entry for each layer, containing one flag for each lower layer.
+	DirectDependencyFlag [][]bool
+
+	// the remaining fields are not decoded.
+}
+
+func (e *VPS_Extension) unmarshal(buf []byte, pos *int, vps *VPS) error {
+	if vps.MaxLayersMinus1 > 0 && vps.BaseLayerInternalFlag {
+		e.ProfileTierLevel = &SPS_ProfileTierLevel{}
+		err := e.ProfileTierLevel.unmarshal(buf, pos, false, vps.MaxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	} else {
+		e.ProfileTierLevel = nil
+	}
+
+	err := bits.HasSpace(buf, *pos, 1+maxScalabilityTypes)
+	if err != nil {
+		return err
+	}
+
+	e.SplittingFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	numScalabilityTypes := 0
+
+	for i := range e.ScalabilityMaskFlag {
+		e.ScalabilityMaskFlag[i] = bits.ReadFlagUnsafe(buf, pos)
+		if e.ScalabilityMaskFlag[i] {
+			numScalabilityTypes++
+		}
+	}
+
+	numDimensionIDLens := numScalabilityTypes
+	if e.SplittingFlag && numDimensionIDLens > 0 {
+		numDimensionIDLens--
+	}
+
+	err = bits.HasSpace(buf, *pos, 3*numDimensionIDLens+1)
+	if err != nil {
+		return err
+	}
+
+	e.DimensionIDLenMinus1 = make([]uint8, numDimensionIDLens)
+
+	for j := range e.DimensionIDLenMinus1 {
+		e.DimensionIDLenMinus1[j] = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	}
+
+	// dimension_id_len_minus1 of the last scalability type is inferred
+	// when splitting_flag is true (F.7.4.3.1.1)
+	dimBitOffset := make([]int, numScalabilityTypes+1)
+	for j := range numScalabilityTypes {
+		if j < numDimensionIDLens {
+			dimBitOffset[j+1] = dimBitOffset[j] + int(e.DimensionIDLenMinus1[j]) + 1
+		} else {
+			dimBitOffset[j+1] = 6
+		}
+	}
+
+	if e.SplittingFlag && numScalabilityTypes > 0 && dimBitOffset[numDimensionIDLens] > 5 {
+		return fmt.Errorf("invalid dimension_id_len_minus1")
+	}
+
+	e.NuhLayerIDPresentFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	e.LayerIDInNuh = make([]uint8, int(vps.MaxLayersMinus1)+1)
+	e.DimensionID = make([][]uint8, int(vps.MaxLayersMinus1)+1)
+	e.DimensionID[0] = make([]uint8, numScalabilityTypes)
+
+	for i := 1; i <= int(vps.MaxLayersMinus1); i++ {
+		if e.NuhLayerIDPresentFlag {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 6)
+			if err != nil {
+				return err
+			}
+			e.LayerIDInNuh[i] = uint8(tmp)
+
+			if e.LayerIDInNuh[i] <= e.LayerIDInNuh[i-1] {
+				return fmt.Errorf("invalid layer_id_in_nuh")
+			}
+		} else {
+			e.LayerIDInNuh[i] = uint8(i)
+		}
+
+		e.DimensionID[i] = make([]uint8, numScalabilityTypes)
+
+		for j := range numScalabilityTypes {
+			if !e.SplittingFlag {
+				var tmp uint64
+				tmp, err = bits.ReadBits(buf, pos, int(e.DimensionIDLenMinus1[j])+1)
+				if err != nil {
+					return err
+				}
+				e.DimensionID[i][j] = uint8(tmp)
+			} else {
+				e.DimensionID[i][j] = uint8((int(e.LayerIDInNuh[i]) & ((1 << dimBitOffset[j+1]) - 1)) >>
+					dimBitOffset[j])
+			}
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+	e.ViewIDLen = uint8(tmp)
+
+	if e.ViewIDLen > 0 {
+		numViews := e.numViews()
+
+		err = bits.HasSpace(buf, *pos, numViews*int(e.ViewIDLen))
+		if err != nil {
+			return err
+		}
+
+		e.ViewIDVal = make([]uint16, numViews)
+
+		for i := range e.ViewIDVal {
+			e.ViewIDVal[i] = uint16(bits.ReadBitsUnsafe(buf, pos, int(e.ViewIDLen)))
+		}
+	} else {
+		e.ViewIDVal = nil
+	}
+
+	e.DirectDependencyFlag = make([][]bool, int(vps.MaxLayersMinus1)+1)
+
+	for i := range e.DirectDependencyFlag {
+		err = bits.HasSpace(buf, *pos, i)
+		if err != nil {
+			return err
+		}
+
+		e.DirectDependencyFlag[i] = make([]bool, i)
+
+		for j := range i {
+			e.DirectDependencyFlag[i][j] = bits.ReadFlagUnsafe(buf, pos)
+		}
+	}
+
+	return nil
+}
+
+// numViews computes NumViews (F.7.4.3.1.1).
+func (e VPS_Extension) numViews() int {
+	if !e.ScalabilityMaskFlag[multiviewScalability] {
+		return 1
+	}
+
+	// index of the view order index among the scalability types
+	viewDim := 0
+	if e.ScalabilityMaskFlag[0] {
+		viewDim = 1
+	}
+
+	numViews := 1
+
+	for i := 1; i < len(e.DimensionID); i++ {
+		newView := true
+
+		for j := range i {
+			if e.DimensionID[i][viewDim] == e.DimensionID[j][viewDim] {
+				newView = false
+				break
+			}
+		}
+
+		if newView {
+			numViews++
+		}
+	}
+
+	return numViews
+}
+
+// VPS is a H265 video parameter set.
+// Specification: ITU-T Rec. H.265, 7.3.2.1
+type VPS struct {
+	ID                              uint8
+	BaseLayerInternalFlag           bool
+	BaseLayerAvailableFlag          bool
+	MaxLayersMinus1                 uint8
+	MaxSubLayersMinus1              uint8
+	TemporalIDNestingFlag           bool
+	ProfileTierLevel                SPS_ProfileTierLevel
+	SubLayerOrderingInfoPresentFlag bool
+	MaxDecPicBufferingMinus1        []uint32
+	MaxNumReorderPics               []uint32
+	MaxLatencyIncreasePlus1         []uint32
+	MaxLayerID                      uint8
+
+	// layer_id_included_flag of layer sets from 1 to vps_num_layer_sets_minus1.
+	LayerIDIncludedFlag [][]bool
+
+	TimingInfo *VPS_TimingInfo
+
+	// vps_extension_flag == true.
+	// ExtensionData contains the byte-aligned RBSP that follows vps_extension_alignment_bit_equal_to_one,
+	// including the trailing bits, and is used by Marshal.
+	// Extension is decoded from ExtensionData and is not used by Marshal.
+	ExtensionData []byte
+	Extension     *VPS_Extension
+}
+
+// Unmarshal decodes a VPS.
+func (v *VPS) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType((buf[0]>>1)&0b111111) != NALUType_VPS_NUT {
+		return fmt.Errorf("not a VPS")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[1:])
+	pos := 8
+
+	err := bits.HasSpace(buf, pos, 4+1+1+6+3+1+16)
+	if err != nil {
+		return err
+	}
+
+	v.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	v.BaseLayerInternalFlag = bits.ReadFlagUnsafe(buf, &pos)
+	v.BaseLayerAvailableFlag = bits.ReadFlagUnsafe(buf, &pos)
+	v.MaxLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	v.MaxSubLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	v.TemporalIDNestingFlag = bits.ReadFlagUnsafe(buf, &pos)
+	pos += 16 // vps_reserved_0xffff_16bits
+
+	if v.MaxLayersMinus1 > maxLayerIDInNuh {
+		return fmt.Errorf("invalid vps_max_layers_minus1")
+	}
+
+	err = v.ProfileTierLevel.unmarshal(buf, &pos, true, v.MaxSubLayersMinus1)
+	if err != nil {
+		return err
+	}
+
+	v.SubLayerOrderingInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	var start uint8
+	if v.SubLayerOrderingInfoPresentFlag {
+		start = 0
+	} else {
+		start = v.MaxSubLayersMinus1
+	}
+
+	v.MaxDecPicBufferingMinus1 = make([]uint32, v.MaxSubLayersMinus1+1)
+	v.MaxNumReorderPics = make([]uint32, v.MaxSubLayersMinus1+1)
+	v.MaxLatencyIncreasePlus1 = make([]uint32, v.MaxSubLayersMinus1+1)
+
+	for i := start; i <= v.MaxSubLayersMinus1; i++ {
+		v.MaxDecPicBufferingMinus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		v.MaxNumReorderPics[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		v.MaxLatencyIncreasePlus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 6)
+	if err != nil {
+		return err
+	}
+	v.MaxLayerID = uint8(tmp)
+
+	numLayerSetsMinus1, err := bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if numLayerSetsMinus1 >= maxLayerSets {
+		return fmt.Errorf("invalid vps_num_layer_sets_minus1")
+	}
+
+	if numLayerSetsMinus1 > 0 {
+		err = bits.HasSpace(buf, pos, int(numLayerSetsMinus1)*(int(v.MaxLayerID)+1))
+		if err != nil {
+			return err
+		}
+
+		v.LayerIDIncludedFlag = make([][]bool, numLayerSetsMinus1)
+
+		for i := range v.LayerIDIncludedFlag {
+			v.LayerIDIncludedFlag[i] = make([]bool, int(v.MaxLayerID)+1)
+
+			for j := range v.LayerIDIncludedFlag[i] {
+				v.LayerIDIncludedFlag[i][j] = bits.ReadFlagUnsafe(buf, &pos)
+			}
+		}
+	} else {
+		v.LayerIDIncludedFlag = nil
+	}
+
+	timingInfoPresentFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if timingInfoPresentFlag {
+		v.TimingInfo = &VPS_TimingInfo{}
+		err = v.TimingInfo.unmarshal(buf, &pos, v.BaseLayerInternalFlag, numLayerSetsMinus1, v.MaxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	} else {
+		v.TimingInfo = nil
+	}
+
+	extensionFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if extensionFlag {
+		// vps_extension_alignment_bit_equal_to_one
+		pos = (pos + 7) / 8 * 8
+
+		if pos >= len(buf)*8 {
+			return fmt.Errorf("not enough bits")
+		}
+
+		v.ExtensionData = buf[pos/8:]
+
+		v.Extension = &VPS_Extension{}
+		pos = 0
+		err = v.Extension.unmarshal(v.ExtensionData, &pos, v)
+		if err != nil {
+			return err
+		}
+	} else {
+		v.ExtensionData = nil
+		v.Extension = nil
+	}
+
+	return nil
+}
+
+func (v VPS) marshalSizeBits() int {
+	n := 16 + 4 + 1 + 1 + 6 + 3 + 1 + 16 +
+		v.ProfileTierLevel.marshalSizeBits(true, v.MaxSubLayersMinus1) +
+		1
+
+	for i := v.firstSubLayerOrderingInfo(); i <= v.MaxSubLayersMinus1; i++ {
+		n += bits.GolombUnsignedSize(v.MaxDecPicBufferingMinus1[i]) +
+			bits.GolombUnsignedSize(v.MaxNumReorderPics[i]) +
+			bits.GolombUnsignedSize(v.MaxLatencyIncreasePlus1[i])
+	}
+
+	n += 6 +
+		bits.GolombUnsignedSize(uint32(len(v.LayerIDIncludedFlag))) +
+		len(v.LayerIDIncludedFlag)*(int(v.MaxLayerID)+1) +
+		1
+
+	if v.TimingInfo != nil {
+		n += v.TimingInfo.marshalSizeBits()
+	}
+
+	n++
+
+	if v.ExtensionData != nil {
+		return (n+7)/8*8 + len(v.ExtensionData)*8
+	}
+
+	return n + 1 // rbsp_stop_one_bit
+}
+
+func (v VPS) firstSubLayerOrderingInfo() uint8 {
+	if v.SubLayerOrderingInfoPresentFlag {
+		return 0
+	}
+	return v.MaxSubLayersMinus1
+}
+
+// Marshal encodes a VPS.
+func (v VPS) Marshal() ([]byte, error) {
+	if v.ID > 15 || v.MaxLayersMinus1 > maxLayerIDInNuh || v.MaxSubLayersMinus1 > 7 || v.MaxLayerID > 63 {
+		return nil, fmt.Errorf("invalid ID, MaxLayersMinus1, MaxSubLayersMinus1 or MaxLayerID")
+	}
+
+	if len(v.MaxDecPicBufferingMinus1) != int(v.MaxSubLayersMinus1)+1 ||
+		len(v.MaxNumReorderPics) != int(v.MaxSubLayersMinus1)+1 ||
+		len(v.MaxLatencyIncreasePlus1) != int(v.MaxSubLayersMinus1)+1 {
+		return nil, fmt.Errorf("sub-layer ordering infos do not match MaxSubLayersMinus1")
+	}
+
+	if len(v.LayerIDIncludedFlag) >= maxLayerSets {
+		return nil, fmt.Errorf("too many layer sets")
+	}
+
+	for _, set := range v.LayerIDIncludedFlag {
+		if len(set) != int(v.MaxLayerID)+1 {
+			return nil, fmt.Errorf("layer sets do not match MaxLayerID")
+		}
+	}
+
+	if v.TimingInfo != nil && len(v.TimingInfo.HRDs) > len(v.LayerIDIncludedFlag)+1 {
+		return nil, fmt.Errorf("too many HRDs")
+	}
+
+	n := v.marshalSizeBits()
+	buf := make([]byte, (n+7)/8)
+
+	buf[0] = byte(NALUType_VPS_NUT) << 1
+	buf[1] = 1 // nuh_temporal_id_plus1
+
+	pos := 16
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(v.ID), 4)
+	bits.WriteFlagUnsafe(buf, &pos, v.BaseLayerInternalFlag)
+	bits.WriteFlagUnsafe(buf, &pos, v.BaseLayerAvailableFlag)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(v.MaxLayersMinus1), 6)
+	bits.WriteBitsUnsafe(buf, &pos, uint64(v.MaxSubLayersMinus1), 3)
+	bits.WriteFlagUnsafe(buf, &pos, v.TemporalIDNestingFlag)
+	bits.WriteBitsUnsafe(buf, &pos, 0xFFFF, 16) // vps_reserved_0xffff_16bits
+
+	err := v.ProfileTierLevel.marshalTo(buf, &pos, true, v.MaxSubLayersMinus1)
+	if err != nil {
+		return nil, err
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, v.SubLayerOrderingInfoPresentFlag)
+
+	for i := v.firstSubLayerOrderingInfo(); i <= v.MaxSubLayersMinus1; i++ {
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, v.MaxDecPicBufferingMinus1[i])
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, v.MaxNumReorderPics[i])
+		bits.WriteGolombUnsignedUnsafe(buf, &pos, v.MaxLatencyIncreasePlus1[i])
+	}
+
+	bits.WriteBitsUnsafe(buf, &pos, uint64(v.MaxLayerID), 6)
+	bits.WriteGolombUnsignedUnsafe(buf, &pos, uint32(len(v.LayerIDIncludedFlag)))
+
+	for _, set := range v.LayerIDIncludedFlag {
+		for _, flag := range set {
+			bits.WriteFlagUnsafe(buf, &pos, flag)
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, v.TimingInfo != nil)
+
+	if v.TimingInfo != nil {
+		err = v.TimingInfo.marshalTo(buf, &pos, v.MaxSubLayersMinus1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, &pos, v.ExtensionData != nil)
+
+	if v.ExtensionData != nil {
+		for pos%8 != 0 {
+			bits.WriteFlagUnsafe(buf, &pos, true) // vps_extension_alignment_bit_equal_to_one
+		}
+
+		copy(buf[pos/8:], v.ExtensionData)
+	} else {
+		bits.WriteFlagUnsafe(buf, &pos, true) // rbsp_stop_one_bit
+	}
+
+	return h264.EmulationPreventionAdd(buf), nil
+}
@@ -0,0 +1,170 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVPS = []struct {
+	name string
+	byts []byte
+	vps  VPS
+}{
+	{
+		"1920x1080",
+		[]byte{
+			0x40, 0x01, 0x0c, 0x01, 0xff, 0xff, 0x01, 0x60,
+			0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00, 0x78, 0x99, 0x98, 0x09,
+		},
+		VPS{
+			BaseLayerInternalFlag:  true,
+			BaseLayerAvailableFlag: true,
+			TemporalIDNestingFlag:  true,
+			ProfileTierLevel: SPS_ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralProfileCompatibilityFlag: [32]bool{
+					false, true, true, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+				},
+				GeneralProgressiveSourceFlag:   true,
+				GeneralFrameOnlyConstraintFlag: true,
+				GeneralLevelIdc:                120,
+			},
+			SubLayerOrderingInfoPresentFlag: true,
+			MaxDecPicBufferingMinus1:        []uint32{5},
+			MaxNumReorderPics:               []uint32{2},
+			MaxLatencyIncreasePlus1:         []uint32{5},
+		},
+	},
+	{
+		"timing info",
+		[]byte{
+			0x40, 0x01, 0x0c, 0x01, 0xff, 0xff, 0x01, 0x40,
+			0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00, 0x7b, 0x11, 0xc0, 0xc0,
+			0x00, 0x00, 0x03, 0x00, 0x40, 0x00, 0x00, 0x0f,
+			0x14,
+		},
+		VPS{
+			BaseLayerInternalFlag:  true,
+			BaseLayerAvailableFlag: true,
+			TemporalIDNestingFlag:  true,
+			ProfileTierLevel: SPS_ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralProfileCompatibilityFlag: [32]bool{
+					false, true, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+				},
+				GeneralProgressiveSourceFlag:   true,
+				GeneralFrameOnlyConstraintFlag: true,
+				GeneralLevelIdc:                123,
+			},
+			MaxDecPicBufferingMinus1: []uint32{3},
+			MaxNumReorderPics:        []uint32{2},
+			MaxLatencyIncreasePlus1:  []uint32{0},
+			TimingInfo: &VPS_TimingInfo{
+				NumUnitsInTick: 1,
+				TimeScale:      60,
+			},
+		},
+	},
+	{
+		"multiview extension",
+		[]byte{
+			0x40, 0x01, 0x0c, 0x11, 0xff, 0xff, 0x01, 0x60,
+			0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x03, 0x00, 0x78, 0x99, 0x98, 0x15,
+			0xbf, 0x5d, 0xa0, 0x00, 0x05, 0xc0,
+		},
+		VPS{
+			BaseLayerInternalFlag:  true,
+			BaseLayerAvailableFlag: true,
+			MaxLayersMinus1:        1,
+			TemporalIDNestingFlag:  true,
+			ProfileTierLevel: SPS_ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralProfileCompatibilityFlag: [32]bool{
+					false, true, true, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+				},
+				GeneralProgressiveSourceFlag:   true,
+				GeneralFrameOnlyConstraintFlag: true,
+				GeneralLevelIdc:                120,
+			},
+			SubLayerOrderingInfoPresentFlag: true,
+			MaxDecPicBufferingMinus1:        []uint32{5},
+			MaxNumReorderPics:               []uint32{2},
+			MaxLatencyIncreasePlus1:         []uint32{5},
+			MaxLayerID:                      1,
+			LayerIDIncludedFlag:             [][]bool{{true, true}},
+			ExtensionData:                   []byte{0x5d, 0xa0, 0x00, 0x05, 0xc0},
+			Extension: &VPS_Extension{
+				ProfileTierLevel: &SPS_ProfileTierLevel{
+					GeneralLevelIdc: 93,
+				},
+				SplittingFlag: true,
+				ScalabilityMaskFlag: [16]bool{
+					false, true, false, false, false, false, false, false,
+					false, false, false, false, false, false, false, false,
+				},
+				DimensionIDLenMinus1: []uint8{},
+				LayerIDInNuh:         []uint8{0, 1},
+				DimensionID:          [][]uint8{{0}, {1}},
+				ViewIDLen:            1,
+				ViewIDVal:            []uint16{0, 1},
+				DirectDependencyFlag: [][]bool{{}, {true}},
+			},
+		},
+	},
+}
+
+func TestVPSUnmarshal(t *testing.T) {
+	for _, ca := range casesVPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var vps VPS
+			err := vps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.vps, vps)
+		})
+	}
+}
+
+func TestVPSMarshal(t *testing.T) {
+	for _, ca := range casesVPS {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.vps.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func FuzzVPSUnmarshal(f *testing.F) {
+	for _, ca := range casesVPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var vps VPS
+		err := vps.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		byts, err := vps.Marshal()
+		require.NoError(t, err)
+
+		var vps2 VPS
+		err = vps2.Unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, vps, vps2)
+	})
+}
@@ -0,0 +1,263 @@
+package h266
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+const (
+	maxReorderedFrames = 10
+	/*
+		(max_size(flags) + max_size(ph_pic_parameter_set_id) + max_size(ph_pic_order_cnt_lsb)) * 4 / 3 =
+		(1 + 2 + 2) * 4 / 3 = 7
+	*/
+	maxBytesToGetPOC = 7
+)
+
+// getPictureOrderCount reads ph_pic_order_cnt_lsb from a picture header.
+// Specification: ITU-T Rec. H.266, 7.3.2.8
+func getPictureOrderCount(buf []byte, pos *int, sps *SPS) (uint32, error) {
+	phGdrOrIrapPicFlag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	*pos++ // ph_non_ref_pic_flag
+
+	if phGdrOrIrapPicFlag {
+		*pos++ // ph_gdr_pic_flag
+	}
+
+	phInterSliceAllowedFlag, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	if phInterSliceAllowedFlag {
+		*pos++ // ph_intra_slice_allowed_flag
+	}
+
+	_, err = bits.ReadGolombUnsigned(buf, pos) // ph_pic_parameter_set_id
+	if err != nil {
+		return 0, err
+	}
+
+	phPicOrderCntLsb, err := bits.ReadBits(buf, pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(phPicOrderCntLsb), nil
+}
+
+func getPictureOrderCountFromNALU(slice []byte, ph []byte, sps *SPS) (uint32, error) {
+	slice = slice[2:]
+	lb := min(len(slice), maxBytesToGetPOC)
+	slice = h264.EmulationPreventionRemove(slice[:lb])
+	pos := 0
+
+	shPictureHeaderInSliceHeaderFlag, err := bits.ReadFlag(slice, &pos)
+	if err != nil {
+		return 0, err
+	}
+
+	if shPictureHeaderInSliceHeaderFlag {
+		return getPictureOrderCount(slice, &pos, sps)
+	}
+
+	if ph == nil {
+		return 0, fmt.Errorf("picture header not found")
+	}
+
+	ph = ph[2:]
+	lb = min(len(ph), maxBytesToGetPOC)
+	ph = h264.EmulationPreventionRemove(ph[:lb])
+	pos = 0
+
+	return getPictureOrderCount(ph, &pos, sps)
+}
+
+func pictureOrderCountDiff(a uint32, b uint32, sps *SPS) int32 {
+	maxVal := uint32(1 << (sps.Log2MaxPicOrderCntLsbMinus4 + 4))
+	d := (a - b) & (maxVal - 1)
+	if d > (maxVal / 2) {
+		return int32(d) - int32(maxVal)
+	}
+	return int32(d)
+}
+
+// DTSExtractor computes DTS from PTS.
+type DTSExtractor struct {
+	sps             []byte
+	spsp            *SPS
+	prevDTSFilled   bool
+	prevDTS         int64
+	randomReceived  bool
+	expectedPOC     uint32
+	reorderedFrames int
+	pause           int
+}
+
+// Initialize initializes a DTSExtractor.
+func (d *DTSExtractor) Initialize() {
+}
+
+func (d *DTSExtractor) timeDiff(ptsDTSDiff int) int64 {
+	if d.spsp.GeneralTimingHRDParameters != nil && d.spsp.GeneralTimingHRDParameters.TimeScale != 0 {
+		return int64(ptsDTSDiff) * 90000 *
+			int64(d.spsp.GeneralTimingHRDParameters.NumUnitsInTick) / int64(d.spsp.GeneralTimingHRDParameters.TimeScale)
+	}
+	return 9000
+}
+
+func (d *DTSExtractor) extractInner(au [][]byte, pts int64) (int64, error) {
+	var ph []byte
+	var random []byte
+	var nonRandom []byte
+
+outer:
+	for _, nalu := range au {
+		if len(nalu) < 2 {
+			return 0, fmt.Errorf("invalid NALU")
+		}
+
+		typ := NALUType(nalu[1] >> 3)
+
+		switch typ {
+		case NALUType_SPS_NUT:
+			if !bytes.Equal(d.sps, nalu) {
+				var spsp SPS
+				err := spsp.Unmarshal(nalu)
+				if err != nil {
+					return 0, fmt.Errorf("invalid SPS: %w", err)
+				}
+
+				d.spsp = &spsp
+				d.sps = nalu
+
+				// reset state
+				d.randomReceived = false
+				d.expectedPOC = 0
+				if len(d.spsp.MaxNumReorderPics) != 0 {
+					d.reorderedFrames = min(int(d.spsp.MaxNumReorderPics[len(d.spsp.MaxNumReorderPics)-1]),
+						maxReorderedFrames)
+				} else {
+					d.reorderedFrames = 0
+				}
+				d.pause = d.reorderedFrames
+			}
+
+		case NALUType_PH_NUT:
+			ph = nalu
+
+		case NALUType_IDR_W_RADL, NALUType_IDR_N_LP, NALUType_CRA_NUT:
+			random = nalu
+			break outer
+
+		case NALUType_TRAIL_NUT, NALUType_STSA_NUT, NALUType_RADL_NUT, NALUType_RASL_NUT, NALUType_GDR_NUT:
+			nonRandom = nalu
+			break outer
+		}
+	}
+
+	if d.spsp == nil {
+		return 0, fmt.Errorf("SPS not received yet")
+	}
+
+	if !d.randomReceived {
+		if random == nil {
+			return 0, fmt.Errorf("random access frame not received yet")
+		}
+		d.randomReceived = true
+	}
+
+	var ptsDTSDiff int
+
+	switch {
+	case random != nil:
+		var err error
+		d.expectedPOC, err = getPictureOrderCountFromNALU(random, ph, d.spsp)
+		if err != nil {
+			return 0, err
+		}
+
+		ptsDTSDiff = 0
+
+	case nonRandom != nil:
+		poc, err := getPictureOrderCountFromNALU(nonRandom, ph, d.spsp)
+		if err != nil {
+			return 0, err
+		}
+
+		d.expectedPOC++
+		d.expectedPOC &= ((1 << (d.spsp.Log2MaxPicOrderCntLsbMinus4 + 4)) - 1)
+
+		ptsDTSDiff = int(pictureOrderCountDiff(poc, d.expectedPOC, d.spsp))
+
+	default:
+		return 0, fmt.Errorf("access unit doesn't contain a VCL NALU")
+	}
+
+	ptsDTSDiff += d.reorderedFrames
+
+	switch {
+	case ptsDTSDiff > (2*d.reorderedFrames + 1):
+		increase := ptsDTSDiff - (2*d.reorderedFrames + 1)
+		if (d.reorderedFrames + increase) > maxReorderedFrames {
+			return 0, fmt.Errorf("too many reordered frames (%d)", d.reorderedFrames+increase)
+		}
+
+		d.reorderedFrames += increase
+		d.pause += increase
+		ptsDTSDiff += increase
+
+	case ptsDTSDiff < 0:
+		increase := -ptsDTSDiff
+		if (d.reorderedFrames + increase) > maxReorderedFrames {
+			return 0, fmt.Errorf("too many reordered frames (%d)", d.reorderedFrames+increase)
+		}
+
+		d.reorderedFrames += increase
+		d.pause += increase
+		ptsDTSDiff += increase
+	}
+
+	if d.pause > 0 {
+		d.pause--
+		if !d.prevDTSFilled {
+			return pts - d.timeDiff(ptsDTSDiff), nil
+		}
+		return d.prevDTS + 90, nil
+	}
+
+	if !d.prevDTSFilled {
+		return pts - d.timeDiff(ptsDTSDiff), nil
+	}
+
+	return d.prevDTS + (pts-d.prevDTS)/(int64(ptsDTSDiff)+1), nil
+}
+
+// Extract extracts the DTS of an access unit.
+func (d *DTSExtractor) Extract(au [][]byte, pts int64) (int64, error) {
+	dts, err := d.extractInner(au, pts)
+	if err != nil {
+		return 0, err
+	}
+
+	if dts > pts {
+		return 0, fmt.Errorf("DTS is greater than PTS")
+	}
+
+	if d.prevDTSFilled && dts < d.prevDTS {
+		return 0, fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			d.prevDTS, dts)
+	}
+
+	d.prevDTSFilled = true
+	d.prevDTS = dts
+
+	return dts, err
+}
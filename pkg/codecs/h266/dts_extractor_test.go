@@ -0,0 +1,228 @@
+package h266
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+type sequenceSample struct {
+	au  [][]byte
+	pts int64
+	dts int64
+}
+
+var testSPS = []byte{
+	0x00, 0x79, 0x00, 0x0d, 0x02, 0x43, 0x80, 0x00,
+	0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+	0x2a, 0xa6, 0xd5, 0xa6, 0xd9, 0x0a, 0x4c, 0x99,
+	0xc2, 0x68, 0x34, 0x24, 0x53, 0xf0, 0xb5, 0x34,
+	0x57, 0xb5, 0xfd, 0xd7, 0xe3, 0x12, 0x00, 0x00,
+	0x03, 0x00, 0x02, 0x00, 0x00, 0x03, 0x00, 0x3c,
+	0x62,
+}
+
+var testPPS = []byte{
+	0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+	0xc8, 0x83, 0x09, 0x04,
+}
+
+var casesDTSExtractor = []struct {
+	name     string
+	sequence []sequenceSample
+}{
+	{
+		"picture header in slice header",
+		[]sequenceSample{
+			{
+				[][]byte{
+					testSPS,
+					testPPS,
+					{ // IDR_W_RADL
+						0x00, 0x39, 0xc4, 0x00, 0x24, 0x68, 0xad,
+					},
+				},
+				0,
+				-3000,
+			},
+			{
+				[][]byte{{ // TRAIL_NUT, POC 2
+					0x00, 0x01, 0x9c, 0x08, 0x24, 0x68, 0xad,
+				}},
+				6000,
+				0,
+			},
+			{
+				[][]byte{{ // TRAIL_NUT, POC 1
+					0x00, 0x01, 0x9c, 0x04, 0x24, 0x68, 0xad,
+				}},
+				3000,
+				3000,
+			},
+			{
+				[][]byte{{ // TRAIL_NUT, POC 4
+					0x00, 0x01, 0x9c, 0x10, 0x24, 0x68, 0xad,
+				}},
+				12000,
+				6000,
+			},
+			{
+				[][]byte{{ // TRAIL_NUT, POC 3
+					0x00, 0x01, 0x9c, 0x0c, 0x24, 0x68, 0xad,
+				}},
+				9000,
+				9000,
+			},
+		},
+	},
+	{
+		"separate picture header",
+		[]sequenceSample{
+			{
+				[][]byte{
+					testSPS,
+					testPPS,
+					{ // PH_NUT, POC 0
+						0x00, 0x99, 0x88, 0x02,
+					},
+					{ // IDR_N_LP
+						0x00, 0x41, 0x09, 0x1a, 0x2b, 0x40,
+					},
+				},
+				0,
+				-3000,
+			},
+			{
+				[][]byte{
+					{ // PH_NUT, POC 2
+						0x00, 0x99, 0x38, 0x12,
+					},
+					{ // TRAIL_NUT
+						0x00, 0x01, 0x09, 0x1a, 0x2b, 0x40,
+					},
+				},
+				6000,
+				0,
+			},
+			{
+				[][]byte{
+					{ // PH_NUT, POC 1
+						0x00, 0x99, 0x38, 0x0a,
+					},
+					{ // TRAIL_NUT
+						0x00, 0x01, 0x09, 0x1a, 0x2b, 0x40,
+					},
+				},
+				3000,
+				3000,
+			},
+		},
+	},
+}
+
+func TestDTSExtractor(t *testing.T) {
+	for _, ca := range casesDTSExtractor {
+		t.Run(ca.name, func(t *testing.T) {
+			ex := &DTSExtractor{}
+			ex.Initialize()
+
+			for _, sample := range ca.sequence {
+				dts, err := ex.Extract(sample.au, sample.pts)
+				require.NoError(t, err)
+				require.Equal(t, sample.dts, dts)
+			}
+		})
+	}
+}
+
+func serializeSequence(seq []sequenceSample) []byte {
+	var buf []byte //nolint:prealloc
+
+	for _, sample := range seq {
+		tmp := make([]byte, 8)
+		binary.LittleEndian.PutUint64(tmp, uint64(sample.pts))
+		buf = append(buf, tmp...)
+
+		au, _ := h264.AnnexB(sample.au).Marshal()
+
+		tmp = make([]byte, 4)
+		binary.LittleEndian.PutUint32(tmp, uint32(len(au)))
+		buf = append(buf, tmp...)
+
+		buf = append(buf, au...)
+	}
+
+	return buf
+}
+
+func unserializeSequence(buf []byte) ([]sequenceSample, error) {
+	var samples []sequenceSample
+
+	for {
+		if len(buf) < 8 {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		pts := int64(binary.LittleEndian.Uint64(buf[:8]))
+		buf = buf[8:]
+
+		if len(buf) < 4 {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		auLen := binary.LittleEndian.Uint32(buf[:4])
+		buf = buf[4:]
+
+		if auLen == 0 {
+			return nil, fmt.Errorf("invalid AU len")
+		}
+		if len(buf) < int(auLen) {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		rawAu := buf[:auLen]
+		buf = buf[auLen:]
+
+		var au h264.AnnexB
+		err := au.Unmarshal(rawAu)
+		if err != nil {
+			return nil, fmt.Errorf("not enough bits")
+		}
+
+		samples = append(samples, sequenceSample{
+			au:  au,
+			pts: pts,
+		})
+
+		if len(buf) == 0 {
+			break
+		}
+	}
+
+	return samples, nil
+}
+
+func FuzzDTSExtractor(f *testing.F) {
+	for _, ca := range casesDTSExtractor {
+		f.Add(serializeSequence(ca.sequence))
+	}
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		seq, err := unserializeSequence(buf)
+		if err != nil {
+			t.Skip()
+			return
+		}
+
+		ex := &DTSExtractor{}
+		ex.Initialize()
+
+		for _, sample := range seq {
+			_, err = ex.Extract(sample.au, sample.pts)
+			if err != nil {
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,10 @@
+// Package h266 contains utilities to work with the H266 codec.
+package h266
+
+const (
+	// MaxAccessUnitSize is the maximum size of an access unit.
+	MaxAccessUnitSize = 8 * 1024 * 1024
+
+	// MaxNALUsPerAccessUnit is the maximum number of NALUs per access unit.
+	MaxNALUsPerAccessUnit = 21
+)
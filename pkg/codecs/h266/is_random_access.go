@@ -0,0 +1,17 @@
+package h266
+
+// IsRandomAccess checks whether the access unit can be randomly accessed.
+func IsRandomAccess(au [][]byte) bool {
+	for _, nalu := range au {
+		if len(nalu) < 2 {
+			continue
+		}
+
+		typ := NALUType(nalu[1] >> 3)
+		switch typ {
+		case NALUType_IDR_W_RADL, NALUType_IDR_N_LP, NALUType_CRA_NUT:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package h266
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+// PPS_ScalingWindow is a scaling window.
+type PPS_ScalingWindow struct { //nolint:revive
+	LeftOffset   int32
+	RightOffset  int32
+	TopOffset    int32
+	BottomOffset int32
+}
+
+func (w *PPS_ScalingWindow) unmarshal(buf []byte, pos *int) error {
+	var err error
+	w.LeftOffset, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.RightOffset, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.TopOffset, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.BottomOffset, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PPS is a H266 picture parameter set.
+// Specification: ITU-T Rec. H.266, 7.3.2.5
+type PPS struct {
+	ID                         uint8
+	SPSID                      uint8
+	MixedNALUTypesInPicFlag    bool
+	PicWidthInLumaSamples      uint32
+	PicHeightInLumaSamples     uint32
+	ConformanceWindow          *SPS_Window
+	ScalingWindow              *PPS_ScalingWindow
+	OutputFlagPresentFlag      bool
+	NoPicPartitionFlag         bool
+	SubpicIDMappingPresentFlag bool
+	// other fields are not decoded.
+}
+
+// Unmarshal decodes a PPS from bytes.
+func (p *PPS) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType(buf[1]>>3) != NALUType_PPS_NUT {
+		return fmt.Errorf("not a PPS")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[2:])
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 6+4+1)
+	if err != nil {
+		return err
+	}
+
+	p.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	p.SPSID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	p.MixedNALUTypesInPicFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	p.PicWidthInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	p.PicHeightInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	conformanceWindowFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if conformanceWindowFlag {
+		p.ConformanceWindow = &SPS_Window{}
+		err = p.ConformanceWindow.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.ConformanceWindow = nil
+	}
+
+	scalingWindowExplicitSignallingFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if scalingWindowExplicitSignallingFlag {
+		p.ScalingWindow = &PPS_ScalingWindow{}
+		err = p.ScalingWindow.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.ScalingWindow = nil
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	p.OutputFlagPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.NoPicPartitionFlag = bits.ReadFlagUnsafe(buf, &pos)
+	p.SubpicIDMappingPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPPS = []struct {
+	name string
+	byts []byte
+	pps  PPS
+}{
+	{
+		"default",
+		[]byte{
+			0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+			0xc8, 0x83, 0x09, 0x04,
+		},
+		PPS{
+			PicWidthInLumaSamples:  1920,
+			PicHeightInLumaSamples: 1080,
+			NoPicPartitionFlag:     true,
+		},
+	},
+	{
+		"windows",
+		[]byte{
+			0x00, 0x81, 0x04, 0x00, 0x05, 0x01, 0x00, 0x5c,
+			0x3e, 0x26, 0x9c, 0x98, 0x00, 0x02,
+		},
+		PPS{
+			ID:                     1,
+			PicWidthInLumaSamples:  1280,
+			PicHeightInLumaSamples: 736,
+			ConformanceWindow: &SPS_Window{
+				BottomOffset: 8,
+			},
+			ScalingWindow: &PPS_ScalingWindow{
+				LeftOffset:   1,
+				RightOffset:  -1,
+				BottomOffset: 2,
+			},
+			OutputFlagPresentFlag: true,
+			NoPicPartitionFlag:    true,
+		},
+	},
+}
+
+func TestPPSUnmarshal(t *testing.T) {
+	for _, ca := range casesPPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var pps PPS
+			err := pps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pps, pps)
+		})
+	}
+}
+
+func FuzzPPSUnmarshal(f *testing.F) {
+	for _, ca := range casesPPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var pps PPS
+		pps.Unmarshal(b) //nolint:errcheck
+	})
+}
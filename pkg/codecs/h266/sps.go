@@ -0,0 +1,1601 @@
+package h266
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+)
+
+const (
+	maxSubpics           = 600
+	maxSubpicIDLen       = 16
+	maxQPTablePoints     = 64
+	maxRefPicLists       = 64
+	maxRefEntries        = 29
+	maxVirtualBoundaries = 3
+	maxSubProfiles       = 255
+)
+
+var subWidthC = []uint32{
+	1,
+	2,
+	2,
+	1,
+}
+
+var subHeightC = []uint32{
+	1,
+	2,
+	1,
+	1,
+}
+
+func ceilLog2(v uint32) int {
+	n := 0
+	for n < 32 && (uint32(1)<<n) < v {
+		n++
+	}
+	return n
+}
+
+// SPS_ProfileTierLevel is a profile, tier and level.
+// Specification: ITU-T Rec. H.266, 7.3.3.1
+type SPS_ProfileTierLevel struct { //nolint:revive
+	GeneralProfileIdc uint8
+	GeneralTierFlag   bool
+	GeneralLevelIdc   uint8
+
+	// ptl_frame_only_constraint_flag, ptl_multilayer_enabled_flag and general_constraints_info(),
+	// including alignment bits.
+	// These are not decoded and are kept as they are in order to fill configuration records.
+	ConstraintInfo []byte
+
+	SubLayerLevelPresentFlag []bool
+	SubLayerLevelIdc         []uint8
+	GeneralSubProfileIdc     []uint32
+}
+
+// FrameOnlyConstraintFlag returns ptl_frame_only_constraint_flag.
+func (p SPS_ProfileTierLevel) FrameOnlyConstraintFlag() bool {
+	return len(p.ConstraintInfo) != 0 && (p.ConstraintInfo[0]&0x80) != 0
+}
+
+// MultilayerEnabledFlag returns ptl_multilayer_enabled_flag.
+func (p SPS_ProfileTierLevel) MultilayerEnabledFlag() bool {
+	return len(p.ConstraintInfo) != 0 && (p.ConstraintInfo[0]&0x40) != 0
+}
+
+func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	if (*pos % 8) != 0 {
+		return fmt.Errorf("profile_tier_level is not byte-aligned")
+	}
+
+	err := bits.HasSpace(buf, *pos, 8+8+2+1)
+	if err != nil {
+		return err
+	}
+
+	p.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 7))
+	p.GeneralTierFlag = bits.ReadFlagUnsafe(buf, pos)
+	p.GeneralLevelIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+
+	start := *pos
+	*pos += 2 // ptl_frame_only_constraint_flag, ptl_multilayer_enabled_flag
+
+	gciPresentFlag := bits.ReadFlagUnsafe(buf, pos)
+
+	if gciPresentFlag {
+		// general constraint flags
+		err = bits.HasSpace(buf, *pos, 71)
+		if err != nil {
+			return err
+		}
+		*pos += 71
+
+		var gciNumAdditionalBits uint64
+		gciNumAdditionalBits, err = bits.ReadBits(buf, pos, 8)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(buf, *pos, int(gciNumAdditionalBits))
+		if err != nil {
+			return err
+		}
+		*pos += int(gciNumAdditionalBits)
+	}
+
+	// gci_alignment_zero_bit
+	if (*pos % 8) != 0 {
+		*pos += 8 - (*pos % 8)
+	}
+
+	err = bits.HasSpace(buf, start, *pos-start)
+	if err != nil {
+		return err
+	}
+
+	p.ConstraintInfo = append([]byte(nil), buf[start/8:*pos/8]...)
+
+	if maxSubLayersMinus1 > 0 {
+		p.SubLayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
+		p.SubLayerLevelIdc = make([]uint8, maxSubLayersMinus1)
+
+		err = bits.HasSpace(buf, *pos, int(maxSubLayersMinus1))
+		if err != nil {
+			return err
+		}
+
+		for i := int(maxSubLayersMinus1) - 1; i >= 0; i-- {
+			p.SubLayerLevelPresentFlag[i] = bits.ReadFlagUnsafe(buf, pos)
+		}
+	} else {
+		p.SubLayerLevelPresentFlag = nil
+		p.SubLayerLevelIdc = nil
+	}
+
+	// ptl_reserved_zero_bit
+	if (*pos % 8) != 0 {
+		*pos += 8 - (*pos % 8)
+	}
+
+	for i := int(maxSubLayersMinus1) - 1; i >= 0; i-- {
+		if p.SubLayerLevelPresentFlag[i] {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 8)
+			if err != nil {
+				return err
+			}
+			p.SubLayerLevelIdc[i] = uint8(tmp)
+		}
+	}
+
+	ptlNumSubProfiles, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	if ptlNumSubProfiles > maxSubProfiles {
+		return fmt.Errorf("ptl_num_sub_profiles exceeds %d", maxSubProfiles)
+	}
+
+	if ptlNumSubProfiles != 0 {
+		err = bits.HasSpace(buf, *pos, 32*int(ptlNumSubProfiles))
+		if err != nil {
+			return err
+		}
+
+		p.GeneralSubProfileIdc = make([]uint32, ptlNumSubProfiles)
+
+		for i := range p.GeneralSubProfileIdc {
+			p.GeneralSubProfileIdc[i] = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+		}
+	} else {
+		p.GeneralSubProfileIdc = nil
+	}
+
+	return nil
+}
+
+// SPS_Window is a window.
+type SPS_Window struct { //nolint:revive
+	LeftOffset   uint32
+	RightOffset  uint32
+	TopOffset    uint32
+	BottomOffset uint32
+}
+
+func (w *SPS_Window) unmarshal(buf []byte, pos *int) error {
+	var err error
+	w.LeftOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.RightOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.TopOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	w.BottomOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SPS_SubpicInfo contains subpicture informations.
+type SPS_SubpicInfo struct { //nolint:revive
+	NumSubpicsMinus1       uint32
+	IndependentSubpicsFlag bool
+	SubpicSameSizeFlag     bool
+
+	CtuTopLeftX                       []uint32
+	CtuTopLeftY                       []uint32
+	WidthMinus1                       []uint32
+	HeightMinus1                      []uint32
+	TreatedAsPicFlag                  []bool
+	LoopFilterAcrossSubpicEnabledFlag []bool
+
+	SubpicIDLenMinus1                      uint32
+	SubpicIDMappingExplicitlySignalledFlag bool
+	SubpicIDMappingPresentFlag             bool
+	SubpicID                               []uint32
+}
+
+func (s *SPS_SubpicInfo) unmarshal(
+	buf []byte,
+	pos *int,
+	picWidth uint32,
+	picHeight uint32,
+	ctbSizeY uint32,
+) error {
+	var err error
+	s.NumSubpicsMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if s.NumSubpicsMinus1 >= maxSubpics {
+		return fmt.Errorf("sps_num_subpics_minus1 exceeds %d", maxSubpics-1)
+	}
+
+	if s.NumSubpicsMinus1 > 0 {
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		s.IndependentSubpicsFlag = bits.ReadFlagUnsafe(buf, pos)
+		s.SubpicSameSizeFlag = bits.ReadFlagUnsafe(buf, pos)
+	} else {
+		s.IndependentSubpicsFlag = true
+		s.SubpicSameSizeFlag = false
+	}
+
+	s.CtuTopLeftX = nil
+	s.CtuTopLeftY = nil
+	s.WidthMinus1 = nil
+	s.HeightMinus1 = nil
+	s.TreatedAsPicFlag = nil
+	s.LoopFilterAcrossSubpicEnabledFlag = nil
+
+	if s.NumSubpicsMinus1 > 0 {
+		n := s.NumSubpicsMinus1 + 1
+		s.CtuTopLeftX = make([]uint32, n)
+		s.CtuTopLeftY = make([]uint32, n)
+		s.WidthMinus1 = make([]uint32, n)
+		s.HeightMinus1 = make([]uint32, n)
+
+		if !s.IndependentSubpicsFlag {
+			s.TreatedAsPicFlag = make([]bool, n)
+			s.LoopFilterAcrossSubpicEnabledFlag = make([]bool, n)
+		}
+
+		widthBits := ceilLog2((picWidth + ctbSizeY - 1) / ctbSizeY)
+		heightBits := ceilLog2((picHeight + ctbSizeY - 1) / ctbSizeY)
+
+		for i := uint32(0); i <= s.NumSubpicsMinus1; i++ {
+			if !s.SubpicSameSizeFlag || i == 0 {
+				if i > 0 && picWidth > ctbSizeY {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, widthBits)
+					if err != nil {
+						return err
+					}
+					s.CtuTopLeftX[i] = uint32(tmp)
+				}
+
+				if i > 0 && picHeight > ctbSizeY {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, heightBits)
+					if err != nil {
+						return err
+					}
+					s.CtuTopLeftY[i] = uint32(tmp)
+				}
+
+				if i < s.NumSubpicsMinus1 && picWidth > ctbSizeY {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, widthBits)
+					if err != nil {
+						return err
+					}
+					s.WidthMinus1[i] = uint32(tmp)
+				}
+
+				if i < s.NumSubpicsMinus1 && picHeight > ctbSizeY {
+					var tmp uint64
+					tmp, err = bits.ReadBits(buf, pos, heightBits)
+					if err != nil {
+						return err
+					}
+					s.HeightMinus1[i] = uint32(tmp)
+				}
+			}
+
+			if !s.IndependentSubpicsFlag {
+				err = bits.HasSpace(buf, *pos, 2)
+				if err != nil {
+					return err
+				}
+
+				s.TreatedAsPicFlag[i] = bits.ReadFlagUnsafe(buf, pos)
+				s.LoopFilterAcrossSubpicEnabledFlag[i] = bits.ReadFlagUnsafe(buf, pos)
+			}
+		}
+	}
+
+	s.SubpicIDLenMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if s.SubpicIDLenMinus1 >= maxSubpicIDLen {
+		return fmt.Errorf("sps_subpic_id_len_minus1 exceeds %d", maxSubpicIDLen-1)
+	}
+
+	s.SubpicIDMappingExplicitlySignalledFlag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if s.SubpicIDMappingExplicitlySignalledFlag {
+		s.SubpicIDMappingPresentFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.SubpicIDMappingPresentFlag = false
+	}
+
+	if s.SubpicIDMappingPresentFlag {
+		s.SubpicID = make([]uint32, s.NumSubpicsMinus1+1)
+
+		for i := range s.SubpicID {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, int(s.SubpicIDLenMinus1+1))
+			if err != nil {
+				return err
+			}
+			s.SubpicID[i] = uint32(tmp)
+		}
+	} else {
+		s.SubpicID = nil
+	}
+
+	return nil
+}
+
+// SPS_ChromaQPTable is a chroma QP mapping table.
+type SPS_ChromaQPTable struct { //nolint:revive
+	QPTableStartMinus26 int32
+	DeltaQPInValMinus1  []uint32
+	DeltaQPDiffVal      []uint32
+}
+
+func (t *SPS_ChromaQPTable) unmarshal(buf []byte, pos *int) error {
+	var err error
+	t.QPTableStartMinus26, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	numPointsInQPTableMinus1, err := bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if numPointsInQPTableMinus1 >= maxQPTablePoints {
+		return fmt.Errorf("sps_num_points_in_qp_table_minus1 exceeds %d", maxQPTablePoints-1)
+	}
+
+	t.DeltaQPInValMinus1 = make([]uint32, numPointsInQPTableMinus1+1)
+	t.DeltaQPDiffVal = make([]uint32, numPointsInQPTableMinus1+1)
+
+	for j := range t.DeltaQPInValMinus1 {
+		t.DeltaQPInValMinus1[j], err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		t.DeltaQPDiffVal[j], err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SPS_RefPicListEntry is an entry of a reference picture list structure.
+type SPS_RefPicListEntry struct { //nolint:revive
+	InterLayerRefPicFlag bool
+
+	// InterLayerRefPicFlag == false
+	StRefPicFlag bool
+
+	// StRefPicFlag == true
+	AbsDeltaPocSt     uint32
+	StrpEntrySignFlag bool
+
+	// StRefPicFlag == false
+	RplsPocLsbLt uint32
+
+	// InterLayerRefPicFlag == true
+	IlrpIdx uint32
+}
+
+// SPS_RefPicListStruct is a reference picture list structure.
+// Specification: ITU-T Rec. H.266, 7.3.10
+type SPS_RefPicListStruct struct { //nolint:revive
+	LtrpInHeaderFlag bool
+	Entries          []SPS_RefPicListEntry
+}
+
+func (r *SPS_RefPicListStruct) unmarshal(buf []byte, pos *int, sps *SPS) error {
+	numRefEntries, err := bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if numRefEntries > maxRefEntries {
+		return fmt.Errorf("num_ref_entries exceeds %d", maxRefEntries)
+	}
+
+	if sps.LongTermRefPicsFlag && numRefEntries > 0 {
+		r.LtrpInHeaderFlag, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		r.LtrpInHeaderFlag = false
+	}
+
+	r.Entries = make([]SPS_RefPicListEntry, numRefEntries)
+
+	for i := range r.Entries {
+		e := &r.Entries[i]
+
+		if sps.InterLayerPredictionEnabledFlag {
+			e.InterLayerRefPicFlag, err = bits.ReadFlag(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !e.InterLayerRefPicFlag {
+			if sps.LongTermRefPicsFlag {
+				e.StRefPicFlag, err = bits.ReadFlag(buf, pos)
+				if err != nil {
+					return err
+				}
+			} else {
+				e.StRefPicFlag = true
+			}
+
+			if e.StRefPicFlag {
+				e.AbsDeltaPocSt, err = bits.ReadGolombUnsigned(buf, pos)
+				if err != nil {
+					return err
+				}
+
+				absDeltaPocSt := e.AbsDeltaPocSt
+				if !(sps.WeightedPredFlag || sps.WeightedBipredFlag) || i == 0 {
+					absDeltaPocSt++
+				}
+
+				if absDeltaPocSt > 0 {
+					e.StrpEntrySignFlag, err = bits.ReadFlag(buf, pos)
+					if err != nil {
+						return err
+					}
+				}
+			} else if !r.LtrpInHeaderFlag {
+				var tmp uint64
+				tmp, err = bits.ReadBits(buf, pos, int(sps.Log2MaxPicOrderCntLsbMinus4+4))
+				if err != nil {
+					return err
+				}
+				e.RplsPocLsbLt = uint32(tmp)
+			}
+		} else {
+			e.IlrpIdx, err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SPS_LADF contains luma adaptive deblocking filter parameters.
+type SPS_LADF struct { //nolint:revive
+	NumLADFIntervalsMinus2 uint8
+	LowestIntervalQPOffset int32
+	QPOffset               []int32
+	DeltaThresholdMinus1   []uint32
+}
+
+func (l *SPS_LADF) unmarshal(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+	l.NumLADFIntervalsMinus2 = uint8(tmp)
+
+	l.LowestIntervalQPOffset, err = bits.ReadGolombSigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	l.QPOffset = make([]int32, l.NumLADFIntervalsMinus2+1)
+	l.DeltaThresholdMinus1 = make([]uint32, l.NumLADFIntervalsMinus2+1)
+
+	for i := range l.QPOffset {
+		l.QPOffset[i], err = bits.ReadGolombSigned(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		l.DeltaThresholdMinus1[i], err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SPS_VirtualBoundaries contains virtual boundaries.
+type SPS_VirtualBoundaries struct { //nolint:revive
+	PosXMinus1 []uint32
+	PosYMinus1 []uint32
+}
+
+func (v *SPS_VirtualBoundaries) unmarshal(buf []byte, pos *int) error {
+	readPositions := func() ([]uint32, error) {
+		num, err := bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if num > maxVirtualBoundaries {
+			return nil, fmt.Errorf("number of virtual boundaries exceeds %d", maxVirtualBoundaries)
+		}
+
+		ret := make([]uint32, num)
+
+		for i := range ret {
+			ret[i], err = bits.ReadGolombUnsigned(buf, pos)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	var err error
+	v.PosXMinus1, err = readPositions()
+	if err != nil {
+		return err
+	}
+
+	v.PosYMinus1, err = readPositions()
+	return err
+}
+
+// SPS_GeneralTimingHRDParameters are general timing and HRD parameters.
+// Specification: ITU-T Rec. H.266, 7.3.5.1
+type SPS_GeneralTimingHRDParameters struct { //nolint:revive
+	NumUnitsInTick                 uint32
+	TimeScale                      uint32
+	GeneralNALHRDParamsPresentFlag bool
+	GeneralVCLHRDParamsPresentFlag bool
+
+	// GeneralNALHRDParamsPresentFlag == true || GeneralVCLHRDParamsPresentFlag == true
+	GeneralSamePicTimingInAllOLSFlag bool
+	GeneralDUHRDParamsPresentFlag    bool
+	TickDivisorMinus2                uint8
+	BitRateScale                     uint8
+	CPBSizeScale                     uint8
+	CPBSizeDUScale                   uint8
+	HRDCPBCntMinus1                  uint32
+}
+
+func (p *SPS_GeneralTimingHRDParameters) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 32+32+2)
+	if err != nil {
+		return err
+	}
+
+	p.NumUnitsInTick = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	p.TimeScale = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	p.GeneralNALHRDParamsPresentFlag = bits.ReadFlagUnsafe(buf, pos)
+	p.GeneralVCLHRDParamsPresentFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	if p.GeneralNALHRDParamsPresentFlag || p.GeneralVCLHRDParamsPresentFlag {
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		p.GeneralSamePicTimingInAllOLSFlag = bits.ReadFlagUnsafe(buf, pos)
+		p.GeneralDUHRDParamsPresentFlag = bits.ReadFlagUnsafe(buf, pos)
+
+		if p.GeneralDUHRDParamsPresentFlag {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 8)
+			if err != nil {
+				return err
+			}
+			p.TickDivisorMinus2 = uint8(tmp)
+		}
+
+		err = bits.HasSpace(buf, *pos, 8)
+		if err != nil {
+			return err
+		}
+
+		p.BitRateScale = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+		p.CPBSizeScale = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+
+		if p.GeneralDUHRDParamsPresentFlag {
+			var tmp uint64
+			tmp, err = bits.ReadBits(buf, pos, 4)
+			if err != nil {
+				return err
+			}
+			p.CPBSizeDUScale = uint8(tmp)
+		}
+
+		p.HRDCPBCntMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SPS is a H266 sequence parameter set.
+// Specification: ITU-T Rec. H.266, 7.3.2.4
+type SPS struct {
+	ID                         uint8
+	VPSID                      uint8
+	MaxSubLayersMinus1         uint8
+	ChromaFormatIdc            uint8
+	Log2CTUSizeMinus5          uint8
+	PTLDPBHRDParamsPresentFlag bool
+
+	// PTLDPBHRDParamsPresentFlag == true
+	ProfileTierLevel *SPS_ProfileTierLevel
+
+	GDREnabledFlag               bool
+	RefPicResamplingEnabledFlag  bool
+	ResChangeInCLVSAllowedFlag   bool
+	PicWidthMaxInLumaSamples     uint32
+	PicHeightMaxInLumaSamples    uint32
+	ConformanceWindow            *SPS_Window
+	SubpicInfo                   *SPS_SubpicInfo
+	BitDepthMinus8               uint32
+	EntropyCodingSyncEnabledFlag bool
+	EntryPointOffsetsPresentFlag bool
+	Log2MaxPicOrderCntLsbMinus4  uint8
+	PocMsbCycleFlag              bool
+	PocMsbCycleLenMinus1         uint32
+	ExtraPhBitPresentFlag        []bool
+	ExtraShBitPresentFlag        []bool
+
+	// PTLDPBHRDParamsPresentFlag == true
+	SubLayerDPBParamsFlag    bool
+	MaxDecPicBufferingMinus1 []uint32
+	MaxNumReorderPics        []uint32
+	MaxLatencyIncreasePlus1  []uint32
+
+	Log2MinLumaCodingBlockSizeMinus2           uint32
+	PartitionConstraintsOverrideEnabledFlag    bool
+	Log2DiffMinQTMinCbIntraSliceLuma           uint32
+	MaxMTTHierarchyDepthIntraSliceLuma         uint32
+	Log2DiffMaxBTMinQTIntraSliceLuma           uint32
+	Log2DiffMaxTTMinQTIntraSliceLuma           uint32
+	QTBTTDualTreeIntraFlag                     bool
+	Log2DiffMinQTMinCbIntraSliceChroma         uint32
+	MaxMTTHierarchyDepthIntraSliceChroma       uint32
+	Log2DiffMaxBTMinQTIntraSliceChroma         uint32
+	Log2DiffMaxTTMinQTIntraSliceChroma         uint32
+	Log2DiffMinQTMinCbInterSlice               uint32
+	MaxMTTHierarchyDepthInterSlice             uint32
+	Log2DiffMaxBTMinQTInterSlice               uint32
+	Log2DiffMaxTTMinQTInterSlice               uint32
+	MaxLumaTransformSize64Flag                 bool
+	TransformSkipEnabledFlag                   bool
+	Log2TransformSkipMaxSizeMinus2             uint32
+	BDPCMEnabledFlag                           bool
+	MTSEnabledFlag                             bool
+	ExplicitMTSIntraEnabledFlag                bool
+	ExplicitMTSInterEnabledFlag                bool
+	LFNSTEnabledFlag                           bool
+	JointCbCrEnabledFlag                       bool
+	SameQPTableForChromaFlag                   bool
+	ChromaQPTables                             []SPS_ChromaQPTable
+	SAOEnabledFlag                             bool
+	ALFEnabledFlag                             bool
+	CCALFEnabledFlag                           bool
+	LMCSEnabledFlag                            bool
+	WeightedPredFlag                           bool
+	WeightedBipredFlag                         bool
+	LongTermRefPicsFlag                        bool
+	InterLayerPredictionEnabledFlag            bool
+	IDRRPLPresentFlag                          bool
+	RPL1SameAsRPL0Flag                         bool
+	RefPicLists                                [2][]SPS_RefPicListStruct
+	RefWraparoundEnabledFlag                   bool
+	TemporalMVPEnabledFlag                     bool
+	SbTMVPEnabledFlag                          bool
+	AMVREnabledFlag                            bool
+	BDOFEnabledFlag                            bool
+	BDOFControlPresentInPhFlag                 bool
+	SMVDEnabledFlag                            bool
+	DMVREnabledFlag                            bool
+	DMVRControlPresentInPhFlag                 bool
+	MMVDEnabledFlag                            bool
+	MMVDFullpelOnlyEnabledFlag                 bool
+	SixMinusMaxNumMergeCand                    uint32
+	SBTEnabledFlag                             bool
+	AffineEnabledFlag                          bool
+	FiveMinusMaxNumSubblockMergeCand           uint32
+	SixParamAffineEnabledFlag                  bool
+	AffineAMVREnabledFlag                      bool
+	AffineProfEnabledFlag                      bool
+	ProfControlPresentInPhFlag                 bool
+	BCWEnabledFlag                             bool
+	CIIPEnabledFlag                            bool
+	GPMEnabledFlag                             bool
+	MaxNumMergeCandMinusMaxNumGPMCand          uint32
+	Log2ParallelMergeLevelMinus2               uint32
+	ISPEnabledFlag                             bool
+	MRLEnabledFlag                             bool
+	MIPEnabledFlag                             bool
+	CCLMEnabledFlag                            bool
+	ChromaHorizontalCollocatedFlag             bool
+	ChromaVerticalCollocatedFlag               bool
+	PaletteEnabledFlag                         bool
+	ACTEnabledFlag                             bool
+	MinQPPrimeTS                               uint32
+	IBCEnabledFlag                             bool
+	SixMinusMaxNumIBCMergeCand                 uint32
+	LADF                                       *SPS_LADF
+	ExplicitScalingListEnabledFlag             bool
+	ScalingMatrixForLFNSTDisabledFlag          bool
+	ScalingMatrixForAltColourSpaceDisabledFlag bool
+	ScalingMatrixDesignatedColourSpaceFlag     bool
+	DepQuantEnabledFlag                        bool
+	SignDataHidingEnabledFlag                  bool
+	VirtualBoundariesEnabledFlag               bool
+	VirtualBoundaries                          *SPS_VirtualBoundaries
+
+	// PTLDPBHRDParamsPresentFlag == true
+	// other fields are not decoded.
+	GeneralTimingHRDParameters *SPS_GeneralTimingHRDParameters
+}
+
+// Unmarshal decodes a SPS from bytes.
+func (s *SPS) Unmarshal(buf []byte) error {
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	if NALUType(buf[1]>>3) != NALUType_SPS_NUT {
+		return fmt.Errorf("not a SPS")
+	}
+
+	buf = h264.EmulationPreventionRemove(buf[2:])
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 16)
+	if err != nil {
+		return err
+	}
+
+	s.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	s.VPSID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	s.MaxSubLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+
+	if s.MaxSubLayersMinus1 > 6 {
+		return fmt.Errorf("invalid sps_max_sublayers_minus1")
+	}
+
+	s.ChromaFormatIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	s.Log2CTUSizeMinus5 = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+
+	if s.Log2CTUSizeMinus5 > 2 {
+		return fmt.Errorf("invalid sps_log2_ctu_size_minus5")
+	}
+
+	s.PTLDPBHRDParamsPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.PTLDPBHRDParamsPresentFlag {
+		s.ProfileTierLevel = &SPS_ProfileTierLevel{}
+		err = s.ProfileTierLevel.unmarshal(buf, &pos, s.MaxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ProfileTierLevel = nil
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.GDREnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.RefPicResamplingEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.RefPicResamplingEnabledFlag {
+		s.ResChangeInCLVSAllowedFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ResChangeInCLVSAllowedFlag = false
+	}
+
+	s.PicWidthMaxInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.PicHeightMaxInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	conformanceWindowFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if conformanceWindowFlag {
+		s.ConformanceWindow = &SPS_Window{}
+		err = s.ConformanceWindow.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ConformanceWindow = nil
+	}
+
+	subpicInfoPresentFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	ctbSizeY := uint32(1) << (s.Log2CTUSizeMinus5 + 5)
+
+	if subpicInfoPresentFlag {
+		s.SubpicInfo = &SPS_SubpicInfo{}
+		err = s.SubpicInfo.unmarshal(buf, &pos, s.PicWidthMaxInLumaSamples, s.PicHeightMaxInLumaSamples, ctbSizeY)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.SubpicInfo = nil
+	}
+
+	s.BitDepthMinus8, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 2+4+1)
+	if err != nil {
+		return err
+	}
+
+	s.EntropyCodingSyncEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.EntryPointOffsetsPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.Log2MaxPicOrderCntLsbMinus4 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+
+	if s.Log2MaxPicOrderCntLsbMinus4 > 12 {
+		return fmt.Errorf("invalid sps_log2_max_pic_order_cnt_lsb_minus4")
+	}
+
+	s.PocMsbCycleFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.PocMsbCycleFlag {
+		s.PocMsbCycleLenMinus1, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.PocMsbCycleLenMinus1 = 0
+	}
+
+	readExtraBits := func() ([]bool, error) {
+		numExtraBytes, err2 := bits.ReadBits(buf, &pos, 2)
+		if err2 != nil {
+			return nil, err2
+		}
+
+		if numExtraBytes == 0 {
+			return nil, nil
+		}
+
+		err2 = bits.HasSpace(buf, pos, int(numExtraBytes*8))
+		if err2 != nil {
+			return nil, err2
+		}
+
+		ret := make([]bool, numExtraBytes*8)
+		for i := range ret {
+			ret[i] = bits.ReadFlagUnsafe(buf, &pos)
+		}
+		return ret, nil
+	}
+
+	s.ExtraPhBitPresentFlag, err = readExtraBits()
+	if err != nil {
+		return err
+	}
+
+	s.ExtraShBitPresentFlag, err = readExtraBits()
+	if err != nil {
+		return err
+	}
+
+	if s.PTLDPBHRDParamsPresentFlag {
+		if s.MaxSubLayersMinus1 > 0 {
+			s.SubLayerDPBParamsFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		} else {
+			s.SubLayerDPBParamsFlag = false
+		}
+
+		var start uint8
+		if !s.SubLayerDPBParamsFlag {
+			start = s.MaxSubLayersMinus1
+		}
+
+		n := s.MaxSubLayersMinus1 - start + 1
+		s.MaxDecPicBufferingMinus1 = make([]uint32, n)
+		s.MaxNumReorderPics = make([]uint32, n)
+		s.MaxLatencyIncreasePlus1 = make([]uint32, n)
+
+		for i := range n {
+			s.MaxDecPicBufferingMinus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			s.MaxNumReorderPics[i], err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			s.MaxLatencyIncreasePlus1[i], err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		s.SubLayerDPBParamsFlag = false
+		s.MaxDecPicBufferingMinus1 = nil
+		s.MaxNumReorderPics = nil
+		s.MaxLatencyIncreasePlus1 = nil
+	}
+
+	s.Log2MinLumaCodingBlockSizeMinus2, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.PartitionConstraintsOverrideEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.Log2DiffMinQTMinCbIntraSliceLuma, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.MaxMTTHierarchyDepthIntraSliceLuma, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.MaxMTTHierarchyDepthIntraSliceLuma != 0 {
+		s.Log2DiffMaxBTMinQTIntraSliceLuma, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		s.Log2DiffMaxTTMinQTIntraSliceLuma, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.Log2DiffMaxBTMinQTIntraSliceLuma = 0
+		s.Log2DiffMaxTTMinQTIntraSliceLuma = 0
+	}
+
+	if s.ChromaFormatIdc != 0 {
+		s.QTBTTDualTreeIntraFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.QTBTTDualTreeIntraFlag = false
+	}
+
+	s.Log2DiffMinQTMinCbIntraSliceChroma = 0
+	s.MaxMTTHierarchyDepthIntraSliceChroma = 0
+	s.Log2DiffMaxBTMinQTIntraSliceChroma = 0
+	s.Log2DiffMaxTTMinQTIntraSliceChroma = 0
+
+	if s.QTBTTDualTreeIntraFlag {
+		s.Log2DiffMinQTMinCbIntraSliceChroma, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		s.MaxMTTHierarchyDepthIntraSliceChroma, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if s.MaxMTTHierarchyDepthIntraSliceChroma != 0 {
+			s.Log2DiffMaxBTMinQTIntraSliceChroma, err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			s.Log2DiffMaxTTMinQTIntraSliceChroma, err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	s.Log2DiffMinQTMinCbInterSlice, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.MaxMTTHierarchyDepthInterSlice, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.MaxMTTHierarchyDepthInterSlice != 0 {
+		s.Log2DiffMaxBTMinQTInterSlice, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		s.Log2DiffMaxTTMinQTInterSlice, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.Log2DiffMaxBTMinQTInterSlice = 0
+		s.Log2DiffMaxTTMinQTInterSlice = 0
+	}
+
+	if ctbSizeY > 32 {
+		s.MaxLumaTransformSize64Flag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.MaxLumaTransformSize64Flag = false
+	}
+
+	s.TransformSkipEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.TransformSkipEnabledFlag {
+		s.Log2TransformSkipMaxSizeMinus2, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		s.BDPCMEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.Log2TransformSkipMaxSizeMinus2 = 0
+		s.BDPCMEnabledFlag = false
+	}
+
+	s.MTSEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.MTSEnabledFlag {
+		err = bits.HasSpace(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+
+		s.ExplicitMTSIntraEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+		s.ExplicitMTSInterEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	} else {
+		s.ExplicitMTSIntraEnabledFlag = false
+		s.ExplicitMTSInterEnabledFlag = false
+	}
+
+	s.LFNSTEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.ChromaFormatIdc != 0 {
+		err = bits.HasSpace(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+
+		s.JointCbCrEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+		s.SameQPTableForChromaFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+		var numQPTables int
+		switch {
+		case s.SameQPTableForChromaFlag:
+			numQPTables = 1
+		case s.JointCbCrEnabledFlag:
+			numQPTables = 3
+		default:
+			numQPTables = 2
+		}
+
+		s.ChromaQPTables = make([]SPS_ChromaQPTable, numQPTables)
+
+		for i := range s.ChromaQPTables {
+			err = s.ChromaQPTables[i].unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		s.JointCbCrEnabledFlag = false
+		s.SameQPTableForChromaFlag = false
+		s.ChromaQPTables = nil
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.SAOEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.ALFEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.ALFEnabledFlag && s.ChromaFormatIdc != 0 {
+		s.CCALFEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.CCALFEnabledFlag = false
+	}
+
+	err = bits.HasSpace(buf, pos, 4)
+	if err != nil {
+		return err
+	}
+
+	s.LMCSEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.WeightedPredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.WeightedBipredFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.LongTermRefPicsFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.VPSID > 0 {
+		s.InterLayerPredictionEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.InterLayerPredictionEnabledFlag = false
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.IDRRPLPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.RPL1SameAsRPL0Flag = bits.ReadFlagUnsafe(buf, &pos)
+
+	numLists := 2
+	if s.RPL1SameAsRPL0Flag {
+		numLists = 1
+	}
+
+	s.RefPicLists = [2][]SPS_RefPicListStruct{}
+
+	for i := range numLists {
+		var numRefPicLists uint32
+		numRefPicLists, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if numRefPicLists > maxRefPicLists {
+			return fmt.Errorf("sps_num_ref_pic_lists exceeds %d", maxRefPicLists)
+		}
+
+		s.RefPicLists[i] = make([]SPS_RefPicListStruct, numRefPicLists)
+
+		for j := range s.RefPicLists[i] {
+			err = s.RefPicLists[i][j].unmarshal(buf, &pos, s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.RefWraparoundEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.TemporalMVPEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.TemporalMVPEnabledFlag {
+		s.SbTMVPEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.SbTMVPEnabledFlag = false
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.AMVREnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.BDOFEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.BDOFEnabledFlag {
+		s.BDOFControlPresentInPhFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.BDOFControlPresentInPhFlag = false
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.SMVDEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.DMVREnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.DMVREnabledFlag {
+		s.DMVRControlPresentInPhFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.DMVRControlPresentInPhFlag = false
+	}
+
+	s.MMVDEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.MMVDEnabledFlag {
+		s.MMVDFullpelOnlyEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.MMVDFullpelOnlyEnabledFlag = false
+	}
+
+	s.SixMinusMaxNumMergeCand, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.SixMinusMaxNumMergeCand > 5 {
+		return fmt.Errorf("invalid sps_six_minus_max_num_merge_cand")
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.SBTEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.AffineEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	s.FiveMinusMaxNumSubblockMergeCand = 0
+	s.SixParamAffineEnabledFlag = false
+	s.AffineAMVREnabledFlag = false
+	s.AffineProfEnabledFlag = false
+	s.ProfControlPresentInPhFlag = false
+
+	if s.AffineEnabledFlag {
+		s.FiveMinusMaxNumSubblockMergeCand, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		s.SixParamAffineEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if s.AMVREnabledFlag {
+			s.AffineAMVREnabledFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+
+		s.AffineProfEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if s.AffineProfEnabledFlag {
+			s.ProfControlPresentInPhFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.BCWEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.CIIPEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	maxNumMergeCand := 6 - s.SixMinusMaxNumMergeCand
+
+	s.GPMEnabledFlag = false
+	s.MaxNumMergeCandMinusMaxNumGPMCand = 0
+
+	if maxNumMergeCand >= 2 {
+		s.GPMEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if s.GPMEnabledFlag && maxNumMergeCand >= 3 {
+			s.MaxNumMergeCandMinusMaxNumGPMCand, err = bits.ReadGolombUnsigned(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	s.Log2ParallelMergeLevelMinus2, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	s.ISPEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.MRLEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.MIPEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.ChromaFormatIdc != 0 {
+		s.CCLMEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.CCLMEnabledFlag = false
+	}
+
+	if s.ChromaFormatIdc == 1 {
+		err = bits.HasSpace(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+
+		s.ChromaHorizontalCollocatedFlag = bits.ReadFlagUnsafe(buf, &pos)
+		s.ChromaVerticalCollocatedFlag = bits.ReadFlagUnsafe(buf, &pos)
+	} else {
+		s.ChromaHorizontalCollocatedFlag = false
+		s.ChromaVerticalCollocatedFlag = false
+	}
+
+	s.PaletteEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.ChromaFormatIdc == 3 && !s.MaxLumaTransformSize64Flag {
+		s.ACTEnabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ACTEnabledFlag = false
+	}
+
+	if s.TransformSkipEnabledFlag || s.PaletteEnabledFlag {
+		s.MinQPPrimeTS, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.MinQPPrimeTS = 0
+	}
+
+	s.IBCEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.IBCEnabledFlag {
+		s.SixMinusMaxNumIBCMergeCand, err = bits.ReadGolombUnsigned(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.SixMinusMaxNumIBCMergeCand = 0
+	}
+
+	ladfEnabledFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if ladfEnabledFlag {
+		s.LADF = &SPS_LADF{}
+		err = s.LADF.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.LADF = nil
+	}
+
+	s.ExplicitScalingListEnabledFlag, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if s.LFNSTEnabledFlag && s.ExplicitScalingListEnabledFlag {
+		s.ScalingMatrixForLFNSTDisabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ScalingMatrixForLFNSTDisabledFlag = false
+	}
+
+	if s.ACTEnabledFlag && s.ExplicitScalingListEnabledFlag {
+		s.ScalingMatrixForAltColourSpaceDisabledFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ScalingMatrixForAltColourSpaceDisabledFlag = false
+	}
+
+	if s.ScalingMatrixForAltColourSpaceDisabledFlag {
+		s.ScalingMatrixDesignatedColourSpaceFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		s.ScalingMatrixDesignatedColourSpaceFlag = false
+	}
+
+	err = bits.HasSpace(buf, pos, 3)
+	if err != nil {
+		return err
+	}
+
+	s.DepQuantEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.SignDataHidingEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.VirtualBoundariesEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	s.VirtualBoundaries = nil
+
+	if s.VirtualBoundariesEnabledFlag {
+		var virtualBoundariesPresentFlag bool
+		virtualBoundariesPresentFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if virtualBoundariesPresentFlag {
+			s.VirtualBoundaries = &SPS_VirtualBoundaries{}
+			err = s.VirtualBoundaries.unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	s.GeneralTimingHRDParameters = nil
+
+	if s.PTLDPBHRDParamsPresentFlag {
+		var timingHRDParamsPresentFlag bool
+		timingHRDParamsPresentFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if timingHRDParamsPresentFlag {
+			s.GeneralTimingHRDParameters = &SPS_GeneralTimingHRDParameters{}
+			err = s.GeneralTimingHRDParameters.unmarshal(buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Width returns the video width.
+func (s SPS) Width() int {
+	width := s.PicWidthMaxInLumaSamples
+
+	if s.ConformanceWindow != nil {
+		cropUnitX := subWidthC[s.ChromaFormatIdc]
+		width -= (s.ConformanceWindow.LeftOffset + s.ConformanceWindow.RightOffset) * cropUnitX
+	}
+
+	return int(width)
+}
+
+// Height returns the video height.
+func (s SPS) Height() int {
+	height := s.PicHeightMaxInLumaSamples
+
+	if s.ConformanceWindow != nil {
+		cropUnitY := subHeightC[s.ChromaFormatIdc]
+		height -= (s.ConformanceWindow.TopOffset + s.ConformanceWindow.BottomOffset) * cropUnitY
+	}
+
+	return int(height)
+}
+
+// FPS returns the frames per second of the video.
+func (s SPS) FPS() float64 {
+	if s.GeneralTimingHRDParameters == nil || s.GeneralTimingHRDParameters.NumUnitsInTick == 0 {
+		return 0
+	}
+
+	return float64(s.GeneralTimingHRDParameters.TimeScale) /
+		float64(s.GeneralTimingHRDParameters.NumUnitsInTick)
+}
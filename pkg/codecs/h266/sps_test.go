@@ -0,0 +1,257 @@
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSPS = []struct {
+	name   string
+	byts   []byte
+	sps    SPS
+	width  int
+	height int
+	fps    float64
+}{
+	{
+		"1920x1080",
+		[]byte{
+			0x00, 0x79, 0x00, 0x0d, 0x02, 0x43, 0x80, 0x00,
+			0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+			0x2a, 0xa6, 0xd5, 0xa6, 0xd9, 0x0a, 0x4c, 0x99,
+			0xc2, 0x68, 0x34, 0x24, 0x53, 0xf0, 0xb5, 0x34,
+			0x57, 0xb5, 0xfd, 0xd7, 0xe3, 0x12, 0x00, 0x00,
+			0x03, 0x00, 0x02, 0x00, 0x00, 0x03, 0x00, 0x3c,
+			0x62,
+		},
+		SPS{
+			ChromaFormatIdc:            1,
+			Log2CTUSizeMinus5:          2,
+			PTLDPBHRDParamsPresentFlag: true,
+			ProfileTierLevel: &SPS_ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralLevelIdc:   67,
+				ConstraintInfo:    []byte{0x80},
+			},
+			PicWidthMaxInLumaSamples:                1920,
+			PicHeightMaxInLumaSamples:               1080,
+			BitDepthMinus8:                          2,
+			EntryPointOffsetsPresentFlag:            true,
+			Log2MaxPicOrderCntLsbMinus4:             4,
+			MaxDecPicBufferingMinus1:                []uint32{3},
+			MaxNumReorderPics:                       []uint32{1},
+			MaxLatencyIncreasePlus1:                 []uint32{0},
+			Log2MinLumaCodingBlockSizeMinus2:        1,
+			PartitionConstraintsOverrideEnabledFlag: true,
+			Log2DiffMinQTMinCbIntraSliceLuma:        1,
+			MaxMTTHierarchyDepthIntraSliceLuma:      2,
+			Log2DiffMaxBTMinQTIntraSliceLuma:        2,
+			Log2DiffMaxTTMinQTIntraSliceLuma:        1,
+			QTBTTDualTreeIntraFlag:                  true,
+			Log2DiffMinQTMinCbIntraSliceChroma:      2,
+			MaxMTTHierarchyDepthIntraSliceChroma:    1,
+			Log2DiffMaxBTMinQTIntraSliceChroma:      2,
+			Log2DiffMaxTTMinQTIntraSliceChroma:      2,
+			Log2DiffMinQTMinCbInterSlice:            2,
+			MaxMTTHierarchyDepthInterSlice:          3,
+			Log2DiffMaxBTMinQTInterSlice:            4,
+			Log2DiffMaxTTMinQTInterSlice:            3,
+			MaxLumaTransformSize64Flag:              true,
+			TransformSkipEnabledFlag:                true,
+			Log2TransformSkipMaxSizeMinus2:          3,
+			BDPCMEnabledFlag:                        true,
+			MTSEnabledFlag:                          true,
+			LFNSTEnabledFlag:                        true,
+			JointCbCrEnabledFlag:                    true,
+			SameQPTableForChromaFlag:                true,
+			ChromaQPTables: []SPS_ChromaQPTable{{
+				QPTableStartMinus26: -9,
+				DeltaQPInValMinus1:  []uint32{25, 9},
+				DeltaQPDiffVal:      []uint32{8, 2},
+			}},
+			SAOEnabledFlag:     true,
+			ALFEnabledFlag:     true,
+			CCALFEnabledFlag:   true,
+			LMCSEnabledFlag:    true,
+			RPL1SameAsRPL0Flag: true,
+			RefPicLists: [2][]SPS_RefPicListStruct{
+				{
+					{
+						Entries: []SPS_RefPicListEntry{
+							{StRefPicFlag: true},
+						},
+					},
+					{
+						Entries: []SPS_RefPicListEntry{
+							{StRefPicFlag: true, AbsDeltaPocSt: 1},
+							{StRefPicFlag: true, AbsDeltaPocSt: 1, StrpEntrySignFlag: true},
+						},
+					},
+				},
+			},
+			TemporalMVPEnabledFlag:            true,
+			SbTMVPEnabledFlag:                 true,
+			AMVREnabledFlag:                   true,
+			BDOFEnabledFlag:                   true,
+			SMVDEnabledFlag:                   true,
+			DMVREnabledFlag:                   true,
+			MMVDEnabledFlag:                   true,
+			SBTEnabledFlag:                    true,
+			AffineEnabledFlag:                 true,
+			SixParamAffineEnabledFlag:         true,
+			AffineAMVREnabledFlag:             true,
+			AffineProfEnabledFlag:             true,
+			BCWEnabledFlag:                    true,
+			CIIPEnabledFlag:                   true,
+			GPMEnabledFlag:                    true,
+			MaxNumMergeCandMinusMaxNumGPMCand: 1,
+			ISPEnabledFlag:                    true,
+			MRLEnabledFlag:                    true,
+			MIPEnabledFlag:                    true,
+			CCLMEnabledFlag:                   true,
+			ChromaHorizontalCollocatedFlag:    true,
+			MinQPPrimeTS:                      2,
+			DepQuantEnabledFlag:               true,
+			GeneralTimingHRDParameters: &SPS_GeneralTimingHRDParameters{
+				NumUnitsInTick: 1,
+				TimeScale:      30,
+			},
+		},
+		1920,
+		1080,
+		30,
+	},
+	{
+		"1280x720 with sublayers",
+		[]byte{
+			0x00, 0x79, 0x00, 0x4b, 0x02, 0x33, 0xa1, 0x80,
+			0x00, 0x00, 0x03, 0x00, 0x00, 0x03, 0x00, 0x00,
+			0x03, 0x00, 0x00, 0x03, 0x00, 0x80, 0x30, 0x01,
+			0x12, 0x34, 0x56, 0x78, 0x40, 0x05, 0x01, 0x00,
+			0x5c, 0x3e, 0x25, 0x12, 0x46, 0x00, 0xad, 0xa9,
+			0x16, 0x52, 0x81, 0xa9, 0xed, 0x0b, 0x26, 0xda,
+			0x6a, 0x81, 0x40, 0x14, 0x30, 0x55, 0x4a, 0x88,
+			0xcc, 0x74, 0x04, 0x08, 0x40,
+		},
+		SPS{
+			MaxSubLayersMinus1:         2,
+			ChromaFormatIdc:            1,
+			Log2CTUSizeMinus5:          1,
+			PTLDPBHRDParamsPresentFlag: true,
+			ProfileTierLevel: &SPS_ProfileTierLevel{
+				GeneralProfileIdc: 1,
+				GeneralLevelIdc:   51,
+				ConstraintInfo: []byte{
+					0xa1, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00,
+				},
+				SubLayerLevelPresentFlag: []bool{false, true},
+				SubLayerLevelIdc:         []uint8{0, 48},
+				GeneralSubProfileIdc:     []uint32{0x12345678},
+			},
+			RefPicResamplingEnabledFlag: true,
+			PicWidthMaxInLumaSamples:    1280,
+			PicHeightMaxInLumaSamples:   736,
+			ConformanceWindow: &SPS_Window{
+				BottomOffset: 8,
+			},
+			Log2MaxPicOrderCntLsbMinus4:      4,
+			PocMsbCycleFlag:                  true,
+			PocMsbCycleLenMinus1:             3,
+			ExtraPhBitPresentFlag:            []bool{true, false, false, false, false, false, false, false},
+			SubLayerDPBParamsFlag:            true,
+			MaxDecPicBufferingMinus1:         []uint32{1, 2, 3},
+			MaxNumReorderPics:                []uint32{0, 1, 1},
+			MaxLatencyIncreasePlus1:          []uint32{0, 0, 0},
+			Log2DiffMinQTMinCbIntraSliceLuma: 1,
+			Log2DiffMinQTMinCbInterSlice:     1,
+			ChromaQPTables: []SPS_ChromaQPTable{
+				{
+					DeltaQPInValMinus1: []uint32{1},
+					DeltaQPDiffVal:     []uint32{0},
+				},
+				{
+					QPTableStartMinus26: -3,
+					DeltaQPInValMinus1:  []uint32{2},
+					DeltaQPDiffVal:      []uint32{1},
+				},
+			},
+			WeightedPredFlag:    true,
+			LongTermRefPicsFlag: true,
+			IDRRPLPresentFlag:   true,
+			RefPicLists: [2][]SPS_RefPicListStruct{
+				{
+					{
+						Entries: []SPS_RefPicListEntry{
+							{StRefPicFlag: true},
+							{StRefPicFlag: true},
+						},
+					},
+				},
+				{
+					{
+						Entries: []SPS_RefPicListEntry{
+							{StRefPicFlag: true, AbsDeltaPocSt: 1, StrpEntrySignFlag: true},
+							{RplsPocLsbLt: 5},
+						},
+					},
+				},
+			},
+			SixMinusMaxNumMergeCand:      4,
+			GPMEnabledFlag:               true,
+			ChromaVerticalCollocatedFlag: true,
+			IBCEnabledFlag:               true,
+			SixMinusMaxNumIBCMergeCand:   1,
+			LADF: &SPS_LADF{
+				NumLADFIntervalsMinus2: 1,
+				LowestIntervalQPOffset: -2,
+				QPOffset:               []int32{1, -1},
+				DeltaThresholdMinus1:   []uint32{3, 5},
+			},
+			SignDataHidingEnabledFlag:    true,
+			VirtualBoundariesEnabledFlag: true,
+			VirtualBoundaries: &SPS_VirtualBoundaries{
+				PosXMinus1: []uint32{63},
+				PosYMinus1: []uint32{},
+			},
+		},
+		1280,
+		720,
+		0,
+	},
+}
+
+func TestSPSUnmarshal(t *testing.T) {
+	for _, ca := range casesSPS {
+		t.Run(ca.name, func(t *testing.T) {
+			var sps SPS
+			err := sps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sps, sps)
+			require.Equal(t, ca.width, sps.Width())
+			require.Equal(t, ca.height, sps.Height())
+			require.Equal(t, ca.fps, sps.FPS())
+			require.Equal(t, true, sps.ProfileTierLevel.FrameOnlyConstraintFlag())
+			require.Equal(t, false, sps.ProfileTierLevel.MultilayerEnabledFlag())
+		})
+	}
+}
+
+func FuzzSPSUnmarshal(f *testing.F) {
+	for _, ca := range casesSPS {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var sps SPS
+		err := sps.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		sps.Width()
+		sps.Height()
+		sps.FPS()
+	})
+}
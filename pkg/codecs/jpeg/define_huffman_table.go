@@ -1,5 +1,9 @@
 package jpeg //nolint:revive
 
+import (
+	"fmt"
+)
+
 // DefineHuffmanTable is a DHT marker.
 type DefineHuffmanTable struct {
 	Codes       []byte
@@ -8,6 +12,44 @@ type DefineHuffmanTable struct {
 	TableClass  int
 }
 
+func (m *DefineHuffmanTable) unmarshal(buf []byte) (int, error) {
+	if len(buf) < 17 {
+		return 0, fmt.Errorf("DHT is too short")
+	}
+
+	m.TableClass = int(buf[0] >> 4)
+	m.TableNumber = int(buf[0] & 0x0F)
+	m.Codes = buf[1:17]
+
+	symbolCount := 0
+	for _, c := range m.Codes {
+		symbolCount += int(c)
+	}
+
+	if len(buf) < (17 + symbolCount) {
+		return 0, fmt.Errorf("DHT is too short")
+	}
+
+	m.Symbols = buf[17 : 17+symbolCount]
+
+	return 17 + symbolCount, nil
+}
+
+// Unmarshal decodes the marker.
+// The marker must contain a single table.
+func (m *DefineHuffmanTable) Unmarshal(buf []byte) error {
+	n, err := m.unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	if n != len(buf) {
+		return fmt.Errorf("DHT contains multiple tables")
+	}
+
+	return nil
+}
+
 // Marshal encodes the marker.
 func (m DefineHuffmanTable) Marshal(buf []byte) []byte {
 	buf = append(buf, []byte{0xFF, MarkerDefineHuffmanTable}...)
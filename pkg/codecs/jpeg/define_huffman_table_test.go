@@ -33,3 +33,39 @@ func TestDefineHuffmanTableMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestDefineHuffmanTableUnmarshal(t *testing.T) {
+	enc := []byte{
+		0xff, 0xc4, 0x00, 0x14, 0x10, 0x01, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x05,
+	}
+
+	var h DefineHuffmanTable
+	err := h.Unmarshal(enc[4:])
+	require.NoError(t, err)
+	require.Equal(t, DefineHuffmanTable{
+		Codes: []byte{
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Symbols:    []byte{0x05},
+		TableClass: 1,
+	}, h)
+
+	byts := h.Marshal(nil)
+	require.Equal(t, enc, byts)
+}
+
+func FuzzDefineHuffmanTableUnmarshal(f *testing.F) {
+	f.Add([]byte{
+		0x10, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x05,
+	})
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h DefineHuffmanTable
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -22,20 +22,29 @@ func (m *DefineQuantizationTable) Unmarshal(buf []byte) error {
 		id := buf[0] & 0x0F
 		precision := buf[0] >> 4
 		buf = buf[1:]
-		if precision != 0 {
+
+		var size int
+		switch precision {
+		case 0:
+			size = 64
+
+		case 1:
+			size = 128
+
+		default:
 			return fmt.Errorf("precision %d is not supported", precision)
 		}
 
-		if len(buf) < 64 {
+		if len(buf) < size {
 			return fmt.Errorf("image is too short")
 		}
 
 		m.Tables = append(m.Tables, QuantizationTable{
 			ID:        id,
 			Precision: precision,
-			Data:      buf[:64],
+			Data:      buf[:size],
 		})
-		buf = buf[64:]
+		buf = buf[size:]
 	}
 
 	return nil
@@ -53,7 +62,7 @@ func (m DefineQuantizationTable) Marshal(buf []byte) []byte {
 	buf = append(buf, []byte{byte(s >> 8), byte(s)}...)
 
 	for _, t := range m.Tables {
-		buf = append(buf, []byte{(t.ID)}...)
+		buf = append(buf, []byte{(t.Precision << 4) | t.ID}...)
 		buf = append(buf, t.Data...)
 	}
 
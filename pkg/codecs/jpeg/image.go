@@ -0,0 +1,223 @@
+package jpeg //nolint:revive
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var exifIdentifier = []byte{'E', 'x', 'i', 'f', 0, 0}
+
+// Image contains the headers of a JPEG image.
+type Image struct {
+	JFIF               *JFIF
+	EXIF               []byte
+	Comments           [][]byte
+	QuantizationTables []QuantizationTable
+	HuffmanTables      []DefineHuffmanTable
+	RestartInterval    uint16
+	StartOfFrame       StartOfFrame
+	Progressive        bool
+	ScanCount          int
+}
+
+// skipEntropyCodedSegment returns the position of the first marker after entropy-coded data.
+func skipEntropyCodedSegment(buf []byte, pos int) int {
+	for {
+		if pos >= (len(buf) - 1) {
+			return len(buf)
+		}
+
+		if buf[pos] == 0xFF {
+			next := buf[pos+1]
+			if next != 0x00 && (next < MarkerRestart0 || next > MarkerRestart7) {
+				return pos
+			}
+			pos += 2
+		} else {
+			pos++
+		}
+	}
+}
+
+func (i *Image) unmarshalStartOfScan(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("SOS is too short")
+	}
+
+	components := int(buf[0])
+	if components == 0 || components > 4 {
+		return fmt.Errorf("invalid number of components: %d", components)
+	}
+
+	if len(buf) != (4 + components*2) {
+		return fmt.Errorf("invalid SOS size of %d", len(buf))
+	}
+
+	for j := 0; j < components; j++ {
+		id := buf[1+j*2]
+		found := false
+
+		for _, c := range i.StartOfFrame.Components {
+			if c.ID == id {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("SOS references unknown component %d", id)
+		}
+	}
+
+	return nil
+}
+
+// Unmarshal decodes an image.
+func (i *Image) Unmarshal(buf []byte) error {
+	if len(buf) < 2 || buf[0] != 0xFF || buf[1] != MarkerStartOfImage {
+		return fmt.Errorf("SOI not found")
+	}
+
+	*i = Image{}
+	sofFound := false
+	pos := 2
+
+	for {
+		if (len(buf) - pos) < 2 {
+			return fmt.Errorf("EOI not found")
+		}
+
+		if buf[pos] != 0xFF {
+			return fmt.Errorf("marker not found")
+		}
+
+		// skip fill bytes
+		for buf[pos+1] == 0xFF {
+			pos++
+			if (len(buf) - pos) < 2 {
+				return fmt.Errorf("EOI not found")
+			}
+		}
+
+		marker := buf[pos+1]
+		pos += 2
+
+		switch {
+		case marker == MarkerEndOfImage:
+			if !sofFound {
+				return fmt.Errorf("SOF not found")
+			}
+			return nil
+
+		case marker == MarkerTemporary, marker >= MarkerRestart0 && marker <= MarkerRestart7:
+			continue
+		}
+
+		if (len(buf) - pos) < 2 {
+			return fmt.Errorf("image is too short")
+		}
+
+		mlen := int(buf[pos])<<8 | int(buf[pos+1])
+		if mlen < 2 {
+			return fmt.Errorf("invalid marker length")
+		}
+
+		if (len(buf) - pos) < mlen {
+			return fmt.Errorf("image is too short")
+		}
+
+		mbuf := buf[pos+2 : pos+mlen]
+		pos += mlen
+
+		switch {
+		case marker == MarkerStartOfFrame1,
+			marker == MarkerStartOfFrameExtendedSequential,
+			marker == MarkerStartOfFrameProgressive:
+			if sofFound {
+				return fmt.Errorf("multiple SOF markers are not supported")
+			}
+
+			err := i.StartOfFrame.Unmarshal(mbuf)
+			if err != nil {
+				return err
+			}
+
+			sofFound = true
+			i.Progressive = (marker == MarkerStartOfFrameProgressive)
+
+		// lossless, hierarchical and arithmetic-coded images
+		case marker >= 0xC3 && marker <= 0xCF && marker != MarkerDefineHuffmanTable:
+			return fmt.Errorf("marker 0x%.2x is not supported", marker)
+
+		case marker == MarkerDefineHuffmanTable:
+			for len(mbuf) != 0 {
+				var t DefineHuffmanTable
+				n, err := t.unmarshal(mbuf)
+				if err != nil {
+					return err
+				}
+
+				i.HuffmanTables = append(i.HuffmanTables, t)
+				mbuf = mbuf[n:]
+			}
+
+		case marker == MarkerDefineQuantizationTable:
+			var dqt DefineQuantizationTable
+			err := dqt.Unmarshal(mbuf)
+			if err != nil {
+				return err
+			}
+
+			i.QuantizationTables = append(i.QuantizationTables, dqt.Tables...)
+
+		case marker == MarkerDefineRestartInterval:
+			var dri DefineRestartInterval
+			err := dri.Unmarshal(mbuf)
+			if err != nil {
+				return err
+			}
+
+			i.RestartInterval = dri.Interval
+
+		case marker == MarkerApplication0:
+			if i.JFIF == nil && bytes.HasPrefix(mbuf, jfifIdentifier) {
+				i.JFIF = &JFIF{}
+				err := i.JFIF.Unmarshal(mbuf)
+				if err != nil {
+					return err
+				}
+			}
+
+		case marker == MarkerApplication1:
+			if i.EXIF == nil && bytes.HasPrefix(mbuf, exifIdentifier) {
+				i.EXIF = mbuf[len(exifIdentifier):]
+			}
+
+		case marker == MarkerComment:
+			i.Comments = append(i.Comments, mbuf)
+
+		case marker == MarkerStartOfScan:
+			if !sofFound {
+				return fmt.Errorf("SOS received before SOF")
+			}
+
+			err := i.unmarshalStartOfScan(mbuf)
+			if err != nil {
+				return err
+			}
+
+			i.ScanCount++
+			pos = skipEntropyCodedSegment(buf, pos)
+		}
+	}
+}
+
+// Width returns the image width.
+func (i Image) Width() int {
+	return i.StartOfFrame.Width
+}
+
+// Height returns the image height.
+func (i Image) Height() int {
+	return i.StartOfFrame.Height
+}
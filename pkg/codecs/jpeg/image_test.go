@@ -0,0 +1,168 @@
+package jpeg //nolint:revive
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testHuffmanTables = []DefineHuffmanTable{
+	{
+		Codes: []byte{
+			0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Symbols: []byte{0x03, 0x04},
+	},
+	{
+		Codes: []byte{
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Symbols:    []byte{0x05},
+		TableClass: 1,
+	},
+}
+
+var casesImage = []struct {
+	name string
+	enc  []byte
+	dec  Image
+}{
+	{
+		"baseline",
+		[]byte{
+			0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 0x4a, 0x46,
+			0x49, 0x46, 0x00, 0x01, 0x02, 0x01, 0x00, 0x48,
+			0x00, 0x48, 0x00, 0x00, 0xff, 0xe1, 0x00, 0x10,
+			0x45, 0x78, 0x69, 0x66, 0x00, 0x00, 0x4d, 0x4d,
+			0x00, 0x2a, 0x00, 0x00, 0x00, 0x08, 0xff, 0xfe,
+			0x00, 0x06, 0x74, 0x65, 0x73, 0x74, 0xff, 0xdb,
+			0x00, 0x43, 0x00, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0xff, 0xc0, 0x00, 0x11, 0x08,
+			0x01, 0xe0, 0x02, 0x80, 0x03, 0x01, 0x22, 0x00,
+			0x02, 0x11, 0x00, 0x03, 0x11, 0x00, 0xff, 0xc4,
+			0x00, 0x27, 0x00, 0x00, 0x01, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x03, 0x04, 0x10, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05, 0xff,
+			0xdd, 0x00, 0x04, 0x00, 0x04, 0xff, 0xda, 0x00,
+			0x0c, 0x03, 0x01, 0x00, 0x02, 0x11, 0x03, 0x11,
+			0x00, 0x3f, 0x00, 0x12, 0xff, 0x00, 0x34, 0xff,
+			0xd0, 0x56, 0xff, 0xd9,
+		},
+		Image{
+			JFIF: &JFIF{
+				Version:  0x0102,
+				Units:    1,
+				XDensity: 72,
+				YDensity: 72,
+			},
+			EXIF:     []byte{0x4d, 0x4d, 0x00, 0x2a, 0x00, 0x00, 0x00, 0x08},
+			Comments: [][]byte{[]byte("test")},
+			QuantizationTables: []QuantizationTable{{
+				Data: bytes.Repeat([]byte{0x01}, 64),
+			}},
+			HuffmanTables:   testHuffmanTables,
+			RestartInterval: 4,
+			StartOfFrame: StartOfFrame{
+				Precision: 8,
+				Height:    480,
+				Width:     640,
+				Components: []StartOfFrameComponent{
+					{
+						ID:                       1,
+						HorizontalSamplingFactor: 2,
+						VerticalSamplingFactor:   2,
+					},
+					{
+						ID:                       2,
+						HorizontalSamplingFactor: 1,
+						VerticalSamplingFactor:   1,
+					},
+					{
+						ID:                       3,
+						HorizontalSamplingFactor: 1,
+						VerticalSamplingFactor:   1,
+					},
+				},
+			},
+			ScanCount: 1,
+		},
+	},
+	{
+		"progressive grayscale",
+		[]byte{
+			0xff, 0xd8, 0xff, 0xdb, 0x00, 0x43, 0x00, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
+			0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0xff,
+			0xc2, 0x00, 0x0b, 0x08, 0x00, 0x10, 0x00, 0x20,
+			0x01, 0x01, 0x11, 0x00, 0xff, 0xc4, 0x00, 0x27,
+			0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x03, 0x04, 0x10, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x05, 0xff, 0xda, 0x00,
+			0x08, 0x01, 0x01, 0x00, 0x00, 0x00, 0x01, 0xaa,
+			0xbb, 0xff, 0xda, 0x00, 0x08, 0x01, 0x01, 0x00,
+			0x01, 0x3f, 0x10, 0xcc, 0xff, 0xff, 0xff, 0xd9,
+		},
+		Image{
+			QuantizationTables: []QuantizationTable{{
+				Data: bytes.Repeat([]byte{0x01}, 64),
+			}},
+			HuffmanTables: testHuffmanTables,
+			StartOfFrame: StartOfFrame{
+				Precision: 8,
+				Height:    16,
+				Width:     32,
+				Components: []StartOfFrameComponent{{
+					ID:                       1,
+					HorizontalSamplingFactor: 1,
+					VerticalSamplingFactor:   1,
+				}},
+			},
+			Progressive: true,
+			ScanCount:   2,
+		},
+	},
+}
+
+func TestImageUnmarshal(t *testing.T) {
+	for _, ca := range casesImage {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Image
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, ca.dec.StartOfFrame.Width, dec.Width())
+			require.Equal(t, ca.dec.StartOfFrame.Height, dec.Height())
+		})
+	}
+}
+
+func FuzzImageUnmarshal(f *testing.F) {
+	for _, ca := range casesImage {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec Image
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
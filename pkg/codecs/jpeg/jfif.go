@@ -0,0 +1,36 @@
+package jpeg //nolint:revive
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var jfifIdentifier = []byte{'J', 'F', 'I', 'F', 0}
+
+// JFIF is a JFIF APP0 segment.
+type JFIF struct {
+	Version  uint16
+	Units    uint8
+	XDensity uint16
+	YDensity uint16
+}
+
+// Unmarshal decodes the segment.
+func (m *JFIF) Unmarshal(buf []byte) error {
+	if !bytes.HasPrefix(buf, jfifIdentifier) {
+		return fmt.Errorf("JFIF identifier not found")
+	}
+
+	buf = buf[len(jfifIdentifier):]
+
+	if len(buf) < 9 {
+		return fmt.Errorf("JFIF is too short")
+	}
+
+	m.Version = uint16(buf[0])<<8 | uint16(buf[1])
+	m.Units = buf[2]
+	m.XDensity = uint16(buf[3])<<8 | uint16(buf[4])
+	m.YDensity = uint16(buf[5])<<8 | uint16(buf[6])
+
+	return nil
+}
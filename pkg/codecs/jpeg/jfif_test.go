@@ -0,0 +1,50 @@
+package jpeg //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesJFIF = []struct {
+	name string
+	enc  []byte
+	dec  JFIF
+}{
+	{
+		"base",
+		[]byte{
+			0xff, 0xe0, 0x00, 0x10, 0x4a, 0x46, 0x49, 0x46,
+			0x00, 0x01, 0x02, 0x01, 0x00, 0x48, 0x00, 0x48,
+			0x00, 0x00,
+		},
+		JFIF{
+			Version:  0x0102,
+			Units:    1,
+			XDensity: 72,
+			YDensity: 72,
+		},
+	},
+}
+
+func TestJFIFUnmarshal(t *testing.T) {
+	for _, ca := range casesJFIF {
+		t.Run(ca.name, func(t *testing.T) {
+			var h JFIF
+			err := h.Unmarshal(ca.enc[4:])
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func FuzzJFIFUnmarshal(f *testing.F) {
+	for _, ca := range casesJFIF {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h JFIF
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -3,12 +3,20 @@ package jpeg //nolint:revive
 
 // standard JPEG markers.
 const (
-	MarkerStartOfImage            = 0xD8
-	MarkerDefineQuantizationTable = 0xDB
-	MarkerDefineHuffmanTable      = 0xC4
-	MarkerDefineRestartInterval   = 0xDD
-	MarkerStartOfFrame1           = 0xC0
-	MarkerStartOfScan             = 0xDA
-	MarkerEndOfImage              = 0xD9
-	MarkerComment                 = 0xFE
+	MarkerStartOfImage                   = 0xD8
+	MarkerDefineQuantizationTable        = 0xDB
+	MarkerDefineHuffmanTable             = 0xC4
+	MarkerDefineRestartInterval          = 0xDD
+	MarkerStartOfFrame1                  = 0xC0
+	MarkerStartOfFrameExtendedSequential = 0xC1
+	MarkerStartOfFrameProgressive        = 0xC2
+	MarkerStartOfScan                    = 0xDA
+	MarkerEndOfImage                     = 0xD9
+	MarkerComment                        = 0xFE
+	MarkerRestart0                       = 0xD0
+	MarkerRestart7                       = 0xD7
+	MarkerApplication0                   = 0xE0
+	MarkerApplication1                   = 0xE1
+	MarkerApplication15                  = 0xEF
+	MarkerTemporary                      = 0x01
 )
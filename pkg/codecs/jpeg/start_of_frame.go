@@ -0,0 +1,60 @@
+package jpeg //nolint:revive
+
+import (
+	"fmt"
+)
+
+// StartOfFrameComponent is a component of a SOF marker.
+type StartOfFrameComponent struct {
+	ID                        uint8
+	HorizontalSamplingFactor  uint8
+	VerticalSamplingFactor    uint8
+	QuantizationTableSelector uint8
+}
+
+// StartOfFrame is a SOF0, SOF1 or SOF2 marker.
+type StartOfFrame struct {
+	Precision  uint8
+	Height     int
+	Width      int
+	Components []StartOfFrameComponent
+}
+
+// Unmarshal decodes the marker.
+func (m *StartOfFrame) Unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("SOF is too short")
+	}
+
+	m.Precision = buf[0]
+	m.Height = int(buf[1])<<8 | int(buf[2])
+	m.Width = int(buf[3])<<8 | int(buf[4])
+
+	components := int(buf[5])
+	if components == 0 || components > 4 {
+		return fmt.Errorf("invalid number of components: %d", components)
+	}
+
+	if len(buf) != (6 + components*3) {
+		return fmt.Errorf("invalid SOF size of %d", len(buf))
+	}
+
+	m.Components = make([]StartOfFrameComponent, components)
+
+	for i := range m.Components {
+		c := buf[6+i*3:]
+		m.Components[i] = StartOfFrameComponent{
+			ID:                        c[0],
+			HorizontalSamplingFactor:  c[1] >> 4,
+			VerticalSamplingFactor:    c[1] & 0x0F,
+			QuantizationTableSelector: c[2],
+		}
+
+		if m.Components[i].HorizontalSamplingFactor == 0 || m.Components[i].HorizontalSamplingFactor > 4 ||
+			m.Components[i].VerticalSamplingFactor == 0 || m.Components[i].VerticalSamplingFactor > 4 {
+			return fmt.Errorf("invalid sampling factors of component %d", i)
+		}
+	}
+
+	return nil
+}
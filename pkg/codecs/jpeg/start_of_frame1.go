@@ -13,45 +13,50 @@ type StartOfFrame1 struct {
 }
 
 // Unmarshal decodes the marker.
+// Only 8-bit, 3-component images with 4:2:2 or 4:2:0 chroma subsampling are supported.
 func (m *StartOfFrame1) Unmarshal(buf []byte) error {
-	if len(buf) != 15 {
-		return fmt.Errorf("unsupported SOF size of %d", len(buf))
+	var sof StartOfFrame
+	err := sof.Unmarshal(buf)
+	if err != nil {
+		return err
 	}
 
-	precision := buf[0]
-	if precision != 8 {
-		return fmt.Errorf("precision %d is not supported", precision)
+	if sof.Precision != 8 {
+		return fmt.Errorf("precision %d is not supported", sof.Precision)
 	}
 
-	m.Height = int(buf[1])<<8 | int(buf[2])
-	m.Width = int(buf[3])<<8 | int(buf[4])
+	if len(sof.Components) == 1 {
+		return fmt.Errorf("grayscale images are not supported")
+	}
+
+	if len(sof.Components) != 3 {
+		return fmt.Errorf("number of components = %d is not supported", len(sof.Components))
+	}
+
+	if sof.Components[1].HorizontalSamplingFactor != 1 || sof.Components[1].VerticalSamplingFactor != 1 {
+		return fmt.Errorf("samp1 %x is not supported", buf[10])
+	}
 
-	components := buf[5]
-	if components != 3 {
-		return fmt.Errorf("number of components = %d is not supported", components)
+	if sof.Components[2].HorizontalSamplingFactor != 1 || sof.Components[2].VerticalSamplingFactor != 1 {
+		return fmt.Errorf("samp2 %x is not supported", buf[13])
 	}
 
-	samp0 := buf[7]
-	switch samp0 {
-	case 0x21:
+	switch {
+	case sof.Components[0].HorizontalSamplingFactor == 2 && sof.Components[0].VerticalSamplingFactor == 1:
 		m.Type = 0
 
-	case 0x22:
+	case sof.Components[0].HorizontalSamplingFactor == 2 && sof.Components[0].VerticalSamplingFactor == 2:
 		m.Type = 1
 
-	default:
-		return fmt.Errorf("samp0 %x is not supported", samp0)
-	}
+	case sof.Components[0].HorizontalSamplingFactor == 1 && sof.Components[0].VerticalSamplingFactor == 1:
+		return fmt.Errorf("4:4:4 chroma subsampling is not supported")
 
-	samp1 := buf[10]
-	if samp1 != 0x11 {
-		return fmt.Errorf("samp1 %x is not supported", samp1)
+	default:
+		return fmt.Errorf("samp0 %x is not supported", buf[7])
 	}
 
-	samp2 := buf[13]
-	if samp2 != 0x11 {
-		return fmt.Errorf("samp2 %x is not supported", samp2)
-	}
+	m.Width = sof.Width
+	m.Height = sof.Height
 
 	return nil
 }
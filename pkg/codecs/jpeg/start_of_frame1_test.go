@@ -39,6 +39,38 @@ func TestStartOfFrame1Unmarshal(t *testing.T) {
 	}
 }
 
+func TestStartOfFrame1UnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"grayscale",
+			[]byte{
+				0xff, 0xc0, 0x00, 0x0b, 0x08, 0x00, 0x10, 0x00,
+				0x20, 0x01, 0x01, 0x11, 0x00,
+			},
+			"grayscale images are not supported",
+		},
+		{
+			"4:4:4",
+			[]byte{
+				0xff, 0xc0, 0x00, 0x11, 0x08, 0x02, 0x58, 0x03,
+				0x20, 0x03, 0x01, 0x11, 0x00, 0x02, 0x11, 0x01,
+				0x03, 0x11, 0x01,
+			},
+			"4:4:4 chroma subsampling is not supported",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h StartOfFrame1
+			err := h.Unmarshal(ca.enc[4:])
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
 func TestStartOfFrame1Marshal(t *testing.T) {
 	for _, ca := range casesStartOfFrame1 {
 		t.Run(ca.name, func(t *testing.T) {
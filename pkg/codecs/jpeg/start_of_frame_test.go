@@ -0,0 +1,85 @@
+package jpeg //nolint:revive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesStartOfFrame = []struct {
+	name string
+	enc  []byte
+	dec  StartOfFrame
+}{
+	{
+		"4:4:4",
+		[]byte{
+			0xff, 0xc0, 0x00, 0x11, 0x08, 0x02, 0x58, 0x03,
+			0x20, 0x03, 0x01, 0x11, 0x00, 0x02, 0x11, 0x01,
+			0x03, 0x11, 0x01,
+		},
+		StartOfFrame{
+			Precision: 8,
+			Height:    600,
+			Width:     800,
+			Components: []StartOfFrameComponent{
+				{
+					ID:                       1,
+					HorizontalSamplingFactor: 1,
+					VerticalSamplingFactor:   1,
+				},
+				{
+					ID:                        2,
+					HorizontalSamplingFactor:  1,
+					VerticalSamplingFactor:    1,
+					QuantizationTableSelector: 1,
+				},
+				{
+					ID:                        3,
+					HorizontalSamplingFactor:  1,
+					VerticalSamplingFactor:    1,
+					QuantizationTableSelector: 1,
+				},
+			},
+		},
+	},
+	{
+		"grayscale",
+		[]byte{
+			0xff, 0xc0, 0x00, 0x0b, 0x08, 0x00, 0x10, 0x00,
+			0x20, 0x01, 0x01, 0x11, 0x00,
+		},
+		StartOfFrame{
+			Precision: 8,
+			Height:    16,
+			Width:     32,
+			Components: []StartOfFrameComponent{{
+				ID:                       1,
+				HorizontalSamplingFactor: 1,
+				VerticalSamplingFactor:   1,
+			}},
+		},
+	},
+}
+
+func TestStartOfFrameUnmarshal(t *testing.T) {
+	for _, ca := range casesStartOfFrame {
+		t.Run(ca.name, func(t *testing.T) {
+			var h StartOfFrame
+			err := h.Unmarshal(ca.enc[4:])
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func FuzzStartOfFrameUnmarshal(f *testing.F) {
+	for _, ca := range casesStartOfFrame {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h StartOfFrame
+		h.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -200,3 +200,18 @@ func (h FrameHeader) SampleCount() int {
 
 	return samplesPerFrame[mpegIndex][h.Layer-1]
 }
+
+// sideInfoSize returns the size of the layer III side information
+// that follows the header.
+func (h FrameHeader) sideInfoSize() int {
+	switch {
+	case !h.MPEG2 && h.ChannelMode == ChannelModeMono:
+		return 17
+	case !h.MPEG2:
+		return 32
+	case h.ChannelMode == ChannelModeMono:
+		return 9
+	default:
+		return 17
+	}
+}
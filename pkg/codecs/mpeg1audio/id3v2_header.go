@@ -0,0 +1,84 @@
+package mpeg1audio
+
+import (
+	"fmt"
+)
+
+const (
+	id3v2HeaderSize = 10
+	id3v2FooterSize = 10
+)
+
+// ID3v2Header is the header of a ID3v2 tag,
+// that is often placed at the beginning of MPEG-1/2 audio files.
+// Specification: https://id3.org/id3v2.4.0-structure
+type ID3v2Header struct {
+	MajorVersion      uint8
+	Revision          uint8
+	Unsynchronisation bool
+	ExtendedHeader    bool
+	Experimental      bool
+	Footer            bool
+
+	// size of the tag, excluding header and footer.
+	Size int
+}
+
+// Unmarshal decodes a ID3v2Header.
+func (h *ID3v2Header) Unmarshal(buf []byte) error {
+	if len(buf) < id3v2HeaderSize {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] != 'I' || buf[1] != 'D' || buf[2] != '3' {
+		return fmt.Errorf("ID3v2 identifier not found")
+	}
+
+	if buf[3] == 0xFF || buf[4] == 0xFF {
+		return fmt.Errorf("invalid ID3v2 version")
+	}
+
+	h.MajorVersion = buf[3]
+	h.Revision = buf[4]
+	h.Unsynchronisation = (buf[5] & 0b10000000) != 0
+	h.ExtendedHeader = (buf[5] & 0b01000000) != 0
+	h.Experimental = (buf[5] & 0b00100000) != 0
+	h.Footer = (buf[5] & 0b00010000) != 0
+
+	// size is a synchsafe integer
+	if ((buf[6] | buf[7] | buf[8] | buf[9]) & 0x80) != 0 {
+		return fmt.Errorf("invalid ID3v2 size")
+	}
+	h.Size = int(buf[6])<<21 | int(buf[7])<<14 | int(buf[8])<<7 | int(buf[9])
+
+	return nil
+}
+
+// TagSize returns the size of the whole tag, including header and footer.
+func (h ID3v2Header) TagSize() int {
+	n := id3v2HeaderSize + h.Size
+	if h.Footer {
+		n += id3v2FooterSize
+	}
+	return n
+}
+
+// SkipID3v2 removes ID3v2 tags from the beginning of a buffer.
+// Buffers that do not start with a ID3v2 tag are returned unchanged.
+func SkipID3v2(buf []byte) ([]byte, error) {
+	for len(buf) >= 3 && buf[0] == 'I' && buf[1] == 'D' && buf[2] == '3' {
+		var h ID3v2Header
+		err := h.Unmarshal(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(buf) < h.TagSize() {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+
+		buf = buf[h.TagSize():]
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,80 @@
+package mpeg1audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesID3v2Header = []struct {
+	name    string
+	enc     []byte
+	dec     ID3v2Header
+	tagSize int
+}{
+	{
+		"v2.3",
+		[]byte{'I', 'D', '3', 0x03, 0x00, 0x00, 0x00, 0x00, 0x02, 0x01},
+		ID3v2Header{
+			MajorVersion: 3,
+			Size:         257,
+		},
+		267,
+	},
+	{
+		"v2.4 with footer",
+		[]byte{'I', 'D', '3', 0x04, 0x00, 0x50, 0x00, 0x01, 0x00, 0x00},
+		ID3v2Header{
+			MajorVersion:   4,
+			ExtendedHeader: true,
+			Footer:         true,
+			Size:           16384,
+		},
+		16404,
+	},
+}
+
+func TestID3v2HeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesID3v2Header {
+		t.Run(ca.name, func(t *testing.T) {
+			var h ID3v2Header
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+			require.Equal(t, ca.tagSize, h.TagSize())
+		})
+	}
+}
+
+func TestSkipID3v2(t *testing.T) {
+	frame := []byte{0xff, 0xfb, 0x90, 0x64, 0x00}
+
+	buf, err := SkipID3v2(frame)
+	require.NoError(t, err)
+	require.Equal(t, frame, buf)
+
+	tag := append([]byte{'I', 'D', '3', 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03}, 1, 2, 3)
+
+	buf, err = SkipID3v2(append(tag, frame...))
+	require.NoError(t, err)
+	require.Equal(t, frame, buf)
+
+	_, err = SkipID3v2(tag[:12])
+	require.EqualError(t, err, "not enough bytes")
+}
+
+func FuzzID3v2HeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesID3v2Header {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h ID3v2Header
+		err := h.Unmarshal(b)
+		if err == nil {
+			h.TagSize()
+		}
+
+		SkipID3v2(b) //nolint:errcheck
+	})
+}
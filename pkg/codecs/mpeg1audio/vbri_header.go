@@ -0,0 +1,85 @@
+package mpeg1audio
+
+import (
+	"fmt"
+)
+
+const (
+	vbriOffset     = 4 + 32
+	vbriHeaderSize = 26
+)
+
+// VBRIHeader is a VBRI header, written by Fraunhofer encoders.
+// It is contained into the first frame of a layer III stream, in place of audio data,
+// and describes the stream.
+type VBRIHeader struct {
+	// header of the frame that contains the VBRI header.
+	FrameHeader FrameHeader
+
+	Version    uint16
+	Delay      uint16
+	Quality    uint16
+	ByteCount  uint32
+	FrameCount uint32
+
+	// seek table.
+	TOCScale          uint16
+	TOCEntrySize      uint16
+	FramesPerTOCEntry uint16
+	TOC               []uint32
+}
+
+// Unmarshal decodes a VBRIHeader from a frame.
+func (h *VBRIHeader) Unmarshal(frame []byte) error {
+	*h = VBRIHeader{}
+
+	err := h.FrameHeader.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	if h.FrameHeader.Layer != 3 || len(frame) < vbriOffset+vbriHeaderSize ||
+		string(frame[vbriOffset:vbriOffset+4]) != "VBRI" {
+		return fmt.Errorf("not a VBRI header")
+	}
+
+	buf := frame[vbriOffset+4:]
+
+	h.Version = uint16(buf[0])<<8 | uint16(buf[1])
+	h.Delay = uint16(buf[2])<<8 | uint16(buf[3])
+	h.Quality = uint16(buf[4])<<8 | uint16(buf[5])
+	h.ByteCount = readUint32(buf[6:])
+	h.FrameCount = readUint32(buf[10:])
+	entryCount := int(buf[14])<<8 | int(buf[15])
+	h.TOCScale = uint16(buf[16])<<8 | uint16(buf[17])
+	h.TOCEntrySize = uint16(buf[18])<<8 | uint16(buf[19])
+	h.FramesPerTOCEntry = uint16(buf[20])<<8 | uint16(buf[21])
+	buf = buf[22:]
+
+	if h.TOCEntrySize < 1 || h.TOCEntrySize > 4 {
+		return fmt.Errorf("invalid TOC entry size: %d", h.TOCEntrySize)
+	}
+
+	entrySize := int(h.TOCEntrySize)
+
+	if len(buf) < entryCount*entrySize {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	h.TOC = make([]uint32, entryCount)
+	for i := range h.TOC {
+		var v uint32
+		for _, b := range buf[:entrySize] {
+			v = v<<8 | uint32(b)
+		}
+		h.TOC[i] = v
+		buf = buf[entrySize:]
+	}
+
+	return nil
+}
+
+// SampleCount returns the number of samples of the stream.
+func (h VBRIHeader) SampleCount() int {
+	return int(h.FrameCount) * h.FrameHeader.SampleCount()
+}
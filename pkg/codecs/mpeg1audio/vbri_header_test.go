@@ -0,0 +1,76 @@
+package mpeg1audio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVBRIHeader = []struct {
+	name        string
+	enc         []byte
+	dec         VBRIHeader
+	sampleCount int
+}{
+	{
+		"standard",
+		buildFrame([]byte{0xff, 0xfb, 0x90, 0x64}, 32, bytes.Join([][]byte{
+			[]byte("VBRI"),
+			{0x00, 0x01},
+			{0x04, 0xb0},
+			{0x00, 0x4b},
+			{0x00, 0x01, 0x45, 0xc8},
+			{0x00, 0x00, 0x00, 0xc8},
+			{0x00, 0x03},
+			{0x00, 0x01},
+			{0x00, 0x02},
+			{0x00, 0x40},
+			{0x00, 0x64, 0x00, 0xc8, 0x01, 0x2c},
+		}, nil), 417),
+		VBRIHeader{
+			FrameHeader: FrameHeader{
+				Layer:       3,
+				Bitrate:     128000,
+				SampleRate:  44100,
+				ChannelMode: ChannelModeJointStereo,
+			},
+			Version:           1,
+			Delay:             1200,
+			Quality:           75,
+			ByteCount:         83400,
+			FrameCount:        200,
+			TOCScale:          1,
+			TOCEntrySize:      2,
+			FramesPerTOCEntry: 64,
+			TOC:               []uint32{100, 200, 300},
+		},
+		200 * 1152,
+	},
+}
+
+func TestVBRIHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesVBRIHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h VBRIHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+			require.Equal(t, ca.sampleCount, h.SampleCount())
+		})
+	}
+}
+
+func FuzzVBRIHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesVBRIHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h VBRIHeader
+		err := h.Unmarshal(b)
+		if err == nil {
+			h.SampleCount()
+		}
+	})
+}
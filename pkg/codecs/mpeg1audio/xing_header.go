@@ -0,0 +1,187 @@
+package mpeg1audio
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	xingFlagFrames  = 0x01
+	xingFlagBytes   = 0x02
+	xingFlagTOC     = 0x04
+	xingFlagQuality = 0x08
+
+	lameHeaderSize = 36
+)
+
+// DecoderDelay is the delay introduced by layer III decoders, in samples.
+const DecoderDelay = 529
+
+// LAMEHeader is the extension of a Xing header written by the LAME encoder
+// and by encoders compatible with it.
+// Specification: http://gabriel.mp3-tech.org/mp3infotag.html
+type LAMEHeader struct {
+	Encoder          string
+	TagRevision      uint8
+	VBRMethod        uint8
+	LowpassFrequency int
+	EncoderDelay     int
+	Padding          int
+	MusicLength      uint32
+	MusicCRC         uint16
+}
+
+func isLAMEEncoder(buf []byte) bool {
+	switch string(buf[:4]) {
+	case "LAME", "Lavf", "Lavc":
+		return true
+	}
+	return false
+}
+
+func (h *LAMEHeader) unmarshal(buf []byte) {
+	h.Encoder = strings.TrimRight(string(buf[:9]), "\x00 ")
+	h.TagRevision = buf[9] >> 4
+	h.VBRMethod = buf[9] & 0x0F
+	h.LowpassFrequency = int(buf[10]) * 100
+	h.EncoderDelay = int(buf[21])<<4 | int(buf[22])>>4
+	h.Padding = int(buf[22]&0x0F)<<8 | int(buf[23])
+	h.MusicLength = uint32(buf[28])<<24 | uint32(buf[29])<<16 | uint32(buf[30])<<8 | uint32(buf[31])
+	h.MusicCRC = uint16(buf[32])<<8 | uint16(buf[33])
+}
+
+// StartPadding returns the number of decoded samples
+// that must be discarded at the beginning of the stream.
+func (h LAMEHeader) StartPadding() int {
+	return h.EncoderDelay + DecoderDelay
+}
+
+// EndPadding returns the number of decoded samples
+// that must be discarded at the end of the stream.
+func (h LAMEHeader) EndPadding() int {
+	return max(h.Padding-DecoderDelay, 0)
+}
+
+// XingHeader is a Xing or Info header.
+// It is contained into the first frame of a layer III stream, in place of audio data,
+// and describes the stream.
+// Specification: http://gabriel.mp3-tech.org/mp3infotag.html
+type XingHeader struct {
+	// header of the frame that contains the Xing header.
+	FrameHeader FrameHeader
+
+	// whether the header is tagged as "Info", that is used in constant bitrate streams.
+	Info bool
+
+	// number of frames, excluding the one that contains the Xing header.
+	FrameCount *uint32
+
+	// number of bytes, including the frame that contains the Xing header.
+	ByteCount *uint32
+
+	// seek table.
+	TOC *[100]byte
+
+	// encoder quality indicator.
+	Quality *uint32
+
+	// LAME extension.
+	LAME *LAMEHeader
+}
+
+// Unmarshal decodes a XingHeader from a frame.
+func (h *XingHeader) Unmarshal(frame []byte) error {
+	*h = XingHeader{}
+
+	err := h.FrameHeader.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	if h.FrameHeader.Layer != 3 {
+		return fmt.Errorf("not a Xing header")
+	}
+
+	pos := 4 + h.FrameHeader.sideInfoSize()
+
+	if len(frame) < pos+8 {
+		return fmt.Errorf("not a Xing header")
+	}
+
+	switch string(frame[pos : pos+4]) {
+	case "Xing":
+	case "Info":
+		h.Info = true
+	default:
+		return fmt.Errorf("not a Xing header")
+	}
+
+	flags := readUint32(frame[pos+4:])
+	pos += 8
+
+	if (flags & xingFlagFrames) != 0 {
+		if len(frame) < pos+4 {
+			return fmt.Errorf("not enough bytes")
+		}
+		v := readUint32(frame[pos:])
+		h.FrameCount = &v
+		pos += 4
+	}
+
+	if (flags & xingFlagBytes) != 0 {
+		if len(frame) < pos+4 {
+			return fmt.Errorf("not enough bytes")
+		}
+		v := readUint32(frame[pos:])
+		h.ByteCount = &v
+		pos += 4
+	}
+
+	if (flags & xingFlagTOC) != 0 {
+		if len(frame) < pos+100 {
+			return fmt.Errorf("not enough bytes")
+		}
+		var toc [100]byte
+		copy(toc[:], frame[pos:])
+		h.TOC = &toc
+		pos += 100
+	}
+
+	if (flags & xingFlagQuality) != 0 {
+		if len(frame) < pos+4 {
+			return fmt.Errorf("not enough bytes")
+		}
+		v := readUint32(frame[pos:])
+		h.Quality = &v
+		pos += 4
+	}
+
+	if len(frame) >= pos+lameHeaderSize && isLAMEEncoder(frame[pos:]) {
+		h.LAME = &LAMEHeader{}
+		h.LAME.unmarshal(frame[pos:])
+	}
+
+	return nil
+}
+
+// SampleCount returns the number of samples of the stream,
+// excluding the frame that contains the Xing header and,
+// when a LAME extension is present, encoder delay and padding.
+// It returns zero when the frame count is not present.
+func (h XingHeader) SampleCount() int {
+	if h.FrameCount == nil {
+		return 0
+	}
+
+	n := int(*h.FrameCount) * h.FrameHeader.SampleCount()
+
+	if h.LAME != nil {
+		n = max(n-h.LAME.StartPadding()-h.LAME.EndPadding(), 0)
+	}
+
+	return n
+}
+
+func readUint32(buf []byte) uint32 {
+	return uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+}
@@ -0,0 +1,131 @@
+package mpeg1audio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func testTOC() *[100]byte {
+	var toc [100]byte
+	for i := range toc {
+		toc[i] = byte(i * 2)
+	}
+	return &toc
+}
+
+// buildFrame builds a frame with the given header,
+// followed by empty side information, the given content and padding.
+func buildFrame(header []byte, sideInfoSize int, content []byte, size int) []byte {
+	buf := append([]byte(nil), header...)
+	buf = append(buf, make([]byte, sideInfoSize)...)
+	buf = append(buf, content...)
+	return append(buf, make([]byte, size-len(buf))...)
+}
+
+var casesXingHeader = []struct {
+	name        string
+	enc         []byte
+	dec         XingHeader
+	sampleCount int
+}{
+	{
+		"info with lame",
+		buildFrame([]byte{0xff, 0xfb, 0x90, 0x64}, 32, bytes.Join([][]byte{
+			[]byte("Info"),
+			{0x00, 0x00, 0x00, 0x0f},
+			{0x00, 0x00, 0x00, 0x64},
+			{0x00, 0x00, 0xa2, 0xe4},
+			testTOC()[:],
+			{0x00, 0x00, 0x00, 0x3c},
+			[]byte("LAME3.100"),
+			{0x21, 0xa0},
+			bytes.Repeat([]byte{0x00}, 10),
+			{0x24, 0x03, 0xe8},
+			{0x00, 0x00, 0x00, 0x00},
+			{0x00, 0x00, 0xa2, 0xe4},
+			{0x12, 0x34},
+			{0x00, 0x00},
+		}, nil), 417),
+		XingHeader{
+			FrameHeader: FrameHeader{
+				Layer:       3,
+				Bitrate:     128000,
+				SampleRate:  44100,
+				ChannelMode: ChannelModeJointStereo,
+			},
+			Info:       true,
+			FrameCount: uint32Ptr(100),
+			ByteCount:  uint32Ptr(41700),
+			TOC:        testTOC(),
+			Quality:    uint32Ptr(60),
+			LAME: &LAMEHeader{
+				Encoder:          "LAME3.100",
+				TagRevision:      2,
+				VBRMethod:        1,
+				LowpassFrequency: 16000,
+				EncoderDelay:     576,
+				Padding:          1000,
+				MusicLength:      41700,
+				MusicCRC:         0x1234,
+			},
+		},
+		100*1152 - (576 + 529) - (1000 - 529),
+	},
+	{
+		"xing mpeg-2 mono",
+		buildFrame([]byte{0xff, 0xf3, 0x80, 0xc0}, 9, bytes.Join([][]byte{
+			[]byte("Xing"),
+			{0x00, 0x00, 0x00, 0x01},
+			{0x00, 0x00, 0x00, 0x32},
+		}, nil), 100),
+		XingHeader{
+			FrameHeader: FrameHeader{
+				MPEG2:       true,
+				Layer:       3,
+				Bitrate:     64000,
+				SampleRate:  22050,
+				ChannelMode: ChannelModeMono,
+			},
+			FrameCount: uint32Ptr(50),
+		},
+		50 * 576,
+	},
+}
+
+func TestXingHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesXingHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var h XingHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+			require.Equal(t, ca.sampleCount, h.SampleCount())
+		})
+	}
+}
+
+func TestXingHeaderUnmarshalAudioFrame(t *testing.T) {
+	var h XingHeader
+	err := h.Unmarshal(buildFrame([]byte{0xff, 0xfb, 0x90, 0x64}, 32, []byte{1, 2, 3, 4, 5, 6, 7, 8}, 417))
+	require.EqualError(t, err, "not a Xing header")
+}
+
+func FuzzXingHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesXingHeader {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var h XingHeader
+		err := h.Unmarshal(b)
+		if err == nil {
+			h.SampleCount()
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package mpeg1video
+
+// Config is the configuration of a MPEG-1/2 Video stream,
+// that is made of a sequence header and, in case of MPEG-2, of a sequence extension.
+type Config struct {
+	SequenceHeader    SequenceHeader
+	SequenceExtension *SequenceExtension // MPEG-2 only
+}
+
+// Unmarshal decodes a Config.
+func (c *Config) Unmarshal(buf []byte) error {
+	err := c.SequenceHeader.Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	c.SequenceExtension = nil
+	pos := 4
+
+	for {
+		pos = nextStartCode(buf, pos)
+		if pos < 0 {
+			return nil
+		}
+
+		switch StartCode(buf[pos+3]) {
+		case ExtensionStartCode:
+			if c.SequenceExtension == nil && len(buf) > (pos+4) &&
+				ExtensionStartCodeIdentifier(buf[pos+4]>>4) == SequenceExtensionID {
+				c.SequenceExtension = &SequenceExtension{}
+				err = c.SequenceExtension.Unmarshal(buf[pos:])
+				if err != nil {
+					return err
+				}
+			}
+
+		case UserDataStartCode:
+
+		default:
+			return nil
+		}
+
+		pos += 4
+	}
+}
+
+// IsMPEG2 returns whether the stream is a MPEG-2 Video stream.
+func (c Config) IsMPEG2() bool {
+	return c.SequenceExtension != nil
+}
+
+// Width returns the video width.
+func (c Config) Width() int {
+	w := int(c.SequenceHeader.HorizontalSizeValue)
+	if c.SequenceExtension != nil {
+		w |= int(c.SequenceExtension.HorizontalSizeExtension) << 12
+	}
+	return w
+}
+
+// Height returns the video height.
+func (c Config) Height() int {
+	h := int(c.SequenceHeader.VerticalSizeValue)
+	if c.SequenceExtension != nil {
+		h |= int(c.SequenceExtension.VerticalSizeExtension) << 12
+	}
+	return h
+}
+
+// frameRate returns the frame rate as a fraction.
+func (c Config) frameRate() (int64, int64, bool) {
+	fr, ok := frameRates[c.SequenceHeader.FrameRateCode]
+	if !ok {
+		return 0, 0, false
+	}
+
+	num, den := fr[0], fr[1]
+
+	if c.SequenceExtension != nil {
+		num *= int64(c.SequenceExtension.FrameRateExtensionN) + 1
+		den *= int64(c.SequenceExtension.FrameRateExtensionD) + 1
+	}
+
+	return num, den, true
+}
+
+// FPS returns the frame rate.
+func (c Config) FPS() float64 {
+	num, den, ok := c.frameRate()
+	if !ok {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
+
+// IsProgressive returns whether the sequence contains progressive frames only.
+// MPEG-1 Video sequences are always progressive.
+func (c Config) IsProgressive() bool {
+	return c.SequenceExtension == nil || c.SequenceExtension.ProgressiveSequence
+}
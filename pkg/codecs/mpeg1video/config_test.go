@@ -0,0 +1,128 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesConfig = []struct {
+	name        string
+	byts        []byte
+	dec         Config
+	width       int
+	height      int
+	fps         float64
+	progressive bool
+}{
+	{
+		"mpeg-2",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x78, 0x04, 0x38, 0x35,
+			0xff, 0xff, 0xe0, 0x18, 0x00, 0x00, 0x01, 0xb5,
+			0x14, 0x4a, 0x00, 0x01, 0x00, 0x00,
+		},
+		Config{
+			SequenceHeader: SequenceHeader{
+				HorizontalSizeValue:    1920,
+				VerticalSizeValue:      1080,
+				AspectRatioInformation: 3,
+				FrameRateCode:          5,
+				BitRateValue:           0x3ffff,
+				VBVBufferSizeValue:     3,
+			},
+			SequenceExtension: &SequenceExtension{
+				ProfileAndLevelIndication: 0x44,
+				ProgressiveSequence:       true,
+				ChromaFormat:              1,
+			},
+		},
+		1920,
+		1080,
+		30,
+		true,
+	},
+	{
+		"mpeg-2 interlaced",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x2d, 0x02, 0x40, 0x23,
+			0xff, 0xff, 0xe3, 0x80, 0x00, 0x00, 0x01, 0xb5,
+			0x14, 0x82, 0x00, 0x01, 0x00, 0x00,
+		},
+		Config{
+			SequenceHeader: SequenceHeader{
+				HorizontalSizeValue:    720,
+				VerticalSizeValue:      576,
+				AspectRatioInformation: 2,
+				FrameRateCode:          3,
+				BitRateValue:           0x3ffff,
+				VBVBufferSizeValue:     112,
+			},
+			SequenceExtension: &SequenceExtension{
+				ProfileAndLevelIndication: 0x48,
+				ChromaFormat:              1,
+			},
+		},
+		720,
+		576,
+		25,
+		false,
+	},
+	{
+		"mpeg-1",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x16, 0x00, 0xf0, 0xc4,
+			0x01, 0x1f, 0xa0, 0xa4, 0x00, 0x00, 0x01, 0xb2,
+			0x01, 0x02, 0x03, 0x04,
+		},
+		Config{
+			SequenceHeader: SequenceHeader{
+				HorizontalSizeValue:       352,
+				VerticalSizeValue:         240,
+				AspectRatioInformation:    12,
+				FrameRateCode:             4,
+				BitRateValue:              1150,
+				VBVBufferSizeValue:        20,
+				ConstrainedParametersFlag: true,
+			},
+		},
+		352,
+		240,
+		30000.0 / 1001.0,
+		true,
+	},
+}
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range casesConfig {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Config
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, ca.dec.SequenceExtension != nil, dec.IsMPEG2())
+			require.Equal(t, ca.width, dec.Width())
+			require.Equal(t, ca.height, dec.Height())
+			require.Equal(t, ca.fps, dec.FPS())
+			require.Equal(t, ca.progressive, dec.IsProgressive())
+		})
+	}
+}
+
+func FuzzConfigUnmarshal(f *testing.F) {
+	for _, ca := range casesConfig {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec Config
+		err := dec.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		dec.Width()
+		dec.Height()
+		dec.FPS()
+	})
+}
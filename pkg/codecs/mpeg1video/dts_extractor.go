@@ -0,0 +1,125 @@
+package mpeg1video
+
+import (
+	"fmt"
+)
+
+// DTSExtractor computes DTS from PTS.
+//
+// B pictures are never used as references, therefore they are decoded at their presentation time,
+// while I and P pictures are decoded before the B pictures that precede them in presentation order.
+type DTSExtractor struct {
+	config         *Config
+	randomReceived bool
+	prevAnchorTR   uint16
+	prevDTSFilled  bool
+	prevDTS        int64
+}
+
+// Initialize initializes a DTSExtractor.
+func (d *DTSExtractor) Initialize() {
+}
+
+func (d *DTSExtractor) extractInner(frame []byte, pts int64) (int64, error) {
+	gopFound := false
+	var pict *PictureHeader
+	pos := 0
+
+outer:
+	for {
+		pos = nextStartCode(frame, pos)
+		if pos < 0 {
+			break
+		}
+
+		switch StartCode(frame[pos+3]) {
+		case SequenceHeaderStartCode:
+			var config Config
+			err := config.Unmarshal(frame[pos:])
+			if err != nil {
+				return 0, fmt.Errorf("invalid sequence header: %w", err)
+			}
+
+			if _, _, ok := config.frameRate(); !ok {
+				return 0, fmt.Errorf("unsupported frame_rate_code: %d", config.SequenceHeader.FrameRateCode)
+			}
+
+			d.config = &config
+
+		case GroupOfPicturesStartCode:
+			gopFound = true
+
+		case PictureStartCode:
+			pict = &PictureHeader{}
+			err := pict.Unmarshal(frame[pos:])
+			if err != nil {
+				return 0, fmt.Errorf("invalid picture header: %w", err)
+			}
+			break outer
+		}
+
+		pos += 4
+	}
+
+	if d.config == nil {
+		return 0, fmt.Errorf("sequence header not received yet")
+	}
+
+	if pict == nil {
+		return 0, fmt.Errorf("picture header not found")
+	}
+
+	intra := pict.PictureCodingType == PictureCodingTypeI || pict.PictureCodingType == PictureCodingTypeD
+
+	if !d.randomReceived {
+		if !intra {
+			return 0, fmt.Errorf("random access frame not received yet")
+		}
+		d.randomReceived = true
+	}
+
+	// low delay sequences do not contain B pictures
+	if d.config.SequenceExtension != nil && d.config.SequenceExtension.LowDelay {
+		return pts, nil
+	}
+
+	if pict.PictureCodingType == PictureCodingTypeB {
+		return pts, nil
+	}
+
+	// distance in presentation order from the previous I or P picture.
+	// temporal_reference is reset after every GOP header.
+	var diff uint16
+	if gopFound {
+		diff = pict.TemporalReference + 1
+	} else {
+		diff = (pict.TemporalReference - d.prevAnchorTR) & 0x3FF
+	}
+	d.prevAnchorTR = pict.TemporalReference
+
+	num, den, _ := d.config.frameRate()
+
+	return pts - int64(diff)*90000*den/num, nil
+}
+
+// Extract extracts the DTS of a frame.
+func (d *DTSExtractor) Extract(frame []byte, pts int64) (int64, error) {
+	dts, err := d.extractInner(frame, pts)
+	if err != nil {
+		return 0, err
+	}
+
+	if dts > pts {
+		return 0, fmt.Errorf("DTS is greater than PTS")
+	}
+
+	if d.prevDTSFilled && dts < d.prevDTS {
+		return 0, fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			d.prevDTS, dts)
+	}
+
+	d.prevDTSFilled = true
+	d.prevDTS = dts
+
+	return dts, err
+}
@@ -0,0 +1,181 @@
+package mpeg1video
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceSample struct {
+	frame []byte
+	pts   int64
+	dts   int64
+}
+
+var casesDTSExtractor = []struct {
+	name     string
+	sequence []sequenceSample
+}{
+	{
+		"open gop with b-frames",
+		[]sequenceSample{
+			{
+				[]byte{
+					0x00, 0x00, 0x01, 0xb3, 0x2d, 0x02, 0x40, 0x23,
+					0xff, 0xff, 0xe3, 0x80, 0x00, 0x00, 0x01, 0xb5,
+					0x14, 0x82, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+					0x01, 0xb8, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00,
+					0x01, 0x00, 0x00, 0x8f, 0xff, 0xf8, 0x00, 0x00,
+					0x01, 0x01, 0x12, 0x34,
+				},
+				7200,
+				-3600,
+			},
+			{
+				[]byte{ // B, temporal reference 0
+					0x00, 0x00, 0x01, 0x00, 0x00, 0x1f, 0xff, 0xf8,
+					0x88, 0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+				},
+				0,
+				0,
+			},
+			{
+				[]byte{ // B, temporal reference 1
+					0x00, 0x00, 0x01, 0x00, 0x00, 0x5f, 0xff, 0xf8,
+					0x88, 0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+				},
+				3600,
+				3600,
+			},
+			{
+				[]byte{ // P, temporal reference 5
+					0x00, 0x00, 0x01, 0x00, 0x01, 0x57, 0xff, 0xf8,
+					0x80, 0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+				},
+				18000,
+				7200,
+			},
+			{
+				[]byte{ // B, temporal reference 3
+					0x00, 0x00, 0x01, 0x00, 0x00, 0xdf, 0xff, 0xf8,
+					0x88, 0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+				},
+				10800,
+				10800,
+			},
+			{
+				[]byte{ // B, temporal reference 4
+					0x00, 0x00, 0x01, 0x00, 0x01, 0x1f, 0xff, 0xf8,
+					0x88, 0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+				},
+				14400,
+				14400,
+			},
+			{
+				[]byte{ // GOP, I, temporal reference 2
+					0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x20, 0x00,
+					0x00, 0x00, 0x01, 0x00, 0x00, 0x8f, 0xff, 0xf8,
+					0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+				},
+				28800,
+				18000,
+			},
+		},
+	},
+}
+
+func TestDTSExtractor(t *testing.T) {
+	for _, ca := range casesDTSExtractor {
+		t.Run(ca.name, func(t *testing.T) {
+			ex := &DTSExtractor{}
+			ex.Initialize()
+
+			for _, sample := range ca.sequence {
+				dts, err := ex.Extract(sample.frame, sample.pts)
+				require.NoError(t, err)
+				require.Equal(t, sample.dts, dts)
+			}
+		})
+	}
+}
+
+func serializeSequence(seq []sequenceSample) []byte {
+	var buf []byte //nolint:prealloc
+
+	for _, sample := range seq {
+		tmp := make([]byte, 8)
+		binary.LittleEndian.PutUint64(tmp, uint64(sample.pts))
+		buf = append(buf, tmp...)
+
+		tmp = make([]byte, 4)
+		binary.LittleEndian.PutUint32(tmp, uint32(len(sample.frame)))
+		buf = append(buf, tmp...)
+
+		buf = append(buf, sample.frame...)
+	}
+
+	return buf
+}
+
+func unserializeSequence(buf []byte) ([]sequenceSample, error) {
+	var samples []sequenceSample
+
+	for {
+		if len(buf) < 8 {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		pts := int64(binary.LittleEndian.Uint64(buf[:8]))
+		buf = buf[8:]
+
+		if len(buf) < 4 {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		frameLen := binary.LittleEndian.Uint32(buf[:4])
+		buf = buf[4:]
+
+		if frameLen == 0 {
+			return nil, fmt.Errorf("invalid frame len")
+		}
+		if len(buf) < int(frameLen) {
+			return nil, fmt.Errorf("not enough bits")
+		}
+
+		samples = append(samples, sequenceSample{
+			frame: buf[:frameLen],
+			pts:   pts,
+		})
+		buf = buf[frameLen:]
+
+		if len(buf) == 0 {
+			break
+		}
+	}
+
+	return samples, nil
+}
+
+func FuzzDTSExtractor(f *testing.F) {
+	for _, ca := range casesDTSExtractor {
+		f.Add(serializeSequence(ca.sequence))
+	}
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		seq, err := unserializeSequence(buf)
+		if err != nil {
+			t.Skip()
+			return
+		}
+
+		ex := &DTSExtractor{}
+		ex.Initialize()
+
+		for _, sample := range seq {
+			_, err = ex.Extract(sample.frame, sample.pts)
+			if err != nil {
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package mpeg1video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// GroupOfPicturesHeader is a MPEG-1/2 Video group of pictures header.
+// Specification: ISO 13818-2, section 6.2.2.6
+type GroupOfPicturesHeader struct {
+	DropFrameFlag bool
+	Hours         uint8
+	Minutes       uint8
+	Seconds       uint8
+	Pictures      uint8
+	ClosedGOP     bool
+	BrokenLink    bool
+}
+
+// Unmarshal decodes a GroupOfPicturesHeader, including its start code.
+func (h *GroupOfPicturesHeader) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, GroupOfPicturesStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 27)
+	if err != nil {
+		return err
+	}
+
+	h.DropFrameFlag = bits.ReadFlagUnsafe(buf, &pos)
+	h.Hours = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+	h.Minutes = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+
+	markerBit := bits.ReadFlagUnsafe(buf, &pos)
+	if !markerBit {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	h.Seconds = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	h.Pictures = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	h.ClosedGOP = bits.ReadFlagUnsafe(buf, &pos)
+	h.BrokenLink = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
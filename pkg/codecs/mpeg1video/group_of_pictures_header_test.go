@@ -0,0 +1,55 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesGroupOfPicturesHeader = []struct {
+	name string
+	byts []byte
+	dec  GroupOfPicturesHeader
+}{
+	{
+		"open",
+		[]byte{0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x20, 0x00},
+		GroupOfPicturesHeader{
+			Seconds: 1,
+		},
+	},
+	{
+		"closed",
+		[]byte{0x00, 0x00, 0x01, 0xb8, 0x84, 0x28, 0x62, 0x40},
+		GroupOfPicturesHeader{
+			DropFrameFlag: true,
+			Hours:         1,
+			Minutes:       2,
+			Seconds:       3,
+			Pictures:      4,
+			ClosedGOP:     true,
+		},
+	},
+}
+
+func TestGroupOfPicturesHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesGroupOfPicturesHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec GroupOfPicturesHeader
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func FuzzGroupOfPicturesHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesGroupOfPicturesHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec GroupOfPicturesHeader
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,39 @@
+package mpeg1video
+
+import (
+	"fmt"
+)
+
+// findPictureHeader finds the first picture header of a frame.
+func findPictureHeader(frame []byte) (*PictureHeader, error) {
+	pos := 0
+
+	for {
+		pos = nextStartCode(frame, pos)
+		if pos < 0 {
+			return nil, fmt.Errorf("picture header not found")
+		}
+
+		if StartCode(frame[pos+3]) == PictureStartCode {
+			var h PictureHeader
+			err := h.Unmarshal(frame[pos:])
+			if err != nil {
+				return nil, err
+			}
+
+			return &h, nil
+		}
+
+		pos += 4
+	}
+}
+
+// IsRandomAccess checks whether the first picture of a frame is an intra-coded picture.
+func IsRandomAccess(frame []byte) bool {
+	h, err := findPictureHeader(frame)
+	if err != nil {
+		return false
+	}
+
+	return h.PictureCodingType == PictureCodingTypeI || h.PictureCodingType == PictureCodingTypeD
+}
@@ -0,0 +1,42 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRandomAccess(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		byts  []byte
+		isRAP bool
+	}{
+		{
+			"gop and i picture",
+			[]byte{
+				0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x00, 0x00,
+				0x00, 0x00, 0x01, 0x00, 0x00, 0x8f, 0xff, 0xf8,
+				0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+			},
+			true,
+		},
+		{
+			"b picture",
+			[]byte{
+				0x00, 0x00, 0x01, 0x00, 0x00, 0x1f, 0xff, 0xf8,
+				0x88, 0x00, 0x00, 0x01, 0x01, 0x12, 0x34,
+			},
+			false,
+		},
+		{
+			"gop only",
+			[]byte{0x00, 0x00, 0x01, 0xb8, 0x01, 0x02, 0x03, 0x04},
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.isRAP, IsRandomAccess(ca.byts))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Package mpeg1video contains utilities to work with MPEG-1/2 Video codecs.
+package mpeg1video
+
+import (
+	"fmt"
+)
+
+const (
+	// MaxFrameSize is the maximum size of a frame.
+	MaxFrameSize = 1 * 1024 * 1024
+)
+
+// StartCode is a MPEG-1/2 Video start code.
+// Specification: ISO 13818-2, Table 6-1
+type StartCode uint8
+
+// start codes.
+const (
+	PictureStartCode         StartCode = 0x00
+	SliceStartCodeFirst      StartCode = 0x01
+	SliceStartCodeLast       StartCode = 0xAF
+	UserDataStartCode        StartCode = 0xB2
+	SequenceHeaderStartCode  StartCode = 0xB3
+	SequenceErrorCode        StartCode = 0xB4
+	ExtensionStartCode       StartCode = 0xB5
+	SequenceEndCode          StartCode = 0xB7
+	GroupOfPicturesStartCode StartCode = 0xB8
+)
+
+// ExtensionStartCodeIdentifier is the identifier of an extension.
+// Specification: ISO 13818-2, Table 6-2
+type ExtensionStartCodeIdentifier uint8
+
+// extension start code identifiers.
+const (
+	SequenceExtensionID                ExtensionStartCodeIdentifier = 1
+	SequenceDisplayExtensionID         ExtensionStartCodeIdentifier = 2
+	QuantMatrixExtensionID             ExtensionStartCodeIdentifier = 3
+	CopyrightExtensionID               ExtensionStartCodeIdentifier = 4
+	SequenceScalableExtensionID        ExtensionStartCodeIdentifier = 5
+	PictureDisplayExtensionID          ExtensionStartCodeIdentifier = 7
+	PictureCodingExtensionID           ExtensionStartCodeIdentifier = 8
+	PictureSpatialScalableExtensionID  ExtensionStartCodeIdentifier = 9
+	PictureTemporalScalableExtensionID ExtensionStartCodeIdentifier = 10
+)
+
+// nextStartCode returns the position of the next start code prefix, starting from pos.
+// It returns -1 when no start code is found.
+func nextStartCode(buf []byte, pos int) int {
+	for i := pos; i < len(buf)-3; i++ {
+		if buf[i] == 0 && buf[i+1] == 0 && buf[i+2] == 1 {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkStartCode checks that a buffer begins with the given start code.
+func checkStartCode(buf []byte, startCode StartCode) error {
+	if len(buf) < 4 || buf[0] != 0 || buf[1] != 0 || buf[2] != 1 || StartCode(buf[3]) != startCode {
+		return fmt.Errorf("start code 0x%.2x not found", uint8(startCode))
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package mpeg1video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// PictureStructure is a picture structure.
+// Specification: ISO 13818-2, Table 6-14
+type PictureStructure uint8
+
+// picture structures.
+const (
+	PictureStructureTopField    PictureStructure = 1
+	PictureStructureBottomField PictureStructure = 2
+	PictureStructureFrame       PictureStructure = 3
+)
+
+// PictureCodingExtensionCompositeDisplay contains the composite display fields
+// of a picture coding extension.
+type PictureCodingExtensionCompositeDisplay struct {
+	VAxis           bool
+	FieldSequence   uint8
+	SubCarrier      bool
+	BurstAmplitude  uint8
+	SubCarrierPhase uint8
+}
+
+// PictureCodingExtension is a MPEG-2 Video picture coding extension.
+// Specification: ISO 13818-2, section 6.2.3.1
+type PictureCodingExtension struct {
+	FCode                    [2][2]uint8
+	IntraDCPrecision         uint8
+	PictureStructure         PictureStructure
+	TopFieldFirst            bool
+	FramePredFrameDCT        bool
+	ConcealmentMotionVectors bool
+	QScaleType               bool
+	IntraVLCFormat           bool
+	AlternateScan            bool
+	RepeatFirstField         bool
+	Chroma420Type            bool
+	ProgressiveFrame         bool
+	CompositeDisplay         *PictureCodingExtensionCompositeDisplay
+}
+
+// Unmarshal decodes a PictureCodingExtension, including its start code.
+func (e *PictureCodingExtension) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, ExtensionStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 34)
+	if err != nil {
+		return err
+	}
+
+	id := ExtensionStartCodeIdentifier(bits.ReadBitsUnsafe(buf, &pos, 4))
+	if id != PictureCodingExtensionID {
+		return fmt.Errorf("not a picture coding extension")
+	}
+
+	e.FCode[0][0] = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	e.FCode[0][1] = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	e.FCode[1][0] = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	e.FCode[1][1] = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	e.IntraDCPrecision = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.PictureStructure = PictureStructure(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.TopFieldFirst = bits.ReadFlagUnsafe(buf, &pos)
+	e.FramePredFrameDCT = bits.ReadFlagUnsafe(buf, &pos)
+	e.ConcealmentMotionVectors = bits.ReadFlagUnsafe(buf, &pos)
+	e.QScaleType = bits.ReadFlagUnsafe(buf, &pos)
+	e.IntraVLCFormat = bits.ReadFlagUnsafe(buf, &pos)
+	e.AlternateScan = bits.ReadFlagUnsafe(buf, &pos)
+	e.RepeatFirstField = bits.ReadFlagUnsafe(buf, &pos)
+	e.Chroma420Type = bits.ReadFlagUnsafe(buf, &pos)
+	e.ProgressiveFrame = bits.ReadFlagUnsafe(buf, &pos)
+
+	compositeDisplayFlag := bits.ReadFlagUnsafe(buf, &pos)
+
+	if compositeDisplayFlag {
+		err = bits.HasSpace(buf, pos, 20)
+		if err != nil {
+			return err
+		}
+
+		e.CompositeDisplay = &PictureCodingExtensionCompositeDisplay{
+			VAxis:           bits.ReadFlagUnsafe(buf, &pos),
+			FieldSequence:   uint8(bits.ReadBitsUnsafe(buf, &pos, 3)),
+			SubCarrier:      bits.ReadFlagUnsafe(buf, &pos),
+			BurstAmplitude:  uint8(bits.ReadBitsUnsafe(buf, &pos, 7)),
+			SubCarrierPhase: uint8(bits.ReadBitsUnsafe(buf, &pos, 8)),
+		}
+	} else {
+		e.CompositeDisplay = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPictureCodingExtension = []struct {
+	name string
+	byts []byte
+	dec  PictureCodingExtension
+}{
+	{
+		"frame",
+		[]byte{0x00, 0x00, 0x01, 0xb5, 0x81, 0x23, 0x4b, 0xaa, 0x00},
+		PictureCodingExtension{
+			FCode:                    [2][2]uint8{{1, 2}, {3, 4}},
+			IntraDCPrecision:         2,
+			PictureStructure:         PictureStructureFrame,
+			TopFieldFirst:            true,
+			ConcealmentMotionVectors: true,
+			IntraVLCFormat:           true,
+			RepeatFirstField:         true,
+		},
+	},
+	{
+		"field with composite display",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb5, 0x8f, 0xff, 0xf1, 0x55,
+			0xf5, 0x93, 0x20,
+		},
+		PictureCodingExtension{
+			FCode:             [2][2]uint8{{15, 15}, {15, 15}},
+			PictureStructure:  PictureStructureTopField,
+			FramePredFrameDCT: true,
+			QScaleType:        true,
+			AlternateScan:     true,
+			Chroma420Type:     true,
+			ProgressiveFrame:  true,
+			CompositeDisplay: &PictureCodingExtensionCompositeDisplay{
+				VAxis:           true,
+				FieldSequence:   5,
+				BurstAmplitude:  100,
+				SubCarrierPhase: 200,
+			},
+		},
+	},
+}
+
+func TestPictureCodingExtensionUnmarshal(t *testing.T) {
+	for _, ca := range casesPictureCodingExtension {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec PictureCodingExtension
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func FuzzPictureCodingExtensionUnmarshal(f *testing.F) {
+	for _, ca := range casesPictureCodingExtension {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec PictureCodingExtension
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,83 @@
+package mpeg1video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// PictureCodingType is a picture coding type.
+// Specification: ISO 13818-2, Table 6-12
+type PictureCodingType uint8
+
+// picture coding types.
+const (
+	PictureCodingTypeI PictureCodingType = 1
+	PictureCodingTypeP PictureCodingType = 2
+	PictureCodingTypeB PictureCodingType = 3
+	PictureCodingTypeD PictureCodingType = 4 // MPEG-1 only
+)
+
+// PictureHeader is a MPEG-1/2 Video picture header.
+// Specification: ISO 13818-2, section 6.2.3
+type PictureHeader struct {
+	TemporalReference     uint16
+	PictureCodingType     PictureCodingType
+	VBVDelay              uint16
+	FullPelForwardVector  bool
+	ForwardFCode          uint8
+	FullPelBackwardVector bool
+	BackwardFCode         uint8
+}
+
+// Unmarshal decodes a PictureHeader, including its start code.
+func (h *PictureHeader) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, PictureStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 29)
+	if err != nil {
+		return err
+	}
+
+	h.TemporalReference = uint16(bits.ReadBitsUnsafe(buf, &pos, 10))
+	h.PictureCodingType = PictureCodingType(bits.ReadBitsUnsafe(buf, &pos, 3))
+	h.VBVDelay = uint16(bits.ReadBitsUnsafe(buf, &pos, 16))
+
+	if h.PictureCodingType == 0 || h.PictureCodingType > PictureCodingTypeD {
+		return fmt.Errorf("invalid picture_coding_type: %d", h.PictureCodingType)
+	}
+
+	if h.PictureCodingType == PictureCodingTypeP || h.PictureCodingType == PictureCodingTypeB {
+		err = bits.HasSpace(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+
+		h.FullPelForwardVector = bits.ReadFlagUnsafe(buf, &pos)
+		h.ForwardFCode = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	} else {
+		h.FullPelForwardVector = false
+		h.ForwardFCode = 0
+	}
+
+	if h.PictureCodingType == PictureCodingTypeB {
+		err = bits.HasSpace(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+
+		h.FullPelBackwardVector = bits.ReadFlagUnsafe(buf, &pos)
+		h.BackwardFCode = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	} else {
+		h.FullPelBackwardVector = false
+		h.BackwardFCode = 0
+	}
+
+	return nil
+}
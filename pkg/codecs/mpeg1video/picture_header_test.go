@@ -0,0 +1,56 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPictureHeader = []struct {
+	name string
+	byts []byte
+	dec  PictureHeader
+}{
+	{
+		"i",
+		[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x8f, 0xff, 0xf8},
+		PictureHeader{
+			TemporalReference: 2,
+			PictureCodingType: PictureCodingTypeI,
+			VBVDelay:          0xffff,
+		},
+	},
+	{
+		"b",
+		[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x18, 0x91, 0xa5, 0x90},
+		PictureHeader{
+			PictureCodingType:    PictureCodingTypeB,
+			VBVDelay:             0x1234,
+			FullPelForwardVector: true,
+			ForwardFCode:         3,
+			BackwardFCode:        2,
+		},
+	},
+}
+
+func TestPictureHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesPictureHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec PictureHeader
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func FuzzPictureHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesPictureHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec PictureHeader
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
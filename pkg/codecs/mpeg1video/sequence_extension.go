@@ -0,0 +1,103 @@
+package mpeg1video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// Profile is a MPEG-2 Video profile.
+// Specification: ISO 13818-2, Table 8-2
+type Profile uint8
+
+// profiles.
+const (
+	ProfileHigh              Profile = 1
+	ProfileSpatiallyScalable Profile = 2
+	ProfileSNRScalable       Profile = 3
+	ProfileMain              Profile = 4
+	ProfileSimple            Profile = 5
+)
+
+// Level is a MPEG-2 Video level.
+// Specification: ISO 13818-2, Table 8-3
+type Level uint8
+
+// levels.
+const (
+	LevelHigh     Level = 4
+	LevelHigh1440 Level = 6
+	LevelMain     Level = 8
+	LevelLow      Level = 10
+)
+
+// SequenceExtension is a MPEG-2 Video sequence extension.
+// Specification: ISO 13818-2, section 6.2.2.3
+type SequenceExtension struct {
+	ProfileAndLevelIndication uint8
+	ProgressiveSequence       bool
+	ChromaFormat              uint8
+	HorizontalSizeExtension   uint8
+	VerticalSizeExtension     uint8
+	BitRateExtension          uint16
+	VBVBufferSizeExtension    uint8
+	LowDelay                  bool
+	FrameRateExtensionN       uint8
+	FrameRateExtensionD       uint8
+}
+
+// Unmarshal decodes a SequenceExtension, including its start code.
+func (e *SequenceExtension) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, ExtensionStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 48)
+	if err != nil {
+		return err
+	}
+
+	id := ExtensionStartCodeIdentifier(bits.ReadBitsUnsafe(buf, &pos, 4))
+	if id != SequenceExtensionID {
+		return fmt.Errorf("not a sequence extension")
+	}
+
+	e.ProfileAndLevelIndication = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+	e.ProgressiveSequence = bits.ReadFlagUnsafe(buf, &pos)
+	e.ChromaFormat = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.HorizontalSizeExtension = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.VerticalSizeExtension = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.BitRateExtension = uint16(bits.ReadBitsUnsafe(buf, &pos, 12))
+
+	markerBit := bits.ReadFlagUnsafe(buf, &pos)
+	if !markerBit {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	e.VBVBufferSizeExtension = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+	e.LowDelay = bits.ReadFlagUnsafe(buf, &pos)
+	e.FrameRateExtensionN = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	e.FrameRateExtensionD = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+
+	return nil
+}
+
+// IsEscape returns whether profile_and_level_indication uses the escape bit,
+// that is used by the 4:2:2 and multi-view profiles.
+func (e SequenceExtension) IsEscape() bool {
+	return (e.ProfileAndLevelIndication & 0x80) != 0
+}
+
+// Profile returns the profile.
+func (e SequenceExtension) Profile() Profile {
+	return Profile((e.ProfileAndLevelIndication >> 4) & 0x07)
+}
+
+// Level returns the level.
+func (e SequenceExtension) Level() Level {
+	return Level(e.ProfileAndLevelIndication & 0x0F)
+}
@@ -0,0 +1,75 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSequenceExtension = []struct {
+	name    string
+	byts    []byte
+	dec     SequenceExtension
+	escape  bool
+	profile Profile
+	level   Level
+}{
+	{
+		"main profile, high level",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb5, 0x14, 0x4a, 0x00, 0x01,
+			0x00, 0x00,
+		},
+		SequenceExtension{
+			ProfileAndLevelIndication: 0x44,
+			ProgressiveSequence:       true,
+			ChromaFormat:              1,
+		},
+		false,
+		ProfileMain,
+		LevelHigh,
+	},
+	{
+		"4:2:2 profile, low delay",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb5, 0x18, 0x54, 0x80, 0x07,
+			0x00, 0xa0,
+		},
+		SequenceExtension{
+			ProfileAndLevelIndication: 0x85,
+			ChromaFormat:              2,
+			HorizontalSizeExtension:   1,
+			BitRateExtension:          3,
+			LowDelay:                  true,
+			FrameRateExtensionN:       1,
+		},
+		true,
+		0,
+		5,
+	},
+}
+
+func TestSequenceExtensionUnmarshal(t *testing.T) {
+	for _, ca := range casesSequenceExtension {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec SequenceExtension
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, ca.escape, dec.IsEscape())
+			require.Equal(t, ca.profile, dec.Profile())
+			require.Equal(t, ca.level, dec.Level())
+		})
+	}
+}
+
+func FuzzSequenceExtensionUnmarshal(f *testing.F) {
+	for _, ca := range casesSequenceExtension {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec SequenceExtension
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,104 @@
+package mpeg1video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// frame rates.
+// Specification: ISO 13818-2, Table 6-4
+var frameRates = map[uint8][2]int64{
+	1: {24000, 1001},
+	2: {24, 1},
+	3: {25, 1},
+	4: {30000, 1001},
+	5: {30, 1},
+	6: {50, 1},
+	7: {60000, 1001},
+	8: {60, 1},
+}
+
+func readQuantiserMatrix(buf []byte, pos *int) ([]uint8, error) {
+	err := bits.HasSpace(buf, *pos, 64*8)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make([]uint8, 64)
+	for i := range m {
+		m[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+
+	return m, nil
+}
+
+// SequenceHeader is a MPEG-1/2 Video sequence header.
+// Specification: ISO 13818-2, section 6.2.2.1
+type SequenceHeader struct {
+	HorizontalSizeValue       uint16
+	VerticalSizeValue         uint16
+	AspectRatioInformation    uint8
+	FrameRateCode             uint8
+	BitRateValue              uint32
+	VBVBufferSizeValue        uint16
+	ConstrainedParametersFlag bool
+	IntraQuantiserMatrix      []uint8 // nil when the default one is used
+	NonIntraQuantiserMatrix   []uint8 // nil when the default one is used
+}
+
+// Unmarshal decodes a SequenceHeader, including its start code.
+func (h *SequenceHeader) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, SequenceHeaderStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 64)
+	if err != nil {
+		return err
+	}
+
+	h.HorizontalSizeValue = uint16(bits.ReadBitsUnsafe(buf, &pos, 12))
+	h.VerticalSizeValue = uint16(bits.ReadBitsUnsafe(buf, &pos, 12))
+	h.AspectRatioInformation = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameRateCode = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.BitRateValue = uint32(bits.ReadBitsUnsafe(buf, &pos, 18))
+
+	markerBit := bits.ReadFlagUnsafe(buf, &pos)
+	if !markerBit {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	h.VBVBufferSizeValue = uint16(bits.ReadBitsUnsafe(buf, &pos, 10))
+	h.ConstrainedParametersFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	loadIntraQuantiserMatrix := bits.ReadFlagUnsafe(buf, &pos)
+	if loadIntraQuantiserMatrix {
+		h.IntraQuantiserMatrix, err = readQuantiserMatrix(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.IntraQuantiserMatrix = nil
+	}
+
+	loadNonIntraQuantiserMatrix, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if loadNonIntraQuantiserMatrix {
+		h.NonIntraQuantiserMatrix, err = readQuantiserMatrix(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		h.NonIntraQuantiserMatrix = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package mpeg1video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesSequenceHeader = []struct {
+	name string
+	byts []byte
+	dec  SequenceHeader
+}{
+	{
+		"mpeg-2",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x78, 0x04, 0x38, 0x35,
+			0xff, 0xff, 0xe0, 0x18,
+		},
+		SequenceHeader{
+			HorizontalSizeValue:    1920,
+			VerticalSizeValue:      1080,
+			AspectRatioInformation: 3,
+			FrameRateCode:          5,
+			BitRateValue:           0x3ffff,
+			VBVBufferSizeValue:     3,
+		},
+	},
+	{
+		"mpeg-1 with intra quantiser matrix",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb3, 0x16, 0x00, 0xf0, 0xc4,
+			0x01, 0x1f, 0xa0, 0xa6, 0x10, 0x12, 0x14, 0x16,
+			0x18, 0x1a, 0x1c, 0x1e, 0x20, 0x22, 0x24, 0x26,
+			0x28, 0x2a, 0x2c, 0x2e, 0x30, 0x32, 0x34, 0x36,
+			0x38, 0x3a, 0x3c, 0x3e, 0x40, 0x42, 0x44, 0x46,
+			0x48, 0x4a, 0x4c, 0x4e, 0x50, 0x52, 0x54, 0x56,
+			0x58, 0x5a, 0x5c, 0x5e, 0x60, 0x62, 0x64, 0x66,
+			0x68, 0x6a, 0x6c, 0x6e, 0x70, 0x72, 0x74, 0x76,
+			0x78, 0x7a, 0x7c, 0x7e, 0x80, 0x82, 0x84, 0x86,
+			0x88, 0x8a, 0x8c, 0x8e,
+		},
+		SequenceHeader{
+			HorizontalSizeValue:       352,
+			VerticalSizeValue:         240,
+			AspectRatioInformation:    12,
+			FrameRateCode:             4,
+			BitRateValue:              1150,
+			VBVBufferSizeValue:        20,
+			ConstrainedParametersFlag: true,
+			IntraQuantiserMatrix: []uint8{
+				8, 9, 10, 11, 12, 13, 14, 15,
+				16, 17, 18, 19, 20, 21, 22, 23,
+				24, 25, 26, 27, 28, 29, 30, 31,
+				32, 33, 34, 35, 36, 37, 38, 39,
+				40, 41, 42, 43, 44, 45, 46, 47,
+				48, 49, 50, 51, 52, 53, 54, 55,
+				56, 57, 58, 59, 60, 61, 62, 63,
+				64, 65, 66, 67, 68, 69, 70, 71,
+			},
+		},
+	},
+}
+
+func TestSequenceHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesSequenceHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec SequenceHeader
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func FuzzSequenceHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesSequenceHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec SequenceHeader
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -34,6 +34,19 @@ type AudioSpecificConfig struct {
 	FrameLengthFlag    bool
 	DependsOnCoreCoder bool
 	CoreCoderDelay     uint16
+
+	// ER AAC-LD / ER AAC-ELD specific
+	AACSectionDataResilienceFlag     bool
+	AACScalefactorDataResilienceFlag bool
+	AACSpectralDataResilienceFlag    bool
+	EPConfig                         uint8
+
+	// ELDSpecificConfig
+	LDSBR         *LDSBRConfig
+	ELDExtensions []ELDExtension
+
+	// UsacConfig
+	USACConfig *USACConfig
 }
 
 // Unmarshal decodes a AudioSpecificConfig.
@@ -56,14 +69,14 @@ func (c *AudioSpecificConfig) UnmarshalFromPos(buf []byte, pos *int) error {
 
 // unmarshalBits decodes a AudioSpecificConfig.
 func (c *AudioSpecificConfig) unmarshalBits(buf []byte, pos *int) error {
-	tmp, err := bits.ReadBits(buf, pos, 5)
+	var err error
+	c.Type, err = readObjectType(buf, pos)
 	if err != nil {
 		return err
 	}
-	c.Type = ObjectType(tmp)
 
 	switch c.Type {
-	case ObjectTypeAACLC, ObjectTypeSBR, ObjectTypePS:
+	case ObjectTypeAACLC, ObjectTypeSBR, ObjectTypePS, ObjectTypeERAACLD, ObjectTypeERAACELD, ObjectTypeUSAC:
 	default:
 		return fmt.Errorf("unsupported object type: %d", c.Type)
 	}
@@ -78,6 +91,7 @@ func (c *AudioSpecificConfig) unmarshalBits(buf []byte, pos *int) error {
 		c.SampleRate = sampleRates[sampleRateIndex]
 
 	case sampleRateIndex == 0x0F:
+		var tmp uint64
 		tmp, err = bits.ReadBits(buf, pos, 24)
 		if err != nil {
 			return err
@@ -88,7 +102,7 @@ func (c *AudioSpecificConfig) unmarshalBits(buf []byte, pos *int) error {
 		return fmt.Errorf("invalid sample rate index (%d)", sampleRateIndex)
 	}
 
-	tmp, err = bits.ReadBits(buf, pos, 4)
+	tmp, err := bits.ReadBits(buf, pos, 4)
 	if err != nil {
 		return err
 	}
@@ -141,19 +155,53 @@ func (c *AudioSpecificConfig) unmarshalBits(buf []byte, pos *int) error {
 			return fmt.Errorf("invalid extension sample rate index: %d", extensionSamplingFrequencyIndex)
 		}
 
-		tmp, err = bits.ReadBits(buf, pos, 5)
+		c.Type, err = readObjectType(buf, pos)
 		if err != nil {
 			return err
 		}
-		c.Type = ObjectType(tmp)
 
 		if c.Type != ObjectTypeAACLC {
 			return fmt.Errorf("unsupported object type: %d", c.Type)
 		}
 	}
 
-	// GASpecificConfig
+	switch c.Type {
+	case ObjectTypeERAACELD:
+		err = c.unmarshalELDSpecificConfig(buf, pos)
+		if err != nil {
+			return err
+		}
+
+	case ObjectTypeUSAC:
+		c.USACConfig = &USACConfig{}
+		return c.USACConfig.unmarshalBits(buf, pos)
+
+	default:
+		err = c.unmarshalGASpecificConfig(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Type == ObjectTypeERAACLD || c.Type == ObjectTypeERAACELD {
+		tmp, err = bits.ReadBits(buf, pos, 2)
+		if err != nil {
+			return err
+		}
+		c.EPConfig = uint8(tmp)
 
+		if c.EPConfig > 1 {
+			return fmt.Errorf("epConfig = %d is not supported", c.EPConfig)
+		}
+	}
+
+	return nil
+}
+
+// unmarshalGASpecificConfig decodes a GASpecificConfig.
+// Specification: ISO 14496-3, Table 4.1
+func (c *AudioSpecificConfig) unmarshalGASpecificConfig(buf []byte, pos *int) error {
+	var err error
 	c.FrameLengthFlag, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
@@ -165,6 +213,7 @@ func (c *AudioSpecificConfig) unmarshalBits(buf []byte, pos *int) error {
 	}
 
 	if c.DependsOnCoreCoder {
+		var tmp uint64
 		tmp, err = bits.ReadBits(buf, pos, 14)
 		if err != nil {
 			return err
@@ -177,15 +226,35 @@ func (c *AudioSpecificConfig) unmarshalBits(buf []byte, pos *int) error {
 		return err
 	}
 
+	// extensionFlag is set for error resilient object types only.
+	if extensionFlag != (c.Type == ObjectTypeERAACLD) {
+		if extensionFlag {
+			return fmt.Errorf("extensionFlag is unsupported")
+		}
+		return fmt.Errorf("extensionFlag is not set")
+	}
+
 	if extensionFlag {
-		return fmt.Errorf("extensionFlag is unsupported")
+		err = bits.HasSpace(buf, *pos, 4)
+		if err != nil {
+			return err
+		}
+
+		c.AACSectionDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+		c.AACScalefactorDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+		c.AACSpectralDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+
+		extensionFlag3 := bits.ReadFlagUnsafe(buf, pos)
+		if extensionFlag3 {
+			return fmt.Errorf("extensionFlag3 is unsupported")
+		}
 	}
 
 	return nil
 }
 
 func (c AudioSpecificConfig) marshalSizeBits() int {
-	n := 5 + 4 + 2 + 1
+	n := 4
 
 	_, ok := reverseSampleRates[c.SampleRate]
 	if !ok {
@@ -201,11 +270,36 @@ func (c AudioSpecificConfig) marshalSizeBits() int {
 		} else {
 			n += 4
 		}
-		n += 5
+		n += objectTypeSize(c.Type)
+		n += objectTypeSize(c.ExtensionType)
+	} else {
+		n += objectTypeSize(c.Type)
 	}
 
-	if c.DependsOnCoreCoder {
-		n += 14
+	switch c.Type {
+	case ObjectTypeERAACELD:
+		n += c.marshalSizeELDSpecificConfigBits()
+
+	case ObjectTypeUSAC:
+		if c.USACConfig != nil {
+			n += c.USACConfig.marshalSizeBits()
+		}
+		return n
+
+	default:
+		n += 3
+
+		if c.DependsOnCoreCoder {
+			n += 14
+		}
+
+		if c.Type == ObjectTypeERAACLD {
+			n += 4
+		}
+	}
+
+	if c.Type == ObjectTypeERAACLD || c.Type == ObjectTypeERAACELD {
+		n += 2
 	}
 
 	return n
@@ -236,10 +330,19 @@ func (c AudioSpecificConfig) Marshal() ([]byte, error) {
 }
 
 func (c AudioSpecificConfig) marshalToBits(buf []byte, pos *int) error {
+	var err error
+
 	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.ExtensionType), 5)
+		if c.Type != ObjectTypeAACLC {
+			return fmt.Errorf("unsupported object type: %d", c.Type)
+		}
+
+		err = writeObjectType(buf, pos, c.ExtensionType)
 	} else {
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.Type), 5)
+		err = writeObjectType(buf, pos, c.Type)
+	}
+	if err != nil {
+		return err
 	}
 
 	sampleRateIndex, ok := reverseSampleRates[c.SampleRate]
@@ -276,17 +379,50 @@ func (c AudioSpecificConfig) marshalToBits(buf []byte, pos *int) error {
 		} else {
 			bits.WriteBitsUnsafe(buf, pos, uint64(sampleRateIndex), 4)
 		}
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.Type), 5)
+		err = writeObjectType(buf, pos, c.Type)
+		if err != nil {
+			return err
+		}
 	}
 
-	bits.WriteFlagUnsafe(buf, pos, c.FrameLengthFlag)
-	bits.WriteFlagUnsafe(buf, pos, c.DependsOnCoreCoder)
+	switch c.Type {
+	case ObjectTypeERAACELD:
+		err = c.marshalELDSpecificConfigToBits(buf, pos)
+		if err != nil {
+			return err
+		}
 
-	if c.DependsOnCoreCoder {
-		bits.WriteBitsUnsafe(buf, pos, uint64(c.CoreCoderDelay), 14)
+	case ObjectTypeUSAC:
+		if c.USACConfig == nil {
+			return fmt.Errorf("USAC configuration is missing")
+		}
+		return c.USACConfig.marshalToBits(buf, pos)
+
+	default:
+		bits.WriteFlagUnsafe(buf, pos, c.FrameLengthFlag)
+		bits.WriteFlagUnsafe(buf, pos, c.DependsOnCoreCoder)
+
+		if c.DependsOnCoreCoder {
+			bits.WriteBitsUnsafe(buf, pos, uint64(c.CoreCoderDelay), 14)
+		}
+
+		if c.Type == ObjectTypeERAACLD {
+			bits.WriteFlagUnsafe(buf, pos, true) // extensionFlag
+			bits.WriteFlagUnsafe(buf, pos, c.AACSectionDataResilienceFlag)
+			bits.WriteFlagUnsafe(buf, pos, c.AACScalefactorDataResilienceFlag)
+			bits.WriteFlagUnsafe(buf, pos, c.AACSpectralDataResilienceFlag)
+			*pos++ // extensionFlag3
+		} else {
+			*pos++ // extensionFlag
+		}
 	}
 
-	*pos++ // extensionFlag
+	if c.Type == ObjectTypeERAACLD || c.Type == ObjectTypeERAACELD {
+		if c.EPConfig > 1 {
+			return fmt.Errorf("epConfig = %d is not supported", c.EPConfig)
+		}
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.EPConfig), 2)
+	}
 
 	return nil
 }
@@ -132,6 +132,115 @@ var audioSpecificConfigCases = []struct {
 			ExtensionType:       ObjectTypePS,
 		},
 	},
+	{
+		"er aac-ld 48khz stereo",
+		[]byte{0xb9, 0x95, 0x00},
+		AudioSpecificConfig{
+			Type:            ObjectTypeERAACLD,
+			SampleRate:      48000,
+			ChannelConfig:   2,
+			ChannelCount:    2,
+			FrameLengthFlag: true,
+		},
+	},
+	{
+		"er aac-eld 48khz stereo sbr",
+		[]byte{0xf8, 0xe6, 0x41, 0xab, 0x20, 0xac, 0x00},
+		AudioSpecificConfig{
+			Type:          ObjectTypeERAACELD,
+			SampleRate:    48000,
+			ChannelConfig: 2,
+			ChannelCount:  2,
+			LDSBR: &LDSBRConfig{
+				SamplingRate: true,
+				Headers: []SBRHeader{{
+					AmpRes:       true,
+					StartFreq:    5,
+					StopFreq:     9,
+					HeaderExtra1: true,
+					FreqScale:    2,
+					AlterScale:   true,
+					NoiseBands:   2,
+				}},
+			},
+		},
+	},
+	{
+		"er aac-eld 16khz mono extension",
+		[]byte{0xf8, 0xf0, 0x30, 0x21, 0xaa, 0x00},
+		AudioSpecificConfig{
+			Type:            ObjectTypeERAACELD,
+			SampleRate:      16000,
+			ChannelConfig:   1,
+			ChannelCount:    1,
+			FrameLengthFlag: true,
+			ELDExtensions: []ELDExtension{{
+				Type: 2,
+				Data: []byte{0xaa},
+			}},
+		},
+	},
+	{
+		"usac 48khz stereo sbr mps",
+		[]byte{
+			0xf9, 0x46, 0x43, 0x62, 0x1c, 0xc0, 0x50, 0x04,
+			0x24, 0x04, 0x84, 0x40, 0x20, 0x40,
+		},
+		AudioSpecificConfig{
+			Type:          ObjectTypeUSAC,
+			SampleRate:    48000,
+			ChannelConfig: 2,
+			ChannelCount:  2,
+			USACConfig: &USACConfig{
+				SampleRate:                48000,
+				CoreSBRFrameLengthIndex:   3,
+				ChannelConfigurationIndex: 2,
+				Elements: []USACElementConfig{
+					{
+						Type: USACElementTypeEXT,
+						ExtElementConfig: &USACExtElementConfig{
+							Type:   3,
+							Config: []byte{},
+						},
+					},
+					{
+						Type:         USACElementTypeCPE,
+						NoiseFilling: true,
+						SBRConfig: &USACSBRConfig{
+							DfltStopFreq: 2,
+						},
+						StereoConfigIndex: 1,
+						MPS212Config: &USACMPS212Config{
+							FreqRes:      1,
+							HighRateMode: true,
+						},
+					},
+				},
+				ConfigExtensions: []USACConfigExtension{{
+					Type: 2,
+					Data: []byte{0x01, 0x02},
+				}},
+			},
+		},
+	},
+	{
+		"usac 44.1khz mono channel_config=0",
+		[]byte{0xf9, 0x48, 0x04, 0x20, 0x08, 0x80, 0xc0},
+		AudioSpecificConfig{
+			Type:       ObjectTypeUSAC,
+			SampleRate: 44100,
+			USACConfig: &USACConfig{
+				SampleRate:              44100,
+				CoreSBRFrameLengthIndex: 1,
+				OutputChannelPositions:  []uint8{2},
+				Elements: []USACElementConfig{{
+					Type:         USACElementTypeSCE,
+					TWMDCT:       true,
+					NoiseFilling: true,
+				}},
+			},
+		},
+	},
 }
 
 func TestAudioSpecificConfigUnmarshal(t *testing.T) {
@@ -0,0 +1,313 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// SBRHeader is a SBR header.
+// Specification: ISO 14496-3, Table 4.63
+type SBRHeader struct {
+	AmpRes        bool
+	StartFreq     uint8
+	StopFreq      uint8
+	XoverBand     uint8
+	HeaderExtra1  bool
+	FreqScale     uint8
+	AlterScale    bool
+	NoiseBands    uint8
+	HeaderExtra2  bool
+	LimiterBands  uint8
+	LimiterGains  uint8
+	InterpolFreq  bool
+	SmoothingMode bool
+}
+
+func (h *SBRHeader) unmarshalBits(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 16)
+	if err != nil {
+		return err
+	}
+
+	h.AmpRes = bits.ReadFlagUnsafe(buf, pos)
+	h.StartFreq = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	h.StopFreq = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	h.XoverBand = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	*pos += 2 // bs_reserved
+	h.HeaderExtra1 = bits.ReadFlagUnsafe(buf, pos)
+	h.HeaderExtra2 = bits.ReadFlagUnsafe(buf, pos)
+
+	if h.HeaderExtra1 {
+		err = bits.HasSpace(buf, *pos, 5)
+		if err != nil {
+			return err
+		}
+
+		h.FreqScale = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		h.AlterScale = bits.ReadFlagUnsafe(buf, pos)
+		h.NoiseBands = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	}
+
+	if h.HeaderExtra2 {
+		err = bits.HasSpace(buf, *pos, 6)
+		if err != nil {
+			return err
+		}
+
+		h.LimiterBands = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		h.LimiterGains = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		h.InterpolFreq = bits.ReadFlagUnsafe(buf, pos)
+		h.SmoothingMode = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	return nil
+}
+
+func (h SBRHeader) marshalSizeBits() int {
+	n := 16
+
+	if h.HeaderExtra1 {
+		n += 5
+	}
+
+	if h.HeaderExtra2 {
+		n += 6
+	}
+
+	return n
+}
+
+func (h SBRHeader) marshalToBits(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, h.AmpRes)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.StartFreq), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.StopFreq), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(h.XoverBand), 3)
+	*pos += 2 // bs_reserved
+	bits.WriteFlagUnsafe(buf, pos, h.HeaderExtra1)
+	bits.WriteFlagUnsafe(buf, pos, h.HeaderExtra2)
+
+	if h.HeaderExtra1 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.FreqScale), 2)
+		bits.WriteFlagUnsafe(buf, pos, h.AlterScale)
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.NoiseBands), 2)
+	}
+
+	if h.HeaderExtra2 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.LimiterBands), 2)
+		bits.WriteBitsUnsafe(buf, pos, uint64(h.LimiterGains), 2)
+		bits.WriteFlagUnsafe(buf, pos, h.InterpolFreq)
+		bits.WriteFlagUnsafe(buf, pos, h.SmoothingMode)
+	}
+}
+
+// LDSBRConfig is the low delay SBR configuration of a ELDSpecificConfig.
+type LDSBRConfig struct {
+	SamplingRate bool
+	CRCFlag      bool
+	Headers      []SBRHeader
+}
+
+// ELDExtension is an extension of a ELDSpecificConfig.
+type ELDExtension struct {
+	Type uint8
+	Data []byte
+}
+
+// ldSBRHeaderCount returns the number of SBR headers contained in ld_sbr_header().
+// Specification: ISO 14496-3, Table 4.181
+func ldSBRHeaderCount(channelConfig uint8) int {
+	switch channelConfig {
+	case 1, 2:
+		return 1
+
+	case 3:
+		return 2
+
+	case 4, 5, 6:
+		return 3
+
+	case 7:
+		return 4
+
+	default:
+		return 0
+	}
+}
+
+// unmarshalELDSpecificConfig decodes a ELDSpecificConfig.
+// Specification: ISO 14496-3, Table 4.180
+func (c *AudioSpecificConfig) unmarshalELDSpecificConfig(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 5)
+	if err != nil {
+		return err
+	}
+
+	c.FrameLengthFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.AACSectionDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.AACScalefactorDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+	c.AACSpectralDataResilienceFlag = bits.ReadFlagUnsafe(buf, pos)
+	ldSBRPresentFlag := bits.ReadFlagUnsafe(buf, pos)
+
+	if ldSBRPresentFlag {
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		c.LDSBR = &LDSBRConfig{
+			SamplingRate: bits.ReadFlagUnsafe(buf, pos),
+			CRCFlag:      bits.ReadFlagUnsafe(buf, pos),
+			Headers:      make([]SBRHeader, ldSBRHeaderCount(c.ChannelConfig)),
+		}
+
+		for i := range c.LDSBR.Headers {
+			err = c.LDSBR.Headers[i].unmarshalBits(buf, pos)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		c.LDSBR = nil
+	}
+
+	c.ELDExtensions = nil
+
+	for {
+		var eldExtType uint64
+		eldExtType, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+
+		// ELDEXT_TERM
+		if eldExtType == 0 {
+			break
+		}
+
+		var eldExtLen uint64
+		eldExtLen, err = bits.ReadBits(buf, pos, 4)
+		if err != nil {
+			return err
+		}
+
+		if eldExtLen == 15 {
+			var eldExtLenAdd uint64
+			eldExtLenAdd, err = bits.ReadBits(buf, pos, 8)
+			if err != nil {
+				return err
+			}
+			eldExtLen += eldExtLenAdd
+
+			if eldExtLenAdd == 255 {
+				var eldExtLenAddAdd uint64
+				eldExtLenAddAdd, err = bits.ReadBits(buf, pos, 16)
+				if err != nil {
+					return err
+				}
+				eldExtLen += eldExtLenAddAdd
+			}
+		}
+
+		err = bits.HasSpace(buf, *pos, int(eldExtLen)*8)
+		if err != nil {
+			return err
+		}
+
+		ext := ELDExtension{
+			Type: uint8(eldExtType),
+			Data: make([]byte, eldExtLen),
+		}
+
+		for i := range ext.Data {
+			ext.Data[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+		}
+
+		c.ELDExtensions = append(c.ELDExtensions, ext)
+	}
+
+	return nil
+}
+
+func (c AudioSpecificConfig) marshalSizeELDSpecificConfigBits() int {
+	n := 5
+
+	if c.LDSBR != nil {
+		n += 2
+		for _, h := range c.LDSBR.Headers {
+			n += h.marshalSizeBits()
+		}
+	}
+
+	for _, ext := range c.ELDExtensions {
+		n += 8
+
+		switch {
+		case len(ext.Data) >= (15 + 255):
+			n += 24
+
+		case len(ext.Data) >= 15:
+			n += 8
+		}
+
+		n += len(ext.Data) * 8
+	}
+
+	n += 4 // ELDEXT_TERM
+
+	return n
+}
+
+func (c AudioSpecificConfig) marshalELDSpecificConfigToBits(buf []byte, pos *int) error {
+	bits.WriteFlagUnsafe(buf, pos, c.FrameLengthFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.AACSectionDataResilienceFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.AACScalefactorDataResilienceFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.AACSpectralDataResilienceFlag)
+	bits.WriteFlagUnsafe(buf, pos, c.LDSBR != nil)
+
+	if c.LDSBR != nil {
+		if len(c.LDSBR.Headers) != ldSBRHeaderCount(c.ChannelConfig) {
+			return fmt.Errorf("invalid SBR header count: %d", len(c.LDSBR.Headers))
+		}
+
+		bits.WriteFlagUnsafe(buf, pos, c.LDSBR.SamplingRate)
+		bits.WriteFlagUnsafe(buf, pos, c.LDSBR.CRCFlag)
+
+		for _, h := range c.LDSBR.Headers {
+			h.marshalToBits(buf, pos)
+		}
+	}
+
+	for _, ext := range c.ELDExtensions {
+		if ext.Type == 0 || ext.Type > 15 {
+			return fmt.Errorf("invalid ELD extension type: %d", ext.Type)
+		}
+
+		bits.WriteBitsUnsafe(buf, pos, uint64(ext.Type), 4)
+
+		switch {
+		case len(ext.Data) >= (15 + 255 + 65536):
+			return fmt.Errorf("ELD extension is too big")
+
+		case len(ext.Data) >= (15 + 255):
+			bits.WriteBitsUnsafe(buf, pos, 15, 4)
+			bits.WriteBitsUnsafe(buf, pos, 255, 8)
+			bits.WriteBitsUnsafe(buf, pos, uint64(len(ext.Data)-15-255), 16)
+
+		case len(ext.Data) >= 15:
+			bits.WriteBitsUnsafe(buf, pos, 15, 4)
+			bits.WriteBitsUnsafe(buf, pos, uint64(len(ext.Data)-15), 8)
+
+		default:
+			bits.WriteBitsUnsafe(buf, pos, uint64(len(ext.Data)), 4)
+		}
+
+		for _, b := range ext.Data {
+			bits.WriteBitsUnsafe(buf, pos, uint64(b), 8)
+		}
+	}
+
+	*pos += 4 // ELDEXT_TERM
+
+	return nil
+}
@@ -1,12 +1,63 @@
 package mpeg4audio
 
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
 // ObjectType is a MPEG-4 Audio object type.
 // Specification: ISO 14496-3, Table 1.17
 type ObjectType int
 
 // supported types.
 const (
-	ObjectTypeAACLC ObjectType = 2
-	ObjectTypeSBR   ObjectType = 5
-	ObjectTypePS    ObjectType = 29
+	ObjectTypeAACLC    ObjectType = 2
+	ObjectTypeSBR      ObjectType = 5
+	ObjectTypeERAACLD  ObjectType = 23
+	ObjectTypePS       ObjectType = 29
+	ObjectTypeERAACELD ObjectType = 39
+	ObjectTypeUSAC     ObjectType = 42
 )
+
+// readObjectType reads an object type, that is encoded with an escape value.
+// Specification: ISO 14496-3, Table 1.14
+func readObjectType(buf []byte, pos *int) (ObjectType, error) {
+	tmp, err := bits.ReadBits(buf, pos, 5)
+	if err != nil {
+		return 0, err
+	}
+
+	if tmp == 31 {
+		tmp, err = bits.ReadBits(buf, pos, 6)
+		if err != nil {
+			return 0, err
+		}
+		tmp += 32
+	}
+
+	return ObjectType(tmp), nil
+}
+
+func objectTypeSize(t ObjectType) int {
+	if t >= 32 {
+		return 11
+	}
+	return 5
+}
+
+func writeObjectType(buf []byte, pos *int, t ObjectType) error {
+	switch {
+	case t >= 0 && t < 31:
+		bits.WriteBitsUnsafe(buf, pos, uint64(t), 5)
+
+	case t >= 32 && t < 96:
+		bits.WriteBitsUnsafe(buf, pos, 31, 5)
+		bits.WriteBitsUnsafe(buf, pos, uint64(t-32), 6)
+
+	default:
+		return fmt.Errorf("invalid object type: %d", t)
+	}
+
+	return nil
+}
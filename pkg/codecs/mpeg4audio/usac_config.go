@@ -0,0 +1,839 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// Specification: ISO 23003-3, Table 72
+var usacSampleRates = map[uint8]int{
+	0x00: 96000,
+	0x01: 88200,
+	0x02: 64000,
+	0x03: 48000,
+	0x04: 44100,
+	0x05: 32000,
+	0x06: 24000,
+	0x07: 22050,
+	0x08: 16000,
+	0x09: 12000,
+	0x0A: 11025,
+	0x0B: 8000,
+	0x0C: 7350,
+	0x0F: 57600,
+	0x10: 51200,
+	0x11: 40000,
+	0x12: 38400,
+	0x13: 34150,
+	0x14: 28800,
+	0x15: 25600,
+	0x16: 20000,
+	0x17: 19200,
+	0x18: 17075,
+	0x19: 14400,
+	0x1A: 12800,
+	0x1B: 9600,
+}
+
+// USACElementType is a USAC element type.
+// Specification: ISO 23003-3, Table 18
+type USACElementType uint8
+
+// USAC element types.
+const (
+	USACElementTypeSCE USACElementType = 0
+	USACElementTypeCPE USACElementType = 1
+	USACElementTypeLFE USACElementType = 2
+	USACElementTypeEXT USACElementType = 3
+)
+
+// USACSBRConfig is a SbrConfig.
+// Specification: ISO 23003-3, Table 13
+type USACSBRConfig struct {
+	HarmonicSBR bool
+	InterTes    bool
+	PVC         bool
+
+	// SbrDfltHeader
+	DfltStartFreq     uint8
+	DfltStopFreq      uint8
+	DfltHeaderExtra1  bool
+	DfltFreqScale     uint8
+	DfltAlterScale    bool
+	DfltNoiseBands    uint8
+	DfltHeaderExtra2  bool
+	DfltLimiterBands  uint8
+	DfltLimiterGains  uint8
+	DfltInterpolFreq  bool
+	DfltSmoothingMode bool
+}
+
+// USACMPS212Config is a Mps212Config.
+// Specification: ISO 23003-3, Table 16
+type USACMPS212Config struct {
+	FreqRes              uint8
+	FixedGainDMX         uint8
+	TempShapeConfig      uint8
+	DecorrConfig         uint8
+	HighRateMode         bool
+	PhaseCoding          bool
+	OttBandsPhasePresent bool
+	OttBandsPhase        uint8
+	ResidualBands        uint8
+	PseudoLR             bool
+	EnvQuantMode         bool
+}
+
+// USACExtElementConfig is a UsacExtElementConfig.
+// Specification: ISO 23003-3, Table 17
+type USACExtElementConfig struct {
+	Type                 uint32
+	DefaultLengthPresent bool
+	DefaultLength        uint32
+	PayloadFrag          bool
+	Config               []byte
+}
+
+// USACElementConfig is the configuration of a USAC element.
+type USACElementConfig struct {
+	Type USACElementType
+
+	// UsacCoreConfig (SCE and CPE only)
+	TWMDCT       bool
+	NoiseFilling bool
+
+	// SCE and CPE only, present when SBR is enabled
+	SBRConfig *USACSBRConfig
+
+	// CPE only
+	StereoConfigIndex uint8
+	MPS212Config      *USACMPS212Config
+
+	// EXT only
+	ExtElementConfig *USACExtElementConfig
+}
+
+// USACConfigExtension is an extension of a UsacConfig.
+type USACConfigExtension struct {
+	Type uint32
+	Data []byte
+}
+
+// USACConfig is a UsacConfig.
+// Specification: ISO 23003-3, Table 5
+type USACConfig struct {
+	SampleRate                int
+	CoreSBRFrameLengthIndex   uint8
+	ChannelConfigurationIndex uint8
+	OutputChannelPositions    []uint8 // filled when ChannelConfigurationIndex is 0
+	Elements                  []USACElementConfig
+	ConfigExtensions          []USACConfigExtension
+}
+
+// readEscapedValue reads an escapedValue().
+// Specification: ISO 23003-3, Table 19
+func readEscapedValue(buf []byte, pos *int, nBits1 int, nBits2 int, nBits3 int) (uint32, error) {
+	v, err := bits.ReadBits(buf, pos, nBits1)
+	if err != nil {
+		return 0, err
+	}
+
+	if v == (1<<nBits1)-1 {
+		var v2 uint64
+		v2, err = bits.ReadBits(buf, pos, nBits2)
+		if err != nil {
+			return 0, err
+		}
+		v += v2
+
+		if nBits3 != 0 && v2 == (1<<nBits2)-1 {
+			var v3 uint64
+			v3, err = bits.ReadBits(buf, pos, nBits3)
+			if err != nil {
+				return 0, err
+			}
+			v += v3
+		}
+	}
+
+	return uint32(v), nil
+}
+
+func escapedValueSize(v uint32, nBits1 int, nBits2 int, nBits3 int) int {
+	max1 := uint32(1<<nBits1) - 1
+	if v < max1 {
+		return nBits1
+	}
+
+	max2 := uint32(1<<nBits2) - 1
+	if nBits3 == 0 || (v-max1) < max2 {
+		return nBits1 + nBits2
+	}
+
+	return nBits1 + nBits2 + nBits3
+}
+
+func writeEscapedValue(buf []byte, pos *int, v uint32, nBits1 int, nBits2 int, nBits3 int) error {
+	max1 := uint32(1<<nBits1) - 1
+	if v < max1 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(v), nBits1)
+		return nil
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(max1), nBits1)
+	v -= max1
+
+	max2 := uint32(1<<nBits2) - 1
+	if nBits3 == 0 || v < max2 {
+		if v > max2 {
+			return fmt.Errorf("value is too big")
+		}
+		bits.WriteBitsUnsafe(buf, pos, uint64(v), nBits2)
+		return nil
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(max2), nBits2)
+	v -= max2
+
+	if v > (uint32(1<<nBits3) - 1) {
+		return fmt.Errorf("value is too big")
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(v), nBits3)
+	return nil
+}
+
+// sbrRatioIndex returns the SBR ratio index associated with a coreSbrFrameLengthIndex.
+// Specification: ISO 23003-3, Table 70
+func sbrRatioIndex(coreSBRFrameLengthIndex uint8) (uint8, error) {
+	switch coreSBRFrameLengthIndex {
+	case 0, 1:
+		return 0, nil
+
+	case 2:
+		return 2, nil
+
+	case 3:
+		return 3, nil
+
+	case 4:
+		return 1, nil
+
+	default:
+		return 0, fmt.Errorf("invalid coreSbrFrameLengthIndex: %d", coreSBRFrameLengthIndex)
+	}
+}
+
+func (c *USACSBRConfig) unmarshalBits(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 13)
+	if err != nil {
+		return err
+	}
+
+	c.HarmonicSBR = bits.ReadFlagUnsafe(buf, pos)
+	c.InterTes = bits.ReadFlagUnsafe(buf, pos)
+	c.PVC = bits.ReadFlagUnsafe(buf, pos)
+	c.DfltStartFreq = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	c.DfltStopFreq = uint8(bits.ReadBitsUnsafe(buf, pos, 4))
+	c.DfltHeaderExtra1 = bits.ReadFlagUnsafe(buf, pos)
+	c.DfltHeaderExtra2 = bits.ReadFlagUnsafe(buf, pos)
+
+	if c.DfltHeaderExtra1 {
+		err = bits.HasSpace(buf, *pos, 5)
+		if err != nil {
+			return err
+		}
+
+		c.DfltFreqScale = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		c.DfltAlterScale = bits.ReadFlagUnsafe(buf, pos)
+		c.DfltNoiseBands = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	}
+
+	if c.DfltHeaderExtra2 {
+		err = bits.HasSpace(buf, *pos, 6)
+		if err != nil {
+			return err
+		}
+
+		c.DfltLimiterBands = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		c.DfltLimiterGains = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+		c.DfltInterpolFreq = bits.ReadFlagUnsafe(buf, pos)
+		c.DfltSmoothingMode = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	return nil
+}
+
+func (c USACSBRConfig) marshalSizeBits() int {
+	n := 13
+
+	if c.DfltHeaderExtra1 {
+		n += 5
+	}
+
+	if c.DfltHeaderExtra2 {
+		n += 6
+	}
+
+	return n
+}
+
+func (c USACSBRConfig) marshalToBits(buf []byte, pos *int) {
+	bits.WriteFlagUnsafe(buf, pos, c.HarmonicSBR)
+	bits.WriteFlagUnsafe(buf, pos, c.InterTes)
+	bits.WriteFlagUnsafe(buf, pos, c.PVC)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.DfltStartFreq), 4)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.DfltStopFreq), 4)
+	bits.WriteFlagUnsafe(buf, pos, c.DfltHeaderExtra1)
+	bits.WriteFlagUnsafe(buf, pos, c.DfltHeaderExtra2)
+
+	if c.DfltHeaderExtra1 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.DfltFreqScale), 2)
+		bits.WriteFlagUnsafe(buf, pos, c.DfltAlterScale)
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.DfltNoiseBands), 2)
+	}
+
+	if c.DfltHeaderExtra2 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.DfltLimiterBands), 2)
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.DfltLimiterGains), 2)
+		bits.WriteFlagUnsafe(buf, pos, c.DfltInterpolFreq)
+		bits.WriteFlagUnsafe(buf, pos, c.DfltSmoothingMode)
+	}
+}
+
+func (c *USACMPS212Config) unmarshalBits(buf []byte, pos *int, stereoConfigIndex uint8) error {
+	err := bits.HasSpace(buf, *pos, 13)
+	if err != nil {
+		return err
+	}
+
+	c.FreqRes = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	c.FixedGainDMX = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	c.TempShapeConfig = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	c.DecorrConfig = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	c.HighRateMode = bits.ReadFlagUnsafe(buf, pos)
+	c.PhaseCoding = bits.ReadFlagUnsafe(buf, pos)
+	c.OttBandsPhasePresent = bits.ReadFlagUnsafe(buf, pos)
+
+	if c.OttBandsPhasePresent {
+		tmp, err := bits.ReadBits(buf, pos, 5)
+		if err != nil {
+			return err
+		}
+		c.OttBandsPhase = uint8(tmp)
+	}
+
+	if stereoConfigIndex > 1 {
+		err = bits.HasSpace(buf, *pos, 6)
+		if err != nil {
+			return err
+		}
+
+		c.ResidualBands = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+		c.PseudoLR = bits.ReadFlagUnsafe(buf, pos)
+	}
+
+	if c.TempShapeConfig == 2 {
+		c.EnvQuantMode, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c USACMPS212Config) marshalSizeBits(stereoConfigIndex uint8) int {
+	n := 13
+
+	if c.OttBandsPhasePresent {
+		n += 5
+	}
+
+	if stereoConfigIndex > 1 {
+		n += 6
+	}
+
+	if c.TempShapeConfig == 2 {
+		n++
+	}
+
+	return n
+}
+
+func (c USACMPS212Config) marshalToBits(buf []byte, pos *int, stereoConfigIndex uint8) {
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.FreqRes), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.FixedGainDMX), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.TempShapeConfig), 2)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.DecorrConfig), 2)
+	bits.WriteFlagUnsafe(buf, pos, c.HighRateMode)
+	bits.WriteFlagUnsafe(buf, pos, c.PhaseCoding)
+	bits.WriteFlagUnsafe(buf, pos, c.OttBandsPhasePresent)
+
+	if c.OttBandsPhasePresent {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.OttBandsPhase), 5)
+	}
+
+	if stereoConfigIndex > 1 {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.ResidualBands), 5)
+		bits.WriteFlagUnsafe(buf, pos, c.PseudoLR)
+	}
+
+	if c.TempShapeConfig == 2 {
+		bits.WriteFlagUnsafe(buf, pos, c.EnvQuantMode)
+	}
+}
+
+func (c *USACExtElementConfig) unmarshalBits(buf []byte, pos *int) error {
+	var err error
+	c.Type, err = readEscapedValue(buf, pos, 4, 8, 16)
+	if err != nil {
+		return err
+	}
+
+	configLength, err := readEscapedValue(buf, pos, 4, 8, 16)
+	if err != nil {
+		return err
+	}
+
+	c.DefaultLengthPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if c.DefaultLengthPresent {
+		c.DefaultLength, err = readEscapedValue(buf, pos, 8, 16, 0)
+		if err != nil {
+			return err
+		}
+		c.DefaultLength++
+	} else {
+		c.DefaultLength = 0
+	}
+
+	c.PayloadFrag, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	err = bits.HasSpace(buf, *pos, int(configLength)*8)
+	if err != nil {
+		return err
+	}
+
+	c.Config = make([]byte, configLength)
+
+	for i := range c.Config {
+		c.Config[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	}
+
+	return nil
+}
+
+func (c USACExtElementConfig) marshalSizeBits() int {
+	n := escapedValueSize(c.Type, 4, 8, 16) +
+		escapedValueSize(uint32(len(c.Config)), 4, 8, 16) +
+		1
+
+	if c.DefaultLengthPresent && c.DefaultLength != 0 {
+		n += escapedValueSize(c.DefaultLength-1, 8, 16, 0)
+	}
+
+	n += 1 + len(c.Config)*8
+
+	return n
+}
+
+func (c USACExtElementConfig) marshalToBits(buf []byte, pos *int) error {
+	err := writeEscapedValue(buf, pos, c.Type, 4, 8, 16)
+	if err != nil {
+		return err
+	}
+
+	err = writeEscapedValue(buf, pos, uint32(len(c.Config)), 4, 8, 16)
+	if err != nil {
+		return err
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, c.DefaultLengthPresent)
+
+	if c.DefaultLengthPresent {
+		if c.DefaultLength == 0 {
+			return fmt.Errorf("invalid default length")
+		}
+
+		err = writeEscapedValue(buf, pos, c.DefaultLength-1, 8, 16, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, c.PayloadFrag)
+
+	for _, b := range c.Config {
+		bits.WriteBitsUnsafe(buf, pos, uint64(b), 8)
+	}
+
+	return nil
+}
+
+func (e *USACElementConfig) unmarshalBits(buf []byte, pos *int, sbrRatioIndex uint8) error {
+	tmp, err := bits.ReadBits(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+	e.Type = USACElementType(tmp)
+
+	switch e.Type {
+	case USACElementTypeSCE, USACElementTypeCPE:
+		err = bits.HasSpace(buf, *pos, 2)
+		if err != nil {
+			return err
+		}
+
+		e.TWMDCT = bits.ReadFlagUnsafe(buf, pos)
+		e.NoiseFilling = bits.ReadFlagUnsafe(buf, pos)
+
+		if sbrRatioIndex > 0 {
+			e.SBRConfig = &USACSBRConfig{}
+			err = e.SBRConfig.unmarshalBits(buf, pos)
+			if err != nil {
+				return err
+			}
+
+			if e.Type == USACElementTypeCPE {
+				tmp, err = bits.ReadBits(buf, pos, 2)
+				if err != nil {
+					return err
+				}
+				e.StereoConfigIndex = uint8(tmp)
+			}
+		}
+
+		if e.StereoConfigIndex > 0 {
+			e.MPS212Config = &USACMPS212Config{}
+			err = e.MPS212Config.unmarshalBits(buf, pos, e.StereoConfigIndex)
+			if err != nil {
+				return err
+			}
+		}
+
+	case USACElementTypeEXT:
+		e.ExtElementConfig = &USACExtElementConfig{}
+		err = e.ExtElementConfig.unmarshalBits(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e USACElementConfig) marshalSizeBits() int {
+	n := 2
+
+	switch e.Type {
+	case USACElementTypeSCE, USACElementTypeCPE:
+		n += 2
+
+		if e.SBRConfig != nil {
+			n += e.SBRConfig.marshalSizeBits()
+
+			if e.Type == USACElementTypeCPE {
+				n += 2
+			}
+		}
+
+		if e.MPS212Config != nil {
+			n += e.MPS212Config.marshalSizeBits(e.StereoConfigIndex)
+		}
+
+	case USACElementTypeEXT:
+		if e.ExtElementConfig != nil {
+			n += e.ExtElementConfig.marshalSizeBits()
+		}
+	}
+
+	return n
+}
+
+func (e USACElementConfig) marshalToBits(buf []byte, pos *int, sbrRatioIndex uint8) error {
+	bits.WriteBitsUnsafe(buf, pos, uint64(e.Type), 2)
+
+	switch e.Type {
+	case USACElementTypeSCE, USACElementTypeCPE:
+		bits.WriteFlagUnsafe(buf, pos, e.TWMDCT)
+		bits.WriteFlagUnsafe(buf, pos, e.NoiseFilling)
+
+		if (sbrRatioIndex > 0) != (e.SBRConfig != nil) {
+			return fmt.Errorf("SBR configuration does not match coreSbrFrameLengthIndex")
+		}
+
+		if e.SBRConfig != nil {
+			e.SBRConfig.marshalToBits(buf, pos)
+
+			if e.Type == USACElementTypeCPE {
+				bits.WriteBitsUnsafe(buf, pos, uint64(e.StereoConfigIndex), 2)
+			}
+		}
+
+		if (e.Type == USACElementTypeCPE && e.SBRConfig != nil && e.StereoConfigIndex > 0) !=
+			(e.MPS212Config != nil) {
+			return fmt.Errorf("MPS212 configuration does not match stereoConfigIndex")
+		}
+
+		if e.MPS212Config != nil {
+			e.MPS212Config.marshalToBits(buf, pos, e.StereoConfigIndex)
+		}
+
+	case USACElementTypeEXT:
+		if e.ExtElementConfig == nil {
+			return fmt.Errorf("extension element configuration is missing")
+		}
+
+		return e.ExtElementConfig.marshalToBits(buf, pos)
+	}
+
+	return nil
+}
+
+func (c *USACConfig) unmarshalBits(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 5)
+	if err != nil {
+		return err
+	}
+	usacSamplingFrequencyIndex := uint8(tmp)
+
+	if usacSamplingFrequencyIndex == 0x1F {
+		tmp, err = bits.ReadBits(buf, pos, 24)
+		if err != nil {
+			return err
+		}
+		c.SampleRate = int(tmp)
+	} else {
+		var ok bool
+		c.SampleRate, ok = usacSampleRates[usacSamplingFrequencyIndex]
+		if !ok {
+			return fmt.Errorf("invalid USAC sample rate index (%d)", usacSamplingFrequencyIndex)
+		}
+	}
+
+	err = bits.HasSpace(buf, *pos, 8)
+	if err != nil {
+		return err
+	}
+
+	c.CoreSBRFrameLengthIndex = uint8(bits.ReadBitsUnsafe(buf, pos, 3))
+	c.ChannelConfigurationIndex = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+	sbrRatioIndex, err := sbrRatioIndex(c.CoreSBRFrameLengthIndex)
+	if err != nil {
+		return err
+	}
+
+	if c.ChannelConfigurationIndex == 0 {
+		var numOutChannels uint32
+		numOutChannels, err = readEscapedValue(buf, pos, 5, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		err = bits.HasSpace(buf, *pos, int(numOutChannels)*5)
+		if err != nil {
+			return err
+		}
+
+		c.OutputChannelPositions = make([]uint8, numOutChannels)
+
+		for i := range c.OutputChannelPositions {
+			c.OutputChannelPositions[i] = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+		}
+	} else {
+		c.OutputChannelPositions = nil
+	}
+
+	numElements, err := readEscapedValue(buf, pos, 4, 8, 16)
+	if err != nil {
+		return err
+	}
+	numElements++
+
+	// each element takes at least 2 bits
+	err = bits.HasSpace(buf, *pos, int(numElements)*2)
+	if err != nil {
+		return err
+	}
+
+	c.Elements = make([]USACElementConfig, numElements)
+
+	for i := range c.Elements {
+		err = c.Elements[i].unmarshalBits(buf, pos, sbrRatioIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	usacConfigExtensionPresent, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	c.ConfigExtensions = nil
+
+	if usacConfigExtensionPresent {
+		var numConfigExtensions uint32
+		numConfigExtensions, err = readEscapedValue(buf, pos, 2, 4, 8)
+		if err != nil {
+			return err
+		}
+		numConfigExtensions++
+
+		for i := uint32(0); i < numConfigExtensions; i++ {
+			var ext USACConfigExtension
+
+			ext.Type, err = readEscapedValue(buf, pos, 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			var extLength uint32
+			extLength, err = readEscapedValue(buf, pos, 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			err = bits.HasSpace(buf, *pos, int(extLength)*8)
+			if err != nil {
+				return err
+			}
+
+			ext.Data = make([]byte, extLength)
+
+			for j := range ext.Data {
+				ext.Data[j] = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+			}
+
+			c.ConfigExtensions = append(c.ConfigExtensions, ext)
+		}
+	}
+
+	return nil
+}
+
+func (c USACConfig) samplingFrequencyIndex() uint8 {
+	for i, v := range usacSampleRates {
+		if v == c.SampleRate {
+			return i
+		}
+	}
+	return 0x1F
+}
+
+func (c USACConfig) marshalSizeBits() int {
+	n := 5
+
+	if c.samplingFrequencyIndex() == 0x1F {
+		n += 24
+	}
+
+	n += 8
+
+	if c.ChannelConfigurationIndex == 0 {
+		n += escapedValueSize(uint32(len(c.OutputChannelPositions)), 5, 8, 16) +
+			len(c.OutputChannelPositions)*5
+	}
+
+	if len(c.Elements) != 0 {
+		n += escapedValueSize(uint32(len(c.Elements)-1), 4, 8, 16)
+	}
+
+	for _, e := range c.Elements {
+		n += e.marshalSizeBits()
+	}
+
+	n++ // usacConfigExtensionPresent
+
+	if len(c.ConfigExtensions) != 0 {
+		n += escapedValueSize(uint32(len(c.ConfigExtensions)-1), 2, 4, 8)
+
+		for _, ext := range c.ConfigExtensions {
+			n += escapedValueSize(ext.Type, 4, 8, 16) +
+				escapedValueSize(uint32(len(ext.Data)), 4, 8, 16) +
+				len(ext.Data)*8
+		}
+	}
+
+	return n
+}
+
+func (c USACConfig) marshalToBits(buf []byte, pos *int) error {
+	usacSamplingFrequencyIndex := c.samplingFrequencyIndex()
+	bits.WriteBitsUnsafe(buf, pos, uint64(usacSamplingFrequencyIndex), 5)
+
+	if usacSamplingFrequencyIndex == 0x1F {
+		bits.WriteBitsUnsafe(buf, pos, uint64(c.SampleRate), 24)
+	}
+
+	sbrRatioIndex, err := sbrRatioIndex(c.CoreSBRFrameLengthIndex)
+	if err != nil {
+		return err
+	}
+
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.CoreSBRFrameLengthIndex), 3)
+	bits.WriteBitsUnsafe(buf, pos, uint64(c.ChannelConfigurationIndex), 5)
+
+	if c.ChannelConfigurationIndex == 0 {
+		err = writeEscapedValue(buf, pos, uint32(len(c.OutputChannelPositions)), 5, 8, 16)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range c.OutputChannelPositions {
+			bits.WriteBitsUnsafe(buf, pos, uint64(p), 5)
+		}
+	}
+
+	if len(c.Elements) == 0 {
+		return fmt.Errorf("USAC configuration must contain at least one element")
+	}
+
+	err = writeEscapedValue(buf, pos, uint32(len(c.Elements)-1), 4, 8, 16)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range c.Elements {
+		err = e.marshalToBits(buf, pos, sbrRatioIndex)
+		if err != nil {
+			return err
+		}
+	}
+
+	bits.WriteFlagUnsafe(buf, pos, len(c.ConfigExtensions) != 0)
+
+	if len(c.ConfigExtensions) != 0 {
+		err = writeEscapedValue(buf, pos, uint32(len(c.ConfigExtensions)-1), 2, 4, 8)
+		if err != nil {
+			return err
+		}
+
+		for _, ext := range c.ConfigExtensions {
+			err = writeEscapedValue(buf, pos, ext.Type, 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			err = writeEscapedValue(buf, pos, uint32(len(ext.Data)), 4, 8, 16)
+			if err != nil {
+				return err
+			}
+
+			for _, b := range ext.Data {
+				bits.WriteBitsUnsafe(buf, pos, uint64(b), 8)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package mpeg4video
+
+import (
+	"fmt"
+)
+
+// Config is the configuration of a MPEG-4 Video stream,
+// that is made of a visual object sequence header, a visual object header,
+// a video object header and a video object layer header.
+type Config struct {
+	ProfileAndLevelIndication uint8
+	VideoObjectLayer          VideoObjectLayer
+}
+
+// Unmarshal decodes a Config.
+func (c *Config) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, VisualObjectSequenceStartCode, VisualObjectSequenceStartCode)
+	if err != nil {
+		return err
+	}
+
+	if len(buf) < 5 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	c.ProfileAndLevelIndication = buf[4]
+	pos := 5
+
+	for {
+		pos = nextStartCode(buf, pos)
+		if pos < 0 {
+			return fmt.Errorf("video object layer not found")
+		}
+
+		startCode := StartCode(buf[pos+3])
+
+		if startCode >= VideoObjectLayerStartCodeFirst && startCode <= VideoObjectLayerStartCodeLast {
+			return c.VideoObjectLayer.Unmarshal(buf[pos:])
+		}
+
+		pos += 4
+	}
+}
+
+// Width returns the video width.
+func (c Config) Width() int {
+	return int(c.VideoObjectLayer.Width)
+}
+
+// Height returns the video height.
+func (c Config) Height() int {
+	return int(c.VideoObjectLayer.Height)
+}
+
+// FPS returns the frame rate, or zero if the frame rate is not fixed.
+func (c Config) FPS() float64 {
+	if !c.VideoObjectLayer.FixedVOPRate || c.VideoObjectLayer.FixedVOPTimeIncrement == 0 {
+		return 0
+	}
+	return float64(c.VideoObjectLayer.VOPTimeIncrementResolution) /
+		float64(c.VideoObjectLayer.FixedVOPTimeIncrement)
+}
@@ -0,0 +1,103 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesConfig = []struct {
+	name   string
+	byts   []byte
+	dec    Config
+	width  int
+	height int
+	fps    float64
+}{
+	{
+		"simple",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb0, 0x01, 0x00, 0x00, 0x01,
+			0xb5, 0x89, 0x13, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00,
+			0xcd, 0x0c, 0x04, 0x24, 0x14, 0x63, 0x00, 0x00,
+			0x01, 0xb2, 0x4c, 0x61, 0x76, 0x63, 0x35, 0x32,
+			0x2e, 0x35, 0x39, 0x2e, 0x30,
+		},
+		Config{
+			ProfileAndLevelIndication: 1,
+			VideoObjectLayer: VideoObjectLayer{
+				VideoObjectTypeIndication: VideoObjectTypeSimple,
+				VideoObjectLayerVerID:     1,
+				VideoObjectLayerPriority:  1,
+				AspectRatioInfo:           1,
+				ControlParameters: &VideoObjectLayerControlParameters{
+					ChromaFormat: 1,
+					LowDelay:     true,
+				},
+				VOPTimeIncrementResolution: 25,
+				Width:                      384,
+				Height:                     288,
+				OBMCDisable:                true,
+			},
+		},
+		384,
+		288,
+		0,
+	},
+	{
+		"advanced simple",
+		[]byte{
+			0x00, 0x00, 0x01, 0xb0, 0xf5, 0x00, 0x00, 0x01,
+			0xb5, 0x09, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x01, 0x20, 0x08, 0xd4, 0x8d, 0x08, 0x00, 0xce,
+			0x18, 0xb4, 0x22, 0x40, 0xaf,
+		},
+		Config{
+			ProfileAndLevelIndication: 0xf5,
+			VideoObjectLayer: VideoObjectLayer{
+				VideoObjectTypeIndication: VideoObjectTypeAdvancedSimple,
+				VideoObjectLayerVerID:     5,
+				VideoObjectLayerPriority:  1,
+				AspectRatioInfo:           1,
+				ControlParameters: &VideoObjectLayerControlParameters{
+					ChromaFormat: 1,
+				},
+				VOPTimeIncrementResolution: 25,
+				FixedVOPRate:               true,
+				FixedVOPTimeIncrement:      1,
+				Width:                      720,
+				Height:                     576,
+				OBMCDisable:                true,
+			},
+		},
+		720,
+		576,
+		25,
+	},
+}
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range casesConfig {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec Config
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, ca.width, dec.Width())
+			require.Equal(t, ca.height, dec.Height())
+			require.Equal(t, ca.fps, dec.FPS())
+		})
+	}
+}
+
+func FuzzConfigUnmarshal(f *testing.F) {
+	for _, ca := range casesConfig {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec Config
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,135 @@
+package mpeg4video
+
+import (
+	"fmt"
+)
+
+// DTSExtractor computes DTS from PTS.
+//
+// B-VOPs are never used as references, therefore they are decoded at their presentation time,
+// while I-VOPs, P-VOPs and S-VOPs are decoded before the B-VOPs that precede them in presentation order.
+type DTSExtractor struct {
+	vol              *VideoObjectLayer
+	randomReceived   bool
+	timeBase         int64
+	prevAnchorTime   int64
+	prevAnchorFilled bool
+	prevDTSFilled    bool
+	prevDTS          int64
+}
+
+// Initialize initializes a DTSExtractor.
+func (d *DTSExtractor) Initialize() {
+}
+
+func (d *DTSExtractor) extractInner(frame []byte, pts int64) (int64, error) {
+	var vop *VOPHeader
+	pos := 0
+
+outer:
+	for {
+		pos = nextStartCode(frame, pos)
+		if pos < 0 {
+			break
+		}
+
+		startCode := StartCode(frame[pos+3])
+
+		switch {
+		case startCode >= VideoObjectLayerStartCodeFirst && startCode <= VideoObjectLayerStartCodeLast:
+			var vol VideoObjectLayer
+			err := vol.Unmarshal(frame[pos:])
+			if err != nil {
+				return 0, fmt.Errorf("invalid video object layer: %w", err)
+			}
+
+			d.vol = &vol
+
+		case startCode == GroupOfVOPStartCode:
+			var gov GroupOfVOPHeader
+			err := gov.Unmarshal(frame[pos:])
+			if err != nil {
+				return 0, fmt.Errorf("invalid group of VOP header: %w", err)
+			}
+
+			// the time base of the next VOP is relative to the time code.
+			d.timeBase = gov.TimeCode()
+
+		case startCode == VOPStartCode:
+			if d.vol == nil {
+				return 0, fmt.Errorf("video object layer not received yet")
+			}
+
+			vop = &VOPHeader{}
+			err := vop.Unmarshal(frame[pos:], d.vol)
+			if err != nil {
+				return 0, fmt.Errorf("invalid VOP header: %w", err)
+			}
+			break outer
+		}
+
+		pos += 4
+	}
+
+	if d.vol == nil {
+		return 0, fmt.Errorf("video object layer not received yet")
+	}
+
+	if vop == nil {
+		return 0, fmt.Errorf("VOP header not found")
+	}
+
+	if !d.randomReceived {
+		if vop.CodingType != VOPCodingTypeI {
+			return 0, fmt.Errorf("random access frame not received yet")
+		}
+		d.randomReceived = true
+	}
+
+	if d.vol.IsLowDelay() {
+		return pts, nil
+	}
+
+	if vop.CodingType == VOPCodingTypeB {
+		return pts, nil
+	}
+
+	// modulo_time_base of I, P and S-VOPs is relative to the previous I, P or S-VOP in decoding order.
+	d.timeBase += int64(vop.ModuloTimeBase)
+	res := int64(d.vol.VOPTimeIncrementResolution)
+	anchorTime := d.timeBase*res + int64(vop.VOPTimeIncrement)
+
+	// distance in presentation order from the previous I, P or S-VOP.
+	var diff int64
+	if d.prevAnchorFilled {
+		diff = anchorTime - d.prevAnchorTime
+	} else {
+		diff = int64(d.vol.FixedVOPTimeIncrement)
+	}
+	d.prevAnchorTime = anchorTime
+	d.prevAnchorFilled = true
+
+	return pts - diff*90000/res, nil
+}
+
+// Extract extracts the DTS of a frame.
+func (d *DTSExtractor) Extract(frame []byte, pts int64) (int64, error) {
+	dts, err := d.extractInner(frame, pts)
+	if err != nil {
+		return 0, err
+	}
+
+	if dts > pts {
+		return 0, fmt.Errorf("DTS is greater than PTS")
+	}
+
+	if d.prevDTSFilled && dts < d.prevDTS {
+		return 0, fmt.Errorf("DTS is not monotonically increasing, was %v, now is %v",
+			d.prevDTS, dts)
+	}
+
+	d.prevDTSFilled = true
+	d.prevDTS = dts
+
+	return dts, err
+}
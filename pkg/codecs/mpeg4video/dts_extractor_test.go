@@ -0,0 +1,210 @@
+package mpeg4video
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceSample struct {
+	frame []byte
+	pts   int64
+	dts   int64
+}
+
+var casesDTSExtractor = []struct {
+	name     string
+	sequence []sequenceSample
+}{
+	{
+		"simple",
+		[]sequenceSample{
+			{
+				[]byte{
+					0x00, 0x00, 0x01, 0xb0, 0x01, 0x00, 0x00, 0x01,
+					0xb5, 0x89, 0x13, 0x00, 0x00, 0x01, 0x00, 0x00,
+					0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00,
+					0xf5, 0x3c, 0x04, 0x87, 0x14, 0x43, 0x00, 0x00,
+					0x01, 0xb6, 0x10, 0x6f, 0x12, 0x34,
+				},
+				0,
+				0,
+			},
+			{
+				[]byte{ // P, time increment 3
+					0x00, 0x00, 0x01, 0xb6, 0x51, 0xef, 0x12, 0x34,
+				},
+				3000,
+				3000,
+			},
+		},
+	},
+	{
+		"advanced simple with b-vops",
+		[]sequenceSample{
+			{
+				[]byte{
+					0x00, 0x00, 0x01, 0xb0, 0xf5, 0x00, 0x00, 0x01,
+					0xb5, 0x09, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+					0x01, 0x20, 0x08, 0xd4, 0x8d, 0x08, 0x00, 0xce,
+					0x18, 0xb4, 0x22, 0x40, 0xaf, 0x00, 0x00, 0x01,
+					0xb3, 0x00, 0x10, 0x27, 0x00, 0x00, 0x01, 0xb6,
+					0x10, 0x6f, 0x12, 0x34,
+				},
+				0,
+				-3600,
+			},
+			{
+				[]byte{ // P, time increment 3
+					0x00, 0x00, 0x01, 0xb6, 0x51, 0xef, 0x12, 0x34,
+				},
+				10800,
+				0,
+			},
+			{
+				[]byte{ // B, time increment 1
+					0x00, 0x00, 0x01, 0xb6, 0x90, 0xef, 0x12, 0x34,
+				},
+				3600,
+				3600,
+			},
+			{
+				[]byte{ // B, time increment 2
+					0x00, 0x00, 0x01, 0xb6, 0x91, 0x6f, 0x12, 0x34,
+				},
+				7200,
+				7200,
+			},
+			{
+				[]byte{ // P, time increment 6
+					0x00, 0x00, 0x01, 0xb6, 0x53, 0x6f, 0x12, 0x34,
+				},
+				21600,
+				10800,
+			},
+			{
+				[]byte{ // B, time increment 4
+					0x00, 0x00, 0x01, 0xb6, 0x92, 0x6f, 0x12, 0x34,
+				},
+				14400,
+				14400,
+			},
+			{
+				[]byte{ // B, time increment 5
+					0x00, 0x00, 0x01, 0xb6, 0x92, 0xef, 0x12, 0x34,
+				},
+				18000,
+				18000,
+			},
+			{
+				[]byte{ // P, modulo time base 1, time increment 1
+					0x00, 0x00, 0x01, 0xb6, 0x68, 0x77, 0x12, 0x34,
+				},
+				93600,
+				21600,
+			},
+			{
+				[]byte{ // B, time increment 24
+					0x00, 0x00, 0x01, 0xb6, 0x9c, 0x6f, 0x12, 0x34,
+				},
+				86400,
+				86400,
+			},
+		},
+	},
+}
+
+func TestDTSExtractor(t *testing.T) {
+	for _, ca := range casesDTSExtractor {
+		t.Run(ca.name, func(t *testing.T) {
+			ex := &DTSExtractor{}
+			ex.Initialize()
+
+			for _, sample := range ca.sequence {
+				dts, err := ex.Extract(sample.frame, sample.pts)
+				require.NoError(t, err)
+				require.Equal(t, sample.dts, dts)
+			}
+		})
+	}
+}
+func serializeSequence(seq []sequenceSample) []byte {
+	var buf []byte //nolint:prealloc
+
+	for _, sample := range seq {
+		tmp := make([]byte, 8)
+		binary.LittleEndian.PutUint64(tmp, uint64(sample.pts))
+		buf = append(buf, tmp...)
+
+		tmp = make([]byte, 4)
+		binary.LittleEndian.PutUint32(tmp, uint32(len(sample.frame)))
+		buf = append(buf, tmp...)
+
+		buf = append(buf, sample.frame...)
+	}
+
+	return buf
+}
+
+func unserializeSequence(buf []byte) ([]sequenceSample, error) {
+	var samples []sequenceSample
+
+	for {
+		if len(buf) < 8 {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		pts := int64(binary.LittleEndian.Uint64(buf[:8]))
+		buf = buf[8:]
+
+		if len(buf) < 4 {
+			return nil, fmt.Errorf("not enough bits")
+		}
+		frameLen := binary.LittleEndian.Uint32(buf[:4])
+		buf = buf[4:]
+
+		if frameLen == 0 {
+			return nil, fmt.Errorf("invalid frame len")
+		}
+		if len(buf) < int(frameLen) {
+			return nil, fmt.Errorf("not enough bits")
+		}
+
+		samples = append(samples, sequenceSample{
+			frame: buf[:frameLen],
+			pts:   pts,
+		})
+		buf = buf[frameLen:]
+
+		if len(buf) == 0 {
+			break
+		}
+	}
+
+	return samples, nil
+}
+
+func FuzzDTSExtractor(f *testing.F) {
+	for _, ca := range casesDTSExtractor {
+		f.Add(serializeSequence(ca.sequence))
+	}
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		seq, err := unserializeSequence(buf)
+		if err != nil {
+			t.Skip()
+			return
+		}
+
+		ex := &DTSExtractor{}
+		ex.Initialize()
+
+		for _, sample := range seq {
+			_, err = ex.Extract(sample.frame, sample.pts)
+			if err != nil {
+				break
+			}
+		}
+	})
+}
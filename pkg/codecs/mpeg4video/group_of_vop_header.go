@@ -0,0 +1,52 @@
+package mpeg4video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// GroupOfVOPHeader is a MPEG-4 Video group of VOP header.
+// Specification: ISO 14496-2, section 6.2.4
+type GroupOfVOPHeader struct {
+	Hours      uint8
+	Minutes    uint8
+	Seconds    uint8
+	ClosedGOV  bool
+	BrokenLink bool
+}
+
+// Unmarshal decodes a GroupOfVOPHeader, including its start code.
+func (h *GroupOfVOPHeader) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, GroupOfVOPStartCode, GroupOfVOPStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 20)
+	if err != nil {
+		return err
+	}
+
+	h.Hours = uint8(bits.ReadBitsUnsafe(buf, &pos, 5))
+	h.Minutes = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+
+	markerBit := bits.ReadFlagUnsafe(buf, &pos)
+	if !markerBit {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	h.Seconds = uint8(bits.ReadBitsUnsafe(buf, &pos, 6))
+	h.ClosedGOV = bits.ReadFlagUnsafe(buf, &pos)
+	h.BrokenLink = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
+
+// TimeCode returns the time code in seconds.
+func (h GroupOfVOPHeader) TimeCode() int64 {
+	return int64(h.Hours)*3600 + int64(h.Minutes)*60 + int64(h.Seconds)
+}
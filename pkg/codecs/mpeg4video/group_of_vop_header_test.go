@@ -0,0 +1,56 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesGroupOfVOPHeader = []struct {
+	name     string
+	byts     []byte
+	dec      GroupOfVOPHeader
+	timeCode int64
+}{
+	{
+		"closed",
+		[]byte{0x00, 0x00, 0x01, 0xb3, 0x00, 0x10, 0x27},
+		GroupOfVOPHeader{
+			ClosedGOV: true,
+		},
+		0,
+	},
+	{
+		"time code",
+		[]byte{0x00, 0x00, 0x01, 0xb3, 0x00, 0x30, 0xa7},
+		GroupOfVOPHeader{
+			Minutes:   1,
+			Seconds:   2,
+			ClosedGOV: true,
+		},
+		62,
+	},
+}
+
+func TestGroupOfVOPHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesGroupOfVOPHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec GroupOfVOPHeader
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, ca.timeCode, dec.TimeCode())
+		})
+	}
+}
+
+func FuzzGroupOfVOPHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesGroupOfVOPHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec GroupOfVOPHeader
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,20 @@
+package mpeg4video
+
+// IsRandomAccess checks whether the first VOP of a frame is an intra-coded VOP.
+func IsRandomAccess(frame []byte) bool {
+	pos := 0
+
+	for {
+		pos = nextStartCode(frame, pos)
+		if pos < 0 || len(frame) < (pos+5) {
+			return false
+		}
+
+		if StartCode(frame[pos+3]) == VOPStartCode {
+			// vop_coding_type can be read without knowing the video object layer.
+			return VOPCodingType(frame[pos+4]>>6) == VOPCodingTypeI
+		}
+
+		pos += 4
+	}
+}
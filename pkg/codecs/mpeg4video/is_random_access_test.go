@@ -0,0 +1,38 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRandomAccess(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		byts  []byte
+		isRAP bool
+	}{
+		{
+			"gov and i-vop",
+			[]byte{
+				0x00, 0x00, 0x01, 0xb3, 0x00, 0x10, 0x27, 0x00,
+				0x00, 0x01, 0xb6, 0x10, 0x6f, 0x12, 0x34,
+			},
+			true,
+		},
+		{
+			"p-vop",
+			[]byte{0x00, 0x00, 0x01, 0xb6, 0x68, 0x77, 0x12, 0x34},
+			false,
+		},
+		{
+			"gov only",
+			[]byte{0x00, 0x00, 0x01, 0xb3, 0x00, 0x10, 0x27},
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.isRAP, IsRandomAccess(ca.byts))
+		})
+	}
+}
@@ -1,6 +1,10 @@
 // Package mpeg4video contains utilities to work with MPEG-4 part 2 video codecs.
 package mpeg4video
 
+import (
+	"fmt"
+)
+
 const (
 	// MaxFrameSize is the maximum size of a frame.
 	MaxFrameSize = 1 * 1024 * 1024
@@ -22,3 +26,23 @@ const (
 	VisualObjectStartCode          StartCode = 0xB5
 	VOPStartCode                   StartCode = 0xB6
 )
+
+// nextStartCode returns the position of the next start code prefix, starting from pos.
+// It returns -1 when no start code is found.
+func nextStartCode(buf []byte, pos int) int {
+	for i := pos; i < len(buf)-3; i++ {
+		if buf[i] == 0 && buf[i+1] == 0 && buf[i+2] == 1 {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkStartCode checks that a buffer begins with a start code included between first and last.
+func checkStartCode(buf []byte, first StartCode, last StartCode) error {
+	if len(buf) < 4 || buf[0] != 0 || buf[1] != 0 || buf[2] != 1 ||
+		StartCode(buf[3]) < first || StartCode(buf[3]) > last {
+		return fmt.Errorf("start code 0x%.2x not found", uint8(first))
+	}
+	return nil
+}
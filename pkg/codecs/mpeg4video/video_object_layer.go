@@ -0,0 +1,316 @@
+package mpeg4video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// VideoObjectType is a video object type.
+// Specification: ISO 14496-2, Table 6-10
+type VideoObjectType uint8
+
+// video object types.
+const (
+	VideoObjectTypeSimple         VideoObjectType = 1
+	VideoObjectTypeSimpleScalable VideoObjectType = 2
+	VideoObjectTypeCore           VideoObjectType = 3
+	VideoObjectTypeMain           VideoObjectType = 4
+	VideoObjectTypeAdvancedSimple VideoObjectType = 17
+)
+
+// VideoObjectLayerShape is the shape of a video object layer.
+// Specification: ISO 14496-2, Table 6-14
+type VideoObjectLayerShape uint8
+
+// video object layer shapes.
+const (
+	VideoObjectLayerShapeRectangular VideoObjectLayerShape = 0
+	VideoObjectLayerShapeBinary      VideoObjectLayerShape = 1
+	VideoObjectLayerShapeBinaryOnly  VideoObjectLayerShape = 2
+	VideoObjectLayerShapeGrayscale   VideoObjectLayerShape = 3
+)
+
+// VideoObjectLayerVBVParameters are the VBV parameters of a video object layer.
+type VideoObjectLayerVBVParameters struct {
+	BitRate       uint32
+	VBVBufferSize uint32
+	VBVOccupancy  uint32
+}
+
+// VideoObjectLayerControlParameters are the control parameters of a video object layer.
+type VideoObjectLayerControlParameters struct {
+	ChromaFormat  uint8
+	LowDelay      bool
+	VBVParameters *VideoObjectLayerVBVParameters
+}
+
+// VideoObjectLayer is a MPEG-4 Video video object layer header.
+// Specification: ISO 14496-2, section 6.2.3
+type VideoObjectLayer struct {
+	RandomAccessibleVOL        bool
+	VideoObjectTypeIndication  VideoObjectType
+	VideoObjectLayerVerID      uint8
+	VideoObjectLayerPriority   uint8
+	AspectRatioInfo            uint8
+	PARWidth                   uint8
+	PARHeight                  uint8
+	ControlParameters          *VideoObjectLayerControlParameters
+	Shape                      VideoObjectLayerShape
+	ShapeExtension             uint8
+	VOPTimeIncrementResolution uint16
+	FixedVOPRate               bool
+	FixedVOPTimeIncrement      uint16
+	Width                      uint16
+	Height                     uint16
+	Interlaced                 bool
+	OBMCDisable                bool
+}
+
+func readMarkerBit(buf []byte, pos *int) error {
+	markerBit, err := bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+	if !markerBit {
+		return fmt.Errorf("invalid marker bit")
+	}
+	return nil
+}
+
+func (p *VideoObjectLayerVBVParameters) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 79)
+	if err != nil {
+		return err
+	}
+
+	firstHalfBitRate := uint32(bits.ReadBitsUnsafe(buf, pos, 15))
+	if !bits.ReadFlagUnsafe(buf, pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+	latterHalfBitRate := uint32(bits.ReadBitsUnsafe(buf, pos, 15))
+	if !bits.ReadFlagUnsafe(buf, pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+	p.BitRate = firstHalfBitRate<<15 | latterHalfBitRate
+
+	firstHalfVBVBufferSize := uint32(bits.ReadBitsUnsafe(buf, pos, 15))
+	if !bits.ReadFlagUnsafe(buf, pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+	latterHalfVBVBufferSize := uint32(bits.ReadBitsUnsafe(buf, pos, 3))
+	p.VBVBufferSize = firstHalfVBVBufferSize<<3 | latterHalfVBVBufferSize
+
+	firstHalfVBVOccupancy := uint32(bits.ReadBitsUnsafe(buf, pos, 11))
+	if !bits.ReadFlagUnsafe(buf, pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+	latterHalfVBVOccupancy := uint32(bits.ReadBitsUnsafe(buf, pos, 15))
+	if !bits.ReadFlagUnsafe(buf, pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+	p.VBVOccupancy = firstHalfVBVOccupancy<<15 | latterHalfVBVOccupancy
+
+	return nil
+}
+
+func (p *VideoObjectLayerControlParameters) unmarshal(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 4)
+	if err != nil {
+		return err
+	}
+
+	p.ChromaFormat = uint8(bits.ReadBitsUnsafe(buf, pos, 2))
+	p.LowDelay = bits.ReadFlagUnsafe(buf, pos)
+	vbvParameters := bits.ReadFlagUnsafe(buf, pos)
+
+	if vbvParameters {
+		p.VBVParameters = &VideoObjectLayerVBVParameters{}
+		err = p.VBVParameters.unmarshal(buf, pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.VBVParameters = nil
+	}
+
+	return nil
+}
+
+// Unmarshal decodes a VideoObjectLayer, including its start code.
+func (l *VideoObjectLayer) Unmarshal(buf []byte) error {
+	err := checkStartCode(buf, VideoObjectLayerStartCodeFirst, VideoObjectLayerStartCodeLast)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 10)
+	if err != nil {
+		return err
+	}
+
+	l.RandomAccessibleVOL = bits.ReadFlagUnsafe(buf, &pos)
+	l.VideoObjectTypeIndication = VideoObjectType(bits.ReadBitsUnsafe(buf, &pos, 8))
+	isObjectLayerIdentifier := bits.ReadFlagUnsafe(buf, &pos)
+
+	if isObjectLayerIdentifier {
+		var tmp uint64
+		tmp, err = bits.ReadBits(buf, &pos, 7)
+		if err != nil {
+			return err
+		}
+		l.VideoObjectLayerVerID = uint8(tmp >> 3)
+		l.VideoObjectLayerPriority = uint8(tmp & 0x07)
+	} else {
+		l.VideoObjectLayerVerID = 1
+		l.VideoObjectLayerPriority = 0
+	}
+
+	tmp, err := bits.ReadBits(buf, &pos, 4)
+	if err != nil {
+		return err
+	}
+	l.AspectRatioInfo = uint8(tmp)
+
+	// extended PAR
+	if l.AspectRatioInfo == 0x0F {
+		tmp, err = bits.ReadBits(buf, &pos, 16)
+		if err != nil {
+			return err
+		}
+		l.PARWidth = uint8(tmp >> 8)
+		l.PARHeight = uint8(tmp)
+	} else {
+		l.PARWidth = 0
+		l.PARHeight = 0
+	}
+
+	volControlParameters, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if volControlParameters {
+		l.ControlParameters = &VideoObjectLayerControlParameters{}
+		err = l.ControlParameters.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	} else {
+		l.ControlParameters = nil
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	l.Shape = VideoObjectLayerShape(tmp)
+
+	if l.Shape == VideoObjectLayerShapeGrayscale && l.VideoObjectLayerVerID != 1 {
+		tmp, err = bits.ReadBits(buf, &pos, 4)
+		if err != nil {
+			return err
+		}
+		l.ShapeExtension = uint8(tmp)
+	} else {
+		l.ShapeExtension = 0
+	}
+
+	err = readMarkerBit(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	tmp, err = bits.ReadBits(buf, &pos, 16)
+	if err != nil {
+		return err
+	}
+	l.VOPTimeIncrementResolution = uint16(tmp)
+
+	if l.VOPTimeIncrementResolution == 0 {
+		return fmt.Errorf("invalid vop_time_increment_resolution")
+	}
+
+	err = readMarkerBit(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	l.FixedVOPRate, err = bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if l.FixedVOPRate {
+		tmp, err = bits.ReadBits(buf, &pos, l.vopTimeIncrementBits())
+		if err != nil {
+			return err
+		}
+		l.FixedVOPTimeIncrement = uint16(tmp)
+	} else {
+		l.FixedVOPTimeIncrement = 0
+	}
+
+	if l.Shape == VideoObjectLayerShapeBinaryOnly {
+		l.Width = 0
+		l.Height = 0
+		l.Interlaced = false
+		l.OBMCDisable = false
+		return nil
+	}
+
+	if l.Shape == VideoObjectLayerShapeRectangular {
+		err = bits.HasSpace(buf, pos, 29)
+		if err != nil {
+			return err
+		}
+
+		if !bits.ReadFlagUnsafe(buf, &pos) {
+			return fmt.Errorf("invalid marker bit")
+		}
+		l.Width = uint16(bits.ReadBitsUnsafe(buf, &pos, 13))
+		if !bits.ReadFlagUnsafe(buf, &pos) {
+			return fmt.Errorf("invalid marker bit")
+		}
+		l.Height = uint16(bits.ReadBitsUnsafe(buf, &pos, 13))
+		if !bits.ReadFlagUnsafe(buf, &pos) {
+			return fmt.Errorf("invalid marker bit")
+		}
+	} else {
+		l.Width = 0
+		l.Height = 0
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	l.Interlaced = bits.ReadFlagUnsafe(buf, &pos)
+	l.OBMCDisable = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
+
+// vopTimeIncrementBits returns the size of vop_time_increment and fixed_vop_time_increment.
+func (l VideoObjectLayer) vopTimeIncrementBits() int {
+	n := 1
+	for (l.VOPTimeIncrementResolution-1)>>n != 0 {
+		n++
+	}
+	return n
+}
+
+// IsLowDelay returns whether the layer does not contain B-VOPs.
+func (l VideoObjectLayer) IsLowDelay() bool {
+	if l.ControlParameters != nil {
+		return l.ControlParameters.LowDelay
+	}
+
+	// when vol_control_parameters is not present,
+	// low_delay is inferred from the video object type.
+	return l.VideoObjectTypeIndication == VideoObjectTypeSimple
+}
@@ -0,0 +1,83 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesVideoObjectLayer = []struct {
+	name     string
+	byts     []byte
+	dec      VideoObjectLayer
+	lowDelay bool
+}{
+	{
+		"simple",
+		[]byte{
+			0x00, 0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88,
+			0x00, 0xf5, 0x3c, 0x04, 0x87, 0x14, 0x43,
+		},
+		VideoObjectLayer{
+			VideoObjectTypeIndication: VideoObjectTypeSimple,
+			VideoObjectLayerVerID:     1,
+			VideoObjectLayerPriority:  1,
+			AspectRatioInfo:           1,
+			ControlParameters: &VideoObjectLayerControlParameters{
+				ChromaFormat: 1,
+				LowDelay:     true,
+			},
+			VOPTimeIncrementResolution: 30,
+			Width:                      1920,
+			Height:                     1080,
+			OBMCDisable:                true,
+		},
+		true,
+	},
+	{
+		"advanced simple",
+		[]byte{
+			0x00, 0x00, 0x01, 0x20, 0x08, 0xd4, 0x8d, 0x08,
+			0x00, 0xce, 0x18, 0xb4, 0x22, 0x40, 0xaf,
+		},
+		VideoObjectLayer{
+			VideoObjectTypeIndication: VideoObjectTypeAdvancedSimple,
+			VideoObjectLayerVerID:     5,
+			VideoObjectLayerPriority:  1,
+			AspectRatioInfo:           1,
+			ControlParameters: &VideoObjectLayerControlParameters{
+				ChromaFormat: 1,
+			},
+			VOPTimeIncrementResolution: 25,
+			FixedVOPRate:               true,
+			FixedVOPTimeIncrement:      1,
+			Width:                      720,
+			Height:                     576,
+			OBMCDisable:                true,
+		},
+		false,
+	},
+}
+
+func TestVideoObjectLayerUnmarshal(t *testing.T) {
+	for _, ca := range casesVideoObjectLayer {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec VideoObjectLayer
+			err := dec.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+			require.Equal(t, ca.lowDelay, dec.IsLowDelay())
+		})
+	}
+}
+
+func FuzzVideoObjectLayerUnmarshal(f *testing.F) {
+	for _, ca := range casesVideoObjectLayer {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec VideoObjectLayer
+		dec.Unmarshal(b) //nolint:errcheck
+	})
+}
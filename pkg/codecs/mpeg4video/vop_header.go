@@ -0,0 +1,83 @@
+package mpeg4video
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/bits"
+)
+
+// VOPCodingType is a VOP coding type.
+// Specification: ISO 14496-2, Table 6-20
+type VOPCodingType uint8
+
+// VOP coding types.
+const (
+	VOPCodingTypeI VOPCodingType = 0
+	VOPCodingTypeP VOPCodingType = 1
+	VOPCodingTypeB VOPCodingType = 2
+	VOPCodingTypeS VOPCodingType = 3
+)
+
+// VOPHeader is a MPEG-4 Video video object plane header.
+// Specification: ISO 14496-2, section 6.2.5
+type VOPHeader struct {
+	CodingType       VOPCodingType
+	ModuloTimeBase   uint32
+	VOPTimeIncrement uint16
+	VOPCoded         bool
+}
+
+// Unmarshal decodes a VOPHeader, including its start code.
+// The video object layer is needed to decode vop_time_increment.
+func (h *VOPHeader) Unmarshal(buf []byte, vol *VideoObjectLayer) error {
+	err := checkStartCode(buf, VOPStartCode, VOPStartCode)
+	if err != nil {
+		return err
+	}
+
+	buf = buf[4:]
+	pos := 0
+
+	tmp, err := bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+	h.CodingType = VOPCodingType(tmp)
+
+	h.ModuloTimeBase = 0
+
+	for {
+		var moduloTimeBase bool
+		moduloTimeBase, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if !moduloTimeBase {
+			break
+		}
+
+		h.ModuloTimeBase++
+	}
+
+	n := vol.vopTimeIncrementBits()
+
+	err = bits.HasSpace(buf, pos, n+3)
+	if err != nil {
+		return err
+	}
+
+	if !bits.ReadFlagUnsafe(buf, &pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	h.VOPTimeIncrement = uint16(bits.ReadBitsUnsafe(buf, &pos, n))
+
+	if !bits.ReadFlagUnsafe(buf, &pos) {
+		return fmt.Errorf("invalid marker bit")
+	}
+
+	h.VOPCoded = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testVOPHeaderVOL = VideoObjectLayer{
+	VOPTimeIncrementResolution: 25,
+}
+
+var casesVOPHeader = []struct {
+	name string
+	byts []byte
+	dec  VOPHeader
+}{
+	{
+		"i",
+		[]byte{0x00, 0x00, 0x01, 0xb6, 0x10, 0x6f, 0x12, 0x34},
+		VOPHeader{
+			CodingType: VOPCodingTypeI,
+			VOPCoded:   true,
+		},
+	},
+	{
+		"p",
+		[]byte{0x00, 0x00, 0x01, 0xb6, 0x68, 0x77, 0x12, 0x34},
+		VOPHeader{
+			CodingType:       VOPCodingTypeP,
+			ModuloTimeBase:   1,
+			VOPTimeIncrement: 1,
+			VOPCoded:         true,
+		},
+	},
+	{
+		"b",
+		[]byte{0x00, 0x00, 0x01, 0xb6, 0x92, 0x6f, 0x12, 0x34},
+		VOPHeader{
+			CodingType:       VOPCodingTypeB,
+			VOPTimeIncrement: 4,
+			VOPCoded:         true,
+		},
+	},
+}
+
+func TestVOPHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesVOPHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec VOPHeader
+			err := dec.Unmarshal(ca.byts, &testVOPHeaderVOL)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func FuzzVOPHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesVOPHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var dec VOPHeader
+		dec.Unmarshal(b, &testVOPHeaderVOL) //nolint:errcheck
+	})
+}
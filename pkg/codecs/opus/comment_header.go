@@ -0,0 +1,124 @@
+package opus
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var commentHeaderSignature = []byte{'O', 'p', 'u', 's', 'T', 'a', 'g', 's'}
+
+func readLE32(buf []byte) uint32 {
+	return uint32(buf[3])<<24 | uint32(buf[2])<<16 | uint32(buf[1])<<8 | uint32(buf[0])
+}
+
+func writeLE32(buf []byte, v uint32) {
+	buf[0] = byte(v)
+	buf[1] = byte(v >> 8)
+	buf[2] = byte(v >> 16)
+	buf[3] = byte(v >> 24)
+}
+
+// CommentHeader is an Opus comment header.
+// Specification: RFC7845, section 5.2
+type CommentHeader struct {
+	Vendor string
+
+	// user comments, in the "NAME=value" form.
+	Comments []string
+}
+
+// Unmarshal decodes a CommentHeader.
+func (h *CommentHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 16 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if !bytes.Equal(buf[:8], commentHeaderSignature) {
+		return fmt.Errorf("magic signature not corresponds")
+	}
+	pos := 8
+
+	vendorLen := int(readLE32(buf[pos:]))
+	pos += 4
+
+	if vendorLen > len(buf[pos:]) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	h.Vendor = string(buf[pos : pos+vendorLen])
+	pos += vendorLen
+
+	if len(buf[pos:]) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	count := int(readLE32(buf[pos:]))
+	pos += 4
+
+	// each comment takes at least 4 bytes
+	if count > len(buf[pos:])/4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	h.Comments = make([]string, count)
+
+	for i := range count {
+		if len(buf[pos:]) < 4 {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		commentLen := int(readLE32(buf[pos:]))
+		pos += 4
+
+		if commentLen > len(buf[pos:]) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		h.Comments[i] = string(buf[pos : pos+commentLen])
+		pos += commentLen
+	}
+
+	// any remaining data is either padding or binary data
+	// that can be discarded.
+
+	return nil
+}
+
+func (h CommentHeader) marshalSize() int {
+	n := 16 + len(h.Vendor)
+	for _, comment := range h.Comments {
+		n += 4 + len(comment)
+	}
+	return n
+}
+
+func (h CommentHeader) marshalTo(buf []byte) (int, error) {
+	pos := copy(buf, commentHeaderSignature)
+
+	writeLE32(buf[pos:], uint32(len(h.Vendor)))
+	pos += 4
+	pos += copy(buf[pos:], h.Vendor)
+
+	writeLE32(buf[pos:], uint32(len(h.Comments)))
+	pos += 4
+
+	for _, comment := range h.Comments {
+		writeLE32(buf[pos:], uint32(len(comment)))
+		pos += 4
+		pos += copy(buf[pos:], comment)
+	}
+
+	return pos, nil
+}
+
+// Marshal encodes a CommentHeader.
+func (h CommentHeader) Marshal() ([]byte, error) {
+	buf := make([]byte, h.marshalSize())
+
+	_, err := h.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
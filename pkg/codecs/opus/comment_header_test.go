@@ -0,0 +1,81 @@
+package opus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var commentHeaderCases = []struct {
+	name string
+	dec  CommentHeader
+	enc  []byte
+}{
+	{
+		"empty",
+		CommentHeader{
+			Vendor:   "",
+			Comments: []string{},
+		},
+		[]byte{
+			0x4F, 0x70, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73, // OpusTags
+			0x00, 0x00, 0x00, 0x00, // vendor string length
+			0x00, 0x00, 0x00, 0x00, // comment count
+		},
+	},
+	{
+		"vendor and comments",
+		CommentHeader{
+			Vendor:   "libopus",
+			Comments: []string{"TITLE=a", "ARTIST=bc"},
+		},
+		[]byte{
+			0x4F, 0x70, 0x75, 0x73, 0x54, 0x61, 0x67, 0x73, // OpusTags
+			0x07, 0x00, 0x00, 0x00, // vendor string length
+			'l', 'i', 'b', 'o', 'p', 'u', 's',
+			0x02, 0x00, 0x00, 0x00, // comment count
+			0x07, 0x00, 0x00, 0x00,
+			'T', 'I', 'T', 'L', 'E', '=', 'a',
+			0x09, 0x00, 0x00, 0x00,
+			'A', 'R', 'T', 'I', 'S', 'T', '=', 'b', 'c',
+		},
+	},
+}
+
+func TestCommentHeaderUnmarshal(t *testing.T) {
+	for _, ca := range commentHeaderCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var h CommentHeader
+			err := h.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, h)
+		})
+	}
+}
+
+func TestCommentHeaderMarshal(t *testing.T) {
+	for _, ca := range commentHeaderCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzCommentHeaderUnmarshal(f *testing.F) {
+	for _, ca := range commentHeaderCases {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var h CommentHeader
+		err := h.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = h.Marshal()
+		require.NoError(t, err)
+	})
+}
@@ -35,9 +35,9 @@ func (h *IDHeader) Unmarshal(buf []byte) error {
 	}
 
 	h.ChannelCount = buf[9]
-	h.PreSkip = uint16(buf[10])<<8 | uint16(buf[11])
-	h.InputSampleRate = uint32(buf[12])<<24 | uint32(buf[13])<<16 | uint32(buf[14])<<8 | uint32(buf[15])
-	h.OutputGain = uint16(buf[16])<<8 | uint16(buf[17])
+	h.PreSkip = uint16(buf[11])<<8 | uint16(buf[10])
+	h.InputSampleRate = uint32(buf[15])<<24 | uint32(buf[14])<<16 | uint32(buf[13])<<8 | uint32(buf[12])
+	h.OutputGain = uint16(buf[17])<<8 | uint16(buf[16])
 	h.ChannelMappingFamily = buf[18]
 	h.ChannelMappingTable = buf[19:]
 
@@ -52,14 +52,14 @@ func (h IDHeader) marshalTo(buf []byte) (int, error) {
 	copy(buf[0:], magicSignature)
 	buf[8] = 1
 	buf[9] = h.ChannelCount
-	buf[10] = byte(h.PreSkip >> 8)
-	buf[11] = byte(h.PreSkip)
-	buf[12] = byte(h.InputSampleRate >> 24)
-	buf[13] = byte(h.InputSampleRate >> 16)
-	buf[14] = byte(h.InputSampleRate >> 8)
-	buf[15] = byte(h.InputSampleRate)
-	buf[16] = byte(h.OutputGain >> 8)
-	buf[17] = byte(h.OutputGain)
+	buf[10] = byte(h.PreSkip)
+	buf[11] = byte(h.PreSkip >> 8)
+	buf[12] = byte(h.InputSampleRate)
+	buf[13] = byte(h.InputSampleRate >> 8)
+	buf[14] = byte(h.InputSampleRate >> 16)
+	buf[15] = byte(h.InputSampleRate >> 24)
+	buf[16] = byte(h.OutputGain)
+	buf[17] = byte(h.OutputGain >> 8)
 	buf[18] = h.ChannelMappingFamily
 	n := copy(buf[19:], h.ChannelMappingTable)
 	return 19 + n, nil
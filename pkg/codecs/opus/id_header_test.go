@@ -26,8 +26,8 @@ var idHeaderCases = []struct {
 			0x4F, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64, // OpusHead
 			0x01,       // version
 			0x02,       // channel count
-			0x01, 0x38, // pre-skip = 312
-			0x00, 0x00, 0xBB, 0x80, // input sample rate = 48000
+			0x38, 0x01, // pre-skip = 312
+			0x80, 0xBB, 0x00, 0x00, // input sample rate = 48000
 			0x00, 0x00, // output gain = 0
 			0x00, // channel mapping family
 		},
@@ -48,8 +48,8 @@ var idHeaderCases = []struct {
 			0x01,       // version
 			0x06,       // channel count
 			0x00, 0x00, // pre-skip = 0
-			0x00, 0x00, 0xBB, 0x80, // input sample rate = 48000
-			0x01, 0x00, // output gain = 256
+			0x80, 0xBB, 0x00, 0x00, // input sample rate = 48000
+			0x00, 0x01, // output gain = 256
 			0x01,                               // channel mapping family
 			0x00, 0x04, 0x01, 0x02, 0x03, 0x05, // channel mapping table
 		},
@@ -69,9 +69,9 @@ var idHeaderCases = []struct {
 			0x4F, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64, // OpusHead
 			0x01,       // version
 			0x01,       // channel count
-			0xAB, 0xCD, // pre-skip = 0xABCD
-			0x00, 0x00, 0xAC, 0x44, // input sample rate = 44100
-			0xFF, 0x00, // output gain = 0xFF00
+			0xCD, 0xAB, // pre-skip = 0xABCD
+			0x44, 0xAC, 0x00, 0x00, // input sample rate = 44100
+			0x00, 0xFF, // output gain = 0xFF00
 			0xFF, // channel mapping family
 		},
 	},
@@ -98,6 +98,31 @@ func TestIDHeaderUnmarshal(t *testing.T) {
 	}
 }
 
+func TestIDHeaderByteOrder(t *testing.T) {
+	// multi-byte fields are little-endian.
+	// Specification: RFC7845, section 5.1
+	enc := []byte{
+		0x4F, 0x70, 0x75, 0x73, 0x48, 0x65, 0x61, 0x64, // OpusHead
+		0x01,       // version
+		0x02,       // channel count
+		0x01, 0x02, // pre-skip
+		0x03, 0x04, 0x05, 0x06, // input sample rate
+		0x07, 0x08, // output gain
+		0x00, // channel mapping family
+	}
+
+	var h IDHeader
+	err := h.Unmarshal(enc)
+	require.NoError(t, err)
+	require.Equal(t, uint16(0x0201), h.PreSkip)
+	require.Equal(t, uint32(0x06050403), h.InputSampleRate)
+	require.Equal(t, uint16(0x0807), h.OutputGain)
+
+	enc2, err := h.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, enc, enc2)
+}
+
 func FuzzIDHeaderUnmarshal(f *testing.F) {
 	for _, ca := range idHeaderCases {
 		f.Add(ca.enc)
@@ -0,0 +1,532 @@
+package opus
+
+import (
+	"fmt"
+)
+
+const (
+	maxFrameSize         = 1275
+	maxFrameCount        = 48
+	maxPacketDuration    = 5760
+	frameLengthEscapeMin = 252
+)
+
+// Mode is an Opus coding mode.
+type Mode int
+
+// modes.
+const (
+	ModeSILK Mode = iota
+	ModeHybrid
+	ModeCELT
+)
+
+// Bandwidth is an Opus audio bandwidth.
+type Bandwidth int
+
+// bandwidths.
+const (
+	BandwidthNarrowband Bandwidth = iota
+	BandwidthMediumband
+	BandwidthWideband
+	BandwidthSuperWideband
+	BandwidthFullband
+)
+
+func readFrameLength(buf []byte) (int, int, error) {
+	if len(buf) < 1 {
+		return 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] < frameLengthEscapeMin {
+		return int(buf[0]), 1, nil
+	}
+
+	if len(buf) < 2 {
+		return 0, 0, fmt.Errorf("not enough bytes")
+	}
+
+	return int(buf[1])*4 + int(buf[0]), 2, nil
+}
+
+func frameLengthSize(l int) int {
+	if l < frameLengthEscapeMin {
+		return 1
+	}
+	return 2
+}
+
+func writeFrameLength(buf []byte, l int) int {
+	if l < frameLengthEscapeMin {
+		buf[0] = byte(l)
+		return 1
+	}
+
+	buf[0] = byte(frameLengthEscapeMin + (l & 3))
+	buf[1] = byte((l - int(buf[0])) / 4)
+	return 2
+}
+
+func paddingLengthSize(padding int) int {
+	n := 1
+	for padding >= 255 {
+		n++
+		padding -= 254
+	}
+	return n
+}
+
+// Packet is an Opus packet.
+// Specification: RFC6716, section 3
+type Packet struct {
+	// configuration number (0-31).
+	Config uint8
+
+	// whether the packet contains stereo audio.
+	Stereo bool
+
+	// frame count code (0-3).
+	Code uint8
+
+	// whether frames of a code 3 packet have different sizes.
+	VBR bool
+
+	// number of padding bytes at the end of a code 3 packet.
+	Padding int
+
+	Frames [][]byte
+}
+
+func (p Packet) isVBR() bool {
+	return p.Code == 2 || (p.Code == 3 && p.VBR)
+}
+
+// Mode returns the coding mode of the packet.
+func (p Packet) Mode() Mode {
+	switch {
+	case p.Config < 12:
+		return ModeSILK
+
+	case p.Config < 16:
+		return ModeHybrid
+
+	default:
+		return ModeCELT
+	}
+}
+
+// Bandwidth returns the audio bandwidth of the packet.
+func (p Packet) Bandwidth() Bandwidth {
+	switch {
+	case p.Config < 12:
+		return Bandwidth(p.Config / 4)
+
+	case p.Config < 16:
+		return BandwidthSuperWideband + Bandwidth((p.Config-12)/2)
+
+	default:
+		// CELT does not support mediumband.
+		bw := Bandwidth((p.Config - 16) / 4)
+		if bw != BandwidthNarrowband {
+			bw++
+		}
+		return bw
+	}
+}
+
+// FrameSize returns the duration of a single frame, in 1/48000 seconds.
+func (p Packet) FrameSize() int {
+	return frameSizes[p.Config&0x1F]
+}
+
+// Duration returns the duration of the packet, in 1/48000 seconds.
+func (p Packet) Duration() int64 {
+	return int64(p.FrameSize()) * int64(len(p.Frames))
+}
+
+// Unmarshal decodes a Packet.
+func (p *Packet) Unmarshal(buf []byte) error {
+	_, err := p.unmarshal(buf, false)
+	return err
+}
+
+// UnmarshalSelfDelimited decodes a Packet in self-delimiting framing.
+// It returns the number of consumed bytes.
+// Specification: RFC6716, appendix B
+func (p *Packet) UnmarshalSelfDelimited(buf []byte) (int, error) {
+	return p.unmarshal(buf, true)
+}
+
+func (p *Packet) unmarshal(buf []byte, selfDelimited bool) (int, error) {
+	if len(buf) < 1 {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	p.Config = buf[0] >> 3
+	p.Stereo = ((buf[0] >> 2) & 0x01) != 0
+	p.Code = buf[0] & 0x03
+	p.VBR = false
+	p.Padding = 0
+	p.Frames = nil
+	pos := 1
+
+	var count int
+
+	switch p.Code {
+	case 0:
+		count = 1
+
+	case 1:
+		count = 2
+
+	case 2:
+		count = 2
+
+	case 3:
+		if len(buf[pos:]) < 1 {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+
+		p.VBR = (buf[pos] & 0x80) != 0
+		hasPadding := (buf[pos] & 0x40) != 0
+		count = int(buf[pos] & 0x3F)
+		pos++
+
+		if count == 0 {
+			return 0, fmt.Errorf("invalid frame count: 0")
+		}
+
+		if count*p.FrameSize() > maxPacketDuration {
+			return 0, fmt.Errorf("packet duration exceeds 120ms")
+		}
+
+		if hasPadding {
+			for {
+				if len(buf[pos:]) < 1 {
+					return 0, fmt.Errorf("not enough bytes")
+				}
+
+				v := int(buf[pos])
+				pos++
+
+				if v != 255 {
+					p.Padding += v
+					break
+				}
+				p.Padding += 254
+			}
+		}
+	}
+
+	vbr := p.isVBR()
+	lengths := make([]int, count)
+
+	if vbr {
+		for i := range count - 1 {
+			l, n, err := readFrameLength(buf[pos:])
+			if err != nil {
+				return 0, err
+			}
+			lengths[i] = l
+			pos += n
+		}
+	}
+
+	avail := len(buf) - pos - p.Padding
+
+	if selfDelimited {
+		l, n, err := readFrameLength(buf[pos:])
+		if err != nil {
+			return 0, err
+		}
+		pos += n
+		avail -= n
+
+		if vbr {
+			lengths[count-1] = l
+		} else {
+			for i := range lengths {
+				lengths[i] = l
+			}
+		}
+	} else {
+		if vbr {
+			sum := 0
+			for _, l := range lengths[:count-1] {
+				sum += l
+			}
+			lengths[count-1] = avail - sum
+		} else {
+			if avail < 0 || (avail%count) != 0 {
+				return 0, fmt.Errorf("invalid CBR payload size: %d", avail)
+			}
+
+			for i := range lengths {
+				lengths[i] = avail / count
+			}
+		}
+	}
+
+	p.Frames = make([][]byte, count)
+
+	for i, l := range lengths {
+		if l < 0 || l > avail {
+			return 0, fmt.Errorf("not enough bytes")
+		}
+
+		if l > maxFrameSize {
+			return 0, fmt.Errorf("frame size %d exceeds maximum", l)
+		}
+
+		p.Frames[i] = buf[pos : pos+l]
+		pos += l
+		avail -= l
+	}
+
+	if p.Padding > len(buf[pos:]) {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+	pos += p.Padding
+
+	return pos, nil
+}
+
+func (p Packet) check() error {
+	if p.Config > 31 {
+		return fmt.Errorf("invalid configuration: %d", p.Config)
+	}
+
+	switch p.Code {
+	case 0:
+		if len(p.Frames) != 1 {
+			return fmt.Errorf("code 0 packets must contain exactly 1 frame")
+		}
+
+	case 1:
+		if len(p.Frames) != 2 {
+			return fmt.Errorf("code 1 packets must contain exactly 2 frames")
+		}
+
+		if len(p.Frames[0]) != len(p.Frames[1]) {
+			return fmt.Errorf("frames of code 1 packets must have the same size")
+		}
+
+	case 2:
+		if len(p.Frames) != 2 {
+			return fmt.Errorf("code 2 packets must contain exactly 2 frames")
+		}
+
+	case 3:
+		if len(p.Frames) == 0 || len(p.Frames) > maxFrameCount {
+			return fmt.Errorf("invalid frame count: %d", len(p.Frames))
+		}
+
+		if p.Duration() > maxPacketDuration {
+			return fmt.Errorf("packet duration exceeds 120ms")
+		}
+
+		if !p.VBR {
+			for _, frame := range p.Frames[1:] {
+				if len(frame) != len(p.Frames[0]) {
+					return fmt.Errorf("frames of CBR packets must have the same size")
+				}
+			}
+		}
+
+	default:
+		return fmt.Errorf("invalid frame count code: %d", p.Code)
+	}
+
+	if p.Code != 3 && p.VBR {
+		return fmt.Errorf("VBR is only supported in code 3 packets")
+	}
+
+	if p.Padding < 0 || (p.Code != 3 && p.Padding != 0) {
+		return fmt.Errorf("padding is only supported in code 3 packets")
+	}
+
+	for _, frame := range p.Frames {
+		if len(frame) > maxFrameSize {
+			return fmt.Errorf("frame size %d exceeds maximum", len(frame))
+		}
+	}
+
+	return nil
+}
+
+func (p Packet) marshalSize(selfDelimited bool) int {
+	n := 1
+
+	if p.Code == 3 {
+		n++
+
+		if p.Padding != 0 {
+			n += paddingLengthSize(p.Padding)
+		}
+	}
+
+	if p.isVBR() {
+		for _, frame := range p.Frames[:len(p.Frames)-1] {
+			n += frameLengthSize(len(frame))
+		}
+	}
+
+	if selfDelimited {
+		n += frameLengthSize(len(p.Frames[len(p.Frames)-1]))
+	}
+
+	for _, frame := range p.Frames {
+		n += len(frame)
+	}
+
+	return n + p.Padding
+}
+
+func (p Packet) marshalTo(buf []byte, selfDelimited bool) (int, error) {
+	buf[0] = p.Config<<3 | p.Code
+	if p.Stereo {
+		buf[0] |= 1 << 2
+	}
+	pos := 1
+
+	if p.Code == 3 {
+		buf[pos] = byte(len(p.Frames))
+		if p.VBR {
+			buf[pos] |= 0x80
+		}
+		pos++
+
+		if p.Padding != 0 {
+			buf[pos-1] |= 0x40
+
+			padding := p.Padding
+			for padding >= 255 {
+				buf[pos] = 255
+				pos++
+				padding -= 254
+			}
+			buf[pos] = byte(padding)
+			pos++
+		}
+	}
+
+	if p.isVBR() {
+		for _, frame := range p.Frames[:len(p.Frames)-1] {
+			pos += writeFrameLength(buf[pos:], len(frame))
+		}
+	}
+
+	if selfDelimited {
+		pos += writeFrameLength(buf[pos:], len(p.Frames[len(p.Frames)-1]))
+	}
+
+	for _, frame := range p.Frames {
+		pos += copy(buf[pos:], frame)
+	}
+
+	for i := range p.Padding {
+		buf[pos+i] = 0
+	}
+	pos += p.Padding
+
+	return pos, nil
+}
+
+func (p Packet) marshal(selfDelimited bool) ([]byte, error) {
+	err := p.check()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, p.marshalSize(selfDelimited))
+
+	_, err = p.marshalTo(buf, selfDelimited)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// Marshal encodes a Packet.
+func (p Packet) Marshal() ([]byte, error) {
+	return p.marshal(false)
+}
+
+// MarshalSelfDelimited encodes a Packet in self-delimiting framing.
+// Specification: RFC6716, appendix B
+func (p Packet) MarshalSelfDelimited() ([]byte, error) {
+	return p.marshal(true)
+}
+
+// Split splits the packet into single-frame packets.
+func (p Packet) Split() []Packet {
+	ret := make([]Packet, len(p.Frames))
+
+	for i, frame := range p.Frames {
+		ret[i] = Packet{
+			Config: p.Config,
+			Stereo: p.Stereo,
+			Code:   0,
+			Frames: [][]byte{frame},
+		}
+	}
+
+	return ret
+}
+
+// Join merges frames of multiple packets into a single packet,
+// picking the most compact frame count code.
+// All packets must share the same configuration and channel count.
+func Join(pkts []Packet) (Packet, error) {
+	if len(pkts) == 0 {
+		return Packet{}, fmt.Errorf("no packets provided")
+	}
+
+	ret := Packet{
+		Config: pkts[0].Config,
+		Stereo: pkts[0].Stereo,
+	}
+
+	for _, pkt := range pkts {
+		if pkt.Config != ret.Config || pkt.Stereo != ret.Stereo {
+			return Packet{}, fmt.Errorf("packets have different configurations")
+		}
+
+		ret.Frames = append(ret.Frames, pkt.Frames...)
+	}
+
+	if len(ret.Frames) == 0 {
+		return Packet{}, fmt.Errorf("no frames provided")
+	}
+
+	sameSize := true
+	for _, frame := range ret.Frames[1:] {
+		if len(frame) != len(ret.Frames[0]) {
+			sameSize = false
+			break
+		}
+	}
+
+	switch {
+	case len(ret.Frames) == 1:
+		ret.Code = 0
+
+	case len(ret.Frames) == 2 && sameSize:
+		ret.Code = 1
+
+	case len(ret.Frames) == 2:
+		ret.Code = 2
+
+	default:
+		ret.Code = 3
+		ret.VBR = !sameSize
+	}
+
+	err := ret.check()
+	if err != nil {
+		return Packet{}, err
+	}
+
+	return ret, nil
+}
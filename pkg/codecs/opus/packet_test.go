@@ -0,0 +1,332 @@
+package opus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var packetCases = []struct {
+	name     string
+	enc      []byte
+	sdEnc    []byte
+	dec      Packet
+	mode     Mode
+	bw       Bandwidth
+	duration int64
+}{
+	{
+		"code 0",
+		[]byte{0x08, 0x01, 0x02, 0x03},
+		[]byte{0x08, 0x03, 0x01, 0x02, 0x03},
+		Packet{
+			Config: 1,
+			Code:   0,
+			Frames: [][]byte{{1, 2, 3}},
+		},
+		ModeSILK,
+		BandwidthNarrowband,
+		960,
+	},
+	{
+		"code 0 long frame",
+		append([]byte{0x7c}, bytes.Repeat([]byte{0x05}, 300)...),
+		append([]byte{0x7c, 0xfc, 0x0c}, bytes.Repeat([]byte{0x05}, 300)...),
+		Packet{
+			Config: 15,
+			Stereo: true,
+			Code:   0,
+			Frames: [][]byte{bytes.Repeat([]byte{0x05}, 300)},
+		},
+		ModeHybrid,
+		BandwidthFullband,
+		960,
+	},
+	{
+		"code 1",
+		[]byte{0xe5, 0x01, 0x02, 0x03, 0x04},
+		[]byte{0xe5, 0x02, 0x01, 0x02, 0x03, 0x04},
+		Packet{
+			Config: 28,
+			Stereo: true,
+			Code:   1,
+			Frames: [][]byte{{1, 2}, {3, 4}},
+		},
+		ModeCELT,
+		BandwidthFullband,
+		240,
+	},
+	{
+		"code 2",
+		[]byte{0xfa, 0x03, 0x01, 0x02, 0x03, 0x04},
+		[]byte{0xfa, 0x03, 0x01, 0x01, 0x02, 0x03, 0x04},
+		Packet{
+			Config: 31,
+			Code:   2,
+			Frames: [][]byte{{1, 2, 3}, {4}},
+		},
+		ModeCELT,
+		BandwidthFullband,
+		1920,
+	},
+	{
+		"code 3 cbr with padding",
+		[]byte{0x63, 0x43, 0x02, 0x01, 0x02, 0x03, 0x00, 0x00},
+		[]byte{0x63, 0x43, 0x02, 0x01, 0x01, 0x02, 0x03, 0x00, 0x00},
+		Packet{
+			Config:  12,
+			Code:    3,
+			Padding: 2,
+			Frames:  [][]byte{{1}, {2}, {3}},
+		},
+		ModeHybrid,
+		BandwidthSuperWideband,
+		1440,
+	},
+	{
+		"code 3 vbr",
+		[]byte{0xab, 0x83, 0x02, 0x01, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		[]byte{0xab, 0x83, 0x02, 0x01, 0x03, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		Packet{
+			Config: 21,
+			Code:   3,
+			VBR:    true,
+			Frames: [][]byte{{1, 2}, {3}, {4, 5, 6}},
+		},
+		ModeCELT,
+		BandwidthWideband,
+		720,
+	},
+	{
+		"code 3 long padding",
+		append([]byte{0x4b, 0x41, 0xff, 0x2e, 0x01}, make([]byte, 300)...),
+		append([]byte{0x4b, 0x41, 0xff, 0x2e, 0x01, 0x01}, make([]byte, 300)...),
+		Packet{
+			Config:  9,
+			Code:    3,
+			Padding: 300,
+			Frames:  [][]byte{{1}},
+		},
+		ModeSILK,
+		BandwidthWideband,
+		960,
+	},
+}
+
+func TestPacketUnmarshal(t *testing.T) {
+	for _, ca := range packetCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var p Packet
+			err := p.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, p)
+			require.Equal(t, ca.mode, p.Mode())
+			require.Equal(t, ca.bw, p.Bandwidth())
+			require.Equal(t, ca.duration, p.Duration())
+			require.Equal(t, PacketDuration2(ca.enc), p.Duration())
+		})
+	}
+}
+
+func TestPacketMarshal(t *testing.T) {
+	for _, ca := range packetCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestPacketUnmarshalSelfDelimited(t *testing.T) {
+	for _, ca := range packetCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var p Packet
+			n, err := p.UnmarshalSelfDelimited(append(ca.sdEnc, 0xaa, 0xbb))
+			require.NoError(t, err)
+			require.Equal(t, len(ca.sdEnc), n)
+			require.Equal(t, ca.dec, p)
+		})
+	}
+}
+
+func TestPacketMarshalSelfDelimited(t *testing.T) {
+	for _, ca := range packetCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.MarshalSelfDelimited()
+			require.NoError(t, err)
+			require.Equal(t, ca.sdEnc, enc)
+		})
+	}
+}
+
+func TestPacketUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		enc  []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bytes",
+		},
+		{
+			"code 1 odd size",
+			[]byte{0x09, 0x01, 0x02, 0x03},
+			"invalid CBR payload size: 3",
+		},
+		{
+			"code 2 invalid size",
+			[]byte{0x0a, 0x05, 0x01},
+			"not enough bytes",
+		},
+		{
+			"code 3 zero frames",
+			[]byte{0x0b, 0x00},
+			"invalid frame count: 0",
+		},
+		{
+			"code 3 too long",
+			[]byte{0x0b, 0x07},
+			"packet duration exceeds 120ms",
+		},
+		{
+			"frame too big",
+			append([]byte{0x08}, make([]byte, 1276)...),
+			"frame size 1276 exceeds maximum",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var p Packet
+			err := p.Unmarshal(ca.enc)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestPacketSplitJoin(t *testing.T) {
+	for _, ca := range packetCases {
+		t.Run(ca.name, func(t *testing.T) {
+			pkts := ca.dec.Split()
+			require.Len(t, pkts, len(ca.dec.Frames))
+
+			for _, pkt := range pkts {
+				_, err := pkt.Marshal()
+				require.NoError(t, err)
+			}
+
+			joined, err := Join(pkts)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec.Frames, joined.Frames)
+			require.Equal(t, ca.dec.Duration(), joined.Duration())
+
+			_, err = joined.Marshal()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		pkts []Packet
+		code uint8
+		vbr  bool
+	}{
+		{
+			"single",
+			[]Packet{{Config: 1, Frames: [][]byte{{1}}}},
+			0,
+			false,
+		},
+		{
+			"two equal",
+			[]Packet{{Config: 1, Frames: [][]byte{{1}}}, {Config: 1, Frames: [][]byte{{2}}}},
+			1,
+			false,
+		},
+		{
+			"two different",
+			[]Packet{{Config: 1, Frames: [][]byte{{1}}}, {Config: 1, Frames: [][]byte{{2, 3}}}},
+			2,
+			false,
+		},
+		{
+			"three equal",
+			[]Packet{
+				{Config: 1, Frames: [][]byte{{1}}},
+				{Config: 1, Code: 1, Frames: [][]byte{{2}, {3}}},
+			},
+			3,
+			false,
+		},
+		{
+			"three different",
+			[]Packet{
+				{Config: 1, Frames: [][]byte{{1}}},
+				{Config: 1, Code: 2, Frames: [][]byte{{2}, {3, 4}}},
+			},
+			3,
+			true,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			joined, err := Join(ca.pkts)
+			require.NoError(t, err)
+			require.Equal(t, ca.code, joined.Code)
+			require.Equal(t, ca.vbr, joined.VBR)
+		})
+	}
+
+	_, err := Join([]Packet{
+		{Config: 1, Frames: [][]byte{{1}}},
+		{Config: 2, Frames: [][]byte{{2}}},
+	})
+	require.EqualError(t, err, "packets have different configurations")
+
+	pkts := make([]Packet, 7)
+	for i := range pkts {
+		pkts[i] = Packet{Config: 3, Frames: [][]byte{{1}}}
+	}
+	_, err = Join(pkts)
+	require.EqualError(t, err, "packet duration exceeds 120ms")
+}
+
+func FuzzPacketUnmarshal(f *testing.F) {
+	for _, ca := range packetCases {
+		f.Add(ca.enc, false)
+		f.Add(ca.sdEnc, true)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte, selfDelimited bool) {
+		var p Packet
+		var err error
+
+		if selfDelimited {
+			_, err = p.UnmarshalSelfDelimited(b)
+		} else {
+			err = p.Unmarshal(b)
+		}
+		if err != nil {
+			return
+		}
+
+		enc, err := p.Marshal()
+		require.NoError(t, err)
+
+		var p2 Packet
+		err = p2.Unmarshal(enc)
+		require.NoError(t, err)
+		require.Equal(t, p.Frames, p2.Frames)
+
+		enc, err = p.MarshalSelfDelimited()
+		require.NoError(t, err)
+
+		n, err := p2.UnmarshalSelfDelimited(enc)
+		require.NoError(t, err)
+		require.Equal(t, len(enc), n)
+		require.Equal(t, p.Frames, p2.Frames)
+	})
+}
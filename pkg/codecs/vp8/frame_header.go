@@ -0,0 +1,91 @@
+package vp8
+
+import (
+	"fmt"
+)
+
+// FrameHeader_FrameSize is the frame size of a key frame.
+type FrameHeader_FrameSize struct { //nolint:revive
+	Width           uint16
+	HorizontalScale uint8
+	Height          uint16
+	VerticalScale   uint8
+}
+
+func (s *FrameHeader_FrameSize) unmarshal(buf []byte) error {
+	if len(buf) < 7 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if buf[0] != 0x9d || buf[1] != 0x01 || buf[2] != 0x2a {
+		return fmt.Errorf("invalid start code")
+	}
+
+	tmp := uint16(buf[3]) | uint16(buf[4])<<8
+	s.Width = tmp & 0x3FFF
+	s.HorizontalScale = uint8(tmp >> 14)
+
+	tmp = uint16(buf[5]) | uint16(buf[6])<<8
+	s.Height = tmp & 0x3FFF
+	s.VerticalScale = uint8(tmp >> 14)
+
+	return nil
+}
+
+// FrameHeader is a VP8 frame header.
+// Specification: RFC6386, section 9.1
+type FrameHeader struct {
+	NonKeyFrame   bool
+	Version       uint8
+	ShowFrame     bool
+	FirstPartSize uint32
+
+	// NonKeyFrame == false
+	FrameSize *FrameHeader_FrameSize
+}
+
+// Unmarshal decodes a FrameHeader.
+func (h *FrameHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 3 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	tag := uint32(buf[0]) | uint32(buf[1])<<8 | uint32(buf[2])<<16
+
+	h.NonKeyFrame = (tag & 0x01) != 0
+	h.Version = uint8((tag >> 1) & 0x07)
+	h.ShowFrame = ((tag >> 4) & 0x01) != 0
+	h.FirstPartSize = tag >> 5
+
+	if h.Version > 3 {
+		return fmt.Errorf("unsupported version: %d", h.Version)
+	}
+
+	if !h.NonKeyFrame {
+		h.FrameSize = &FrameHeader_FrameSize{}
+		err := h.FrameSize.unmarshal(buf[3:])
+		if err != nil {
+			return err
+		}
+	} else {
+		h.FrameSize = nil
+	}
+
+	return nil
+}
+
+// Width returns the video width.
+func (h FrameHeader) Width() int {
+	if h.FrameSize == nil {
+		return 0
+	}
+	return int(h.FrameSize.Width)
+}
+
+// Height returns the video height.
+func (h FrameHeader) Height() int {
+	if h.FrameSize == nil {
+		return 0
+	}
+	return int(h.FrameSize.Height)
+}
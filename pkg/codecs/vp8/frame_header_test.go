@@ -0,0 +1,93 @@
+package vp8
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesFrameHeader = []struct {
+	name   string
+	byts   []byte
+	fh     FrameHeader
+	width  int
+	height int
+}{
+	{
+		"key frame",
+		[]byte{
+			0x50, 0x42, 0x00, 0x9d, 0x01, 0x2a, 0x80, 0x02,
+			0xe0, 0x01, 0x00, 0x00,
+		},
+		FrameHeader{
+			ShowFrame:     true,
+			FirstPartSize: 530,
+			FrameSize: &FrameHeader_FrameSize{
+				Width:  640,
+				Height: 480,
+			},
+		},
+		640,
+		480,
+	},
+	{
+		"key frame with scaling",
+		[]byte{
+			0x02, 0x03, 0x00, 0x9d, 0x01, 0x2a, 0x80, 0x47,
+			0x38, 0x84,
+		},
+		FrameHeader{
+			Version:       1,
+			FirstPartSize: 24,
+			FrameSize: &FrameHeader_FrameSize{
+				Width:           1920,
+				HorizontalScale: 1,
+				Height:          1080,
+				VerticalScale:   2,
+			},
+		},
+		1920,
+		1080,
+	},
+	{
+		"inter frame",
+		[]byte{0x31, 0x1a, 0x00, 0x00},
+		FrameHeader{
+			NonKeyFrame:   true,
+			ShowFrame:     true,
+			FirstPartSize: 209,
+		},
+		0,
+		0,
+	},
+}
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range casesFrameHeader {
+		t.Run(ca.name, func(t *testing.T) {
+			var fh FrameHeader
+			err := fh.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.fh, fh)
+			require.Equal(t, ca.width, fh.Width())
+			require.Equal(t, ca.height, fh.Height())
+		})
+	}
+}
+
+func FuzzFrameHeaderUnmarshal(f *testing.F) {
+	for _, ca := range casesFrameHeader {
+		f.Add(ca.byts)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		var fh FrameHeader
+		err := fh.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		fh.Width()
+		fh.Height()
+	})
+}
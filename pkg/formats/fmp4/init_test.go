@@ -7,6 +7,7 @@ import (
 	amp4 "github.com/abema/go-mp4"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac4"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/fmp4/seekablebuffer"
@@ -240,6 +241,103 @@ var casesInit = []struct {
 			}},
 		},
 	},
+	{
+		"vp8",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x6f, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xd3,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x6f, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x1a, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xda, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0x8e, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x7e, 0x76, 0x70, 0x30, 0x38, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x14, 0x76,
+			0x70, 0x63, 0x43, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x0a, 0x82, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40, 0x00,
+			0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
+			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{{
+				ID:        1,
+				TimeScale: 90000,
+				Codec: &codecs.VP8{
+					Width:  1920,
+					Height: 1080,
+				},
+			}},
+		},
+	},
 	{
 		"h265",
 		[]byte{
@@ -356,6 +454,126 @@ var casesInit = []struct {
 			},
 		},
 	},
+	{
+		"h266",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0xc0, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x24,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xc0, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x6b, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
+			0x2b, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xdf, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xcf, 0x76, 0x76, 0x63, 0x31, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x65, 0x76,
+			0x76, 0x63, 0x43, 0x00, 0x00, 0x00, 0x00, 0xff,
+			0x00, 0x11, 0x5f, 0x01, 0x02, 0x43, 0x80, 0x00,
+			0x07, 0x80, 0x04, 0x38, 0x00, 0x00, 0x02, 0x8f,
+			0x00, 0x01, 0x00, 0x31, 0x00, 0x79, 0x00, 0x0d,
+			0x02, 0x43, 0x80, 0x00, 0x00, 0x0f, 0x02, 0x00,
+			0x43, 0x91, 0xa8, 0x02, 0x2a, 0xa6, 0xd5, 0xa6,
+			0xd9, 0x0a, 0x4c, 0x99, 0xc2, 0x68, 0x34, 0x24,
+			0x53, 0xf0, 0xb5, 0x34, 0x57, 0xb5, 0xfd, 0xd7,
+			0xe3, 0x12, 0x00, 0x00, 0x03, 0x00, 0x02, 0x00,
+			0x00, 0x03, 0x00, 0x3c, 0x62, 0x90, 0x00, 0x01,
+			0x00, 0x0c, 0x00, 0x81, 0x00, 0x00, 0x07, 0x81,
+			0x00, 0x21, 0xc8, 0x83, 0x09, 0x04, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40,
+			0x00, 0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &codecs.H266{
+						SPS: []byte{
+							0x00, 0x79, 0x00, 0x0d, 0x02, 0x43, 0x80, 0x00,
+							0x00, 0x0f, 0x02, 0x00, 0x43, 0x91, 0xa8, 0x02,
+							0x2a, 0xa6, 0xd5, 0xa6, 0xd9, 0x0a, 0x4c, 0x99,
+							0xc2, 0x68, 0x34, 0x24, 0x53, 0xf0, 0xb5, 0x34,
+							0x57, 0xb5, 0xfd, 0xd7, 0xe3, 0x12, 0x00, 0x00,
+							0x03, 0x00, 0x02, 0x00, 0x00, 0x03, 0x00, 0x3c,
+							0x62,
+						},
+						PPS: []byte{
+							0x00, 0x81, 0x00, 0x00, 0x07, 0x81, 0x00, 0x21,
+							0xc8, 0x83, 0x09, 0x04,
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		"h264",
 		[]byte{
@@ -469,13 +687,13 @@ var casesInit = []struct {
 		},
 	},
 	{
-		"mpeg-4 video",
+		"h265 hdr10",
 		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0xbc, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0xf7, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -489,7 +707,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x20,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x5b,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -501,8 +719,8 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
-			0x03, 0x20, 0x00, 0x00, 0x02, 0x58, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0xbc, 0x6d, 0x64, 0x69, 0x61,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xf7, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
@@ -513,7 +731,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
-			0x67, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0xa2, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
@@ -521,76 +739,102 @@ var casesInit = []struct {
 			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
 			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
-			0x27, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
-			0xdb, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
-			0xcb, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
+			0x62, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x01,
+			0x16, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
+			0x06, 0x68, 0x76, 0x63, 0x31, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x20, 0x02,
-			0x58, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x61, 0x65,
-			0x73, 0x64, 0x73, 0x00, 0x00, 0x00, 0x00, 0x03,
-			0x80, 0x80, 0x80, 0x50, 0x00, 0x01, 0x00, 0x04,
-			0x80, 0x80, 0x80, 0x42, 0x20, 0x11, 0x00, 0x00,
-			0x00, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42,
-			0x40, 0x05, 0x80, 0x80, 0x80, 0x30, 0x00, 0x00,
-			0x01, 0xb0, 0x01, 0x00, 0x00, 0x01, 0xb5, 0x89,
-			0x13, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
-			0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00, 0xf5, 0x3c,
-			0x04, 0x87, 0x14, 0x63, 0x00, 0x00, 0x01, 0xb2,
-			0x4c, 0x61, 0x76, 0x63, 0x35, 0x38, 0x2e, 0x31,
-			0x33, 0x34, 0x2e, 0x31, 0x30, 0x30, 0x06, 0x80,
-			0x80, 0x80, 0x01, 0x02, 0x00, 0x00, 0x00, 0x14,
-			0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42, 0x40,
-			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x5d, 0x68,
+			0x76, 0x63, 0x43, 0x01, 0x01, 0x60, 0x00, 0x00,
+			0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03, 0x78,
+			0xf0, 0x00, 0xfc, 0xfd, 0xf8, 0xf8, 0x00, 0x00,
+			0x13, 0x03, 0x20, 0x00, 0x01, 0x00, 0x04, 0x01,
+			0x02, 0x03, 0x04, 0x21, 0x00, 0x01, 0x00, 0x2a,
+			0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+			0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+			0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+			0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+			0xe0, 0x80, 0x22, 0x00, 0x01, 0x00, 0x01, 0x08,
+			0x00, 0x00, 0x00, 0x13, 0x63, 0x6f, 0x6c, 0x72,
+			0x6e, 0x63, 0x6c, 0x78, 0x00, 0x09, 0x00, 0x10,
+			0x00, 0x09, 0x00, 0x00, 0x00, 0x00, 0x20, 0x6d,
+			0x64, 0x63, 0x76, 0x33, 0xc2, 0x86, 0xc4, 0x1d,
+			0x4c, 0x0b, 0xb8, 0x84, 0xd0, 0x3e, 0x80, 0x3d,
+			0x13, 0x40, 0x42, 0x00, 0x98, 0x96, 0x80, 0x00,
+			0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x0c, 0x63,
+			0x6c, 0x6c, 0x69, 0x03, 0xe8, 0x01, 0x90, 0x00,
+			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40, 0x00,
+			0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
+			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28,
-			0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20,
-			0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
 					TimeScale: 90000,
-					Codec: &codecs.MPEG4Video{
-						Config: []byte{
-							0x00, 0x00, 0x01, 0xb0, 0x01, 0x00, 0x00, 0x01,
-							0xb5, 0x89, 0x13, 0x00, 0x00, 0x01, 0x00, 0x00,
-							0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00,
-							0xf5, 0x3c, 0x04, 0x87, 0x14, 0x63, 0x00, 0x00,
-							0x01, 0xb2, 0x4c, 0x61, 0x76, 0x63, 0x35, 0x38,
-							0x2e, 0x31, 0x33, 0x34, 0x2e, 0x31, 0x30, 0x30,
-						},
-					},
-				},
+					Codec: &codecs.H265{
+						VPS: []byte{0x01, 0x02, 0x03, 0x04},
+						SPS: []byte{
+							0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+							0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+							0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+							0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+							0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+							0xe0, 0x80,
+						},
+						PPS: []byte{0x08},
+						ColorInfo: &codecs.ColorInfo{
+							ColorPrimaries:          9,
+							TransferCharacteristics: 16,
+							MatrixCoefficients:      9,
+						},
+						MasteringDisplay: &codecs.MasteringDisplay{
+							DisplayPrimariesX: [3]uint16{13250, 7500, 34000},
+							DisplayPrimariesY: [3]uint16{34500, 3000, 16000},
+							WhitePointX:       15635,
+							WhitePointY:       16450,
+							MaxLuminance:      10000000,
+							MinLuminance:      50,
+						},
+						ContentLightLevel: &codecs.ContentLightLevel{
+							MaxContentLightLevel:    1000,
+							MaxPicAverageLightLevel: 400,
+						},
+					},
+				},
 			},
 		},
 	},
 	{
-		"mpeg-1 video",
+		"h264 colour description",
 		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0xa2, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0x9d, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -604,7 +848,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x06,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x01,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -616,8 +860,8 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
-			0x03, 0x20, 0x00, 0x00, 0x02, 0x58, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0xa2, 0x6d, 0x64, 0x69, 0x61,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x9d, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
@@ -628,7 +872,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
-			0x4d, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x48, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
@@ -636,70 +880,71 @@ var casesInit = []struct {
 			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
 			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
-			0x0d, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
-			0xc1, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x08, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xbc, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
-			0xb1, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
+			0xac, 0x61, 0x76, 0x63, 0x31, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x20, 0x02,
-			0x58, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x47, 0x65,
-			0x73, 0x64, 0x73, 0x00, 0x00, 0x00, 0x00, 0x03,
-			0x80, 0x80, 0x80, 0x36, 0x00, 0x01, 0x00, 0x04,
-			0x80, 0x80, 0x80, 0x28, 0x61, 0x11, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x2f, 0x61,
+			0x76, 0x63, 0x43, 0x01, 0x64, 0x00, 0x29, 0xff,
+			0xe1, 0x00, 0x1b, 0x67, 0x64, 0x00, 0x29, 0xac,
+			0x13, 0x31, 0x40, 0x78, 0x04, 0x47, 0xde, 0x03,
+			0xea, 0x02, 0x02, 0x03, 0xe0, 0x00, 0x00, 0x03,
+			0x00, 0x20, 0x00, 0x00, 0x06, 0x52, 0x01, 0x00,
+			0x01, 0x08, 0x00, 0x00, 0x00, 0x13, 0x63, 0x6f,
+			0x6c, 0x72, 0x6e, 0x63, 0x6c, 0x78, 0x00, 0x01,
+			0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42,
-			0x40, 0x05, 0x80, 0x80, 0x80, 0x16, 0x00, 0x00,
-			0x01, 0xb3, 0x78, 0x04, 0x38, 0x35, 0xff, 0xff,
-			0xe0, 0x18, 0x00, 0x00, 0x01, 0xb5, 0x14, 0x4a,
-			0x00, 0x01, 0x00, 0x00, 0x06, 0x80, 0x80, 0x80,
-			0x01, 0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
-			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0f,
-			0x42, 0x40, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x00,
-			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
+			0x40, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73,
+			0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73,
+			0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
-			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
-			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
-			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00,
+			0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
 					TimeScale: 90000,
-					Codec: &codecs.MPEG1Video{
-						Config: []byte{
-							0x00, 0x00, 0x01, 0xb3, 0x78, 0x04, 0x38, 0x35,
-							0xff, 0xff, 0xe0, 0x18, 0x00, 0x00, 0x01, 0xb5,
-							0x14, 0x4a, 0x00, 0x01, 0x00, 0x00,
+					Codec: &codecs.H264{
+						SPS: []byte{ // colour description is in VUI
+							0x67, 0x64, 0x00, 0x29, 0xac, 0x13, 0x31, 0x40,
+							0x78, 0x04, 0x47, 0xde, 0x03, 0xea, 0x02, 0x02,
+							0x03, 0xe0, 0x00, 0x00, 0x03, 0x00, 0x20, 0x00,
+							0x00, 0x06, 0x52,
 						},
+						PPS: []byte{0x08},
 					},
 				},
 			},
 		},
 	},
 	{
-		"mjpeg video",
+		"h265 dolby vision 8.1",
 		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x87, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0xd8, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -713,7 +958,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xeb,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x3c,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -725,8 +970,8 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
-			0x02, 0x80, 0x00, 0x00, 0x01, 0xe0, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x87, 0x6d, 0x64, 0x69, 0x61,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xd8, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
@@ -737,75 +982,98 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
-			0x32, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x83, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
 			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
 			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
-			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
-			0xf2, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
-			0xa6, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
+			0x43, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xf7, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
-			0x96, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
+			0xe7, 0x68, 0x76, 0x63, 0x31, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x80, 0x01,
-			0xe0, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x2c, 0x65,
-			0x73, 0x64, 0x73, 0x00, 0x00, 0x00, 0x00, 0x03,
-			0x80, 0x80, 0x80, 0x1b, 0x00, 0x01, 0x00, 0x04,
-			0x80, 0x80, 0x80, 0x0d, 0x6c, 0x11, 0x00, 0x00,
-			0x00, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42,
-			0x40, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02, 0x00,
-			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40, 0x00,
-			0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10, 0x73,
-			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
-			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
-			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x5d, 0x68,
+			0x76, 0x63, 0x43, 0x01, 0x01, 0x60, 0x00, 0x00,
+			0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03, 0x78,
+			0xf0, 0x00, 0xfc, 0xfd, 0xf8, 0xf8, 0x00, 0x00,
+			0x13, 0x03, 0x20, 0x00, 0x01, 0x00, 0x04, 0x01,
+			0x02, 0x03, 0x04, 0x21, 0x00, 0x01, 0x00, 0x2a,
+			0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+			0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+			0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+			0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+			0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+			0xe0, 0x80, 0x22, 0x00, 0x01, 0x00, 0x01, 0x08,
+			0x00, 0x00, 0x00, 0x20, 0x64, 0x76, 0x76, 0x43,
+			0x01, 0x00, 0x10, 0x35, 0x10, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
-			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
-			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40,
+			0x00, 0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
 					TimeScale: 90000,
-					Codec: &codecs.MJPEG{
-						Width:  640,
-						Height: 480,
+					Codec: &codecs.H265{
+						VPS: []byte{0x01, 0x02, 0x03, 0x04},
+						SPS: []byte{
+							0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+							0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+							0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+							0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+							0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+							0xe0, 0x80,
+						},
+						PPS: []byte{0x08},
+						DolbyVision: &codecs.DolbyVisionConfig{
+							VersionMajor:            1,
+							Profile:                 8,
+							Level:                   6,
+							RPUPresent:              true,
+							BLPresent:               true,
+							BLSignalCompatibilityID: 1,
+						},
 					},
 				},
 			},
 		},
 	},
 	{
-		"opus",
+		"av1 dolby vision 10.0",
 		[]byte{
-			0x00, 0x00, 0x00, 0x20,
-			'f', 't', 'y', 'p',
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x38,
-			'm', 'o', 'o', 'v',
-			0x00, 0x00, 0x00, 0x6c,
-			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x02, 0x94, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
@@ -818,84 +1086,106 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x9c,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xf8,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x38, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x03, 0x24, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x94, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xbb, 0x80,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
 			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
-			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
-			0xe3, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
-			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
-			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
-			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xa7, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x5b, 0x73, 0x74, 0x73,
-			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x4b, 0x4f, 0x70, 0x75,
-			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x3f, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
-			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x13, 0x64, 0x4f, 0x70, 0x73, 0x00, 0x02, 0x01,
-			0x38, 0x00, 0x00, 0xbb, 0x80, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
-			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xff, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xb3, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xa3, 0x64, 0x61, 0x76, 0x31, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x03,
+			0x24, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x19, 0x61,
+			0x76, 0x31, 0x43, 0x81, 0x08, 0x0c, 0x00, 0x0a,
+			0x0b, 0x00, 0x00, 0x00, 0x42, 0xa7, 0xbf, 0xe4,
+			0x60, 0x0d, 0x00, 0x40, 0x00, 0x00, 0x00, 0x20,
+			0x64, 0x76, 0x76, 0x43, 0x01, 0x00, 0x14, 0x4d,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
-			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42, 0x40,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
-			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28,
+			0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20,
+			0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
-					TimeScale: 48000,
-					Codec: &codecs.Opus{
-						ChannelCount: 2,
+					TimeScale: 90000,
+					Codec: &codecs.AV1{
+						SequenceHeader: []byte{
+							0x08, 0x00, 0x00, 0x00, 0x42, 0xa7, 0xbf, 0xe4,
+							0x60, 0x0d, 0x00, 0x40,
+						},
+						DolbyVision: &codecs.DolbyVisionConfig{
+							VersionMajor: 1,
+							Profile:      10,
+							Level:        9,
+							RPUPresent:   true,
+							BLPresent:    true,
+						},
 					},
 				},
 			},
 		},
 	},
 	{
-		"flac",
+		"mpeg-4 video",
 		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x57, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0xbc, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -909,96 +1199,1055 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbb,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x20,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x57, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xbc, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
 			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
-			0x02, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
-			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x67, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
+			0x27, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xdb, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xcb, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x61, 0x65,
+			0x73, 0x64, 0x73, 0x00, 0x00, 0x00, 0x00, 0x03,
+			0x80, 0x80, 0x80, 0x50, 0x00, 0x01, 0x00, 0x04,
+			0x80, 0x80, 0x80, 0x42, 0x20, 0x11, 0x00, 0x00,
+			0x00, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42,
+			0x40, 0x05, 0x80, 0x80, 0x80, 0x30, 0x00, 0x00,
+			0x01, 0xb0, 0x01, 0x00, 0x00, 0x01, 0xb5, 0x89,
+			0x13, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+			0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00, 0xf5, 0x3c,
+			0x04, 0x87, 0x14, 0x63, 0x00, 0x00, 0x01, 0xb2,
+			0x4c, 0x61, 0x76, 0x63, 0x35, 0x38, 0x2e, 0x31,
+			0x33, 0x34, 0x2e, 0x31, 0x30, 0x30, 0x06, 0x80,
+			0x80, 0x80, 0x01, 0x02, 0x00, 0x00, 0x00, 0x14,
+			0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42, 0x40,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28,
+			0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20,
+			0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &codecs.MPEG4Video{
+						Config: []byte{
+							0x00, 0x00, 0x01, 0xb0, 0x01, 0x00, 0x00, 0x01,
+							0xb5, 0x89, 0x13, 0x00, 0x00, 0x01, 0x00, 0x00,
+							0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00,
+							0xf5, 0x3c, 0x04, 0x87, 0x14, 0x63, 0x00, 0x00,
+							0x01, 0xb2, 0x4c, 0x61, 0x76, 0x63, 0x35, 0x38,
+							0x2e, 0x31, 0x33, 0x34, 0x2e, 0x31, 0x30, 0x30,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"mpeg-1 video",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0xa2, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x02, 0x06,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xa2, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x4d, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x01,
+			0x0d, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xc1, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xb1, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x07, 0x80, 0x04,
+			0x38, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x47, 0x65,
+			0x73, 0x64, 0x73, 0x00, 0x00, 0x00, 0x00, 0x03,
+			0x80, 0x80, 0x80, 0x36, 0x00, 0x01, 0x00, 0x04,
+			0x80, 0x80, 0x80, 0x28, 0x61, 0x11, 0x00, 0x00,
+			0x00, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42,
+			0x40, 0x05, 0x80, 0x80, 0x80, 0x16, 0x00, 0x00,
+			0x01, 0xb3, 0x78, 0x04, 0x38, 0x35, 0xff, 0xff,
+			0xe0, 0x18, 0x00, 0x00, 0x01, 0xb5, 0x14, 0x4a,
+			0x00, 0x01, 0x00, 0x00, 0x06, 0x80, 0x80, 0x80,
+			0x01, 0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
+			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0f,
+			0x42, 0x40, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &codecs.MPEG1Video{
+						Config: []byte{
+							0x00, 0x00, 0x01, 0xb3, 0x78, 0x04, 0x38, 0x35,
+							0xff, 0xff, 0xe0, 0x18, 0x00, 0x00, 0x01, 0xb5,
+							0x14, 0x4a, 0x00, 0x01, 0x00, 0x00,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"mjpeg video",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x87, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xeb,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x02, 0x80, 0x00, 0x00, 0x01, 0xe0, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x87, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x76, 0x69, 0x64, 0x65, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x56, 0x69, 0x64, 0x65, 0x6f, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x32, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x14, 0x76, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0xf2, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0xa6, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x96, 0x6d, 0x70, 0x34, 0x76, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x80, 0x01,
+			0xe0, 0x00, 0x48, 0x00, 0x00, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x18, 0xff, 0xff, 0x00, 0x00, 0x00, 0x2c, 0x65,
+			0x73, 0x64, 0x73, 0x00, 0x00, 0x00, 0x00, 0x03,
+			0x80, 0x80, 0x80, 0x1b, 0x00, 0x01, 0x00, 0x04,
+			0x80, 0x80, 0x80, 0x0d, 0x6c, 0x11, 0x00, 0x00,
+			0x00, 0x00, 0x0f, 0x42, 0x40, 0x00, 0x0f, 0x42,
+			0x40, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02, 0x00,
+			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40, 0x00,
+			0x0f, 0x42, 0x40, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
+			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &codecs.MJPEG{
+						Width:  640,
+						Height: 480,
+					},
+				},
+			},
+		},
+	},
+	{
+		"opus",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20,
+			'f', 't', 'y', 'p',
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x38,
+			'm', 'o', 'o', 'v',
+			0x00, 0x00, 0x00, 0x6c,
+			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x9c,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x38, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xbb, 0x80,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xe3, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xa7, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x5b, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x4b, 0x4f, 0x70, 0x75,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x13, 0x64, 0x4f, 0x70, 0x73, 0x00, 0x02, 0x01,
+			0x38, 0x00, 0x00, 0xbb, 0x80, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 48000,
+					Codec: &codecs.Opus{
+						ChannelCount: 2,
+					},
+				},
+			},
+		},
+	},
+	{
+		"flac",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x57, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbb,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x57, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x02, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xc6, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x7a, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x6a, 0x66, 0x4c, 0x61,
+			0x43, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x32, 0x64, 0x66, 0x4c, 0x61, 0x00, 0x00, 0x00,
+			0x00, 0x80, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+			0xc4, 0x42, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
+			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 44100,
+					Codec: &codecs.FLAC{
+						StreamInfo: &flac.StreamInfo{
+							SampleRate:   44100,
+							ChannelCount: 2,
+							BitDepth:     16,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"flac with metadata blocks",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x72, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xd6,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x72, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x1d, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xe1, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x95, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x85, 0x66, 0x4c, 0x61,
+			0x43, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x4d, 0x64, 0x66, 0x4c, 0x61, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+			0xc4, 0x42, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x84,
+			0x00, 0x00, 0x17, 0x04, 0x00, 0x00, 0x00, 0x74,
+			0x65, 0x73, 0x74, 0x01, 0x00, 0x00, 0x00, 0x07,
+			0x00, 0x00, 0x00, 0x54, 0x49, 0x54, 0x4c, 0x45,
+			0x3d, 0x74, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
+			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 44100,
+					Codec: &codecs.FLAC{
+						StreamInfo: &flac.StreamInfo{
+							SampleRate:   44100,
+							ChannelCount: 2,
+							BitDepth:     16,
+						},
+						MetadataBlocks: []flac.MetadataBlock{
+							&flac.VorbisComment{
+								Vendor:   "test",
+								Comments: []string{"TITLE=t"},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"mpeg-4 audio",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20,
+			'f', 't', 'y', 'p',
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x58,
+			'm', 'o', 'o', 'v',
+			0x00, 0x00, 0x00, 0x6c,
+			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbc,
+			't', 'r', 'a', 'k',
+			0x00, 0x00, 0x00, 0x5c,
+			't', 'k', 'h', 'd',
+			0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x58,
+			'm', 'd', 'i', 'a',
+			0x00, 0x00, 0x00, 0x20,
+			'm', 'd', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d,
+			'h', 'd', 'l', 'r',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x03,
+			'm', 'i', 'n', 'f',
+			0x00, 0x00, 0x00, 0x10,
+			's', 'm', 'h', 'd',
+			0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24,
+			'd', 'i', 'n', 'f',
+			0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xc7, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x7b, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x6b,
+			'm', 'p', '4', 'a',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x33,
+			'e', 's', 'd', 's',
+			0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x22, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x14, 0x40, 0x15,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x02,
+			0x12, 0x10, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02,
+			0x00, 0x00, 0x00, 0x14,
+			'b', 't', 'r', 't',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28,
+			'm', 'v', 'e', 'x',
+			0x00, 0x00, 0x00, 0x20,
+			't', 'r', 'e', 'x',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: uint32(testAudioTrack.Config.SampleRate),
+					Codec:     testAudioTrack,
+				},
+			},
+		},
+	},
+	{
+		"mpeg-4 audio usac",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20,
+			'f', 't', 'y', 'p',
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x5d,
+			'm', 'o', 'o', 'v',
+			0x00, 0x00, 0x00, 0x6c,
+			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xc1,
+			't', 'r', 'a', 'k',
+			0x00, 0x00, 0x00, 0x5c,
+			't', 'k', 'h', 'd',
+			0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x5d,
+			'm', 'd', 'i', 'a',
+			0x00, 0x00, 0x00, 0x20,
+			'm', 'd', 'h', 'd',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d,
+			'h', 'd', 'l', 'r',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
+			0x08,
+			'm', 'i', 'n', 'f',
+			0x00, 0x00, 0x00, 0x10,
+			's', 'm', 'h', 'd',
+			0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24,
+			'd', 'i', 'n', 'f',
+			0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xc6, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x7a, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0xcc, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x80, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x6a, 0x66, 0x4c, 0x61,
-			0x43, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x70,
+			'm', 'p', '4', 'a',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x10, 0x00, 0x00, 0x00,
 			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x32, 0x64, 0x66, 0x4c, 0x61, 0x00, 0x00, 0x00,
-			0x00, 0x80, 0x00, 0x00, 0x22, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
-			0xc4, 0x42, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x38,
+			'e', 's', 'd', 's',
+			0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x27, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x19, 0x40, 0x15,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x07,
+			0xf9, 0x48, 0x04, 0x20, 0x08, 0x80, 0xc0, 0x06,
+			0x80, 0x80, 0x80, 0x01, 0x02,
+			0x00, 0x00, 0x00, 0x14,
+			'b', 't', 'r', 't',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x28,
+			'm', 'v', 'e', 'x',
+			0x00, 0x00, 0x00, 0x20,
+			't', 'r', 'e', 'x',
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 44100,
+					Codec: &codecs.MPEG4Audio{
+						Config: mpeg4audio.AudioSpecificConfig{
+							Type:       mpeg4audio.ObjectTypeUSAC,
+							SampleRate: 44100,
+							USACConfig: &mpeg4audio.USACConfig{
+								SampleRate:              44100,
+								CoreSBRFrameLengthIndex: 1,
+								OutputChannelPositions:  []uint8{2},
+								Elements: []mpeg4audio.USACElementConfig{{
+									Type:         mpeg4audio.USACElementTypeSCE,
+									TWMDCT:       true,
+									NoiseFilling: true,
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"mpeg-1 audio",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x51, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xb5,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x51, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xfc, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xc0, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x74, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x64, 0x6d, 0x70, 0x34,
+			0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x2c, 0x65, 0x73, 0x64, 0x73, 0x00, 0x00, 0x00,
+			0x00, 0x03, 0x80, 0x80, 0x80, 0x1b, 0x00, 0x01,
+			0x00, 0x04, 0x80, 0x80, 0x80, 0x0d, 0x6b, 0x15,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x01, 0xf7, 0x39, 0x06, 0x80, 0x80, 0x80, 0x01,
+			0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72,
+			0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7,
+			0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63,
+			0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65,
+			0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65,
+			0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &codecs.MPEG1Audio{
+						SampleRate:   48000,
+						ChannelCount: 2,
+					},
+				},
+			},
+		},
+	},
+	{
+		"ac-3",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x30, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x94,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x30, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xdb, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x9f, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x53, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x43, 0x61, 0x63, 0x2d,
+			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0b, 0x64, 0x61, 0x63, 0x33, 0x10, 0x3d, 0xe0,
+			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
+			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
+			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
-			0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73,
-			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
-			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
-			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
+			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
-			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
-			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
-					TimeScale: 44100,
-					Codec: &codecs.FLAC{
-						StreamInfo: &flac.StreamInfo{
-							SampleRate:   44100,
-							ChannelCount: 2,
-							BitDepth:     16,
-						},
+					TimeScale: 90000,
+					Codec: &codecs.AC3{
+						SampleRate:   48000,
+						ChannelCount: 6,
+						Fscod:        0x0,
+						Bsid:         0x8,
+						Bsmod:        0x0,
+						Acmod:        0x7,
+						LfeOn:        true,
+						BitRateCode:  0xf,
 					},
 				},
 			},
 		},
 	},
-	{
-		"mpeg-4 audio",
-		[]byte{
-			0x00, 0x00, 0x00, 0x20,
-			'f', 't', 'y', 'p',
+	{ //nolint:dupl
+		"e-ac-3 5.1",
+		[]byte{ //nolint:dupl
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x58,
-			'm', 'o', 'o', 'v',
-			0x00, 0x00, 0x00, 0x6c,
-			'm', 'v', 'h', 'd',
+			0x00, 0x00, 0x02, 0x32, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
@@ -1011,11 +2260,9 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xbc,
-			't', 'r', 'a', 'k',
-			0x00, 0x00, 0x00, 0x5c,
-			't', 'k', 'h', 'd',
-			0x00, 0x00, 0x00, 0x03,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x96,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1026,87 +2273,79 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x58,
-			'm', 'd', 'i', 'a',
-			0x00, 0x00, 0x00, 0x20,
-			'm', 'd', 'h', 'd',
+			0x00, 0x00, 0x01, 0x32, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xac, 0x44,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
 			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x2d,
-			'h', 'd', 'l', 'r',
+			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x73, 0x6f, 0x75, 0x6e, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
-			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x01,
-			0x03,
-			'm', 'i', 'n', 'f',
-			0x00, 0x00, 0x00, 0x10,
-			's', 'm', 'h', 'd',
-			0x00, 0x00, 0x00,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xdd, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x24,
-			'd', 'i', 'n', 'f',
-			0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xc7, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x7b, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0xa1, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x55, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x6b,
-			'm', 'p', '4', 'a',
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x45, 0x65, 0x63, 0x2d,
+			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
-			0x00, 0xac, 0x44, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x33,
-			'e', 's', 'd', 's',
-			0x00, 0x00, 0x00,
-			0x00, 0x03, 0x80, 0x80, 0x80, 0x22, 0x00, 0x01,
-			0x00, 0x04, 0x80, 0x80, 0x80, 0x14, 0x40, 0x15,
-			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
-			0x01, 0xf7, 0x39, 0x05, 0x80, 0x80, 0x80, 0x02,
-			0x12, 0x10, 0x06, 0x80, 0x80, 0x80, 0x01, 0x02,
-			0x00, 0x00, 0x00, 0x14,
-			'b', 't', 'r', 't',
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
-			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
-			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x0d, 0x64, 0x65, 0x63, 0x33, 0x14, 0x00, 0x20,
+			0x0f, 0x00, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
+			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x28,
-			'm', 'v', 'e', 'x',
-			0x00, 0x00, 0x00, 0x20,
-			't', 'r', 'e', 'x',
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
+			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
-					TimeScale: uint32(testAudioTrack.Config.SampleRate),
-					Codec:     testAudioTrack,
+					TimeScale: 90000,
+					Codec: &codecs.EAC3{
+						SampleRate:   48000,
+						ChannelCount: 6, // 5.1 surround
+						DataRate:     640,
+						Asvc:         false,
+						Bsmod:        0,
+						Acmod:        7, // 3/2 (L, C, R, Ls, Rs)
+						LfeOn:        true,
+						NumDepSub:    0,
+						ChanLoc:      0,
+					},
 				},
 			},
 		},
 	},
-	{
-		"mpeg-1 audio",
+	{ //nolint:dupl
+		"e-ac-3 atmos with additional substream",
 		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x51, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0x37, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -1120,7 +2359,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xb5,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x9b,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1133,7 +2372,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x51, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x01, 0x37, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
@@ -1144,65 +2383,73 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
-			0xfc, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0xe2, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xc0, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x74, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0xa6, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x5a, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x64, 0x6d, 0x70, 0x34,
-			0x61, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x4a, 0x65, 0x63, 0x2d,
+			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
 			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x2c, 0x65, 0x73, 0x64, 0x73, 0x00, 0x00, 0x00,
-			0x00, 0x03, 0x80, 0x80, 0x80, 0x1b, 0x00, 0x01,
-			0x00, 0x04, 0x80, 0x80, 0x80, 0x0d, 0x6b, 0x15,
+			0x12, 0x64, 0x65, 0x63, 0x33, 0x14, 0x01, 0x20,
+			0x0f, 0x00, 0x20, 0x04, 0x00, 0x01, 0x10, 0x00,
+			0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00,
-			0x01, 0xf7, 0x39, 0x06, 0x80, 0x80, 0x80, 0x01,
-			0x02, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72,
-			0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7,
-			0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00,
-			0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00,
+			0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73,
+			0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63,
-			0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65,
-			0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65,
-			0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00,
+			0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
 					TimeScale: 90000,
-					Codec: &codecs.MPEG1Audio{
+					Codec: &codecs.EAC3{
 						SampleRate:   48000,
-						ChannelCount: 2,
+						ChannelCount: 6, // 5.1 surround
+						DataRate:     640,
+						Asvc:         false,
+						Bsmod:        0,
+						Acmod:        7, // 3/2 (L, C, R, Ls, Rs)
+						LfeOn:        true,
+						NumDepSub:    0,
+						ChanLoc:      0,
+						AdditionalSubstreams: []codecs.EAC3Substream{{
+							Acmod: 2,
+						}},
+						FlagEC3ExtensionTypeA: true,
+						ComplexityIndexTypeA:  16,
 					},
 				},
 			},
 		},
 	},
-	{
-		"ac-3",
-		[]byte{
+	{ //nolint:dupl
+		"e-ac-3 7.1",
+		[]byte{ //nolint:dupl
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x30, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0x33, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -1216,7 +2463,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x94,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x97,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1229,7 +2476,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x30, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x01, 0x33, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
@@ -1240,66 +2487,68 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
-			0xdb, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0xde, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x9f, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x53, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0xa2, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x56, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x43, 0x61, 0x63, 0x2d,
+			0x01, 0x00, 0x00, 0x00, 0x46, 0x65, 0x63, 0x2d,
 			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x08, 0x00, 0x10, 0x00, 0x00, 0x00,
 			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x0b, 0x64, 0x61, 0x63, 0x33, 0x10, 0x3d, 0xe0,
-			0x00, 0x00, 0x00, 0x14, 0x62, 0x74, 0x72, 0x74,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0xf7, 0x39,
-			0x00, 0x01, 0xf7, 0x39, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
-			0x73, 0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x14,
-			0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00, 0x00,
+			0x0e, 0x64, 0x65, 0x63, 0x33, 0x18, 0x00, 0x20,
+			0x0f, 0x02, 0x18, 0x00, 0x00, 0x00, 0x14, 0x62,
+			0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x63, 0x6f,
+			0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x28, 0x6d, 0x76, 0x65, 0x78,
-			0x00, 0x00, 0x00, 0x20, 0x74, 0x72, 0x65, 0x78,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
+			0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d,
+			0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74,
+			0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
 					TimeScale: 90000,
-					Codec: &codecs.AC3{
+					Codec: &codecs.EAC3{
 						SampleRate:   48000,
-						ChannelCount: 6,
-						Fscod:        0x0,
-						Bsid:         0x8,
-						Bsmod:        0x0,
-						Acmod:        0x7,
+						ChannelCount: 8, // 7.1 surround
+						DataRate:     768,
+						Asvc:         false,
+						Bsmod:        0,
+						Acmod:        7,
 						LfeOn:        true,
-						BitRateCode:  0xf,
+						NumDepSub:    1,           // 1 dependent substream
+						ChanLoc:      0b000011000, // Lrs, Rrs positions
 					},
 				},
 			},
 		},
 	},
-	{ //nolint:dupl
-		"e-ac-3 5.1",
+	{
+		"ac-4",
 		[]byte{ //nolint:dupl
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x32, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0x4e, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -1313,7 +2562,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x96,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0xb2,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1326,10 +2575,10 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x32, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x01, 0x4e, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xbb, 0x80,
 			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1337,62 +2586,85 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
-			0xdd, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0xf9, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xa1, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x55, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0xbd, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x71, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x45, 0x65, 0x63, 0x2d,
-			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x61, 0x61, 0x63, 0x2d,
+			0x34, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x06, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
 			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x0d, 0x64, 0x65, 0x63, 0x33, 0x14, 0x00, 0x20,
-			0x0f, 0x00, 0x00, 0x00, 0x00, 0x14, 0x62, 0x74,
-			0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-			0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x00,
-			0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x29, 0x64, 0x61, 0x63, 0x34, 0x20, 0xa4, 0x01,
+			0x00, 0x00, 0x00, 0x00, 0x1f, 0xff, 0xff, 0xff,
+			0xe0, 0x01, 0x13, 0xf8, 0x00, 0x00, 0x08, 0x40,
+			0x00, 0x00, 0x4a, 0x02, 0x00, 0x00, 0x00, 0x66,
+			0x1b, 0x2b, 0x73, 0x38, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x01,
+			0xf7, 0x39, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
+			0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74,
-			0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d, 0x76,
-			0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74, 0x72,
-			0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74,
+			0x73, 0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00,
+			0x00, 0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00,
+			0x00, 0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
-					TimeScale: 90000,
-					Codec: &codecs.EAC3{
+					TimeScale: 48000,
+					Codec: &codecs.AC4{
 						SampleRate:   48000,
-						ChannelCount: 6, // 5.1 surround
-						DataRate:     640,
-						Asvc:         false,
-						Bsmod:        0,
-						Acmod:        7, // 3/2 (L, C, R, Ls, Rs)
-						LfeOn:        true,
-						NumDepSub:    0,
-						ChanLoc:      0,
+						ChannelCount: 2,
+						DSI: &ac4.DSI{
+							BitstreamVersion: 2,
+							FsIndex:          1,
+							FrameRateIndex:   2,
+							BitRate: ac4.DSIBitRate{
+								Precision: 0xFFFFFFFF,
+							},
+							Presentations: []ac4.DSIPresentation{{
+								Version:      1,
+								Config:       0x1F,
+								Enabled:      true,
+								ChannelCoded: true,
+								ChannelMode:  ac4.ChannelModeStereo,
+								ChannelMask:  1,
+								SubstreamGroups: []ac4.DSISubstreamGroup{{
+									SubstreamsPresent: true,
+									ChannelCoded:      true,
+									Substreams: []ac4.DSISubstream{{
+										ChannelMask: 1,
+									}},
+									ContentType: &ac4.ContentType{
+										Classifier:  1,
+										LanguageTag: "eng",
+									},
+								}},
+							}},
+						},
 					},
 				},
 			},
 		},
 	},
-	{ //nolint:dupl
-		"e-ac-3 7.1",
+	{
+		"lpcm",
 		[]byte{ //nolint:dupl
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
@@ -1446,13 +2718,13 @@ var casesInit = []struct {
 			0x01, 0x00, 0x00, 0x00, 0xa2, 0x73, 0x74, 0x62,
 			0x6c, 0x00, 0x00, 0x00, 0x56, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x46, 0x65, 0x63, 0x2d,
-			0x33, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x46, 0x69, 0x70, 0x63,
+			0x6d, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x08, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x18, 0x00, 0x00, 0x00,
 			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x0e, 0x64, 0x65, 0x63, 0x33, 0x18, 0x00, 0x20,
-			0x0f, 0x02, 0x18, 0x00, 0x00, 0x00, 0x14, 0x62,
+			0x0e, 0x70, 0x63, 0x6d, 0x43, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x18, 0x00, 0x00, 0x00, 0x14, 0x62,
 			0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00,
 			0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00,
@@ -1475,29 +2747,23 @@ var casesInit = []struct {
 				{
 					ID:        1,
 					TimeScale: 90000,
-					Codec: &codecs.EAC3{
+					Codec: &codecs.LPCM{
+						BitDepth:     24,
 						SampleRate:   48000,
-						ChannelCount: 8, // 7.1 surround
-						DataRate:     768,
-						Asvc:         false,
-						Bsmod:        0,
-						Acmod:        7,
-						LfeOn:        true,
-						NumDepSub:    1,           // 1 dependent substream
-						ChanLoc:      0b000011000, // Lrs, Rrs positions
+						ChannelCount: 2,
 					},
 				},
 			},
 		},
 	},
 	{
-		"lpcm",
-		[]byte{ //nolint:dupl
+		"g711",
+		[]byte{
 			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
 			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
 			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
-			0x00, 0x00, 0x02, 0x33, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x02, 0x25, 0x6d, 0x6f, 0x6f, 0x76,
 			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
@@ -1511,7 +2777,7 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x97,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x89,
 			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
 			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1524,10 +2790,10 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x33, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x01, 0x25, 0x6d, 0x64, 0x69, 0x61,
 			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x5f, 0x90,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f, 0x40,
 			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x2d, 0x68, 0x64, 0x6c, 0x72,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1535,50 +2801,133 @@ var casesInit = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x53, 0x6f, 0x75, 0x6e, 0x64, 0x48, 0x61, 0x6e,
 			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
-			0xde, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0xd0, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x10, 0x73, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
 			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0xa2, 0x73, 0x74, 0x62,
-			0x6c, 0x00, 0x00, 0x00, 0x56, 0x73, 0x74, 0x73,
+			0x01, 0x00, 0x00, 0x00, 0x94, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x48, 0x73, 0x74, 0x73,
 			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0x00, 0x00, 0x00, 0x46, 0x69, 0x70, 0x63,
-			0x6d, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x38, 0x75, 0x6c, 0x61,
+			0x77, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x02, 0x00, 0x18, 0x00, 0x00, 0x00,
-			0x00, 0xbb, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x0e, 0x70, 0x63, 0x6d, 0x43, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x18, 0x00, 0x00, 0x00, 0x14, 0x62,
-			0x74, 0x72, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7, 0x39, 0x00,
-			0x00, 0x00, 0x10, 0x73, 0x74, 0x74, 0x73, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x10, 0x00, 0x00, 0x00,
+			0x00, 0x1f, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7,
+			0x39, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73,
+			0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73,
+			0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x10, 0x73, 0x74, 0x73, 0x63, 0x00,
+			0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x14, 0x73, 0x74, 0x73, 0x7a, 0x00,
+			0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+		Init{
+			Tracks: []*InitTrack{
+				{
+					ID:        1,
+					TimeScale: 8000,
+					Codec: &codecs.G711{
+						MULaw:        true,
+						SampleRate:   8000,
+						ChannelCount: 1,
+					},
+				},
+			},
+		},
+	},
+	{
+		"cea608",
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x69, 0x73, 0x6f, 0x6d, 0x68, 0x6c, 0x73, 0x66,
+			0x00, 0x00, 0x02, 0x15, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x73,
-			0x74, 0x63, 0x6f, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x28, 0x6d,
-			0x76, 0x65, 0x78, 0x00, 0x00, 0x00, 0x20, 0x74,
-			0x72, 0x65, 0x78, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x01, 0x79,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x15, 0x6d, 0x64, 0x69, 0x61,
+			0x00, 0x00, 0x00, 0x20, 0x6d, 0x64, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x75, 0x30,
+			0x00, 0x00, 0x00, 0x00, 0x55, 0xc4, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x35, 0x68, 0x64, 0x6c, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x63, 0x6c, 0x63, 0x70, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x43, 0x61,
+			0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x6e,
+			0x64, 0x6c, 0x65, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0xb8, 0x6d, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x0c, 0x6e, 0x6d, 0x68, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x24, 0x64, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x1c, 0x64, 0x72, 0x65,
+			0x66, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0c, 0x75, 0x72, 0x6c,
+			0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x80, 0x73, 0x74, 0x62, 0x6c, 0x00, 0x00, 0x00,
+			0x34, 0x73, 0x74, 0x73, 0x64, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x24, 0x63, 0x36, 0x30, 0x38, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x14, 0x62, 0x74, 0x72, 0x74, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0xf7, 0x39, 0x00, 0x01, 0xf7,
+			0x39, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x74,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x10, 0x73, 0x74, 0x73,
+			0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x14, 0x73, 0x74, 0x73,
+			0x7a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x10, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x28, 0x6d, 0x76, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x20, 0x74, 0x72, 0x65, 0x78, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00,
 		},
 		Init{
 			Tracks: []*InitTrack{
 				{
 					ID:        1,
-					TimeScale: 90000,
-					Codec: &codecs.LPCM{
-						BitDepth:     24,
-						SampleRate:   48000,
-						ChannelCount: 2,
-					},
+					TimeScale: 30000,
+					Codec:     &codecs.CEA608{},
 				},
 			},
 		},
@@ -2208,6 +3557,10 @@ func TestInitUnmarshalOnly(t *testing.T) {
 							0x44, 0x01, 0xc0, 0x25, 0x2f, 0x05, 0x32, 0x40,
 						},
 					},
+				}, {
+					ID:        2,
+					TimeScale: 30000,
+					Codec:     &codecs.CEA608{},
 				}},
 			},
 		},
@@ -2320,7 +3673,7 @@ func TestInitUnmarshalOnly(t *testing.T) {
 			},
 		},
 		{
-			"ignored closed captions",
+			"closed captions from file",
 			[]byte{
 				0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
 				0x6d, 0x70, 0x34, 0x32, 0x00, 0x00, 0x00, 0x01,
@@ -2478,6 +3831,10 @@ func TestInitUnmarshalOnly(t *testing.T) {
 							0x28, 0xf9, 0x09, 0x09, 0xcb,
 						},
 					},
+				}, {
+					ID:        2,
+					TimeScale: 30000,
+					Codec:     &codecs.CEA608{},
 				}},
 			},
 		},
@@ -2502,6 +3859,162 @@ func TestInitMarshal(t *testing.T) {
 	}
 }
 
+func TestInitDec3Definition(t *testing.T) {
+	var enc []byte
+	for _, ca := range casesInit {
+		if ca.name == "e-ac-3 5.1" {
+			enc = ca.enc
+		}
+	}
+
+	var init Init
+	err := init.Unmarshal(bytes.NewReader(enc))
+	require.NoError(t, err)
+
+	var buf seekablebuffer.Buffer
+	err = init.Marshal(&buf)
+	require.NoError(t, err)
+
+	// the dec3 definition provided by go-mp4 must not be replaced
+	boxes, err := amp4.ExtractBoxWithPayload(bytes.NewReader(enc), nil, amp4.BoxPath{
+		amp4.BoxTypeMoov(), amp4.BoxTypeTrak(), amp4.BoxTypeMdia(), amp4.BoxTypeMinf(),
+		amp4.BoxTypeStbl(), amp4.BoxTypeStsd(), amp4.BoxTypeEC3(), amp4.BoxTypeDec3(),
+	})
+	require.NoError(t, err)
+	require.Len(t, boxes, 1)
+	require.IsType(t, &amp4.Dec3{}, boxes[0].Payload)
+}
+
+func TestInitMarshalH265ProfileFromVPS(t *testing.T) {
+	init := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 90000,
+			Codec: &codecs.H265{
+				// profile 1, level 123, progressive and frame-only constraints
+				VPS: []byte{
+					0x40, 0x01, 0x0c, 0x01, 0xff, 0xff, 0x01, 0x40,
+					0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+					0x00, 0x00, 0x03, 0x00, 0x7b, 0x11, 0xc0, 0xc0,
+					0x00, 0x00, 0x03, 0x00, 0x40, 0x00, 0x00, 0x0f,
+					0x14,
+				},
+				// empty profile_tier_level
+				SPS: []byte{
+					0x42, 0x01, 0x01, 0x00, 0x00, 0x03, 0x00, 0x00,
+					0x03, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03, 0x00,
+					0x00, 0x03, 0x00, 0x00, 0xa0, 0x03, 0xc0, 0x80,
+					0x10, 0xe5, 0x96, 0x66, 0x69, 0x24, 0xca, 0xe0,
+					0x10, 0x00, 0x00, 0x03, 0x00, 0x10, 0x00, 0x00,
+					0x03, 0x01, 0xe0, 0x80,
+				},
+				PPS: []byte{0x08},
+			},
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := init.Marshal(&buf)
+	require.NoError(t, err)
+
+	boxes, err := amp4.ExtractBoxWithPayload(bytes.NewReader(buf.Bytes()), nil, amp4.BoxPath{
+		amp4.BoxTypeMoov(), amp4.BoxTypeTrak(), amp4.BoxTypeMdia(), amp4.BoxTypeMinf(),
+		amp4.BoxTypeStbl(), amp4.BoxTypeStsd(), amp4.BoxTypeHvc1(), amp4.BoxTypeHvcC(),
+	})
+	require.NoError(t, err)
+	require.Len(t, boxes, 1)
+
+	hvcC := boxes[0].Payload.(*amp4.HvcC)
+	require.Equal(t, uint8(0), hvcC.GeneralProfileSpace)
+	require.Equal(t, false, hvcC.GeneralTierFlag)
+	require.Equal(t, uint8(1), hvcC.GeneralProfileIdc)
+	require.Equal(t, [32]bool{false, true}, hvcC.GeneralProfileCompatibility)
+	require.Equal(t, [6]uint8{0x90, 0, 0, 0, 0, 0}, hvcC.GeneralConstraintIndicator)
+	require.Equal(t, uint8(123), hvcC.GeneralLevelIdc)
+}
+
+func TestInitMarshalUnparsableVideoConfig(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		codec codecs.Codec
+	}{
+		{
+			"mpeg-4 video",
+			&codecs.MPEG4Video{
+				Config: []byte{0x00, 0x00, 0x01, 0xb0, 0x01},
+			},
+		},
+		{
+			"mpeg-1 video",
+			&codecs.MPEG1Video{
+				Config: []byte{0x00, 0x00, 0x01, 0xb3, 0x78},
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			init := Init{
+				Tracks: []*InitTrack{{
+					ID:        1,
+					TimeScale: 90000,
+					Codec:     ca.codec,
+				}},
+			}
+
+			var buf seekablebuffer.Buffer
+			err := init.Marshal(&buf)
+			require.NoError(t, err)
+
+			boxes, err := amp4.ExtractBoxWithPayload(bytes.NewReader(buf.Bytes()), nil, amp4.BoxPath{
+				amp4.BoxTypeMoov(), amp4.BoxTypeTrak(), amp4.BoxTypeMdia(), amp4.BoxTypeMinf(),
+				amp4.BoxTypeStbl(), amp4.BoxTypeStsd(), amp4.BoxTypeMp4v(),
+			})
+			require.NoError(t, err)
+			require.Len(t, boxes, 1)
+
+			entry := boxes[0].Payload.(*amp4.VisualSampleEntry)
+			require.Equal(t, uint16(800), entry.Width)
+			require.Equal(t, uint16(600), entry.Height)
+
+			var init2 Init
+			err = init2.Unmarshal(bytes.NewReader(buf.Bytes()))
+			require.NoError(t, err)
+			require.Equal(t, ca.codec, init2.Tracks[0].Codec)
+		})
+	}
+}
+
+func TestInitUnmarshalFLACInvalidMetadataBlock(t *testing.T) {
+	init := Init{
+		Tracks: []*InitTrack{{
+			ID:        1,
+			TimeScale: 44100,
+			Codec: &codecs.FLAC{
+				StreamInfo: &flac.StreamInfo{
+					SampleRate:   44100,
+					ChannelCount: 2,
+					BitDepth:     16,
+				},
+				MetadataBlocks: []flac.MetadataBlock{
+					// VORBIS_COMMENT with an invalid vendor length
+					&flac.UnknownMetadataBlock{
+						Type: flac.MetadataBlockTypeVorbisComment,
+						Data: []byte{0xff, 0xff, 0xff, 0xff},
+					},
+				},
+			},
+		}},
+	}
+
+	var buf seekablebuffer.Buffer
+	err := init.Marshal(&buf)
+	require.NoError(t, err)
+
+	var init2 Init
+	err = init2.Unmarshal(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, init, init2)
+}
+
 func TestInitMarshalEmptyParameters(t *testing.T) {
 	for _, ca := range []struct {
 		name  string
@@ -2511,6 +4024,10 @@ func TestInitMarshalEmptyParameters(t *testing.T) {
 			"av1",
 			&codecs.AV1{},
 		},
+		{
+			"vp8",
+			&codecs.VP8{},
+		},
 		{
 			"vp9",
 			&codecs.VP9{},
@@ -2519,6 +4036,10 @@ func TestInitMarshalEmptyParameters(t *testing.T) {
 			"h265",
 			&codecs.H265{},
 		},
+		{
+			"h266",
+			&codecs.H266{},
+		},
 		{
 			"h264",
 			&codecs.H264{},
@@ -2535,6 +4056,10 @@ func TestInitMarshalEmptyParameters(t *testing.T) {
 			"mjpeg",
 			&codecs.MJPEG{},
 		},
+		{
+			"ac-4",
+			&codecs.AC4{},
+		},
 	} {
 		t.Run(ca.name, func(t *testing.T) {
 			i := Init{
@@ -42,6 +42,7 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		|    |    |minf|
 		|    |    |    |vmhd| (video)
 		|    |    |    |smhd| (audio)
+		|    |    |    |nmhd| (closed captions)
 		|    |    |    |dinf|
 		|    |    |    |    |dref|
 		|    |    |    |    |    |url|
@@ -67,7 +68,8 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		return err
 	}
 
-	if it.Codec.IsVideo() {
+	switch {
+	case it.Codec.IsVideo():
 		_, err = w.WriteBox(&amp4.Tkhd{ // <tkhd/>
 			FullBox: amp4.FullBox{
 				Flags: [3]byte{0, 0, 3},
@@ -80,7 +82,20 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		if err != nil {
 			return err
 		}
-	} else {
+
+	case imp4.IsClosedCaptions(it.Codec):
+		_, err = w.WriteBox(&amp4.Tkhd{ // <tkhd/>
+			FullBox: amp4.FullBox{
+				Flags: [3]byte{0, 0, 3},
+			},
+			TrackID: uint32(it.ID),
+			Matrix:  [9]int32{0x10000, 0, 0, 0, 0x10000, 0, 0, 0, 0x40000000},
+		})
+		if err != nil {
+			return err
+		}
+
+	default:
 		_, err = w.WriteBox(&amp4.Tkhd{ // <tkhd/>
 			FullBox: amp4.FullBox{
 				Flags: [3]byte{0, 0, 3},
@@ -108,7 +123,8 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		return err
 	}
 
-	if it.Codec.IsVideo() {
+	switch {
+	case it.Codec.IsVideo():
 		_, err = w.WriteBox(&amp4.Hdlr{ // <hdlr/>
 			HandlerType: [4]byte{'v', 'i', 'd', 'e'},
 			Name:        "VideoHandler",
@@ -116,7 +132,17 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		if err != nil {
 			return err
 		}
-	} else {
+
+	case imp4.IsClosedCaptions(it.Codec):
+		_, err = w.WriteBox(&amp4.Hdlr{ // <hdlr/>
+			HandlerType: [4]byte{'c', 'l', 'c', 'p'},
+			Name:        "ClosedCaptionHandler",
+		})
+		if err != nil {
+			return err
+		}
+
+	default:
 		_, err = w.WriteBox(&amp4.Hdlr{ // <hdlr/>
 			HandlerType: [4]byte{'s', 'o', 'u', 'n'},
 			Name:        "SoundHandler",
@@ -131,7 +157,8 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		return err
 	}
 
-	if it.Codec.IsVideo() {
+	switch {
+	case it.Codec.IsVideo():
 		_, err = w.WriteBox(&amp4.Vmhd{ // <vmhd/>
 			FullBox: amp4.FullBox{
 				Flags: [3]byte{0, 0, 1},
@@ -140,7 +167,14 @@ func (it InitTrack) marshal(w *imp4.Writer) error {
 		if err != nil {
 			return err
 		}
-	} else {
+
+	case imp4.IsClosedCaptions(it.Codec):
+		_, err = w.WriteBox(&imp4.Nmhd{}) // <nmhd/>
+		if err != nil {
+			return err
+		}
+
+	default:
 		_, err = w.WriteBox(&amp4.Smhd{}) // <smhd/>
 		if err != nil {
 			return err
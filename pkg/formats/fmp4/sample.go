@@ -4,6 +4,7 @@ import (
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
 )
 
 // PartSample is a fMP4 sample.
@@ -41,6 +42,43 @@ func (ps *Sample) FillAV1(tu [][]byte) error {
 	return nil
 }
 
+// FillAV12 fills a Sample with AV1 data.
+// Unlike FillAV1, frame headers are decoded and the sample is marked as sync
+// only when it contains a sequence header and a shown key frame.
+// sh is the active sequence header. The sequence header that is active
+// after the temporal unit is returned.
+func (ps *Sample) FillAV12(sh *av1.SequenceHeader, tu [][]byte) (*av1.SequenceHeader, error) {
+	var info av1.TemporalUnitInfo
+	err := info.Unmarshal(sh, tu)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := av1.Bitstream(tu).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	ps.IsNonSyncSample = !info.IsSync()
+	ps.Payload = bs
+
+	return info.SequenceHeader, nil
+}
+
+// FillH266 fills a Sample with H266 data.
+func (ps *Sample) FillH266(ptsOffset int32, au [][]byte) error {
+	avcc, err := h264.AVCC(au).Marshal()
+	if err != nil {
+		return err
+	}
+
+	ps.PTSOffset = ptsOffset
+	ps.IsNonSyncSample = !h266.IsRandomAccess(au)
+	ps.Payload = avcc
+
+	return nil
+}
+
 // NewSampleH265 creates a sample with H265 data.
 //
 // Deprecated: replaced by FillH265.
@@ -98,6 +136,11 @@ func (ps Sample) GetAV1() ([][]byte, error) {
 	return tu, nil
 }
 
+// GetH266 gets H266 data from the sample.
+func (ps Sample) GetH266() ([][]byte, error) {
+	return ps.GetH264()
+}
+
 // GetH265 gets H265 data from the sample.
 func (ps Sample) GetH265() ([][]byte, error) {
 	return ps.GetH264()
@@ -0,0 +1,37 @@
+package fmp4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
+)
+
+func TestSampleFillAV12(t *testing.T) {
+	sequenceHeader := []byte{
+		8, 0, 0, 0, 66, 167, 191, 228, 96, 13, 0, 64,
+	}
+	keyFrame := []byte{0x32, 0x02, 0x10, 0x00}
+	interFrame := []byte{0x1a, 0x06, 0x32, 0x00, 0x10, 0x53, 0x97, 0x00}
+
+	var ps Sample
+	sh, err := ps.FillAV12(nil, [][]byte{sequenceHeader, keyFrame})
+	require.NoError(t, err)
+	require.NotNil(t, sh)
+	require.False(t, ps.IsNonSyncSample)
+
+	var sh2 *av1.SequenceHeader
+	sh2, err = ps.FillAV12(sh, [][]byte{sequenceHeader, interFrame})
+	require.NoError(t, err)
+	require.Equal(t, sh, sh2)
+	require.True(t, ps.IsNonSyncSample)
+
+	// FillAV1 only looks for the sequence header
+	err = ps.FillAV1([][]byte{sequenceHeader, interFrame})
+	require.NoError(t, err)
+	require.False(t, ps.IsNonSyncSample)
+
+	_, err = ps.FillAV12(nil, [][]byte{interFrame})
+	require.EqualError(t, err, "sequence header is missing")
+}
@@ -0,0 +1,18 @@
+package codecs
+
+import "github.com/bluenviron/mediacommon/v2/pkg/codecs/ac4"
+
+// AC4 is the AC-4 codec.
+// DSI can be filled from a raw frame with ac4.DSI.UnmarshalFrame.
+type AC4 struct {
+	SampleRate   int
+	ChannelCount int
+	DSI          *ac4.DSI
+}
+
+// IsVideo implements Codec.
+func (*AC4) IsVideo() bool {
+	return false
+}
+
+func (*AC4) isCodec() {}
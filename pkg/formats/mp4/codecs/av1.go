@@ -3,6 +3,18 @@ package codecs
 // AV1 is the AV1 codec.
 type AV1 struct {
 	SequenceHeader []byte
+
+	// colour description.
+	// When nil, it is derived from the sequence header.
+	ColorInfo *ColorInfo
+
+	// HDR static metadata.
+	// They can be filled with FillHDRMetadata().
+	MasteringDisplay  *MasteringDisplay
+	ContentLightLevel *ContentLightLevel
+
+	// Dolby Vision configuration.
+	DolbyVision *DolbyVisionConfig
 }
 
 // IsVideo implements Codec.
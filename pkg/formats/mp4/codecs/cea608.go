@@ -0,0 +1,12 @@
+package codecs
+
+// CEA608 is the CEA-608 closed caption codec.
+// Samples are in the format described by cea708.C608Sample.
+type CEA608 struct{}
+
+// IsVideo implements Codec.
+func (*CEA608) IsVideo() bool {
+	return false
+}
+
+func (*CEA608) isCodec() {}
@@ -0,0 +1,17 @@
+package codecs
+
+// DolbyVisionConfig is a Dolby Vision decoder configuration.
+// Specification: Dolby Vision Streams Within the ISO Base Media File Format, section 3.2
+type DolbyVisionConfig struct {
+	VersionMajor uint8
+	VersionMinor uint8
+	Profile      uint8
+	Level        uint8
+	RPUPresent   bool
+	ELPresent    bool
+	BLPresent    bool
+
+	// 0 means that the base layer is not compatible with other players,
+	// and the track uses a Dolby Vision sample entry (dvh1, dav1).
+	BLSignalCompatibilityID uint8
+}
@@ -1,7 +1,31 @@
 package codecs
 
+// EAC3Substream is an additional independent substream of an E-AC-3 track.
+type EAC3Substream struct {
+	// Asvc indicates if this is an associated audio service (1 bit).
+	Asvc bool
+
+	// Bsmod is the bit stream mode (3 bits), indicating the type of service.
+	Bsmod uint8
+
+	// Acmod is the audio coding mode (3 bits), indicating channel configuration.
+	Acmod uint8
+
+	// LfeOn indicates if the LFE (Low Frequency Effects) channel is present (1 bit).
+	LfeOn bool
+
+	// NumDepSub is the number of dependent substreams associated with this
+	// independent substream (4 bits).
+	NumDepSub uint8
+
+	// ChanLoc is the channel location bitmap for dependent substreams (9 bits).
+	// Only valid when NumDepSub > 0.
+	ChanLoc uint16
+}
+
 // EAC3 is the E-AC-3 (Enhanced AC-3 / Dolby Digital Plus) codec.
 // Fields are based on the dec3 (EC3SpecificBox) structure per ETSI TS 102 366.
+// They can be filled from sync frames with eac3.Config.UnmarshalFrames.
 type EAC3 struct {
 	SampleRate   int
 	ChannelCount int
@@ -10,7 +34,6 @@ type EAC3 struct {
 	DataRate uint16
 
 	// Fields below are for the first (primary) independent substream.
-	// For multi-substream content, additional parsing would be needed.
 
 	// Asvc indicates if this is an associated audio service (1 bit).
 	Asvc bool
@@ -31,6 +54,15 @@ type EAC3 struct {
 	// ChanLoc is the channel location bitmap for dependent substreams (9 bits).
 	// Only valid when NumDepSub > 0.
 	ChanLoc uint16
+
+	// AdditionalSubstreams are independent substreams that follow the first one.
+	AdditionalSubstreams []EAC3Substream
+
+	// FlagEC3ExtensionTypeA indicates that Joint Object Coding (Dolby Atmos) is in use.
+	FlagEC3ExtensionTypeA bool
+
+	// ComplexityIndexTypeA is the number of Joint Object Coding objects.
+	ComplexityIndexTypeA uint8
 }
 
 // IsVideo implements Codec.
@@ -5,6 +5,9 @@ import "github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
 // FLAC is the FLAC codec.
 type FLAC struct {
 	StreamInfo *flac.StreamInfo
+
+	// metadata blocks that follow STREAMINFO.
+	MetadataBlocks []flac.MetadataBlock
 }
 
 // IsVideo implements Codec.
@@ -0,0 +1,15 @@
+package codecs
+
+// G711 is the G711 codec.
+type G711 struct {
+	MULaw        bool
+	SampleRate   int
+	ChannelCount int
+}
+
+// IsVideo implements Codec.
+func (*G711) IsVideo() bool {
+	return false
+}
+
+func (*G711) isCodec() {}
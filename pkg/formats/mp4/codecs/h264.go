@@ -4,6 +4,15 @@ package codecs
 type H264 struct {
 	SPS []byte
 	PPS []byte
+
+	// colour description.
+	// When nil, it is derived from the SPS.
+	ColorInfo *ColorInfo
+
+	// HDR static metadata.
+	// They can be filled with FillHDRMetadata().
+	MasteringDisplay  *MasteringDisplay
+	ContentLightLevel *ContentLightLevel
 }
 
 // IsVideo implements Codec.
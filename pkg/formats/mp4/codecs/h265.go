@@ -5,6 +5,18 @@ type H265 struct {
 	VPS []byte
 	SPS []byte
 	PPS []byte
+
+	// colour description.
+	// When nil, it is derived from the SPS.
+	ColorInfo *ColorInfo
+
+	// HDR static metadata.
+	// They can be filled with FillHDRMetadata().
+	MasteringDisplay  *MasteringDisplay
+	ContentLightLevel *ContentLightLevel
+
+	// Dolby Vision configuration.
+	DolbyVision *DolbyVisionConfig
 }
 
 // IsVideo implements Codec.
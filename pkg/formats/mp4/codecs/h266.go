@@ -0,0 +1,15 @@
+package codecs
+
+// H266 is the H266 codec.
+type H266 struct {
+	VPS []byte // optional
+	SPS []byte
+	PPS []byte
+}
+
+// IsVideo implements Codec.
+func (*H266) IsVideo() bool {
+	return true
+}
+
+func (*H266) isCodec() {}
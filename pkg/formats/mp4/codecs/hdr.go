@@ -0,0 +1,199 @@
+package codecs
+
+import (
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+)
+
+// ColorInfo is the colour description of a video track.
+// Values are the ones defined in ITU-T Rec. H.273.
+// Specification: ISO 14496-12, section 12.1.5
+type ColorInfo struct {
+	ColorPrimaries          uint8
+	TransferCharacteristics uint8
+	MatrixCoefficients      uint8
+	FullRange               bool
+}
+
+// MasteringDisplay describes the colour volume of the display used to master a video track.
+// Values have the same units of the mastering_display_colour_volume SEI message of H265.
+// Specification: ISO 14496-12 (mdcv)
+type MasteringDisplay struct {
+	DisplayPrimariesX [3]uint16
+	DisplayPrimariesY [3]uint16
+	WhitePointX       uint16
+	WhitePointY       uint16
+	MaxLuminance      uint32
+	MinLuminance      uint32
+}
+
+// ContentLightLevel contains the light level of a video track.
+// Values are in candelas per square metre.
+// Specification: ISO 14496-12 (clli)
+type ContentLightLevel struct {
+	MaxContentLightLevel    uint16
+	MaxPicAverageLightLevel uint16
+}
+
+func masteringDisplayFromSEI(sei *h264.SEIMasteringDisplayColourVolume) *MasteringDisplay {
+	return &MasteringDisplay{
+		DisplayPrimariesX: sei.DisplayPrimariesX,
+		DisplayPrimariesY: sei.DisplayPrimariesY,
+		WhitePointX:       sei.WhitePointX,
+		WhitePointY:       sei.WhitePointY,
+		MaxLuminance:      sei.MaxDisplayMasteringLuminance,
+		MinLuminance:      sei.MinDisplayMasteringLuminance,
+	}
+}
+
+func contentLightLevelFromSEI(sei *h264.SEIContentLightLevelInfo) *ContentLightLevel {
+	return &ContentLightLevel{
+		MaxContentLightLevel:    sei.MaxContentLightLevel,
+		MaxPicAverageLightLevel: sei.MaxPicAverageLightLevel,
+	}
+}
+
+func convertAV1Chromaticity(v uint16) uint16 {
+	// from 0.16 fixed point to units of 0.00002
+	return uint16((uint32(v)*50000 + 32768) >> 16)
+}
+
+func masteringDisplayFromAV1(m *av1.MetadataHDRMDCV) *MasteringDisplay {
+	ret := &MasteringDisplay{
+		WhitePointX: convertAV1Chromaticity(m.WhitePointChromaticityX),
+		WhitePointY: convertAV1Chromaticity(m.WhitePointChromaticityY),
+		// from 24.8 and 18.14 fixed point to units of 0.0001 cd/m2
+		MaxLuminance: uint32((uint64(m.LuminanceMax)*10000 + 128) >> 8),
+		MinLuminance: uint32((uint64(m.LuminanceMin)*10000 + 8192) >> 14),
+	}
+
+	// AV1 primaries are in the order red, green, blue,
+	// while mdcv primaries are usually in the order green, blue, red.
+	for i, j := range [3]int{1, 2, 0} {
+		ret.DisplayPrimariesX[i] = convertAV1Chromaticity(m.PrimaryChromaticityX[j])
+		ret.DisplayPrimariesY[i] = convertAV1Chromaticity(m.PrimaryChromaticityY[j])
+	}
+
+	return ret
+}
+
+func fillHDRMetadataFromSEI(
+	payloadType uint32,
+	payload []byte,
+	masteringDisplay **MasteringDisplay,
+	contentLightLevel **ContentLightLevel,
+) error {
+	switch h264.SEIPayloadType(payloadType) {
+	case h264.SEIPayloadTypeMasteringDisplayColourVolume:
+		var sei h264.SEIMasteringDisplayColourVolume
+		err := sei.Unmarshal(payload)
+		if err != nil {
+			return err
+		}
+
+		*masteringDisplay = masteringDisplayFromSEI(&sei)
+
+	case h264.SEIPayloadTypeContentLightLevelInfo:
+		var sei h264.SEIContentLightLevelInfo
+		err := sei.Unmarshal(payload)
+		if err != nil {
+			return err
+		}
+
+		*contentLightLevel = contentLightLevelFromSEI(&sei)
+	}
+
+	return nil
+}
+
+// FillHDRMetadata fills MasteringDisplay and ContentLightLevel
+// with the SEI messages contained in an access unit, if present.
+func (c *H264) FillHDRMetadata(au [][]byte) error {
+	for _, nalu := range au {
+		if len(nalu) < 1 || h264.NALUType(nalu[0]&0x1F) != h264.NALUTypeSEI {
+			continue
+		}
+
+		var sei h264.SEI
+		err := sei.Unmarshal(nalu)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range sei.Messages {
+			err = fillHDRMetadataFromSEI(uint32(msg.PayloadType), msg.Payload, &c.MasteringDisplay, &c.ContentLightLevel)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FillHDRMetadata fills MasteringDisplay and ContentLightLevel
+// with the SEI messages contained in an access unit, if present.
+func (c *H265) FillHDRMetadata(au [][]byte) error {
+	for _, nalu := range au {
+		if len(nalu) < 2 || h265.NALUType((nalu[0]>>1)&0b111111) != h265.NALUType_PREFIX_SEI_NUT {
+			continue
+		}
+
+		var sei h265.SEI
+		err := sei.Unmarshal(nalu)
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range sei.Messages {
+			err = fillHDRMetadataFromSEI(uint32(msg.PayloadType), msg.Payload, &c.MasteringDisplay, &c.ContentLightLevel)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FillHDRMetadata fills MasteringDisplay and ContentLightLevel
+// with the metadata OBUs contained in a temporal unit, if present.
+func (c *AV1) FillHDRMetadata(tu [][]byte) error {
+	for _, obu := range tu {
+		if len(obu) < 1 || av1.OBUType(obu[0]>>3) != av1.OBUTypeMetadata {
+			continue
+		}
+
+		var m av1.Metadata
+		err := m.Unmarshal(obu)
+		if err != nil {
+			return err
+		}
+
+		switch m.Type {
+		case av1.MetadataTypeHDRMDCV:
+			var mdcv av1.MetadataHDRMDCV
+			err = mdcv.Unmarshal(m.Payload)
+			if err != nil {
+				return err
+			}
+
+			c.MasteringDisplay = masteringDisplayFromAV1(&mdcv)
+
+		case av1.MetadataTypeHDRCLL:
+			var cll av1.MetadataHDRCLL
+			err = cll.Unmarshal(m.Payload)
+			if err != nil {
+				return err
+			}
+
+			c.ContentLightLevel = &ContentLightLevel{
+				MaxContentLightLevel:    cll.MaxCLL,
+				MaxPicAverageLightLevel: cll.MaxFALL,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package codecs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/av1"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+)
+
+var testMasteringDisplay = &MasteringDisplay{
+	DisplayPrimariesX: [3]uint16{13250, 7500, 34000},
+	DisplayPrimariesY: [3]uint16{34500, 3000, 16000},
+	WhitePointX:       15635,
+	WhitePointY:       16450,
+	MaxLuminance:      10000000,
+	MinLuminance:      50,
+}
+
+var testContentLightLevel = &ContentLightLevel{
+	MaxContentLightLevel:    1000,
+	MaxPicAverageLightLevel: 400,
+}
+
+func testHDRSEIMessages(t *testing.T) ([]byte, []byte) {
+	mdcv, err := h264.SEIMasteringDisplayColourVolume{
+		DisplayPrimariesX:            [3]uint16{13250, 7500, 34000},
+		DisplayPrimariesY:            [3]uint16{34500, 3000, 16000},
+		WhitePointX:                  15635,
+		WhitePointY:                  16450,
+		MaxDisplayMasteringLuminance: 10000000,
+		MinDisplayMasteringLuminance: 50,
+	}.Marshal()
+	require.NoError(t, err)
+
+	cll, err := h264.SEIContentLightLevelInfo{
+		MaxContentLightLevel:    1000,
+		MaxPicAverageLightLevel: 400,
+	}.Marshal()
+	require.NoError(t, err)
+
+	return mdcv, cll
+}
+
+func TestH264FillHDRMetadata(t *testing.T) {
+	mdcv, cll := testHDRSEIMessages(t)
+
+	sei, err := h264.SEI{
+		Messages: []h264.SEIMessage{
+			{PayloadType: h264.SEIPayloadTypeMasteringDisplayColourVolume, Payload: mdcv},
+			{PayloadType: h264.SEIPayloadTypeContentLightLevelInfo, Payload: cll},
+		},
+	}.Marshal()
+	require.NoError(t, err)
+
+	var c H264
+	err = c.FillHDRMetadata([][]byte{sei, {0x65, 0x88}})
+	require.NoError(t, err)
+	require.Equal(t, testMasteringDisplay, c.MasteringDisplay)
+	require.Equal(t, testContentLightLevel, c.ContentLightLevel)
+}
+
+func TestH265FillHDRMetadata(t *testing.T) {
+	mdcv, cll := testHDRSEIMessages(t)
+
+	sei, err := h265.SEI{
+		Messages: []h265.SEIMessage{
+			{PayloadType: h265.SEIPayloadTypeMasteringDisplayColourVolume, Payload: mdcv},
+			{PayloadType: h265.SEIPayloadTypeContentLightLevelInfo, Payload: cll},
+		},
+	}.Marshal()
+	require.NoError(t, err)
+
+	var c H265
+	err = c.FillHDRMetadata([][]byte{sei, {0x26, 0x01, 0xaf}})
+	require.NoError(t, err)
+	require.Equal(t, testMasteringDisplay, c.MasteringDisplay)
+	require.Equal(t, testContentLightLevel, c.ContentLightLevel)
+}
+
+func TestAV1FillHDRMetadata(t *testing.T) {
+	mdcv, err := av1.MetadataHDRMDCV{
+		PrimaryChromaticityX:    [3]uint16{0xae14, 0x43d7, 0x2666},
+		PrimaryChromaticityY:    [3]uint16{0x51ec, 0xb0a4, 0x0f5c},
+		WhitePointChromaticityX: 0x500d,
+		WhitePointChromaticityY: 0x5439,
+		LuminanceMax:            1000 << 8,
+		LuminanceMin:            0x52,
+	}.Marshal()
+	require.NoError(t, err)
+
+	mdcvOBU, err := av1.Metadata{Type: av1.MetadataTypeHDRMDCV, Payload: mdcv}.Marshal()
+	require.NoError(t, err)
+
+	cll, err := av1.MetadataHDRCLL{MaxCLL: 1000, MaxFALL: 400}.Marshal()
+	require.NoError(t, err)
+
+	cllOBU, err := av1.Metadata{Type: av1.MetadataTypeHDRCLL, Payload: cll}.Marshal()
+	require.NoError(t, err)
+
+	var c AV1
+	err = c.FillHDRMetadata([][]byte{{0x12, 0x00}, mdcvOBU, cllOBU})
+	require.NoError(t, err)
+	require.Equal(t, testMasteringDisplay, c.MasteringDisplay)
+	require.Equal(t, testContentLightLevel, c.ContentLightLevel)
+}
@@ -0,0 +1,14 @@
+package codecs
+
+// VP8 is the VP8 codec.
+type VP8 struct {
+	Width  int
+	Height int
+}
+
+// IsVideo implements Codec.
+func (*VP8) IsVideo() bool {
+	return true
+}
+
+func (*VP8) isCodec() {}
@@ -8,6 +8,13 @@ type VP9 struct {
 	BitDepth          uint8
 	ChromaSubsampling uint8
 	ColorRange        bool
+
+	// colour description.
+	ColorInfo *ColorInfo
+
+	// HDR static metadata.
+	MasteringDisplay  *MasteringDisplay
+	ContentLightLevel *ContentLightLevel
 }
 
 // IsVideo implements Codec.
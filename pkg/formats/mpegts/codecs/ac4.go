@@ -0,0 +1,18 @@
+package codecs
+
+import "github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/substructs"
+
+// AC4 is an AC-4 codec.
+// Specification: ETSI EN 300 468, Annex D.7
+type AC4 struct {
+	Desc         *substructs.AC4Descriptor
+	SampleRate   int
+	ChannelCount int
+}
+
+// IsVideo implements Codec.
+func (*AC4) IsVideo() bool {
+	return false
+}
+
+func (*AC4) isCodec() {}
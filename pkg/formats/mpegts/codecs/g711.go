@@ -0,0 +1,15 @@
+package codecs
+
+// G711 is a G711 codec.
+// It is carried in a private stream with a registration descriptor.
+// Sample rate is always 8000 and channel count is always 1.
+type G711 struct {
+	MULaw bool
+}
+
+// IsVideo implements Codec.
+func (*G711) IsVideo() bool {
+	return false
+}
+
+func (*G711) isCodec() {}
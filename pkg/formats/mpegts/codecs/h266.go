@@ -0,0 +1,17 @@
+package codecs
+
+// H266 is a H266 codec.
+// Specification: ISO 13818-1
+type H266 struct {
+	// in Go, empty structs share the same pointer,
+	// therefore they cannot be used as map keys
+	// or in equality operations. Prevent this.
+	unused int //nolint:unused
+}
+
+// IsVideo implements Codec.
+func (*H266) IsVideo() bool {
+	return true
+}
+
+func (*H266) isCodec() {}
@@ -7,9 +7,11 @@ import (
 	"github.com/asticode/go-astits"
 
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac3"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac4"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/eac3"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/codecs"
@@ -23,6 +25,9 @@ type ReaderOnDecodeErrorFunc func(err error)
 // ReaderOnDataH264Func is the prototype of the callback passed to OnDataH264.
 type ReaderOnDataH264Func func(pts int64, dts int64, au [][]byte) error
 
+// ReaderOnDataH266Func is the prototype of the callback passed to OnDataH266.
+type ReaderOnDataH266Func func(pts int64, dts int64, au [][]byte) error
+
 // ReaderOnDataH265Func is the prototype of the callback passed to OnDataH265.
 type ReaderOnDataH265Func func(pts int64, dts int64, au [][]byte) error
 
@@ -47,6 +52,12 @@ type ReaderOnDataAC3Func func(pts int64, frame []byte) error
 // ReaderOnDataEAC3Func is the prototype of the callback passed to OnDataEAC3.
 type ReaderOnDataEAC3Func func(pts int64, frame []byte) error
 
+// ReaderOnDataAC4Func is the prototype of the callback passed to OnDataAC4.
+type ReaderOnDataAC4Func func(pts int64, frame []byte) error
+
+// ReaderOnDataG711Func is the prototype of the callback passed to OnDataG711.
+type ReaderOnDataG711Func func(pts int64, samples []byte) error
+
 // ReaderOnDataKLVFunc is the prototype of the callback passed to OnDataKLV.
 type ReaderOnDataKLVFunc func(pts int64, data []byte) error
 
@@ -255,6 +266,24 @@ func (r *Reader) OnDecodeError(cb ReaderOnDecodeErrorFunc) {
 	r.dem.OnDecodeError = cb
 }
 
+// OnDataH266 sets a callback that is called when data from an H266 track is received.
+func (r *Reader) OnDataH266(track *Track, cb ReaderOnDataH266Func) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		var au h264.AnnexB
+		err := au.Unmarshal(data)
+		if err != nil {
+			r.onDecodeError(err)
+			return nil
+		}
+
+		if len(au[0]) >= 2 && h266.NALUType(au[0][1]>>3) == h266.NALUType_AUD_NUT {
+			au = au[1:]
+		}
+
+		return cb(pts, dts, au)
+	}
+}
+
 // OnDataH265 sets a callback that is called when data from an H265 track is received.
 func (r *Reader) OnDataH265(track *Track, cb ReaderOnDataH265Func) {
 	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
@@ -459,6 +488,37 @@ func (r *Reader) OnDataEAC3(track *Track, cb ReaderOnDataEAC3Func) {
 	}
 }
 
+// OnDataAC4 sets a callback that is called when data from an AC-4 track is received.
+// The sync frame header is removed, therefore the callback receives raw frames.
+func (r *Reader) OnDataAC4(track *Track, cb ReaderOnDataAC4Func) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		if pts != dts {
+			r.onDecodeError(fmt.Errorf("PTS is not equal to DTS"))
+			return nil
+		}
+
+		frame, err := ac4.UnmarshalSyncFrame(data)
+		if err != nil {
+			r.onDecodeError(err)
+			return nil
+		}
+
+		return cb(pts, frame)
+	}
+}
+
+// OnDataG711 sets a callback that is called when data from a G711 track is received.
+func (r *Reader) OnDataG711(track *Track, cb ReaderOnDataG711Func) {
+	r.onData[track.PID] = func(pts int64, dts int64, data []byte) error {
+		if pts != dts {
+			r.onDecodeError(fmt.Errorf("PTS is not equal to DTS"))
+			return nil
+		}
+
+		return cb(pts, data)
+	}
+}
+
 // OnDataKLV sets a callback that is called when data from a KLV track is received.
 func (r *Reader) OnDataKLV(track *Track, cb ReaderOnDataKLVFunc) {
 	codec := track.Codec.(*codecs.KLV)
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/codecs"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/substructs"
@@ -151,6 +152,95 @@ var casesReadWriter = []struct {
 			},
 		},
 	},
+	{
+		"h266",
+		&Track{
+			PID:   257,
+			Codec: &codecs.H266{},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{
+					{0x00, byte(h266.NALUType_CRA_NUT)<<3 | 1},
+				},
+			},
+			{
+				30*90000 + 2*90000,
+				30*90000 + 1*90000,
+				[][]byte{
+					{0x00, byte(h266.NALUType_TRAIL_NUT)<<3 | 1},
+				},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x12, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x33, 0xe1, 0x01,
+					0xf0, 0x00, 0x0d, 0x48, 0x3b, 0xb2,
+				}, bytes.Repeat([]byte{0xff}, 162)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                156,
+					StuffingLength:        149,
+					RandomAccessIndicator: true,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x00, 0x00,
+					0x00, 0x01, 0x00, 0xa1, 0xa8, 0x00, 0x00, 0x00,
+					0x01, 0x00, 0x49,
+				},
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:         151,
+					StuffingLength: 150,
+				},
+				Header: astits.PacketHeader{
+					ContinuityCounter:         1,
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xe0, 0x00, 0x00, 0x80, 0xc0,
+					0x0a, 0x31, 0x00, 0xaf, 0xe4, 0x01, 0x11, 0x00,
+					0xab, 0x24, 0xe1, 0x00, 0x00, 0x00, 0x01, 0x00,
+					0xa1, 0x28, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01,
+				},
+			},
+		},
+	},
 	{
 		"h264",
 		&Track{
@@ -1000,6 +1090,83 @@ var casesReadWriter = []struct {
 			},
 		},
 	},
+	{
+		"ac-4",
+		&Track{
+			PID: 257,
+			Codec: &codecs.AC4{
+				Desc: &substructs.AC4Descriptor{
+					ConfigPresent: true,
+					ChannelMode:   substructs.AC4ChannelModeStereo,
+				},
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				// raw frame containing a TOC with a stereo presentation
+				[][]byte{{
+					0x80, 0x54, 0xb3, 0x00, 0x04, 0xa5, 0x00, 0x6e,
+					0x04, 0xc1, 0xb2, 0xb7, 0x33, 0x80, 0x00, 0x00,
+				}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x17, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x06, 0xe1, 0x01,
+					// extension descriptor containing the AC-4 descriptor
+					0xf0, 0x05, 0x7f, 0x03, 0x15, 0x80, 0x20,
+					// CRC32
+					0x0c, 0x1e, 0x4d, 0x1d,
+				}, bytes.Repeat([]byte{0xff}, 157)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                149,
+					StuffingLength:        142,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+					RandomAccessIndicator: true,
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xbd, 0x00, 0x1c, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1,
+					// sync frame
+					0xac, 0x40, 0x00, 0x10, 0x80, 0x54, 0xb3, 0x00,
+					0x04, 0xa5, 0x00, 0x6e, 0x04, 0xc1, 0xb2, 0xb7,
+					0x33, 0x80, 0x00, 0x00,
+				},
+			},
+		},
+	},
 	{
 		"klv sync",
 		&Track{
@@ -1239,6 +1406,69 @@ var casesReadWriter = []struct {
 			},
 		},
 	},
+	{
+		"g711",
+		&Track{
+			PID: 257,
+			Codec: &codecs.G711{
+				MULaw: true,
+			},
+		},
+		[]sample{
+			{
+				30 * 90000,
+				30 * 90000,
+				[][]byte{{1, 2, 3}},
+			},
+		},
+		[]*astits.Packet{
+			{ // PMT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       0,
+				},
+				Payload: append([]byte{
+					0x00, 0x00, 0xb0, 0x0d, 0x00, 0x00, 0xc1, 0x00,
+					0x00, 0x00, 0x01, 0xf0, 0x00, 0x71, 0x10, 0xd8,
+					0x78,
+				}, bytes.Repeat([]byte{0xff}, 167)...),
+			},
+			{ // PAT
+				Header: astits.PacketHeader{
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       4096,
+				},
+				Payload: append([]byte{
+					0x00, 0x02, 0xb0, 0x18, 0x00, 0x01, 0xc1, 0x00,
+					0x00, 0xe1, 0x01, 0xf0, 0x00, 0x06, 0xe1, 0x01,
+					0xf0, 0x06, 0x05, 0x04, 0x55, 0x4c, 0x41, 0x57,
+					0x11, 0xcf, 0x0e, 0xf2,
+				}, bytes.Repeat([]byte{0xff}, 156)...),
+			},
+			{ // PES
+				AdaptationField: &astits.PacketAdaptationField{
+					Length:                166,
+					StuffingLength:        159,
+					RandomAccessIndicator: true,
+					HasPCR:                true,
+					PCR:                   &astits.ClockReference{Base: 2691000},
+				},
+				Header: astits.PacketHeader{
+					HasAdaptationField:        true,
+					HasPayload:                true,
+					PayloadUnitStartIndicator: true,
+					PID:                       257,
+				},
+				Payload: []byte{
+					0x00, 0x00, 0x01, 0xbd, 0x00, 0x0b, 0x80, 0x80,
+					0x05, 0x21, 0x00, 0xa5, 0x65, 0xc1, 0x01, 0x02,
+					0x03,
+				},
+			},
+		},
+	},
 }
 
 func TestReader(t *testing.T) {
@@ -1259,6 +1489,15 @@ func TestReader(t *testing.T) {
 			i := 0
 
 			switch ca.track.Codec.(type) {
+			case *codecs.H266:
+				r.OnDataH266(ca.track, func(pts int64, dts int64, au [][]byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].dts, dts)
+					require.Equal(t, ca.samples[i].data, au)
+					i++
+					return nil
+				})
+
 			case *codecs.H265:
 				r.OnDataH265(ca.track, func(pts int64, dts int64, au [][]byte) error {
 					require.Equal(t, ca.samples[i].pts, pts)
@@ -1341,6 +1580,22 @@ func TestReader(t *testing.T) {
 					return nil
 				})
 
+			case *codecs.AC4:
+				r.OnDataAC4(ca.track, func(pts int64, frame []byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data[0], frame)
+					i++
+					return nil
+				})
+
+			case *codecs.G711:
+				r.OnDataG711(ca.track, func(pts int64, samples []byte) error {
+					require.Equal(t, ca.samples[i].pts, pts)
+					require.Equal(t, ca.samples[i].data[0], samples)
+					i++
+					return nil
+				})
+
 			case *codecs.KLV:
 				r.OnDataKLV(ca.track, func(pts int64, frame []byte) error {
 					require.Equal(t, ca.samples[i].pts, pts)
@@ -1822,6 +2077,81 @@ func (dummyReader) Read(_ []byte) (int, error) {
 	return 0, errCustom
 }
 
+func TestReaderEAC3TruncatedFrame(t *testing.T) {
+	// 7.1, made of an independent and a dependent substream
+	frames := []byte{
+		0x0b, 0x77, 0x00, 0x0f, 0x3f, 0x86, 0xc8, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x0b, 0x77, 0x40, 0x0f, 0x34, 0x86, 0xd0, 0x20,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	// dependent substream is truncated
+	truncated := frames[:42]
+
+	for _, ca := range []struct {
+		name         string
+		pes          [][]byte
+		channelCount int
+	}{
+		{
+			"next pes",
+			[][]byte{truncated, frames},
+			8,
+		},
+		{
+			"no other pes",
+			[][]byte{truncated},
+			6,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			mux := astits.NewMuxer(context.Background(), &buf)
+
+			err := mux.AddElementaryStream(astits.PMTElementaryStream{
+				ElementaryPID: 123,
+				StreamType:    astits.StreamTypeEAC3Audio,
+			})
+			require.NoError(t, err)
+
+			mux.SetPCRPID(123)
+
+			for i, pes := range ca.pes {
+				_, err = mux.WriteData(&astits.MuxerData{
+					PID: 123,
+					PES: &astits.PESData{
+						Header: &astits.PESHeader{
+							OptionalHeader: &astits.PESOptionalHeader{
+								MarkerBits:      2,
+								PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
+								PTS:             &astits.ClockReference{Base: 90000 + int64(i)*2880},
+							},
+							StreamID: streamIDAudio,
+						},
+						Data: pes,
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			r, err := NewReader(&buf)
+			require.NoError(t, err)
+			require.Equal(t, []*Track{{
+				PID: 123,
+				Codec: &codecs.EAC3{
+					SampleRate:   48000,
+					ChannelCount: ca.channelCount,
+				},
+			}}, r.Tracks())
+		})
+	}
+}
+
 func TestReaderFatalError(t *testing.T) {
 	_, err := NewReader(&dummyReader{})
 	require.Equal(t, errCustom, err)
@@ -0,0 +1,134 @@
+package substructs
+
+import "fmt"
+
+// ETSI EN 300 468, table 109
+const (
+	DescriptorTagExtensionAC4 = 0x15
+)
+
+// AC4ChannelMode is the ac4_channel_mode field of an AC4Descriptor.
+type AC4ChannelMode uint8
+
+// channel modes.
+const (
+	AC4ChannelModeMono         AC4ChannelMode = 0
+	AC4ChannelModeStereo       AC4ChannelMode = 1
+	AC4ChannelModeMultichannel AC4ChannelMode = 2
+)
+
+// AC4Descriptor is an AC-4_descriptor.
+// It is contained into an extension_descriptor.
+// Specification: ETSI EN 300 468, Annex D.7
+type AC4Descriptor struct {
+	ConfigPresent            bool
+	DialogEnhancementEnabled bool
+	ChannelMode              AC4ChannelMode
+
+	// ac4_dsi_toc, in the format of a ac4.DSI. It is nil when not present.
+	DSITOC []byte
+
+	AdditionalInfo []byte
+}
+
+// Unmarshal decodes an AC4Descriptor.
+// buf must contain the bytes that follow descriptor_tag_extension.
+func (d *AC4Descriptor) Unmarshal(buf []byte) error {
+	if len(buf) < 1 {
+		return fmt.Errorf("buffer is too short")
+	}
+
+	d.ConfigPresent = (buf[0] & 0b10000000) != 0
+	tocPresent := (buf[0] & 0b01000000) != 0
+	n := 1
+
+	if d.ConfigPresent {
+		if len(buf[n:]) < 1 {
+			return fmt.Errorf("buffer is too short")
+		}
+
+		d.DialogEnhancementEnabled = (buf[n] & 0b10000000) != 0
+		d.ChannelMode = AC4ChannelMode((buf[n] >> 5) & 0b11)
+		if d.ChannelMode > AC4ChannelModeMultichannel {
+			return fmt.Errorf("invalid channel mode: %d", d.ChannelMode)
+		}
+		n++
+	} else {
+		d.DialogEnhancementEnabled = false
+		d.ChannelMode = 0
+	}
+
+	if tocPresent {
+		if len(buf[n:]) < 1 {
+			return fmt.Errorf("buffer is too short")
+		}
+
+		l := int(buf[n])
+		n++
+
+		if len(buf[n:]) < l {
+			return fmt.Errorf("buffer is too short")
+		}
+
+		d.DSITOC = buf[n : n+l]
+		n += l
+	} else {
+		d.DSITOC = nil
+	}
+
+	if len(buf[n:]) != 0 {
+		d.AdditionalInfo = buf[n:]
+	} else {
+		d.AdditionalInfo = nil
+	}
+
+	return nil
+}
+
+func (d AC4Descriptor) marshalSize() int {
+	n := 1
+	if d.ConfigPresent {
+		n++
+	}
+	if d.DSITOC != nil {
+		n += 1 + len(d.DSITOC)
+	}
+	n += len(d.AdditionalInfo)
+	return n
+}
+
+// Marshal encodes an AC4Descriptor.
+func (d AC4Descriptor) Marshal() ([]byte, error) {
+	if d.ChannelMode > AC4ChannelModeMultichannel {
+		return nil, fmt.Errorf("invalid channel mode: %d", d.ChannelMode)
+	}
+
+	if len(d.DSITOC) > 0xFF {
+		return nil, fmt.Errorf("DSI TOC is too big")
+	}
+
+	buf := make([]byte, d.marshalSize())
+	n := 1
+
+	if d.ConfigPresent {
+		buf[0] |= 0b10000000
+
+		if d.DialogEnhancementEnabled {
+			buf[n] |= 0b10000000
+		}
+		buf[n] |= byte(d.ChannelMode) << 5
+		n++
+	}
+
+	if d.DSITOC != nil {
+		buf[0] |= 0b01000000
+
+		buf[n] = byte(len(d.DSITOC))
+		n++
+		n += copy(buf[n:], d.DSITOC)
+	}
+
+	copy(buf[n:], d.AdditionalInfo)
+
+	return buf, nil
+}
@@ -0,0 +1,84 @@
+package substructs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesAC4Descriptor = []struct {
+	name string
+	enc  []byte
+	dec  AC4Descriptor
+}{
+	{
+		"empty",
+		[]byte{0x00},
+		AC4Descriptor{},
+	},
+	{
+		"config",
+		[]byte{0x80, 0xc0},
+		AC4Descriptor{
+			ConfigPresent:            true,
+			DialogEnhancementEnabled: true,
+			ChannelMode:              AC4ChannelModeMultichannel,
+		},
+	},
+	{
+		"config, toc and additional info",
+		[]byte{0xc0, 0x20, 0x03, 0x01, 0x02, 0x03, 0x04, 0x05},
+		AC4Descriptor{
+			ConfigPresent:  true,
+			ChannelMode:    AC4ChannelModeStereo,
+			DSITOC:         []byte{1, 2, 3},
+			AdditionalInfo: []byte{4, 5},
+		},
+	},
+}
+
+func TestAC4DescriptorUnmarshal(t *testing.T) {
+	for _, ca := range casesAC4Descriptor {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec AC4Descriptor
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestAC4DescriptorMarshal(t *testing.T) {
+	for _, ca := range casesAC4Descriptor {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestAC4DescriptorUnmarshalErrors(t *testing.T) {
+	var dec AC4Descriptor
+	err := dec.Unmarshal([]byte{0x80, 0x60})
+	require.EqualError(t, err, "invalid channel mode: 3")
+}
+
+func FuzzAC4DescriptorUnmarshal(f *testing.F) {
+	for _, ca := range casesAC4Descriptor {
+		f.Add(ca.enc)
+	}
+
+	f.Add([]byte{0xad, 0x61})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var d AC4Descriptor
+		err := d.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		_, err = d.Marshal()
+		require.NoError(t, err)
+	})
+}
@@ -1,11 +1,14 @@
 package mpegts
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/asticode/go-astits"
 
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac3"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac4"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/eac3"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/codecs"
@@ -15,8 +18,13 @@ import (
 const (
 	opusIdentifier = 'O'<<24 | 'p'<<16 | 'u'<<8 | 's'
 	klvaIdentifier = 'K'<<24 | 'L'<<16 | 'V'<<8 | 'A'
+	alawIdentifier = 'A'<<24 | 'L'<<16 | 'A'<<8 | 'W'
+	ulawIdentifier = 'U'<<24 | 'L'<<16 | 'A'<<8 | 'W'
 )
 
+// ISO 13818-1, Table 2-34
+const streamTypeH266Video astits.StreamType = 0x33
+
 // MISB ST 1402, Table 4
 const (
 	metadataApplicationFormatGeneral            = 0x0100
@@ -80,9 +88,16 @@ func findAC3Parameters(dem *robustDemuxer, pid uint16) (int, int, error) {
 }
 
 func findEAC3Parameters(dem *robustDemuxer, pid uint16) (int, int, error) {
+	// first frame of a PES that could not be fully decoded,
+	// used when no other PES is available.
+	var fallback *eac3.SyncInfo
+
 	for {
 		data, err := dem.nextData()
 		if err != nil {
+			if fallback != nil && errors.Is(err, io.EOF) {
+				return fallback.SampleRate(), fallback.ChannelCount(), nil
+			}
 			return 0, 0, err
 		}
 
@@ -96,7 +111,45 @@ func findEAC3Parameters(dem *robustDemuxer, pid uint16) (int, int, error) {
 			return 0, 0, fmt.Errorf("invalid E-AC-3 frame: %w", err)
 		}
 
-		return syncInfo.SampleRate(), syncInfo.ChannelCount(), nil
+		// channels of dependent substreams are added to the ones of the first independent substream.
+		// When the PES cannot be fully decoded, try with the next one.
+		var config eac3.Config
+		err = config.UnmarshalFrames(data.PES.Data)
+		if err != nil {
+			if fallback == nil {
+				fallback = &syncInfo
+			}
+			continue
+		}
+
+		return syncInfo.SampleRate(), config.ChannelCount(), nil
+	}
+}
+
+func findAC4Parameters(dem *robustDemuxer, pid uint16) (int, int, error) {
+	for {
+		data, err := dem.nextData()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if data.PES == nil || data.PID != pid {
+			continue
+		}
+
+		var syncInfo ac4.SyncInfo
+		err = syncInfo.Unmarshal(data.PES.Data)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid AC-4 frame: %w", err)
+		}
+
+		var toc ac4.TOC
+		err = toc.Unmarshal(data.PES.Data[syncInfo.HeaderSize():])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid AC-4 frame: %w", err)
+		}
+
+		return toc.SampleRate(), toc.ChannelCount(), nil
 	}
 }
 
@@ -168,10 +221,29 @@ func findOpusAudioDescriptor(descriptors []*astits.Descriptor) (*substructs.Opus
 	return nil, fmt.Errorf("opus audio descriptor not found")
 }
 
+func findAC4Descriptor(descriptors []*astits.Descriptor) (*substructs.AC4Descriptor, error) {
+	for _, sd := range descriptors {
+		if sd.Extension != nil && sd.Extension.Tag == substructs.DescriptorTagExtensionAC4 &&
+			sd.Extension.Unknown != nil {
+			var desc substructs.AC4Descriptor
+			err := desc.Unmarshal(*sd.Extension.Unknown)
+			if err != nil {
+				return nil, err
+			}
+
+			return &desc, nil
+		}
+	}
+	return nil, nil
+}
+
 func findCodec(dem *robustDemuxer, es *astits.PMTElementaryStream) (codecs.Codec, error) {
 	switch es.StreamType {
 	// video
 
+	case streamTypeH266Video:
+		return &codecs.H266{}, nil
+
 	case astits.StreamTypeH265Video:
 		return &codecs.H265{}, nil
 
@@ -244,6 +316,11 @@ func findCodec(dem *robustDemuxer, es *astits.PMTElementaryStream) (codecs.Codec
 				return &codecs.KLV{
 					Synchronous: false,
 				}, nil
+
+			case alawIdentifier, ulawIdentifier:
+				return &codecs.G711{
+					MULaw: id == ulawIdentifier,
+				}, nil
 			}
 		} else if items := findDVBSubtitlingDescriptor(es.ElementaryStreamDescriptors); items != nil {
 			return &codecs.DVBSubtitle{
@@ -251,6 +328,24 @@ func findCodec(dem *robustDemuxer, es *astits.PMTElementaryStream) (codecs.Codec
 			}, nil
 		}
 
+		desc, err := findAC4Descriptor(es.ElementaryStreamDescriptors)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AC-4 descriptor: %w", err)
+		}
+
+		if desc != nil {
+			sampleRate, channelCount, err := findAC4Parameters(dem, es.ElementaryPID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &codecs.AC4{
+				Desc:         desc,
+				SampleRate:   sampleRate,
+				ChannelCount: channelCount,
+			}, nil
+		}
+
 	case astits.StreamTypeMetadata:
 		desc := findKLVMetadataDescriptor(es.ElementaryStreamDescriptors)
 		if desc != nil {
@@ -320,6 +415,18 @@ func eac3ComponentType(channels int, fullService bool) uint8 {
 	return ct
 }
 
+// ac4ChannelMode builds the ac4_channel_mode field of the AC-4 descriptor.
+func ac4ChannelMode(channels int) substructs.AC4ChannelMode {
+	switch channels {
+	case 1:
+		return substructs.AC4ChannelModeMono
+	case 2:
+		return substructs.AC4ChannelModeStereo
+	default:
+		return substructs.AC4ChannelModeMultichannel
+	}
+}
+
 // Track is a MPEG-TS track.
 type Track struct {
 	// PID.
@@ -362,6 +469,12 @@ func (t Track) marshal() (*astits.PMTElementaryStream, error) {
 	switch c := t.Codec.(type) {
 	// video
 
+	case *codecs.H266:
+		es = &astits.PMTElementaryStream{
+			ElementaryPID: t.PID,
+			StreamType:    streamTypeH266Video,
+		}
+
 	case *codecs.H265:
 		es = &astits.PMTElementaryStream{
 			ElementaryPID: t.PID,
@@ -428,6 +541,28 @@ func (t Track) marshal() (*astits.PMTElementaryStream, error) {
 			},
 		}
 
+	case *codecs.G711:
+		id := uint32(alawIdentifier)
+		if c.MULaw {
+			id = ulawIdentifier
+		}
+
+		es = &astits.PMTElementaryStream{
+			ElementaryPID: t.PID,
+			StreamType:    astits.StreamTypePrivateData,
+			ElementaryStreamDescriptors: []*astits.Descriptor{
+				{
+					// Length must be different than zero.
+					// https://github.com/asticode/go-astits/blob/7c2bf6b71173d24632371faa01f28a9122db6382/descriptor.go#L2146-L2148
+					Length: 1,
+					Tag:    astits.DescriptorTagRegistration,
+					Registration: &astits.DescriptorRegistration{
+						FormatIdentifier: id,
+					},
+				},
+			},
+		}
+
 	case *codecs.AC3:
 		es = &astits.PMTElementaryStream{
 			ElementaryPID: t.PID,
@@ -470,6 +605,37 @@ func (t Track) marshal() (*astits.PMTElementaryStream, error) {
 			},
 		}
 
+	case *codecs.AC4:
+		desc := c.Desc
+		if desc == nil {
+			desc = &substructs.AC4Descriptor{
+				ConfigPresent: true,
+				ChannelMode:   ac4ChannelMode(c.ChannelCount),
+			}
+		}
+
+		enc, err := desc.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		es = &astits.PMTElementaryStream{
+			ElementaryPID: t.PID,
+			StreamType:    astits.StreamTypePrivateData,
+			ElementaryStreamDescriptors: []*astits.Descriptor{
+				{
+					// Length must be different than zero.
+					// https://github.com/asticode/go-astits/blob/7c2bf6b71173d24632371faa01f28a9122db6382/descriptor.go#L2146-L2148
+					Length: 1,
+					Tag:    astits.DescriptorTagExtension,
+					Extension: &astits.DescriptorExtension{
+						Tag:     substructs.DescriptorTagExtensionAC4,
+						Unknown: &enc,
+					},
+				},
+			},
+		}
+
 	case *codecs.MPEG4Audio:
 		es = &astits.PMTElementaryStream{
 			ElementaryPID: t.PID,
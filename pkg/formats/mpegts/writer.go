@@ -8,9 +8,12 @@ import (
 
 	"github.com/asticode/go-astits"
 
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/ac4"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h265"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h266"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1audio"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg1video"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4audio"
 	"github.com/bluenviron/mediacommon/v2/pkg/codecs/mpeg4video"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/codecs"
@@ -123,6 +126,36 @@ func NewWriter(
 	return w
 }
 
+// WriteH266 writes a H266 access unit.
+func (w *Writer) WriteH266(
+	track *Track,
+	pts int64,
+	dts int64,
+	au [][]byte,
+) error {
+	randomAccess := h266.IsRandomAccess(au)
+
+	// prepend an AUD. This is required by video.js, iOS, QuickTime
+	if len(au[0]) < 2 || h266.NALUType(au[0][1]>>3) != h266.NALUType_AUD_NUT {
+		// aud_irap_or_gdr_flag, aud_pic_type = 2, rbsp_stop_one_bit
+		aud := byte(0x28)
+		if randomAccess {
+			aud |= 0x80
+		}
+
+		au = append([][]byte{
+			{0, byte(h266.NALUType_AUD_NUT)<<3 | 1, aud},
+		}, au...)
+	}
+
+	enc, err := h264.AnnexB(au).Marshal()
+	if err != nil {
+		return err
+	}
+
+	return w.writeVideo(track, pts, dts, randomAccess, enc)
+}
+
 // WriteH265 writes a H265 access unit.
 func (w *Writer) WriteH265(
 	track *Track,
@@ -188,7 +221,7 @@ func (w *Writer) WriteMPEG1Video(
 	pts int64,
 	frame []byte,
 ) error {
-	randomAccess := bytes.Contains(frame, []byte{0, 0, 1, 0xB8})
+	randomAccess := bytes.Contains(frame, []byte{0, 0, 1, byte(mpeg1video.GroupOfPicturesStartCode)})
 
 	return w.writeVideo(track, pts, pts, randomAccess, frame)
 }
@@ -309,6 +342,30 @@ func (w *Writer) WriteEAC3(
 	return w.writeAudio(track, pts, frame)
 }
 
+// WriteAC4 writes an AC-4 raw frame.
+// The frame is wrapped into a sync frame.
+func (w *Writer) WriteAC4(
+	track *Track,
+	pts int64,
+	frame []byte,
+) error {
+	enc, err := ac4.MarshalSyncFrame(frame)
+	if err != nil {
+		return err
+	}
+
+	return w.writeData(track, true, pts, streamIDPrivate, enc)
+}
+
+// WriteG711 writes G711 samples.
+func (w *Writer) WriteG711(
+	track *Track,
+	pts int64,
+	samples []byte,
+) error {
+	return w.writeData(track, true, pts, streamIDPrivate, samples)
+}
+
 // WriteKLV writes a KLV unit.
 func (w *Writer) WriteKLV(
 	track *Track,
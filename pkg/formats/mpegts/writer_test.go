@@ -11,6 +11,8 @@ import (
 	"github.com/asticode/go-astits"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/cea708"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/h264"
 	"github.com/bluenviron/mediacommon/v2/pkg/formats/mpegts/codecs"
 )
 
@@ -24,6 +26,9 @@ func TestWriter(t *testing.T) {
 				var err error
 
 				switch ca.track.Codec.(type) {
+				case *codecs.H266:
+					err = w.WriteH266(ca.track, sample.pts, sample.dts, sample.data)
+
 				case *codecs.H265:
 					err = w.WriteH265(ca.track, sample.pts, sample.dts, sample.data)
 
@@ -54,6 +59,12 @@ func TestWriter(t *testing.T) {
 				case *codecs.EAC3:
 					err = w.WriteEAC3(ca.track, sample.pts, sample.data[0])
 
+				case *codecs.AC4:
+					err = w.WriteAC4(ca.track, sample.pts, sample.data[0])
+
+				case *codecs.G711:
+					err = w.WriteG711(ca.track, sample.pts, sample.data[0])
+
 				case *codecs.KLV:
 					err = w.WriteKLV(ca.track, sample.pts, sample.data[0])
 
@@ -399,3 +410,62 @@ func TestWriterWriteTables(t *testing.T) {
 		require.Equal(t, 2*188, n2)
 	})
 }
+
+func TestWriterReaderClosedCaptions(t *testing.T) {
+	cc := cea708.CCData{
+		ProcessCCDataFlag: true,
+		EMData:            0xff,
+		Packets: []cea708.CCPacket{{
+			Valid: true,
+			Type:  cea708.CCTypeNTSCField1,
+			Data:  [2]byte{0x94, 0x20},
+		}},
+	}
+
+	sei, err := cc.MarshalH264SEI()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := &Writer{
+		W: &buf,
+		Tracks: []*Track{
+			{
+				Codec: &codecs.H264{},
+			},
+		},
+	}
+	err = w.Initialize()
+	require.NoError(t, err)
+
+	err = w.WriteH264(w.Tracks[0], 90000, 90000, [][]byte{
+		{byte(h264.NALUTypeAccessUnitDelimiter), 240},
+		sei,
+		{byte(h264.NALUTypeIDR), 1, 2, 3},
+	})
+	require.NoError(t, err)
+
+	r := &Reader{
+		R: bytes.NewReader(buf.Bytes()),
+	}
+	err = r.Initialize()
+	require.NoError(t, err)
+
+	ok := false
+
+	r.OnDataH264(r.Tracks()[0], func(_ int64, _ int64, au [][]byte) error {
+		packets := cea708.ExtractH264(au)
+		require.Equal(t, cc.Packets, packets)
+		ok = true
+		return nil
+	})
+
+	for {
+		err = r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.True(t, ok)
+}
@@ -0,0 +1,9 @@
+// Package codecs contains Ogg codecs.
+package codecs
+
+// Codec is a Ogg codec.
+type Codec interface {
+	IsVideo() bool
+
+	isCodec()
+}
@@ -0,0 +1,20 @@
+package codecs
+
+import "github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
+
+// FLAC is the FLAC codec.
+// Specification: https://xiph.org/flac/ogg_mapping.html
+type FLAC struct {
+	StreamInfo *flac.StreamInfo
+
+	// metadata blocks that follow STREAMINFO.
+	// The first one should be a VORBIS_COMMENT block.
+	MetadataBlocks []flac.MetadataBlock
+}
+
+// IsVideo implements Codec.
+func (*FLAC) IsVideo() bool {
+	return false
+}
+
+func (*FLAC) isCodec() {}
@@ -0,0 +1,17 @@
+package codecs
+
+import "github.com/bluenviron/mediacommon/v2/pkg/codecs/opus"
+
+// Opus is the Opus codec.
+// Specification: RFC7845
+type Opus struct {
+	IDHeader      *opus.IDHeader
+	CommentHeader *opus.CommentHeader
+}
+
+// IsVideo implements Codec.
+func (*Opus) IsVideo() bool {
+	return false
+}
+
+func (*Opus) isCodec() {}
@@ -0,0 +1,32 @@
+package ogg
+
+// Ogg uses a CRC-32 with polynomial 0x04c11db7,
+// no reflection, initial value 0 and no final XOR.
+var crcTable = func() [256]uint32 {
+	var t [256]uint32
+
+	for i := range t {
+		r := uint32(i) << 24
+		for range 8 {
+			if (r & 0x80000000) != 0 {
+				r = (r << 1) ^ 0x04c11db7
+			} else {
+				r <<= 1
+			}
+		}
+		t[i] = r
+	}
+
+	return t
+}()
+
+func crc32Update(crc uint32, buf []byte) uint32 {
+	for _, b := range buf {
+		crc = (crc << 8) ^ crcTable[byte(crc>>24)^b]
+	}
+	return crc
+}
+
+func crc32(buf []byte) uint32 {
+	return crc32Update(0, buf)
+}
@@ -0,0 +1,83 @@
+package ogg
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
+)
+
+const (
+	flacStreamInfoSize    = 34
+	flacMappingHeaderSize = 13 + 4 + flacStreamInfoSize
+)
+
+var (
+	flacMappingSignature = []byte{0x7F, 'F', 'L', 'A', 'C'}
+	flacNativeSignature  = []byte{'f', 'L', 'a', 'C'}
+)
+
+// Specification: https://xiph.org/flac/ogg_mapping.html
+func unmarshalFLACMappingHeader(buf []byte) (*flac.StreamInfo, bool, error) {
+	if len(buf) < flacMappingHeaderSize {
+		return nil, false, fmt.Errorf("not enough bytes")
+	}
+
+	if !bytes.Equal(buf[:5], flacMappingSignature) {
+		return nil, false, fmt.Errorf("invalid signature")
+	}
+
+	if buf[5] != 1 {
+		return nil, false, fmt.Errorf("unsupported mapping version: %d.%d", buf[5], buf[6])
+	}
+
+	if !bytes.Equal(buf[9:13], flacNativeSignature) {
+		return nil, false, fmt.Errorf("invalid native signature")
+	}
+
+	var h flac.MetadataBlockHeader
+	err := h.Unmarshal(buf[13:])
+	if err != nil {
+		return nil, false, err
+	}
+
+	if h.Type != flac.MetadataBlockTypeStreamInfo || h.Length != flacStreamInfoSize {
+		return nil, false, fmt.Errorf("first metadata block is not STREAMINFO")
+	}
+
+	var si flac.StreamInfo
+	err = si.Unmarshal(buf[17 : 17+flacStreamInfoSize])
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &si, h.Last, nil
+}
+
+func marshalFLACMappingHeader(si *flac.StreamInfo, headerCount int, last bool) ([]byte, error) {
+	enc, err := si.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := flac.MetadataBlockHeader{
+		Last:   last,
+		Type:   flac.MetadataBlockTypeStreamInfo,
+		Length: uint32(len(enc)),
+	}.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, flacMappingHeaderSize)
+	copy(buf, flacMappingSignature)
+	buf[5] = 1
+	buf[6] = 0
+	buf[7] = byte(headerCount >> 8)
+	buf[8] = byte(headerCount)
+	copy(buf[9:], flacNativeSignature)
+	copy(buf[13:], header)
+	copy(buf[17:], enc)
+
+	return buf, nil
+}
@@ -0,0 +1,2 @@
+// Package ogg contains a Ogg reader and writer.
+package ogg
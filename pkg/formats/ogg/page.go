@@ -0,0 +1,218 @@
+package ogg
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	pageHeaderSize  = 27
+	maxSegmentCount = 255
+	maxSegmentSize  = 255
+)
+
+var capturePattern = []byte{'O', 'g', 'g', 'S'}
+
+func readLE32(buf []byte) uint32 {
+	return uint32(buf[3])<<24 | uint32(buf[2])<<16 | uint32(buf[1])<<8 | uint32(buf[0])
+}
+
+func writeLE32(buf []byte, v uint32) {
+	buf[0] = byte(v)
+	buf[1] = byte(v >> 8)
+	buf[2] = byte(v >> 16)
+	buf[3] = byte(v >> 24)
+}
+
+func segmentCount(packetLen int, incomplete bool) int {
+	if incomplete {
+		return packetLen / maxSegmentSize
+	}
+	return packetLen/maxSegmentSize + 1
+}
+
+// Page is an Ogg page.
+// Specification: RFC3533, section 6
+type Page struct {
+	// whether the first packet is the continuation of a packet of the previous page.
+	Continued bool
+
+	// whether this is the first page of a logical bitstream.
+	BOS bool
+
+	// whether this is the last page of a logical bitstream.
+	EOS bool
+
+	// codec-dependent position of the last packet completed in this page.
+	// -1 means that no packet is completed in this page.
+	GranulePosition int64
+
+	SerialNumber   uint32
+	SequenceNumber uint32
+
+	// packets, or packet fragments, contained in the page.
+	Packets [][]byte
+
+	// whether the last packet continues in the next page.
+	Incomplete bool
+}
+
+// Unmarshal decodes a Page.
+// It returns the number of consumed bytes.
+func (p *Page) Unmarshal(buf []byte) (int, error) {
+	if len(buf) < pageHeaderSize {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	if !bytes.Equal(buf[:4], capturePattern) {
+		return 0, fmt.Errorf("invalid capture pattern")
+	}
+
+	if buf[4] != 0 {
+		return 0, fmt.Errorf("unsupported version: %d", buf[4])
+	}
+
+	headerType := buf[5]
+	p.Continued = (headerType & 0x01) != 0
+	p.BOS = (headerType & 0x02) != 0
+	p.EOS = (headerType & 0x04) != 0
+	p.GranulePosition = int64(uint64(readLE32(buf[10:]))<<32 | uint64(readLE32(buf[6:])))
+	p.SerialNumber = readLE32(buf[14:])
+	p.SequenceNumber = readLE32(buf[18:])
+	checksum := readLE32(buf[22:])
+	segCount := int(buf[26])
+	pos := pageHeaderSize
+
+	if len(buf[pos:]) < segCount {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	segments := buf[pos : pos+segCount]
+	pos += segCount
+
+	bodySize := 0
+	for _, s := range segments {
+		bodySize += int(s)
+	}
+
+	if len(buf[pos:]) < bodySize {
+		return 0, fmt.Errorf("not enough bytes")
+	}
+
+	// compute CRC with the checksum field set to zero
+	crc := crc32(buf[:22])
+	crc = crc32Update(crc, []byte{0, 0, 0, 0})
+	crc = crc32Update(crc, buf[26:pos+bodySize])
+
+	if crc != checksum {
+		return 0, fmt.Errorf("CRC mismatch: expected %08x, got %08x", checksum, crc)
+	}
+
+	p.Packets = nil
+	p.Incomplete = false
+	start := pos
+	packetLen := 0
+
+	for _, s := range segments {
+		packetLen += int(s)
+
+		if s < maxSegmentSize {
+			p.Packets = append(p.Packets, buf[start:start+packetLen])
+			start += packetLen
+			packetLen = 0
+		}
+	}
+
+	if segCount != 0 && segments[segCount-1] == maxSegmentSize {
+		p.Packets = append(p.Packets, buf[start:start+packetLen])
+		p.Incomplete = true
+	}
+
+	return pos + bodySize, nil
+}
+
+func (p Page) marshalSize() int {
+	n := pageHeaderSize
+
+	for i, pkt := range p.Packets {
+		n += segmentCount(len(pkt), p.Incomplete && i == len(p.Packets)-1) + len(pkt)
+	}
+
+	return n
+}
+
+func (p Page) marshalTo(buf []byte) (int, error) {
+	segCount := 0
+	for i, pkt := range p.Packets {
+		segCount += segmentCount(len(pkt), p.Incomplete && i == len(p.Packets)-1)
+	}
+
+	if segCount > maxSegmentCount {
+		return 0, fmt.Errorf("too many segments: %d", segCount)
+	}
+
+	if p.Incomplete {
+		if len(p.Packets) == 0 {
+			return 0, fmt.Errorf("incomplete pages must contain at least a packet")
+		}
+
+		last := p.Packets[len(p.Packets)-1]
+		if len(last) == 0 || (len(last)%maxSegmentSize) != 0 {
+			return 0, fmt.Errorf("size of the last packet of an incomplete page must be a multiple of %d",
+				maxSegmentSize)
+		}
+	}
+
+	copy(buf, capturePattern)
+	buf[4] = 0
+
+	buf[5] = 0
+	if p.Continued {
+		buf[5] |= 0x01
+	}
+	if p.BOS {
+		buf[5] |= 0x02
+	}
+	if p.EOS {
+		buf[5] |= 0x04
+	}
+
+	writeLE32(buf[6:], uint32(uint64(p.GranulePosition)))
+	writeLE32(buf[10:], uint32(uint64(p.GranulePosition)>>32))
+	writeLE32(buf[14:], p.SerialNumber)
+	writeLE32(buf[18:], p.SequenceNumber)
+	writeLE32(buf[22:], 0)
+	buf[26] = byte(segCount)
+	pos := pageHeaderSize
+
+	for i, pkt := range p.Packets {
+		n := segmentCount(len(pkt), p.Incomplete && i == len(p.Packets)-1)
+		rem := len(pkt)
+
+		for range n {
+			buf[pos] = byte(min(rem, maxSegmentSize))
+			rem -= int(buf[pos])
+			pos++
+		}
+	}
+
+	for _, pkt := range p.Packets {
+		pos += copy(buf[pos:], pkt)
+	}
+
+	writeLE32(buf[22:], crc32(buf[:pos]))
+
+	return pos, nil
+}
+
+// Marshal encodes a Page.
+func (p Page) Marshal() ([]byte, error) {
+	buf := make([]byte, p.marshalSize())
+
+	_, err := p.marshalTo(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,180 @@
+package ogg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesPage = []struct {
+	name string
+	enc  []byte
+	dec  Page
+}{
+	{
+		"bos",
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x02, 0x08, 0x07,
+			0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0xdd, 0xcc,
+			0xbb, 0xaa, 0x07, 0x00, 0x00, 0x00, 0xa9, 0x84,
+			0xaf, 0x99, 0x02, 0x03, 0x00, 0x01, 0x02, 0x03,
+		},
+		Page{
+			BOS:             true,
+			GranulePosition: 0x0102030405060708,
+			SerialNumber:    0xaabbccdd,
+			SequenceNumber:  7,
+			Packets:         [][]byte{{1, 2, 3}, {}},
+		},
+	},
+	{
+		"continued incomplete",
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x01, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x00,
+			0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x8e, 0xf1,
+			0x2f, 0x9c, 0x03, 0x02, 0xff, 0xff, 0x09, 0x09,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+			0x05, 0x05, 0x05, 0x05, 0x05, 0x05,
+		},
+		Page{
+			Continued:       true,
+			GranulePosition: -1,
+			SerialNumber:    1,
+			SequenceNumber:  2,
+			Packets:         [][]byte{{9, 9}, bytes.Repeat([]byte{5}, 510)},
+			Incomplete:      true,
+		},
+	},
+	{
+		"eos empty",
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x04, 0xe8, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x26, 0xb9,
+			0xa2, 0xd6, 0x00,
+		},
+		Page{
+			EOS:             true,
+			GranulePosition: 1000,
+			SerialNumber:    1,
+			SequenceNumber:  3,
+		},
+	},
+}
+
+func TestPageUnmarshal(t *testing.T) {
+	for _, ca := range casesPage {
+		t.Run(ca.name, func(t *testing.T) {
+			var p Page
+			n, err := p.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, len(ca.enc), n)
+			require.Equal(t, ca.dec, p)
+		})
+	}
+}
+
+func TestPageMarshal(t *testing.T) {
+	for _, ca := range casesPage {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestPageUnmarshalCRCMismatch(t *testing.T) {
+	enc := append([]byte(nil), casesPage[0].enc...)
+	enc[len(enc)-1]++
+
+	var p Page
+	_, err := p.Unmarshal(enc)
+	require.EqualError(t, err, "CRC mismatch: expected 99af84a9, got 87e8d4ac")
+}
+
+func FuzzPageUnmarshal(f *testing.F) {
+	for _, ca := range casesPage {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var p Page
+		_, err := p.Unmarshal(b)
+		if err != nil {
+			return
+		}
+
+		enc, err := p.Marshal()
+		require.NoError(t, err)
+
+		var p2 Page
+		_, err = p2.Unmarshal(enc)
+		require.NoError(t, err)
+		require.Equal(t, p, p2)
+	})
+}
@@ -0,0 +1,359 @@
+package ogg
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	maxPageSize = pageHeaderSize + maxSegmentCount + maxSegmentCount*maxSegmentSize
+)
+
+// ReaderOnDecodeErrorFunc is the prototype of the callback passed to OnDecodeError.
+type ReaderOnDecodeErrorFunc func(err error)
+
+// ReaderOnDataOpusFunc is the prototype of the callback passed to OnDataOpus.
+type ReaderOnDataOpusFunc func(pts int64, packet []byte) error
+
+// ReaderOnDataFLACFunc is the prototype of the callback passed to OnDataFLAC.
+type ReaderOnDataFLACFunc func(pts int64, frame []byte) error
+
+type readerStream struct {
+	track       *Track
+	headersDone bool
+	nextSeqNum  uint32
+	partial     []byte
+	started     bool
+	granule     int64
+	onData      func(int64, []byte) error
+}
+
+func (s *readerStream) assemble(page *Page, onDecodeError ReaderOnDecodeErrorFunc) [][]byte {
+	if page.SequenceNumber != s.nextSeqNum {
+		onDecodeError(fmt.Errorf("unexpected sequence number: %d, expected %d",
+			page.SequenceNumber, s.nextSeqNum))
+		s.partial = nil
+	}
+	s.nextSeqNum = page.SequenceNumber + 1
+
+	if !page.Continued && s.partial != nil {
+		onDecodeError(fmt.Errorf("discarding incomplete packet"))
+		s.partial = nil
+	}
+
+	var ret [][]byte
+
+	for i, pkt := range page.Packets {
+		if i == 0 && page.Continued {
+			// the first part of the packet has been lost
+			if s.partial == nil {
+				continue
+			}
+
+			pkt = append(s.partial, pkt...)
+			s.partial = nil
+		}
+
+		if i == len(page.Packets)-1 && page.Incomplete {
+			s.partial = append([]byte(nil), pkt...)
+			continue
+		}
+
+		ret = append(ret, pkt)
+	}
+
+	return ret
+}
+
+// Reader is a Ogg reader.
+type Reader struct {
+	R io.Reader
+
+	br            *bufio.Reader
+	tracks        []*Track
+	streams       map[uint32]*readerStream
+	queue         []*Page
+	onDecodeError ReaderOnDecodeErrorFunc
+}
+
+// Initialize initializes a Reader.
+func (r *Reader) Initialize() error {
+	r.br = bufio.NewReaderSize(r.R, maxPageSize)
+	r.streams = make(map[uint32]*readerStream)
+	r.onDecodeError = func(_ error) {}
+
+	bosDone := false
+
+	for {
+		page, err := r.readPage()
+		if err != nil {
+			return err
+		}
+
+		if page.BOS {
+			if bosDone {
+				return fmt.Errorf("chained streams are not supported")
+			}
+
+			err = r.addStream(page)
+			if err != nil {
+				return err
+			}
+		} else {
+			bosDone = true
+
+			s, ok := r.streams[page.SerialNumber]
+			if !ok || s.track == nil {
+				continue
+			}
+
+			if s.headersDone {
+				r.queue = append(r.queue, page)
+				continue
+			}
+
+			for _, pkt := range s.assemble(page, r.onDecodeError) {
+				if s.headersDone {
+					return fmt.Errorf("data packets must start on a fresh page")
+				}
+
+				s.headersDone, err = s.track.unmarshalNextHeader(pkt)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if bosDone && r.headersDone() {
+			break
+		}
+	}
+
+	if len(r.tracks) == 0 {
+		return fmt.Errorf("no supported tracks found")
+	}
+
+	return nil
+}
+
+func (r *Reader) addStream(page *Page) error {
+	if _, ok := r.streams[page.SerialNumber]; ok {
+		return fmt.Errorf("duplicate serial number: %d", page.SerialNumber)
+	}
+
+	if len(page.Packets) != 1 || page.Continued || page.Incomplete {
+		return fmt.Errorf("first page must contain exactly one packet")
+	}
+
+	track := &Track{
+		SerialNumber: page.SerialNumber,
+	}
+
+	supported, headersDone, err := track.unmarshalFirstHeader(page.Packets[0])
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		r.streams[page.SerialNumber] = &readerStream{}
+		return nil
+	}
+
+	r.streams[page.SerialNumber] = &readerStream{
+		track:       track,
+		headersDone: headersDone,
+		nextSeqNum:  page.SequenceNumber + 1,
+	}
+	r.tracks = append(r.tracks, track)
+
+	return nil
+}
+
+func (r *Reader) headersDone() bool {
+	for _, s := range r.streams {
+		if s.track != nil && !s.headersDone {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Reader) readPage() (*Page, error) {
+	lostSync := false
+
+	for {
+		header, err := r.br.Peek(pageHeaderSize)
+		if err != nil {
+			// partial page
+			if errors.Is(err, io.EOF) && len(header) >= 4 && bytes.Equal(header[:4], capturePattern) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		if !bytes.Equal(header[:4], capturePattern) {
+			if !lostSync {
+				r.onDecodeError(fmt.Errorf("lost synchronization"))
+				lostSync = true
+			}
+
+			i := bytes.Index(header[1:], capturePattern[:1])
+			if i < 0 {
+				i = len(header) - 1
+			}
+
+			_, err = r.br.Discard(i + 1)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		size := pageHeaderSize + int(header[26])
+
+		buf, err := r.br.Peek(size)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		for _, s := range buf[pageHeaderSize:] {
+			size += int(s)
+		}
+
+		buf, err = r.br.Peek(size)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		buf = append([]byte(nil), buf...)
+
+		var page Page
+		_, err = page.Unmarshal(buf)
+		if err != nil {
+			r.onDecodeError(err)
+
+			_, err = r.br.Discard(1)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		_, err = r.br.Discard(size)
+		if err != nil {
+			return nil, err
+		}
+
+		return &page, nil
+	}
+}
+
+// Tracks returns detected tracks.
+func (r *Reader) Tracks() []*Track {
+	return r.tracks
+}
+
+// OnDecodeError sets a callback that is called when a non-fatal decode error occurs.
+func (r *Reader) OnDecodeError(cb ReaderOnDecodeErrorFunc) {
+	r.onDecodeError = cb
+}
+
+// OnDataOpus sets a callback that is called when data from an Opus track is received.
+// Timestamps are expressed in 1/48000 seconds and take pre-skip into account.
+func (r *Reader) OnDataOpus(track *Track, cb ReaderOnDataOpusFunc) {
+	r.streams[track.SerialNumber].onData = cb
+}
+
+// OnDataFLAC sets a callback that is called when data from a FLAC track is received.
+// Timestamps are expressed in 1/sample_rate seconds.
+func (r *Reader) OnDataFLAC(track *Track, cb ReaderOnDataFLACFunc) {
+	r.streams[track.SerialNumber].onData = cb
+}
+
+// Read reads a page and calls the callbacks of the packets it contains.
+func (r *Reader) Read() error {
+	var page *Page
+
+	if len(r.queue) != 0 {
+		page = r.queue[0]
+		r.queue = r.queue[1:]
+	} else {
+		var err error
+		page, err = r.readPage()
+		if err != nil {
+			return err
+		}
+	}
+
+	s, ok := r.streams[page.SerialNumber]
+	if !ok {
+		if page.BOS {
+			r.onDecodeError(fmt.Errorf("chained streams are not supported"))
+		} else {
+			r.onDecodeError(fmt.Errorf("received page from undeclared stream %d", page.SerialNumber))
+		}
+		return nil
+	}
+
+	if s.track == nil {
+		return nil
+	}
+
+	packets := s.assemble(page, r.onDecodeError)
+	if len(packets) == 0 {
+		return nil
+	}
+
+	durations := make([]int64, len(packets))
+
+	for i, pkt := range packets {
+		var err error
+		durations[i], err = s.track.packetDuration(pkt)
+		if err != nil {
+			r.onDecodeError(err)
+			durations[i] = -1
+		}
+	}
+
+	// compute the position of the first packet from the
+	// granule position of the first page that contains audio data.
+	if !s.started {
+		s.started = true
+
+		if page.GranulePosition >= 0 && !page.EOS {
+			s.granule = page.GranulePosition
+			for _, d := range durations {
+				if d > 0 {
+					s.granule -= d
+				}
+			}
+		}
+	}
+
+	for i, pkt := range packets {
+		if durations[i] < 0 {
+			continue
+		}
+
+		pts := s.granule - s.track.ptsOffset()
+		s.granule += durations[i]
+
+		if s.onData != nil {
+			err := s.onData(pts, pkt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,364 @@
+package ogg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/opus"
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/ogg/codecs"
+)
+
+var testOpusTrack = &Track{
+	SerialNumber: 0x11223344,
+	Codec: &codecs.Opus{
+		IDHeader: &opus.IDHeader{
+			Version:             1,
+			ChannelCount:        2,
+			PreSkip:             312,
+			InputSampleRate:     48000,
+			ChannelMappingTable: []uint8{},
+		},
+		CommentHeader: &opus.CommentHeader{
+			Vendor:   "test",
+			Comments: []string{},
+		},
+	},
+}
+
+var testFLACTrack = &Track{
+	SerialNumber: 0x55667788,
+	Codec: &codecs.FLAC{
+		StreamInfo: &flac.StreamInfo{
+			MinBlockSize: 4096,
+			MaxBlockSize: 4096,
+			SampleRate:   44100,
+			ChannelCount: 2,
+			BitDepth:     16,
+		},
+		MetadataBlocks: []flac.MetadataBlock{
+			&flac.VorbisComment{
+				Comments: []string{},
+			},
+		},
+	},
+}
+
+type sample struct {
+	track int
+	pts   int64
+	data  []byte
+}
+
+var casesReadWriter = []struct {
+	name    string
+	tracks  []*Track
+	samples []sample
+	enc     []byte
+}{
+	{
+		"opus",
+		[]*Track{testOpusTrack},
+		[]sample{
+			{0, 0, []byte{0xfc, 0x01, 0x02, 0x03}},
+			{0, 960, []byte{0xfc, 0x04, 0x05}},
+			{0, 1920, []byte{0xfc, 0x06}},
+		},
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x02, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x33,
+			0x22, 0x11, 0x00, 0x00, 0x00, 0x00, 0xb0, 0xd7,
+			0x10, 0xd5, 0x01, 0x13, 0x4f, 0x70, 0x75, 0x73,
+			0x48, 0x65, 0x61, 0x64, 0x01, 0x02, 0x38, 0x01,
+			0x80, 0xbb, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4f,
+			0x67, 0x67, 0x53, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x33, 0x22,
+			0x11, 0x01, 0x00, 0x00, 0x00, 0x1f, 0x3c, 0xdf,
+			0x00, 0x01, 0x14, 0x4f, 0x70, 0x75, 0x73, 0x54,
+			0x61, 0x67, 0x73, 0x04, 0x00, 0x00, 0x00, 0x74,
+			0x65, 0x73, 0x74, 0x00, 0x00, 0x00, 0x00, 0x4f,
+			0x67, 0x67, 0x53, 0x00, 0x00, 0x78, 0x0c, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x33, 0x22,
+			0x11, 0x02, 0x00, 0x00, 0x00, 0x3e, 0x7e, 0x6e,
+			0xf3, 0x03, 0x04, 0x03, 0x02, 0xfc, 0x01, 0x02,
+			0x03, 0xfc, 0x04, 0x05, 0xfc, 0x06, 0x4f, 0x67,
+			0x67, 0x53, 0x00, 0x04, 0x78, 0x0c, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x44, 0x33, 0x22, 0x11,
+			0x03, 0x00, 0x00, 0x00, 0xf3, 0xc4, 0x9d, 0xec,
+			0x00,
+		},
+	},
+	{
+		"flac",
+		[]*Track{testFLACTrack},
+		[]sample{
+			{0, 0, []byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2, 0xaa}},
+			{0, 4096, []byte{0xff, 0xf8, 0xc9, 0x18, 0x01, 0xc5, 0xbb}},
+			{0, 8192, []byte{0xff, 0xf8, 0x69, 0x18, 0x02, 0x3f, 0x28, 0xcc}},
+		},
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x02, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x88, 0x77,
+			0x66, 0x55, 0x00, 0x00, 0x00, 0x00, 0x80, 0x49,
+			0xfd, 0x11, 0x01, 0x33, 0x7f, 0x46, 0x4c, 0x41,
+			0x43, 0x01, 0x00, 0x00, 0x01, 0x66, 0x4c, 0x61,
+			0x43, 0x00, 0x00, 0x00, 0x22, 0x10, 0x00, 0x10,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+			0xc4, 0x42, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4f,
+			0x67, 0x67, 0x53, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x88, 0x77, 0x66,
+			0x55, 0x01, 0x00, 0x00, 0x00, 0x2e, 0x89, 0x9d,
+			0xe2, 0x01, 0x0c, 0x84, 0x00, 0x00, 0x08, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4f,
+			0x67, 0x67, 0x53, 0x00, 0x00, 0x40, 0x20, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x88, 0x77, 0x66,
+			0x55, 0x02, 0x00, 0x00, 0x00, 0xb1, 0x81, 0x72,
+			0x52, 0x03, 0x07, 0x07, 0x08, 0xff, 0xf8, 0xc9,
+			0x18, 0x00, 0xc2, 0xaa, 0xff, 0xf8, 0xc9, 0x18,
+			0x01, 0xc5, 0xbb, 0xff, 0xf8, 0x69, 0x18, 0x02,
+			0x3f, 0x28, 0xcc, 0x4f, 0x67, 0x67, 0x53, 0x00,
+			0x04, 0x40, 0x20, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x88, 0x77, 0x66, 0x55, 0x03, 0x00, 0x00,
+			0x00, 0x3b, 0x5f, 0xe9, 0x38, 0x00,
+		},
+	},
+	{
+		"opus + flac",
+		[]*Track{testOpusTrack, testFLACTrack},
+		[]sample{
+			{0, 0, []byte{0xfc, 0x01, 0x02, 0x03}},
+			{0, 960, []byte{0xfc, 0x04, 0x05}},
+			{0, 1920, []byte{0xfc, 0x06}},
+			{1, 0, []byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2, 0xaa}},
+			{1, 4096, []byte{0xff, 0xf8, 0xc9, 0x18, 0x01, 0xc5, 0xbb}},
+			{1, 8192, []byte{0xff, 0xf8, 0x69, 0x18, 0x02, 0x3f, 0x28, 0xcc}},
+		},
+		[]byte{
+			0x4f, 0x67, 0x67, 0x53, 0x00, 0x02, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x33,
+			0x22, 0x11, 0x00, 0x00, 0x00, 0x00, 0xb0, 0xd7,
+			0x10, 0xd5, 0x01, 0x13, 0x4f, 0x70, 0x75, 0x73,
+			0x48, 0x65, 0x61, 0x64, 0x01, 0x02, 0x38, 0x01,
+			0x80, 0xbb, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4f,
+			0x67, 0x67, 0x53, 0x00, 0x02, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x88, 0x77, 0x66,
+			0x55, 0x00, 0x00, 0x00, 0x00, 0x80, 0x49, 0xfd,
+			0x11, 0x01, 0x33, 0x7f, 0x46, 0x4c, 0x41, 0x43,
+			0x01, 0x00, 0x00, 0x01, 0x66, 0x4c, 0x61, 0x43,
+			0x00, 0x00, 0x00, 0x22, 0x10, 0x00, 0x10, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a, 0xc4,
+			0x42, 0xf0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4f, 0x67,
+			0x67, 0x53, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x44, 0x33, 0x22, 0x11,
+			0x01, 0x00, 0x00, 0x00, 0x1f, 0x3c, 0xdf, 0x00,
+			0x01, 0x14, 0x4f, 0x70, 0x75, 0x73, 0x54, 0x61,
+			0x67, 0x73, 0x04, 0x00, 0x00, 0x00, 0x74, 0x65,
+			0x73, 0x74, 0x00, 0x00, 0x00, 0x00, 0x4f, 0x67,
+			0x67, 0x53, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x88, 0x77, 0x66, 0x55,
+			0x01, 0x00, 0x00, 0x00, 0x2e, 0x89, 0x9d, 0xe2,
+			0x01, 0x0c, 0x84, 0x00, 0x00, 0x08, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x4f, 0x67,
+			0x67, 0x53, 0x00, 0x00, 0x78, 0x0c, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x44, 0x33, 0x22, 0x11,
+			0x02, 0x00, 0x00, 0x00, 0x3e, 0x7e, 0x6e, 0xf3,
+			0x03, 0x04, 0x03, 0x02, 0xfc, 0x01, 0x02, 0x03,
+			0xfc, 0x04, 0x05, 0xfc, 0x06, 0x4f, 0x67, 0x67,
+			0x53, 0x00, 0x00, 0x40, 0x20, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x88, 0x77, 0x66, 0x55, 0x02,
+			0x00, 0x00, 0x00, 0xb1, 0x81, 0x72, 0x52, 0x03,
+			0x07, 0x07, 0x08, 0xff, 0xf8, 0xc9, 0x18, 0x00,
+			0xc2, 0xaa, 0xff, 0xf8, 0xc9, 0x18, 0x01, 0xc5,
+			0xbb, 0xff, 0xf8, 0x69, 0x18, 0x02, 0x3f, 0x28,
+			0xcc, 0x4f, 0x67, 0x67, 0x53, 0x00, 0x04, 0x78,
+			0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44,
+			0x33, 0x22, 0x11, 0x03, 0x00, 0x00, 0x00, 0xf3,
+			0xc4, 0x9d, 0xec, 0x00, 0x4f, 0x67, 0x67, 0x53,
+			0x00, 0x04, 0x40, 0x20, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x88, 0x77, 0x66, 0x55, 0x03, 0x00,
+			0x00, 0x00, 0x3b, 0x5f, 0xe9, 0x38, 0x00,
+		},
+	},
+}
+
+func TestReader(t *testing.T) {
+	for _, ca := range casesReadWriter {
+		t.Run(ca.name, func(t *testing.T) {
+			r := &Reader{R: bytes.NewReader(ca.enc)}
+			err := r.Initialize()
+			require.NoError(t, err)
+			require.Equal(t, ca.tracks, r.Tracks())
+
+			var samples []sample
+
+			for i, track := range r.Tracks() {
+				switch track.Codec.(type) {
+				case *codecs.Opus:
+					require.Equal(t, 48000, track.ClockRate())
+
+					r.OnDataOpus(track, func(pts int64, packet []byte) error {
+						samples = append(samples, sample{i, pts, packet})
+						return nil
+					})
+
+				case *codecs.FLAC:
+					require.Equal(t, 44100, track.ClockRate())
+
+					r.OnDataFLAC(track, func(pts int64, frame []byte) error {
+						samples = append(samples, sample{i, pts, frame})
+						return nil
+					})
+				}
+			}
+
+			for {
+				err = r.Read()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, ca.samples, samples)
+		})
+	}
+}
+
+func TestReaderStartOffset(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := &Writer{
+		W:      &buf,
+		Tracks: []*Track{testOpusTrack},
+	}
+	err := w.Initialize()
+	require.NoError(t, err)
+
+	err = w.WriteOpus(testOpusTrack, 48000, []byte{0xfc, 0x01})
+	require.NoError(t, err)
+
+	err = w.WriteOpus(testOpusTrack, 48960, []byte{0xfc, 0x02})
+	require.NoError(t, err)
+
+	err = w.WriteOpus(testOpusTrack, 49920, []byte{0xfc, 0x03})
+	require.NoError(t, err)
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	r := &Reader{R: &buf}
+	err = r.Initialize()
+	require.NoError(t, err)
+
+	var pts []int64
+	r.OnDataOpus(r.Tracks()[0], func(p int64, _ []byte) error {
+		pts = append(pts, p)
+		return nil
+	})
+
+	for {
+		err = r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []int64{48000, 48960, 49920}, pts)
+}
+
+func TestReaderDecodeErrors(t *testing.T) {
+	t.Run("garbage", func(t *testing.T) {
+		enc := append([]byte{0x01, 0x02, 'O', 0x03}, casesReadWriter[0].enc...)
+
+		r := &Reader{R: bytes.NewReader(enc)}
+		err := r.Initialize()
+		require.NoError(t, err)
+
+		n := 0
+		r.OnDataOpus(r.Tracks()[0], func(_ int64, _ []byte) error {
+			n++
+			return nil
+		})
+
+		for {
+			err = r.Read()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 3, n)
+	})
+
+	t.Run("crc mismatch", func(t *testing.T) {
+		enc := append([]byte(nil), casesReadWriter[0].enc...)
+		enc[len(enc)-28] ^= 0xFF
+
+		r := &Reader{R: bytes.NewReader(enc)}
+		err := r.Initialize()
+		require.NoError(t, err)
+
+		var decodeErrors []string
+		r.OnDecodeError(func(err error) {
+			decodeErrors = append(decodeErrors, err.Error())
+		})
+
+		for {
+			err = r.Read()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []string{
+			"CRC mismatch: expected f36e7e3e, got 42993e8a",
+			"lost synchronization",
+			"unexpected sequence number: 3, expected 2",
+		}, decodeErrors)
+	})
+}
+
+func FuzzReader(f *testing.F) {
+	for _, ca := range casesReadWriter {
+		f.Add(ca.enc)
+	}
+
+	f.Fuzz(func(_ *testing.T, b []byte) {
+		r := &Reader{R: bytes.NewReader(b)}
+		err := r.Initialize()
+		if err != nil {
+			return
+		}
+
+		for _, track := range r.Tracks() {
+			switch track.Codec.(type) {
+			case *codecs.Opus:
+				r.OnDataOpus(track, func(_ int64, _ []byte) error {
+					return nil
+				})
+
+			case *codecs.FLAC:
+				r.OnDataFLAC(track, func(_ int64, _ []byte) error {
+					return nil
+				})
+			}
+		}
+
+		for {
+			err = r.Read()
+			if err != nil {
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package ogg
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/flac"
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/opus"
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/ogg/codecs"
+)
+
+var opusIDHeaderSignature = []byte{'O', 'p', 'u', 's', 'H', 'e', 'a', 'd'}
+
+// Track is a Ogg track, that is, a logical bitstream.
+type Track struct {
+	SerialNumber uint32
+	Codec        codecs.Codec
+}
+
+// ClockRate returns the clock rate of timestamps of the track.
+func (t *Track) ClockRate() int {
+	switch codec := t.Codec.(type) {
+	case *codecs.Opus:
+		return 48000
+
+	case *codecs.FLAC:
+		return int(codec.StreamInfo.SampleRate)
+	}
+
+	return 0
+}
+
+// ptsOffset returns the difference between granule positions and timestamps.
+func (t *Track) ptsOffset() int64 {
+	if codec, ok := t.Codec.(*codecs.Opus); ok {
+		return int64(codec.IDHeader.PreSkip)
+	}
+	return 0
+}
+
+// packetDuration returns the duration of a data packet, in clock rate units.
+func (t *Track) packetDuration(pkt []byte) (int64, error) {
+	switch t.Codec.(type) {
+	case *codecs.Opus:
+		return opus.PacketDuration2(pkt), nil
+
+	case *codecs.FLAC:
+		var h flac.FrameHeader
+		err := h.Unmarshal(pkt)
+		if err != nil {
+			return 0, fmt.Errorf("invalid FLAC frame header: %w", err)
+		}
+		return int64(h.BlockSize), nil
+	}
+
+	return 0, fmt.Errorf("unsupported codec")
+}
+
+// unmarshalFirstHeader decodes the first packet of a logical bitstream.
+// It returns false if the codec is not supported.
+func (t *Track) unmarshalFirstHeader(pkt []byte) (bool, bool, error) {
+	switch {
+	case bytes.HasPrefix(pkt, opusIDHeaderSignature):
+		var h opus.IDHeader
+		err := h.Unmarshal(pkt)
+		if err != nil {
+			return false, false, fmt.Errorf("invalid Opus ID header: %w", err)
+		}
+
+		t.Codec = &codecs.Opus{IDHeader: &h}
+		return true, false, nil
+
+	case bytes.HasPrefix(pkt, flacMappingSignature):
+		si, last, err := unmarshalFLACMappingHeader(pkt)
+		if err != nil {
+			return false, false, fmt.Errorf("invalid FLAC header: %w", err)
+		}
+
+		t.Codec = &codecs.FLAC{StreamInfo: si}
+		return true, last, nil
+	}
+
+	return false, false, nil
+}
+
+// unmarshalNextHeader decodes a header packet that follows the first one.
+// It returns true when all header packets have been decoded.
+func (t *Track) unmarshalNextHeader(pkt []byte) (bool, error) {
+	switch codec := t.Codec.(type) {
+	case *codecs.Opus:
+		var h opus.CommentHeader
+		err := h.Unmarshal(pkt)
+		if err != nil {
+			return false, fmt.Errorf("invalid Opus comment header: %w", err)
+		}
+
+		codec.CommentHeader = &h
+		return true, nil
+
+	case *codecs.FLAC:
+		var h flac.MetadataBlockHeader
+		err := h.Unmarshal(pkt)
+		if err != nil {
+			return false, fmt.Errorf("invalid FLAC metadata block: %w", err)
+		}
+
+		// each packet contains exactly one metadata block
+		var blocks flac.MetadataBlocks
+		err = blocks.Unmarshal(pkt)
+		if err != nil {
+			return false, fmt.Errorf("invalid FLAC metadata block: %w", err)
+		}
+
+		codec.MetadataBlocks = append(codec.MetadataBlocks, blocks...)
+		return h.Last, nil
+	}
+
+	return false, fmt.Errorf("unsupported codec")
+}
+
+func (t *Track) marshalHeaders() ([][]byte, error) {
+	switch codec := t.Codec.(type) {
+	case *codecs.Opus:
+		if codec.IDHeader == nil {
+			return nil, fmt.Errorf("missing Opus ID header")
+		}
+
+		idHeader, err := codec.IDHeader.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		commentHeader := codec.CommentHeader
+		if commentHeader == nil {
+			commentHeader = &opus.CommentHeader{}
+		}
+
+		enc, err := commentHeader.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]byte{idHeader, enc}, nil
+
+	case *codecs.FLAC:
+		if codec.StreamInfo == nil {
+			return nil, fmt.Errorf("missing FLAC stream info")
+		}
+
+		blocks := flac.MetadataBlocks(codec.MetadataBlocks)
+		if len(blocks) == 0 {
+			blocks = flac.MetadataBlocks{&flac.VorbisComment{}}
+		}
+
+		first, err := marshalFLACMappingHeader(codec.StreamInfo, len(blocks), false)
+		if err != nil {
+			return nil, err
+		}
+
+		enc, err := blocks.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		ret := [][]byte{first}
+
+		// each metadata block goes into a dedicated packet
+		for len(enc) != 0 {
+			var h flac.MetadataBlockHeader
+			err = h.Unmarshal(enc)
+			if err != nil {
+				return nil, err
+			}
+
+			n := 4 + int(h.Length)
+			ret = append(ret, enc[:n])
+			enc = enc[n:]
+		}
+
+		return ret, nil
+	}
+
+	return nil, fmt.Errorf("unsupported codec: %T", t.Codec)
+}
@@ -0,0 +1,218 @@
+package ogg
+
+import (
+	"fmt"
+	"io"
+)
+
+// pages are flushed when their body reaches this size.
+const targetPageBodySize = 4096
+
+type writerPacket struct {
+	data    []byte
+	granule int64
+}
+
+type writerStream struct {
+	track     *Track
+	seqNum    uint32
+	queue     []writerPacket
+	queueSize int
+	granule   int64
+}
+
+func (s *writerStream) writePage(w io.Writer, page *Page) error {
+	buf, err := page.Marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	s.seqNum++
+	return nil
+}
+
+func (s *writerStream) enqueue(pkt []byte, granule int64) {
+	s.queue = append(s.queue, writerPacket{data: pkt, granule: granule})
+	s.queueSize += len(pkt)
+	s.granule = granule
+}
+
+func (s *writerStream) flush(w io.Writer) error {
+	continued := false
+
+	for len(s.queue) != 0 {
+		page := &Page{
+			Continued:       continued,
+			GranulePosition: -1,
+			SerialNumber:    s.track.SerialNumber,
+			SequenceNumber:  s.seqNum,
+		}
+		continued = false
+		segCount := 0
+
+		for len(s.queue) != 0 {
+			pkt := s.queue[0]
+			n := segmentCount(len(pkt.data), false)
+
+			if segCount+n <= maxSegmentCount {
+				page.Packets = append(page.Packets, pkt.data)
+				page.GranulePosition = pkt.granule
+				segCount += n
+				s.queue = s.queue[1:]
+				continue
+			}
+
+			// split the packet across pages
+			avail := maxSegmentCount - segCount
+			if avail > 0 {
+				page.Packets = append(page.Packets, pkt.data[:avail*maxSegmentSize])
+				page.Incomplete = true
+				s.queue[0].data = pkt.data[avail*maxSegmentSize:]
+				continued = true
+			}
+			break
+		}
+
+		err := s.writePage(w, page)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.queueSize = 0
+	return nil
+}
+
+// Writer is a Ogg writer.
+type Writer struct {
+	W      io.Writer
+	Tracks []*Track
+
+	streams map[*Track]*writerStream
+}
+
+// Initialize initializes a Writer.
+func (w *Writer) Initialize() error {
+	w.streams = make(map[*Track]*writerStream)
+	serialNumbers := make(map[uint32]struct{})
+
+	for i, track := range w.Tracks {
+		if track.SerialNumber == 0 {
+			track.SerialNumber = uint32(i + 1)
+		}
+
+		if _, ok := serialNumbers[track.SerialNumber]; ok {
+			return fmt.Errorf("duplicate serial number: %d", track.SerialNumber)
+		}
+		serialNumbers[track.SerialNumber] = struct{}{}
+
+		w.streams[track] = &writerStream{track: track}
+	}
+
+	headers := make([][][]byte, len(w.Tracks))
+
+	// the first page of each logical bitstream contains only the first header packet
+	// and all first pages come before any other page.
+	for i, track := range w.Tracks {
+		var err error
+		headers[i], err = track.marshalHeaders()
+		if err != nil {
+			return err
+		}
+
+		s := w.streams[track]
+
+		err = s.writePage(w.W, &Page{
+			BOS:             true,
+			GranulePosition: 0,
+			SerialNumber:    track.SerialNumber,
+			SequenceNumber:  s.seqNum,
+			Packets:         [][]byte{headers[i][0]},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// remaining header packets end on a page boundary,
+	// since data packets must start on a fresh page.
+	for i, track := range w.Tracks {
+		s := w.streams[track]
+
+		for _, pkt := range headers[i][1:] {
+			s.enqueue(pkt, 0)
+		}
+
+		err := s.flush(w.W)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteOpus writes an Opus packet.
+// Timestamps are expressed in 1/48000 seconds and do not include pre-skip.
+func (w *Writer) WriteOpus(track *Track, pts int64, packet []byte) error {
+	return w.writePacket(track, pts, packet)
+}
+
+// WriteFLAC writes a FLAC frame.
+// Timestamps are expressed in 1/sample_rate seconds.
+func (w *Writer) WriteFLAC(track *Track, pts int64, frame []byte) error {
+	return w.writePacket(track, pts, frame)
+}
+
+func (w *Writer) writePacket(track *Track, pts int64, pkt []byte) error {
+	s, ok := w.streams[track]
+	if !ok {
+		return fmt.Errorf("track not found")
+	}
+
+	duration, err := track.packetDuration(pkt)
+	if err != nil {
+		return err
+	}
+
+	s.enqueue(pkt, pts+track.ptsOffset()+duration)
+
+	if s.queueSize >= targetPageBodySize {
+		return s.flush(w.W)
+	}
+
+	return nil
+}
+
+// Close writes pending packets and marks the end of all logical bitstreams.
+func (w *Writer) Close() error {
+	for _, track := range w.Tracks {
+		err := w.streams[track].flush(w.W)
+		if err != nil {
+			return err
+		}
+	}
+
+	// end of stream is signaled with an empty page, in order to prevent
+	// the last data page from being interpreted as end-trimmed.
+	for _, track := range w.Tracks {
+		s := w.streams[track]
+
+		err := s.writePage(w.W, &Page{
+			EOS:             true,
+			GranulePosition: s.granule,
+			SerialNumber:    track.SerialNumber,
+			SequenceNumber:  s.seqNum,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
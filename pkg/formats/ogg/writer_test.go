@@ -0,0 +1,119 @@
+package ogg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediacommon/v2/pkg/codecs/opus"
+	"github.com/bluenviron/mediacommon/v2/pkg/formats/ogg/codecs"
+)
+
+func TestWriter(t *testing.T) {
+	for _, ca := range casesReadWriter {
+		t.Run(ca.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w := &Writer{
+				W:      &buf,
+				Tracks: ca.tracks,
+			}
+			err := w.Initialize()
+			require.NoError(t, err)
+
+			for _, sample := range ca.samples {
+				track := ca.tracks[sample.track]
+
+				switch track.Codec.(type) {
+				case *codecs.Opus:
+					err = w.WriteOpus(track, sample.pts, sample.data)
+					require.NoError(t, err)
+
+				case *codecs.FLAC:
+					err = w.WriteFLAC(track, sample.pts, sample.data)
+					require.NoError(t, err)
+				}
+			}
+
+			err = w.Close()
+			require.NoError(t, err)
+
+			require.Equal(t, ca.enc, buf.Bytes())
+		})
+	}
+}
+
+func TestWriterAutomaticSerialNumber(t *testing.T) {
+	track := &Track{
+		Codec: &codecs.Opus{
+			IDHeader: &opus.IDHeader{
+				Version:      1,
+				ChannelCount: 2,
+			},
+		},
+	}
+
+	w := &Writer{
+		W:      io.Discard,
+		Tracks: []*Track{track},
+	}
+	err := w.Initialize()
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), track.SerialNumber)
+}
+
+func TestWriterReaderLongPackets(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := &Writer{
+		W:      &buf,
+		Tracks: []*Track{testFLACTrack},
+	}
+	err := w.Initialize()
+	require.NoError(t, err)
+
+	frames := [][]byte{
+		append([]byte{0xff, 0xf8, 0xc9, 0x18, 0x00, 0xc2}, bytes.Repeat([]byte{1}, 100000)...),
+		append([]byte{0xff, 0xf8, 0xc9, 0x18, 0x01, 0xc5}, bytes.Repeat([]byte{2}, 255*255-6)...),
+		{0xff, 0xf8, 0xc9, 0x18, 0x02, 0xcc},
+	}
+
+	for i, frame := range frames {
+		err = w.WriteFLAC(testFLACTrack, int64(i)*4096, frame)
+		require.NoError(t, err)
+	}
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	r := &Reader{R: &buf}
+	err = r.Initialize()
+	require.NoError(t, err)
+
+	var decodeErrors []error
+	r.OnDecodeError(func(err error) {
+		decodeErrors = append(decodeErrors, err)
+	})
+
+	var received [][]byte
+	var pts []int64
+	r.OnDataFLAC(r.Tracks()[0], func(p int64, frame []byte) error {
+		pts = append(pts, p)
+		received = append(received, frame)
+		return nil
+	})
+
+	for {
+		err = r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.Empty(t, decodeErrors)
+	require.Equal(t, frames, received)
+	require.Equal(t, []int64{0, 4096, 8192}, pts)
+}
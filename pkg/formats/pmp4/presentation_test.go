@@ -577,7 +577,7 @@ var casesPresentation = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00,
-			0x00, 0x00, 0x03, 0x20, 0x00, 0x00, 0x02, 0x58,
+			0x00, 0x00, 0x07, 0x80, 0x00, 0x00, 0x04, 0x38,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x24, 0x65, 0x64,
 			0x74, 0x73, 0x00, 0x00, 0x00, 0x1c, 0x65, 0x6c,
 			0x73, 0x74, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -608,7 +608,7 @@ var casesPresentation = []struct {
 			0x70, 0x34, 0x76, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x03, 0x20, 0x02, 0x58, 0x00,
+			0x00, 0x00, 0x00, 0x07, 0x80, 0x04, 0x38, 0x00,
 			0x48, 0x00, 0x00, 0x00, 0x48, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -653,8 +653,8 @@ var casesPresentation = []struct {
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x03, 0x20,
-			0x00, 0x00, 0x02, 0x58, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x07, 0x80,
+			0x00, 0x00, 0x04, 0x38, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x24, 0x65, 0x64, 0x74, 0x73, 0x00, 0x00,
 			0x00, 0x1c, 0x65, 0x6c, 0x73, 0x74, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
@@ -684,8 +684,8 @@ var casesPresentation = []struct {
 			0x00, 0x00, 0x9d, 0x6d, 0x70, 0x34, 0x76, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03,
-			0x20, 0x02, 0x58, 0x00, 0x48, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x07,
+			0x80, 0x04, 0x38, 0x00, 0x48, 0x00, 0x00, 0x00,
 			0x48, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
@@ -1302,6 +1302,215 @@ var casesPresentation = []struct {
 			0x02,
 		},
 	},
+	{
+		"vp8",
+		Presentation{
+			Tracks: []*Track{
+				{
+					ID:        1,
+					TimeScale: 90000,
+					Codec: &codecs.VP8{
+						Width:  1920,
+						Height: 1080,
+					},
+					Samples: []*Sample{{
+						Duration:    90000,
+						PayloadSize: 2,
+						GetPayload: func() ([]byte, error) {
+							return []byte{1, 2}, nil
+						},
+					}},
+				},
+			},
+		},
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x69, 0x73, 0x6f, 0x6d, 0x00, 0x00, 0x00, 0x01,
+			0x69, 0x73, 0x6f, 0x6d, 0x69, 0x73, 0x6f, 0x32,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x00, 0x00, 0x02, 0x8b, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x03, 0xe8, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x02, 0x17,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x03, 0xe8, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x07, 0x80, 0x00, 0x00, 0x04, 0x38, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x24, 0x65, 0x64, 0x74, 0x73,
+			0x00, 0x00, 0x00, 0x1c, 0x65, 0x6c, 0x73, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x03, 0xe8, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x8f,
+			0x6d, 0x64, 0x69, 0x61, 0x00, 0x00, 0x00, 0x20,
+			0x6d, 0x64, 0x68, 0x64, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x5f, 0x90, 0x00, 0x01, 0x5f, 0x90,
+			0x55, 0xc4, 0x00, 0x00, 0x00, 0x00, 0x00, 0x2d,
+			0x68, 0x64, 0x6c, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x76, 0x69, 0x64, 0x65,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x56, 0x69, 0x64, 0x65,
+			0x6f, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
+			0x00, 0x00, 0x00, 0x01, 0x3a, 0x6d, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x14, 0x76, 0x6d, 0x68,
+			0x64, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xfa, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x7a, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x6a, 0x76, 0x70, 0x30,
+			0x38, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x07, 0x80, 0x04, 0x38, 0x00, 0x48, 0x00,
+			0x00, 0x00, 0x48, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x18, 0xff, 0xff, 0x00,
+			0x00, 0x00, 0x14, 0x76, 0x70, 0x63, 0x43, 0x01,
+			0x00, 0x00, 0x00, 0x00, 0x0a, 0x82, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x18, 0x73,
+			0x74, 0x74, 0x73, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x01, 0x5f, 0x90, 0x00, 0x00, 0x00, 0x18, 0x63,
+			0x74, 0x74, 0x73, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1c, 0x73,
+			0x74, 0x73, 0x63, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x00, 0x18, 0x73, 0x74, 0x73, 0x7a, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02, 0x00,
+			0x00, 0x00, 0x14, 0x73, 0x74, 0x63, 0x6f, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+			0x00, 0x02, 0xb3, 0x00, 0x00, 0x00, 0x0a, 0x6d,
+			0x64, 0x61, 0x74, 0x01, 0x02,
+		},
+	},
+	{
+		"cea608",
+		Presentation{
+			Tracks: []*Track{
+				{
+					ID:        1,
+					TimeScale: 30000,
+					Codec:     &codecs.CEA608{},
+					Samples: []*Sample{{
+						Duration:    1001,
+						PayloadSize: 10,
+						GetPayload: func() ([]byte, error) {
+							return []byte{0x00, 0x00, 0x00, 0x0a, 'c', 'd', 'a', 't', 0x94, 0x2c}, nil
+						},
+					}},
+				},
+			},
+		},
+		[]byte{
+			0x00, 0x00, 0x00, 0x20, 0x66, 0x74, 0x79, 0x70,
+			0x69, 0x73, 0x6f, 0x6d, 0x00, 0x00, 0x00, 0x01,
+			0x69, 0x73, 0x6f, 0x6d, 0x69, 0x73, 0x6f, 0x32,
+			0x6d, 0x70, 0x34, 0x31, 0x6d, 0x70, 0x34, 0x32,
+			0x00, 0x00, 0x02, 0x31, 0x6d, 0x6f, 0x6f, 0x76,
+			0x00, 0x00, 0x00, 0x6c, 0x6d, 0x76, 0x68, 0x64,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0xe8,
+			0x00, 0x00, 0x00, 0x21, 0x00, 0x01, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x01, 0xbd,
+			0x74, 0x72, 0x61, 0x6b, 0x00, 0x00, 0x00, 0x5c,
+			0x74, 0x6b, 0x68, 0x64, 0x00, 0x00, 0x00, 0x03,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x21, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x24, 0x65, 0x64, 0x74, 0x73,
+			0x00, 0x00, 0x00, 0x1c, 0x65, 0x6c, 0x73, 0x74,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			0x00, 0x00, 0x00, 0x21, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x35,
+			0x6d, 0x64, 0x69, 0x61, 0x00, 0x00, 0x00, 0x20,
+			0x6d, 0x64, 0x68, 0x64, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x75, 0x30, 0x00, 0x00, 0x03, 0xe9,
+			0x55, 0xc4, 0x00, 0x00, 0x00, 0x00, 0x00, 0x35,
+			0x68, 0x64, 0x6c, 0x72, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x63, 0x6c, 0x63, 0x70,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x43, 0x6c, 0x6f, 0x73,
+			0x65, 0x64, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f,
+			0x6e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
+			0x00, 0x00, 0x00, 0x00, 0xd8, 0x6d, 0x69, 0x6e,
+			0x66, 0x00, 0x00, 0x00, 0x0c, 0x6e, 0x6d, 0x68,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x24, 0x64, 0x69, 0x6e, 0x66, 0x00, 0x00, 0x00,
+			0x1c, 0x64, 0x72, 0x65, 0x66, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x0c, 0x75, 0x72, 0x6c, 0x20, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0xa0, 0x73, 0x74, 0x62,
+			0x6c, 0x00, 0x00, 0x00, 0x20, 0x73, 0x74, 0x73,
+			0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x10, 0x63, 0x36, 0x30,
+			0x38, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x18, 0x73, 0x74, 0x74,
+			0x73, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x03,
+			0xe9, 0x00, 0x00, 0x00, 0x18, 0x63, 0x74, 0x74,
+			0x73, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x1c, 0x73, 0x74, 0x73,
+			0x63, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+			0x18, 0x73, 0x74, 0x73, 0x7a, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x00,
+			0x14, 0x73, 0x74, 0x63, 0x6f, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x02,
+			0x59, 0x00, 0x00, 0x00, 0x12, 0x6d, 0x64, 0x61,
+			0x74, 0x00, 0x00, 0x00, 0x0a, 0x63, 0x64, 0x61,
+			0x74, 0x94, 0x2c,
+		},
+	},
 }
 
 func getSampleData(t *testing.T, p *Presentation) map[int][][]byte {
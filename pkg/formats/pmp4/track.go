@@ -48,6 +48,7 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 		|    |    |minf|
 		|    |    |    |vmhd| (video)
 		|    |    |    |smhd| (audio)
+		|    |    |    |nmhd| (closed captions)
 		|    |    |    |dinf|
 		|    |    |    |    |dref|
 		|    |    |    |    |    |url|
@@ -81,7 +82,8 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 
 	presentationDuration := uint32(((int64(sampleDuration) + int64(t.TimeOffset)) * globalTimescale) / int64(t.TimeScale))
 
-	if t.Codec.IsVideo() {
+	switch {
+	case t.Codec.IsVideo():
 		_, err = w.WriteBox(&amp4.Tkhd{ // <tkhd/>
 			FullBox: amp4.FullBox{
 				Flags: [3]byte{0, 0, 3},
@@ -95,7 +97,21 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 		if err != nil {
 			return nil, err
 		}
-	} else {
+
+	case imp4.IsClosedCaptions(t.Codec):
+		_, err = w.WriteBox(&amp4.Tkhd{ // <tkhd/>
+			FullBox: amp4.FullBox{
+				Flags: [3]byte{0, 0, 3},
+			},
+			TrackID:    uint32(t.ID),
+			DurationV0: presentationDuration,
+			Matrix:     [9]int32{0x10000, 0, 0, 0, 0x10000, 0, 0, 0, 0x40000000},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	default:
 		_, err = w.WriteBox(&amp4.Tkhd{ // <tkhd/>
 			FullBox: amp4.FullBox{
 				Flags: [3]byte{0, 0, 3},
@@ -140,7 +156,8 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 		return nil, err
 	}
 
-	if t.Codec.IsVideo() {
+	switch {
+	case t.Codec.IsVideo():
 		_, err = w.WriteBox(&amp4.Hdlr{ // <hdlr/>
 			HandlerType: [4]byte{'v', 'i', 'd', 'e'},
 			Name:        "VideoHandler",
@@ -148,7 +165,17 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 		if err != nil {
 			return nil, err
 		}
-	} else {
+
+	case imp4.IsClosedCaptions(t.Codec):
+		_, err = w.WriteBox(&amp4.Hdlr{ // <hdlr/>
+			HandlerType: [4]byte{'c', 'l', 'c', 'p'},
+			Name:        "ClosedCaptionHandler",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	default:
 		_, err = w.WriteBox(&amp4.Hdlr{ // <hdlr/>
 			HandlerType: [4]byte{'s', 'o', 'u', 'n'},
 			Name:        "SoundHandler",
@@ -163,7 +190,8 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 		return nil, err
 	}
 
-	if t.Codec.IsVideo() {
+	switch {
+	case t.Codec.IsVideo():
 		_, err = w.WriteBox(&amp4.Vmhd{ // <vmhd/>
 			FullBox: amp4.FullBox{
 				Flags: [3]byte{0, 0, 1},
@@ -172,7 +200,14 @@ func (t Track) marshal(w *imp4.Writer) (*headerTrackMarshalResult, error) {
 		if err != nil {
 			return nil, err
 		}
-	} else {
+
+	case imp4.IsClosedCaptions(t.Codec):
+		_, err = w.WriteBox(&imp4.Nmhd{}) // <nmhd/>
+		if err != nil {
+			return nil, err
+		}
+
+	default:
 		_, err = w.WriteBox(&amp4.Smhd{}) // <smhd/>
 		if err != nil {
 			return nil, err